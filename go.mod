@@ -8,7 +8,9 @@ require (
 	github.com/gomodule/redigo v1.8.9
 	github.com/lib/pq v1.10.7
 	github.com/lucsky/cuid v1.2.1
+	github.com/mattn/go-sqlite3 v1.14.22
 	github.com/pkg/errors v0.9.1
+	github.com/pkoukk/tiktoken-go v0.1.6
 	github.com/sashabaranov/go-openai v1.5.2
 	github.com/stretchr/testify v1.8.2
 	google.golang.org/grpc v1.53.0
@@ -17,7 +19,9 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dlclark/regexp2 v1.10.0 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/google/uuid v1.3.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/robfig/cron v1.2.0 // indirect
 	golang.org/x/net v0.8.0 // indirect
@@ -1,9 +1,62 @@
 package openai
 
+import (
+	"context"
+	"strings"
+)
+
+// MockTokenCounter counts a token for every word, which is close enough to check prompts against a budget in tests.
+type MockTokenCounter struct{}
+
+func (MockTokenCounter) CountTokens(text string) int64 {
+	return int64(len(strings.Fields(text)))
+}
+
 type MockClientSuccess struct {
-	Text string
+	MockTokenCounter
+	Text             string
+	Model            string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+func (m *MockClientSuccess) CallCompletionApi(ctx context.Context, prompt string) (*Completion, error) {
+	return &Completion{
+		Text:             m.Text,
+		Model:            m.Model,
+		PromptTokens:     m.PromptTokens,
+		CompletionTokens: m.CompletionTokens,
+	}, nil
+}
+
+// MockClientSequence responds with each of Texts in turn, and keeps repeating the last one.
+type MockClientSequence struct {
+	MockTokenCounter
+	Texts []string
+	calls int
+}
+
+func (m *MockClientSequence) CallCompletionApi(ctx context.Context, prompt string) (*Completion, error) {
+	index := m.calls
+	if index >= len(m.Texts) {
+		index = len(m.Texts) - 1
+	}
+	m.calls++
+	return &Completion{Text: m.Texts[index]}, nil
+}
+
+// MockClientBlocking stands in for an OpenAI request that never comes back, until it is abandoned because ctx is done.
+// Called is signalled on every call that it has room for.
+type MockClientBlocking struct {
+	MockTokenCounter
+	Called chan struct{}
 }
 
-func (m *MockClientSuccess) CallCompletionApi(prompt string) (string, error) {
-	return m.Text, nil
+func (m *MockClientBlocking) CallCompletionApi(ctx context.Context, prompt string) (*Completion, error) {
+	select {
+	case m.Called <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
 }
@@ -4,44 +4,78 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/pkoukk/tiktoken-go"
 	openaigo "github.com/sashabaranov/go-openai"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 type client struct {
-	apiKey string
-	logger utilities.Logger
+	apiKey   string
+	baseUrl  string
+	encoding *tiktoken.Tiktoken
+	logger   utilities.Logger
 }
 
 type Client interface {
-	CallCompletionApi(prompt string) (string, error)
+	CallCompletionApi(ctx context.Context, prompt string) (*Completion, error)
+	// CountTokens counts the tokens text uses with the model the completions are made with.
+	CountTokens(text string) int64
 }
 
 type OpenAiClientOptions struct {
 	ApiKey string
+	// BaseUrl defaults to the OpenAI API.
+	BaseUrl string
 }
 
-func NewClient(opts OpenAiClientOptions, logger utilities.Logger) Client {
+type Completion struct {
+	Text             string
+	Model            string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// NewClient loads the token encoding of COMPLETION_MODEL. If it cannot be loaded, such as when it cannot be downloaded,
+// the client logs a warning and estimates token counts instead.
+func NewClient(opts OpenAiClientOptions, logger utilities.Logger) (Client, error) {
 	return &client{
-		apiKey: opts.ApiKey,
-		logger: logger,
-	}
+		apiKey:   opts.ApiKey,
+		baseUrl:  opts.BaseUrl,
+		encoding: loadEncoding(COMPLETION_MODEL, logger),
+		logger:   logger,
+	}, nil
+}
+
+func (c *client) CountTokens(text string) int64 {
+	return countTokens(c.encoding, text)
 }
 
-func (c *client) CallCompletionApi(prompt string) (string, error) {
+// The request is abandoned as soon as ctx is done.
+func (c *client) CallCompletionApi(ctx context.Context, prompt string) (*Completion, error) {
 	c.logger.LogMessageln(prompt)
-	openAiGoClient := openaigo.NewClient(c.apiKey)
-	ctx := context.Background()
+	config := openaigo.DefaultConfig(c.apiKey)
+	if c.baseUrl != "" {
+		config.BaseURL = c.baseUrl
+	}
+	openAiGoClient := openaigo.NewClientWithConfig(config)
 
 	req := openaigo.CompletionRequest{
-		Model:     openaigo.GPT3TextDavinci003,
-		MaxTokens: 50,
+		Model:     COMPLETION_MODEL,
+		MaxTokens: MAX_COMPLETION_TOKENS,
 		Prompt:    prompt,
 	}
 	resp, err := openAiGoClient.CreateCompletion(ctx, req)
 	if err != nil {
 		c.logger.LogError(err)
-		return "", errors.Wrap(err, "Open Ai error")
+		return nil, errors.Wrap(err, "Open Ai error")
 	}
-	return resp.Choices[0].Text, nil
+
+	c.logger.LogMessagef("openai usage. model: %s, prompt tokens: %d, counted prompt tokens: %d, completion tokens: %d\n", resp.Model, resp.Usage.PromptTokens, c.CountTokens(prompt), resp.Usage.CompletionTokens)
+
+	return &Completion{
+		Text:             resp.Choices[0].Text,
+		Model:            resp.Model,
+		PromptTokens:     int64(resp.Usage.PromptTokens),
+		CompletionTokens: int64(resp.Usage.CompletionTokens),
+	}, nil
 }
@@ -0,0 +1,54 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func Test_CallCompletionApi(t *testing.T) {
+	t.Run("returns the completion", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"model":"text-davinci-003","choices":[{"text":"hello"}],"usage":{"prompt_tokens":3,"completion_tokens":1}}`)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(OpenAiClientOptions{ApiKey: "key", BaseUrl: server.URL}, &utilities.NullLogger{})
+		assert.NoError(t, err)
+		completion, err := client.CallCompletionApi(context.Background(), "say hello")
+		assert.NoError(t, err)
+		assert.Equal(t, &Completion{Text: "hello", Model: "text-davinci-003", PromptTokens: 3, CompletionTokens: 1}, completion)
+	})
+
+	t.Run("stops waiting on the API once the context is done", func(t *testing.T) {
+		requestReceived := make(chan struct{})
+		testDone := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestReceived)
+			<-testDone
+		}))
+		defer server.Close()
+		defer close(testDone)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-requestReceived
+			cancel()
+		}()
+
+		client, err := NewClient(OpenAiClientOptions{ApiKey: "key", BaseUrl: server.URL}, &utilities.NullLogger{})
+		assert.NoError(t, err)
+		start := time.Now()
+		completion, err := client.CallCompletionApi(ctx, "say hello")
+		assert.Nil(t, completion)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
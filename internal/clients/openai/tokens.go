@@ -0,0 +1,76 @@
+package openai
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/pkoukk/tiktoken-go"
+	openaigo "github.com/sashabaranov/go-openai"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+const COMPLETION_MODEL = openaigo.GPT3TextDavinci003
+const MAX_COMPLETION_TOKENS = 50
+
+const defaultContextWindow = 2049
+
+var contextWindows = map[string]int64{
+	openaigo.GPT3TextDavinci003: 4097,
+	openaigo.GPT3TextDavinci002: 4097,
+	openaigo.GPT3TextCurie001:   2049,
+	openaigo.GPT3TextBabbage001: 2049,
+	openaigo.GPT3TextAda001:     2049,
+}
+
+// PromptTokenLimit is the most tokens a prompt for model can use while leaving room for the completion.
+func PromptTokenLimit(model string) int64 {
+	contextWindow, ok := contextWindows[model]
+	if !ok {
+		contextWindow = defaultContextWindow
+	}
+	return contextWindow - MAX_COMPLETION_TOKENS
+}
+
+// Loading an encoding reads the whole vocabulary of the model, so each one is only loaded once.
+var encodingsLock sync.Mutex
+var encodings = map[string]*tiktoken.Tiktoken{}
+
+// The vocabulary of a model is downloaded the first time it is used, and cached in TIKTOKEN_CACHE_DIR after that.
+func encodingForModel(model string) (*tiktoken.Tiktoken, error) {
+	encodingsLock.Lock()
+	defer encodingsLock.Unlock()
+
+	encoding, ok := encodings[model]
+	if ok {
+		return encoding, nil
+	}
+	encoding, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading the token encoding of %s", model)
+	}
+	encodings[model] = encoding
+	return encoding, nil
+}
+
+// loadEncoding returns nil if the encoding of model cannot be loaded, after warning that token counts are estimated.
+func loadEncoding(model string, logger utilities.Logger) *tiktoken.Tiktoken {
+	encoding, err := encodingForModel(model)
+	if err != nil {
+		logger.LogMessagef("warning: estimating token counts from the length of the text: %v\n", err)
+		return nil
+	}
+	return encoding
+}
+
+// A token is about four bytes of English text. Without the encoding, tokens are counted as fewer bytes than that, so
+// that prompts trimmed to a budget with the estimate still fit.
+const estimatedBytesPerToken = 3
+
+// countTokens counts the tokens text uses with the byte pair encoding of the model, in the same way the API counts them.
+// It estimates the count from the length of text when there is no encoding.
+func countTokens(encoding *tiktoken.Tiktoken, text string) int64 {
+	if encoding == nil {
+		return int64((len(text) + estimatedBytesPerToken - 1) / estimatedBytesPerToken)
+	}
+	return int64(len(encoding.EncodeOrdinary(text)))
+}
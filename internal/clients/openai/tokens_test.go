@@ -0,0 +1,125 @@
+package openai
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/pkoukk/tiktoken-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// testBpeLoader stands in for the vocabularies of the models, which are otherwise downloaded. Every byte is a token,
+// and the only merges are the ones that make up "hello".
+type testBpeLoader struct{}
+
+func (l *testBpeLoader) LoadTiktokenBpe(tiktokenBpeFile string) (map[string]int, error) {
+	ranks := map[string]int{}
+	for b := 0; b < 256; b++ {
+		ranks[string([]byte{byte(b)})] = b
+	}
+	for i, merge := range []string{"he", "ll", "llo", "hello"} {
+		ranks[merge] = 256 + i
+	}
+	return ranks, nil
+}
+
+// failingBpeLoader stands in for the vocabularies of the models when they cannot be downloaded.
+type failingBpeLoader struct{}
+
+func (l *failingBpeLoader) LoadTiktokenBpe(tiktokenBpeFile string) (map[string]int, error) {
+	return nil, errors.New("no network")
+}
+
+type recordingLogger struct {
+	utilities.NullLogger
+	messages []string
+}
+
+func (l *recordingLogger) LogMessagef(format string, a ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(format, a...))
+}
+
+func TestMain(m *testing.M) {
+	tiktoken.SetBpeLoader(&testBpeLoader{})
+	os.Exit(m.Run())
+}
+
+func Test_CountTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput int64
+	}{
+		{
+			name:           "counts nothing for empty text",
+			input:          "",
+			expectedOutput: 0,
+		},
+		{
+			name:           "counts a word that merges completely as one token",
+			input:          "hello",
+			expectedOutput: 1,
+		},
+		{
+			name:           "counts every byte of a word without merges",
+			input:          "hello world",
+			expectedOutput: 7,
+		},
+		{
+			name:           "counts special tokens as ordinary text",
+			input:          "<|endoftext|>",
+			expectedOutput: 13,
+		},
+	}
+
+	client, err := NewClient(OpenAiClientOptions{ApiKey: "key"}, &utilities.NullLogger{})
+	assert.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOutput, client.CountTokens(tt.input))
+		})
+	}
+}
+
+func Test_CountTokens_WithoutEncoding(t *testing.T) {
+	tiktoken.SetBpeLoader(&failingBpeLoader{})
+	defer tiktoken.SetBpeLoader(&testBpeLoader{})
+
+	logger := &recordingLogger{}
+	// No other test loads the encoding of this model, so it is not cached.
+	encoding := loadEncoding("gpt-3.5-turbo", logger)
+	assert.Nil(t, encoding)
+	assert.Equal(t, []string{"warning: estimating token counts from the length of the text: loading the token encoding of gpt-3.5-turbo: no network\n"}, logger.messages)
+
+	client := &client{encoding: encoding, logger: logger}
+	assert.Equal(t, int64(0), client.CountTokens(""))
+	assert.Equal(t, int64(1), client.CountTokens("hi"))
+	assert.Equal(t, int64(4), client.CountTokens("hello world"))
+}
+
+func Test_EncodingForModel(t *testing.T) {
+	t.Run("loads each encoding once", func(t *testing.T) {
+		encoding, err := encodingForModel(COMPLETION_MODEL)
+		assert.NoError(t, err)
+		cachedEncoding, err := encodingForModel(COMPLETION_MODEL)
+		assert.NoError(t, err)
+		assert.Same(t, encoding, cachedEncoding)
+	})
+
+	t.Run("errors for a model without an encoding", func(t *testing.T) {
+		_, err := encodingForModel("unknown")
+		assert.EqualError(t, err, "loading the token encoding of unknown: no encoding for model unknown")
+	})
+}
+
+func Test_PromptTokenLimit(t *testing.T) {
+	t.Run("leaves room for the completion in a known model", func(t *testing.T) {
+		assert.Equal(t, int64(4047), PromptTokenLimit("text-davinci-003"))
+	})
+	t.Run("uses the smallest context window for an unknown model", func(t *testing.T) {
+		assert.Equal(t, int64(1999), PromptTokenLimit("unknown"))
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -12,6 +13,7 @@ type Config struct {
 	RedisUrl         string
 	TestDbUrl        string
 	DbUrl            string
+	ReadReplicaDbUrl string
 	CaCertBase64     string
 	ServerCertBase64 string
 	ServerKeyBase64  string
@@ -20,6 +22,18 @@ type Config struct {
 	SentryDsn        string
 	Environment      string
 	LoggerMode       string
+	PromptsDir       string
+	PromptExperiment string
+
+	DbMaxOpenConns     int
+	DbMaxIdleConns     int
+	DbConnMaxLifetime  time.Duration
+	DbConnMaxIdleTime  time.Duration
+	DbStatementTimeout time.Duration
+	DbQueryTimeout     time.Duration
+	DbMaxRetries       int
+	DbRetryBackoff     time.Duration
+	DbRetryMaxBackoff  time.Duration
 }
 
 func envVarLoaderBool(envVarName string, required bool, errorCollector *[]error) bool {
@@ -45,6 +59,32 @@ func envVarLoaderString(envVarName string, required bool, errorCollector *[]erro
 	return value
 }
 
+func envVarLoaderInt(envVarName string, defaultValue int, errorCollector *[]error) int {
+	value, ok := os.LookupEnv(envVarName)
+	if !ok {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		*errorCollector = append(*errorCollector, errors.Errorf("Env var %s is expected to be an integer", envVarName))
+		return defaultValue
+	}
+	return intValue
+}
+
+func envVarLoaderDuration(envVarName string, defaultValue time.Duration, errorCollector *[]error) time.Duration {
+	value, ok := os.LookupEnv(envVarName)
+	if !ok {
+		return defaultValue
+	}
+	durationValue, err := time.ParseDuration(value)
+	if err != nil {
+		*errorCollector = append(*errorCollector, errors.Errorf("Env var %s is expected to be a duration, like 5s", envVarName))
+		return defaultValue
+	}
+	return durationValue
+}
+
 func NewConfigFromEnvVars() (*Config, []error) {
 	c := Config{}
 
@@ -54,6 +94,7 @@ func NewConfigFromEnvVars() (*Config, []error) {
 	c.RedisUrl = envVarLoaderString("REDIS_URL", true, &errs)
 	c.TestDbUrl = envVarLoaderString("TEST_DB_URL", false, &errs)
 	c.DbUrl = envVarLoaderString("DB_URL", true, &errs)
+	c.ReadReplicaDbUrl = envVarLoaderString("READ_REPLICA_DB_URL", false, &errs)
 	c.CaCertBase64 = envVarLoaderString("CA_CERT_BASE64", true, &errs)
 	c.ServerCertBase64 = envVarLoaderString("SERVER_CERT_BASE64", true, &errs)
 	c.ServerKeyBase64 = envVarLoaderString("SERVER_KEY_BASE64", true, &errs)
@@ -62,6 +103,17 @@ func NewConfigFromEnvVars() (*Config, []error) {
 	c.SentryDsn = envVarLoaderString("SENTRY_DSN", false, &errs)
 	c.Environment = envVarLoaderString("ENVIRONMENT", true, &errs)
 	c.LoggerMode = envVarLoaderString("LOGGER_MODE", true, &errs)
+	c.PromptsDir = envVarLoaderString("PROMPTS_DIR", false, &errs)
+	c.PromptExperiment = envVarLoaderString("PROMPT_EXPERIMENT", false, &errs)
+	c.DbMaxOpenConns = envVarLoaderInt("DB_MAX_OPEN_CONNS", 25, &errs)
+	c.DbMaxIdleConns = envVarLoaderInt("DB_MAX_IDLE_CONNS", 10, &errs)
+	c.DbConnMaxLifetime = envVarLoaderDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute, &errs)
+	c.DbConnMaxIdleTime = envVarLoaderDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute, &errs)
+	c.DbStatementTimeout = envVarLoaderDuration("DB_STATEMENT_TIMEOUT", 10*time.Second, &errs)
+	c.DbQueryTimeout = envVarLoaderDuration("DB_QUERY_TIMEOUT", 15*time.Second, &errs)
+	c.DbMaxRetries = envVarLoaderInt("DB_MAX_RETRIES", 3, &errs)
+	c.DbRetryBackoff = envVarLoaderDuration("DB_RETRY_BACKOFF", 100*time.Millisecond, &errs)
+	c.DbRetryMaxBackoff = envVarLoaderDuration("DB_RETRY_MAX_BACKOFF", 2*time.Second, &errs)
 
 	return &c, errs
 }
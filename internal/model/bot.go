@@ -2,27 +2,40 @@ package model
 
 import (
 	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 const totalNumberOfBotsPerGame = 5
+const maxStatedFactsPerBot = 20
 
 type Bot struct {
-	id        string
-	name      string
-	typeOfBot botType
-	player    *Player
-	helpCount int64
+	id                  string
+	name                string
+	typeOfBot           botType
+	player              *Player
+	helpCount           int64
+	lastHelpSuggestions []string
+	statedFacts         []string
+	tagCount            int64
+	lastTaggedAt        *time.Time
+	eliminated          bool
 }
 
 type BotOptions struct {
-	Id              string
-	Name            string
-	TypeOfBot       string
-	ConnectedPlayer *Player
-	HelpCount       int64
+	Id                  string
+	Name                string
+	TypeOfBot           string
+	ConnectedPlayer     *Player
+	HelpCount           int64
+	LastHelpSuggestions []string
+	StatedFacts         []string
+	TagCount            int64
+	LastTaggedAt        *time.Time
+	Eliminated          bool
 }
 
 func NewBot(opts BotOptions) (*Bot, error) {
@@ -51,11 +64,16 @@ func NewBot(opts BotOptions) (*Bot, error) {
 	}
 
 	return &Bot{
-		id:        opts.Id,
-		name:      opts.Name,
-		typeOfBot: typeOfBot,
-		player:    opts.ConnectedPlayer,
-		helpCount: opts.HelpCount,
+		id:                  opts.Id,
+		name:                opts.Name,
+		typeOfBot:           typeOfBot,
+		player:              opts.ConnectedPlayer,
+		helpCount:           opts.HelpCount,
+		lastHelpSuggestions: opts.LastHelpSuggestions,
+		statedFacts:         opts.StatedFacts,
+		tagCount:            opts.TagCount,
+		lastTaggedAt:        opts.LastTaggedAt,
+		eliminated:          opts.Eliminated,
 	}, nil
 }
 
@@ -75,10 +93,61 @@ func (b *Bot) IsHuman() bool {
 	return b.typeOfBot == human
 }
 
+func (b *Bot) IsEliminated() bool {
+	return b.eliminated
+}
+
+func (b *Bot) TagsRemaining() int64 {
+	if b.tagCount >= MAX_TAGS_PER_HUMAN {
+		return 0
+	}
+	return MAX_TAGS_PER_HUMAN - b.tagCount
+}
+
+// NextTagAt is when the tag cooldown of this bot ends. It is nil if the bot has not tagged yet.
+func (b *Bot) NextTagAt() *time.Time {
+	if b.lastTaggedAt == nil {
+		return nil
+	}
+	nextTagAt := b.lastTaggedAt.Add(TAG_COOLDOWN)
+	return &nextTagAt
+}
+
 func (b *Bot) CanGetHelp() bool {
 	return b.helpCount > 0
 }
 
+func (b *Bot) StatedFacts() []string {
+	return b.statedFacts
+}
+
+// StatedFactsWith adds newFacts to the facts this bot has already stated, skipping any it has stated before.
+// Only the most recent facts are kept, so that the profile stays small enough to send with every prompt.
+func (b *Bot) StatedFactsWith(newFacts []string) []string {
+	statedFacts := append([]string{}, b.statedFacts...)
+	for _, newFact := range newFacts {
+		newFact = strings.TrimSpace(newFact)
+		if utilities.IsBlank(newFact) {
+			continue
+		}
+		alreadyStated := false
+		for _, statedFact := range statedFacts {
+			if strings.EqualFold(statedFact, newFact) {
+				alreadyStated = true
+				break
+			}
+		}
+		if !alreadyStated {
+			statedFacts = append(statedFacts, newFact)
+		}
+	}
+
+	if len(statedFacts) > maxStatedFactsPerBot {
+		statedFacts = statedFacts[len(statedFacts)-maxStatedFactsPerBot:]
+	}
+	return statedFacts
+}
+
 func (b *Bot) ConnectPlayer(player *Player) error {
 	if player == nil {
 		return errors.New("Cannot connect an empty player")
@@ -1,8 +1,10 @@
 package model
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -225,6 +227,46 @@ func Test_Bot_CanGetHelp(t *testing.T) {
 	}
 }
 
+func Test_Bot_StatedFactsWith(t *testing.T) {
+	manyFacts := []string{}
+	for i := 0; i < maxStatedFactsPerBot; i++ {
+		manyFacts = append(manyFacts, fmt.Sprintf("fact %d", i+1))
+	}
+
+	tests := []struct {
+		name           string
+		input          *Bot
+		newFacts       []string
+		expectedOutput []string
+	}{
+		{
+			name:           "adds facts to a bot without any",
+			input:          &Bot{id: "id1", typeOfBot: ai},
+			newFacts:       []string{"I love pizza", " I live in Paris "},
+			expectedOutput: []string{"I love pizza", "I live in Paris"},
+		},
+		{
+			name:           "skips blank facts and facts stated before",
+			input:          &Bot{id: "id1", typeOfBot: ai, statedFacts: []string{"I love pizza"}},
+			newFacts:       []string{"i love pizza", "", "I have a dog"},
+			expectedOutput: []string{"I love pizza", "I have a dog"},
+		},
+		{
+			name:           "keeps only the most recent facts",
+			input:          &Bot{id: "id1", typeOfBot: ai, statedFacts: manyFacts},
+			newFacts:       []string{"I have a dog"},
+			expectedOutput: append(append([]string{}, manyFacts[1:]...), "I have a dog"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.input.StatedFactsWith(tt.newFacts)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
 func Test_Bot_ConectPlayer(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -347,3 +389,24 @@ func Test_RandomBotNames(t *testing.T) {
 		})
 	}
 }
+
+func Test_Bot_TagsRemainingAndNextTagAt(t *testing.T) {
+	lastTaggedAt := time.Now()
+
+	t.Run("a bot that has not tagged yet can tag right away", func(t *testing.T) {
+		bot := &Bot{id: "id1", typeOfBot: human}
+		assert.Equal(t, int64(MAX_TAGS_PER_HUMAN), bot.TagsRemaining())
+		assert.Nil(t, bot.NextTagAt())
+	})
+
+	t.Run("a bot that has tagged has fewer tags and a cooldown", func(t *testing.T) {
+		bot := &Bot{id: "id1", typeOfBot: human, tagCount: 1, lastTaggedAt: &lastTaggedAt}
+		assert.Equal(t, int64(MAX_TAGS_PER_HUMAN-1), bot.TagsRemaining())
+		assert.Equal(t, lastTaggedAt.Add(TAG_COOLDOWN), *bot.NextTagAt())
+	})
+
+	t.Run("tags remaining never goes below zero", func(t *testing.T) {
+		bot := &Bot{id: "id1", typeOfBot: human, tagCount: MAX_TAGS_PER_HUMAN + 1}
+		assert.Equal(t, int64(0), bot.TagsRemaining())
+	})
+}
@@ -1,6 +1,7 @@
 package model
 
 type BotView struct {
-	Id   string
-	Name string
+	Id         string
+	Name       string
+	Eliminated bool
 }
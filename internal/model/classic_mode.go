@@ -0,0 +1,491 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// In a classic game, the bots take turns asking each other questions. A human wins by tagging the other human, and
+// loses by tagging an AI bot. The game can additionally have AI accusations, elimination rounds or a final vote.
+type classicMode struct{}
+
+func (m *classicMode) Type() gameModeType {
+	return classic
+}
+
+func (m *classicMode) HumanPlayerCount() int64 {
+	return 2
+}
+
+func (m *classicMode) AiImitatesHumans() bool {
+	return false
+}
+
+func (m *classicMode) HelpBudget() int64 {
+	return 3
+}
+
+// Every round starts with a fresh turn order, and the first bot in it asks the first question.
+func (m *classicMode) GameUpdateToStartRound(game *Game) (*GameUpdate, error) {
+	turnOrder := game.RandomizedTurnOrder()
+	if len(turnOrder) == 0 {
+		return nil, errors.New("no bots left to start a round")
+	}
+
+	firstTurnBot := game.BotWithId(turnOrder[0])
+	var state gameState
+	if firstTurnBot.IsAi() {
+		state = waitingForAiQuestion
+	} else if firstTurnBot.IsHuman() {
+		state = waitingForHumanQuestion
+	} else {
+		return nil, utilities.NewBadError("first bot was neither human nor ai")
+	}
+
+	startTurnIndex := int64(0)
+	stateHandled := false
+	return &GameUpdate{
+		State:            state,
+		CurrentTurnIndex: &startTurnIndex,
+		TurnOrder:        turnOrder,
+		StateHandled:     &stateHandled,
+	}, nil
+}
+
+// Questions and follow-ups are asked by the bot whose turn it is, and answered by the bot they were asked to.
+// Either of them can pass instead, which hands the turn to the next bot. Reactions can be sent by anyone at any time
+// during the conversation, and do not change whose turn it is.
+func (m *classicMode) GameUpdateAfterIncomingMessage(game *Game, sourceBotId string, targetBotId string, text string, msgType messageType) (*GameUpdate, error) {
+	sourceBot := game.BotWithId(sourceBotId)
+	targetBot := game.BotWithId(targetBotId)
+
+	if sourceBot == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if targetBot == nil {
+		return nil, errors.New("invalid targetBotId")
+	}
+
+	if sourceBot.eliminated || targetBot.eliminated {
+		return nil, errors.New("eliminated bots cannot take part in the conversation")
+	}
+
+	state := game.state
+	if msgType == reactionMessage {
+		if !state.isWaitingForMessage() {
+			return nil, errors.New("this game is not waiting for messages currently")
+		}
+		return gameUpdateAfterReaction(game, sourceBotId, targetBotId, text)
+	}
+
+	if msgType != questionMessage && msgType != answerMessage && msgType != followUpMessage && msgType != passMessage {
+		return nil, errors.Errorf("cannot send a %s message in this game", msgType)
+	}
+
+	expectedSourceBotId, err := game.expectedSourceBotIdForWaitingMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedSourceBotId != sourceBotId {
+		return nil, errors.New("incorrect sourceBotId")
+	}
+
+	if state.isWaitingOnAi() && !sourceBot.IsAi() {
+		return nil, errors.New("expecting AI message but did not receive one")
+	}
+
+	if state.isWaitingOnHuman() && !sourceBot.IsHuman() {
+		return nil, errors.New("expecting Human message but did not receive one")
+	}
+
+	update := GameUpdate{}
+	var nextBot *Bot
+
+	if msgType == passMessage {
+		if sourceBotId != targetBotId {
+			return nil, errors.New("pass should have same source and target bot")
+		}
+		nextBot = game.BotWithId(game.getNextTurnBotId())
+		update.State = getQuestionStateForBot(nextBot)
+	} else if state.isWaitingForAQuestion() {
+		if !msgType.isQuestion() {
+			return nil, errors.Errorf("expecting a question but received %s", msgType)
+		}
+		if sourceBotId == targetBotId {
+			return nil, errors.New("questioning message should have different source and target bot")
+		}
+		if msgType == followUpMessage {
+			if utilities.IsBlank(game.lastQuestionTargetBotId) {
+				return nil, errors.New("there is no answer to follow up on")
+			}
+			if targetBotId != game.lastQuestionTargetBotId {
+				return nil, errors.New("follow-up should be asked to the bot that answered the last question")
+			}
+		}
+		nextBot = targetBot
+		update.State = getNewStateForNextBot(state, nextBot)
+	} else if state.isWaitingForAnAnswer() {
+		if msgType != answerMessage {
+			return nil, errors.Errorf("expecting an answer but received %s", msgType)
+		}
+		if sourceBotId != targetBotId {
+			return nil, errors.New("answering message should have same source and target bot")
+		}
+		nextBot = game.BotWithId(game.getNextTurnBotId())
+		update.State = getNewStateForNextBot(state, nextBot)
+	}
+
+	if update.State.isWaitingForAQuestion() {
+		nextIndex := game.currentTurnIndex + 1
+		update.CurrentTurnIndex = &nextIndex
+		if game.votingHasBeenReached(nextIndex) {
+			votingStartedAt := time.Now()
+			votingTotalTime := int64(VOTING_DURATION.Seconds())
+			update.State = voting
+			update.StateHandledAt = &votingStartedAt
+			update.StateTotalTime = &votingTotalTime
+		}
+	} else if update.State.isWaitingForAnAnswer() {
+		update.LastQuestion = &text
+		update.LastQuestionTargetBotId = &(nextBot.id)
+	}
+
+	// TODO: Wondering if we really need stateHandled on all the games.
+	stateHandled := false
+	update.StateHandled = &stateHandled
+
+	return &update, nil
+}
+
+func getNewStateForNextBot(currentState gameState, nextBot *Bot) gameState {
+	if currentState.isWaitingForAQuestion() {
+		if nextBot.IsAi() {
+			return waitingForAiAnswer
+		} else if nextBot.IsHuman() {
+			return waitingForHumanAnswer
+		}
+	} else if currentState.isWaitingForAnAnswer() {
+		return getQuestionStateForBot(nextBot)
+	}
+	return undefinedGameState
+}
+
+func getQuestionStateForBot(bot *Bot) gameState {
+	if bot.IsAi() {
+		return waitingForAiQuestion
+	} else if bot.IsHuman() {
+		return waitingForHumanQuestion
+	}
+	return undefinedGameState
+}
+
+func (m *classicMode) GameUpdateAfterTag(game *Game, sourceBotId string, targetBotId string) (*GameUpdate, error) {
+	if game.isFinished() {
+		return nil, errors.New("game has already finished")
+	}
+
+	if sourceBotId == targetBotId {
+		return nil, errors.New("cannot tag self")
+	}
+
+	sourceBot := game.BotWithId(sourceBotId)
+	targetBot := game.BotWithId(targetBotId)
+
+	if sourceBot == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if targetBot == nil {
+		return nil, errors.New("invalid targetBotId")
+	}
+
+	if sourceBot.IsAi() {
+		if !game.aiAccusations {
+			return nil, errors.New("ai cannot perform tagging")
+		}
+		return m.gameUpdateAfterAiTag(game, sourceBot, targetBot)
+	}
+
+	if game.votingRounds > 0 {
+		return nil, errors.New("cannot tag in a voting game")
+	}
+
+	if game.elimination {
+		return m.gameUpdateAfterEliminationTag(game, sourceBot, targetBot, time.Now())
+	}
+
+	return gameUpdateAfterHumanTag(game, sourceBot, targetBot)
+}
+
+// A human tagging the other human wins, and a human tagging an AI bot loses, which makes the other human the winner.
+func gameUpdateAfterHumanTag(game *Game, sourceBot *Bot, targetBot *Bot) (*GameUpdate, error) {
+	update := GameUpdate{}
+
+	if targetBot.IsHuman() {
+		result := fmt.Sprintf("%s tagged %s and won.", sourceBot.name, targetBot.name)
+		update.State = finished
+		update.WinningBotId = &sourceBot.id
+		update.Result = &result
+	} else if targetBot.IsAi() {
+		var otherBot *Bot
+		for _, bot := range game.bots {
+			if bot.IsHuman() && bot.id != sourceBot.id {
+				otherBot = bot
+			}
+		}
+		result := fmt.Sprintf("%s tagged %s and lost. %s won.", sourceBot.name, targetBot.name, otherBot.name)
+		update.State = finished
+		update.WinningBotId = &otherBot.id
+		update.Result = &result
+	} else {
+		return nil, utilities.NewBadError("target bot was neither human nor ai")
+	}
+	return &update, nil
+}
+
+// An AI bot tagging a human means that the human was not convincing enough, so they lose and the other human wins.
+func (m *classicMode) gameUpdateAfterAiTag(game *Game, sourceBot *Bot, targetBot *Bot) (*GameUpdate, error) {
+	if !targetBot.IsHuman() {
+		return nil, errors.New("ai can only tag humans")
+	}
+
+	var otherBot *Bot
+	for _, bot := range game.bots {
+		if bot.IsHuman() && bot.id != targetBot.id {
+			otherBot = bot
+		}
+	}
+	if otherBot == nil {
+		return nil, utilities.NewBadError("game does not have another human")
+	}
+
+	result := fmt.Sprintf("%s was tagged by %s and lost. %s won.", targetBot.name, sourceBot.name, otherBot.name)
+	return &GameUpdate{
+		State:        finished,
+		WinningBotId: &otherBot.id,
+		Result:       &result,
+	}, nil
+}
+
+// In elimination games, a human tagging the other human still wins. Tagging an AI bot costs the human a point,
+// which is the tag it used up, and eliminates the AI bot. The round then ends, and the remaining bots start another.
+// Once every AI bot has been eliminated, the human that used fewer tags wins.
+func (m *classicMode) gameUpdateAfterEliminationTag(game *Game, sourceBot *Bot, targetBot *Bot, taggedAt time.Time) (*GameUpdate, error) {
+	if !game.state.isWaitingForMessage() {
+		return nil, errors.New("can only tag while the round is being played")
+	}
+
+	if targetBot.eliminated {
+		return nil, errors.New("cannot tag an eliminated bot")
+	}
+
+	if sourceBot.TagsRemaining() == 0 {
+		return nil, errors.New("no tags remaining")
+	}
+
+	if nextTagAt := sourceBot.NextTagAt(); nextTagAt != nil && taggedAt.Before(*nextTagAt) {
+		return nil, errors.Errorf("cannot tag again for another %d seconds", int64(nextTagAt.Sub(taggedAt).Seconds())+1)
+	}
+
+	update := GameUpdate{TaggingBotId: &sourceBot.id}
+
+	if targetBot.IsHuman() {
+		result := fmt.Sprintf("%s tagged %s and won.", sourceBot.name, targetBot.name)
+		update.State = finished
+		update.WinningBotId = &sourceBot.id
+		update.Result = &result
+		return &update, nil
+	}
+
+	remainingAiBotCount := 0
+	for _, bot := range game.bots {
+		if bot.IsAi() && !bot.eliminated && bot.id != targetBot.id {
+			remainingAiBotCount++
+		}
+	}
+
+	if remainingAiBotCount == 0 {
+		result, winningBot := m.resultOnceAllAiBotsAreEliminated(game, sourceBot, targetBot)
+		update.State = finished
+		update.Result = &result
+		if winningBot != nil {
+			update.WinningBotId = &winningBot.id
+		}
+	} else {
+		stateHandled := false
+		update.State = botEliminated
+		update.StateHandled = &stateHandled
+	}
+
+	turnOrder := []string{}
+	for _, botId := range game.turnOrder {
+		if botId != targetBot.id {
+			turnOrder = append(turnOrder, botId)
+		}
+	}
+	update.TurnOrder = turnOrder
+	update.EliminatedBotId = &targetBot.id
+	return &update, nil
+}
+
+// The tag that eliminated the last AI bot counts against sourceBot, since it has not been recorded on the bot yet.
+func (m *classicMode) resultOnceAllAiBotsAreEliminated(game *Game, sourceBot *Bot, targetBot *Bot) (string, *Bot) {
+	var otherBot *Bot
+	for _, bot := range game.bots {
+		if bot.IsHuman() && bot.id != sourceBot.id {
+			otherBot = bot
+		}
+	}
+
+	sourceTagCount := sourceBot.tagCount + 1
+	if otherBot == nil || sourceTagCount == otherBot.tagCount {
+		return fmt.Sprintf("%s tagged %s, the last AI bot. It's a draw.", sourceBot.name, targetBot.name), nil
+	}
+	if sourceTagCount < otherBot.tagCount {
+		return fmt.Sprintf("%s tagged %s, the last AI bot, and won with fewer tags.", sourceBot.name, targetBot.name), sourceBot
+	}
+	return fmt.Sprintf("%s tagged %s, the last AI bot. %s won with fewer tags.", sourceBot.name, targetBot.name, otherBot.name), otherBot
+}
+
+// The votes cast by the humans are tallied along with aiVotes, which the AI bots cast as voting closes.
+// The human with the most votes loses, and the other human wins. Anything else is a draw.
+func (m *classicMode) GameUpdateAfterVoting(game *Game, aiVotes []*Vote) (*GameUpdate, error) {
+	if !game.IsInStateVoting() {
+		return nil, errors.New("game is not accepting votes")
+	}
+
+	voteCounts := map[string]int{}
+	for _, vote := range append(append([]*Vote{}, game.votes...), aiVotes...) {
+		voteCounts[vote.SuspectBotId]++
+	}
+
+	var mostVotedBot *Bot
+	mostVotes := 0
+	tied := false
+	for _, bot := range game.bots {
+		count := voteCounts[bot.id]
+		if count > mostVotes {
+			mostVotedBot = bot
+			mostVotes = count
+			tied = false
+		} else if count > 0 && count == mostVotes {
+			tied = true
+		}
+	}
+
+	update := GameUpdate{State: finished}
+	var result string
+
+	if mostVotedBot == nil {
+		result = "Nobody voted. It's a draw."
+	} else if tied {
+		result = "The vote was tied. It's a draw."
+	} else if mostVotedBot.IsAi() {
+		result = fmt.Sprintf("%s got the most votes but is an AI bot. It's a draw.", mostVotedBot.name)
+	} else {
+		var otherBot *Bot
+		for _, bot := range game.bots {
+			if bot.IsHuman() && bot.id != mostVotedBot.id {
+				otherBot = bot
+			}
+		}
+		if otherBot == nil {
+			return nil, utilities.NewBadError("game does not have another human")
+		}
+		result = fmt.Sprintf("%s got the most votes and lost. %s won.", mostVotedBot.name, otherBot.name)
+		update.WinningBotId = &otherBot.id
+	}
+
+	update.Result = &result
+	return &update, nil
+}
+
+// Only the bot the game is waiting on can send a message, and a human can ask for help with it instead.
+// Humans can tag until the game finishes, unless the game ends in a vote. In elimination games, they can only tag
+// while a round is being played and they have tags left. AI bots only tag in games with AI accusations.
+func (m *classicMode) AllowedActions(game *Game, bot *Bot) []gameAction {
+	actions := []gameAction{}
+	if bot == nil || bot.eliminated {
+		return actions
+	}
+
+	if waitingOnBot := game.GetBotThatGameIsWaitingOn(); waitingOnBot != nil && waitingOnBot.id == bot.id {
+		actions = append(actions, sendMessageAction)
+		if bot.IsHuman() {
+			actions = append(actions, helpAction)
+		}
+	}
+
+	if bot.IsHuman() {
+		if !game.isFinished() && game.votingRounds == 0 {
+			if !game.elimination || (game.IsInPlay() && bot.TagsRemaining() > 0) {
+				actions = append(actions, tagAction)
+			}
+		}
+		if game.IsInStateVoting() {
+			actions = append(actions, voteAction)
+		}
+	} else if bot.IsAi() && game.aiAccusations && game.IsInPlay() {
+		actions = append(actions, tagAction)
+	}
+
+	return actions
+}
+
+// Classic games have no time limit. Voting runs out of time, but it is closed through GameUpdateAfterVoting instead.
+func (m *classicMode) GameUpdateAfterTimeUp(game *Game) (*GameUpdate, error) {
+	return nil, errors.New("game does not have a time limit")
+}
+
+func (m *classicMode) ViewState(game *Game, myBotId string) (gameViewState, string) {
+	waitingOnBot := game.GetBotThatGameIsWaitingOn()
+	switch game.state {
+	case started, playersJoined:
+		return waitingForPlayersToJoin, "Waiting for players to join in"
+	case waitingForAiQuestion:
+		return waitingOnBotToAskAQuestion, "Someone is asking a question"
+	case waitingForAiAnswer:
+		return waitingOnBotToAnswer,
+			fmt.Sprintf("%s is answering the question", waitingOnBot.name)
+	case waitingForHumanQuestion:
+		if game.getCurrentTurnBotId() == myBotId {
+			return waitingOnYouToAskAQuestion, "Ask a question. OR Click help!"
+		} else {
+			return waitingOnBotToAskAQuestion, "Someone is asking a question"
+		}
+	case waitingForHumanAnswer:
+		if game.lastQuestionTargetBotId == myBotId {
+			return waitingOnYouToAnswer, "Answer the question. OR Click help!"
+		} else {
+			return waitingOnBotToAnswer,
+				fmt.Sprintf("%s is answering the question", waitingOnBot.name)
+		}
+	case finished:
+		return finishedViewState(game, myBotId)
+	case botEliminated:
+		return aiBotEliminated, "An AI bot has been eliminated. The next round is starting."
+	case voting:
+		if myVote := game.VoteOf(myBotId); myVote != nil {
+			if suspectBot := game.BotWithId(myVote.SuspectBotId); suspectBot != nil {
+				return waitingForVotes, fmt.Sprintf("You voted for %s. Waiting for the votes to come in.", suspectBot.name)
+			}
+		}
+		return waitingForVotes, "Vote for the bot you think is human."
+	default:
+		return undefinedGameViewState, "This is not supposed to happen. What did happen?"
+	}
+}
+
+func finishedViewState(game *Game, myBotId string) (gameViewState, string) {
+	if game.winningBotId == myBotId {
+		return youWon, game.result
+	} else if !utilities.IsBlank(game.winningBotId) {
+		return youLost, game.result
+	}
+	return timeUp, game.result
+}
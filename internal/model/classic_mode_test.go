@@ -0,0 +1,406 @@
+package model
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClassicTestGame(t *testing.T, opts GameOptions) *Game {
+	bots := []*Bot{
+		{id: "bot_id1", name: "bot1", typeOfBot: ai},
+		{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+		{id: "bot_id3", name: "bot3", typeOfBot: human, player: &Player{id: "player_id2"}},
+		{id: "bot_id4", name: "bot4", typeOfBot: ai},
+	}
+	opts.Id = "game_id1"
+	opts.TurnOrder = []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"}
+	opts.Bots = bots
+	game, err := NewGame(opts)
+	assert.NoError(t, err)
+	return game
+}
+
+func Test_ClassicMode_AllowedActions(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameOptions    GameOptions
+		botId          string
+		expectedOutput []gameAction
+	}{
+		{
+			name:           "human can only tag while waiting for players",
+			gameOptions:    GameOptions{State: "PLAYERS_JOINED"},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{tagAction},
+		},
+		{
+			name:           "human can send a message, get help or tag on its turn",
+			gameOptions:    GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{sendMessageAction, helpAction, tagAction},
+		},
+		{
+			name:           "human can only tag on another bot's turn",
+			gameOptions:    GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			botId:          "bot_id3",
+			expectedOutput: []gameAction{tagAction},
+		},
+		{
+			name:           "human can answer a question asked to it",
+			gameOptions:    GameOptions{State: "WAITING_FOR_HUMAN_ANSWER", LastQuestionTargetBotId: "bot_id3"},
+			botId:          "bot_id3",
+			expectedOutput: []gameAction{sendMessageAction, helpAction, tagAction},
+		},
+		{
+			name:           "ai bot can send a message on its turn but not get help",
+			gameOptions:    GameOptions{State: "WAITING_FOR_AI_QUESTION"},
+			botId:          "bot_id1",
+			expectedOutput: []gameAction{sendMessageAction},
+		},
+		{
+			name:           "ai bot can tag in games with ai accusations",
+			gameOptions:    GameOptions{State: "WAITING_FOR_AI_QUESTION", AiAccusations: true},
+			botId:          "bot_id4",
+			expectedOutput: []gameAction{tagAction},
+		},
+		{
+			name:           "nobody can do anything once the game has finished",
+			gameOptions:    GameOptions{State: "FINISHED"},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+		{
+			name:           "human cannot tag between elimination rounds",
+			gameOptions:    GameOptions{State: "BOT_ELIMINATED", Elimination: true},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+		{
+			name:           "human can tag during an elimination round",
+			gameOptions:    GameOptions{State: "WAITING_FOR_AI_QUESTION", Elimination: true},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{tagAction},
+		},
+		{
+			name:           "human cannot tag in a voting game",
+			gameOptions:    GameOptions{State: "WAITING_FOR_AI_QUESTION", VotingRounds: 2},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+		{
+			name:           "human can vote while voting",
+			gameOptions:    GameOptions{State: "VOTING", VotingRounds: 2},
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{voteAction},
+		},
+		{
+			name:           "ai bot cannot vote while voting",
+			gameOptions:    GameOptions{State: "VOTING", VotingRounds: 2, AiVotes: true},
+			botId:          "bot_id1",
+			expectedOutput: []gameAction{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newClassicTestGame(t, tt.gameOptions)
+			actions := game.mode.AllowedActions(game, game.BotWithId(tt.botId))
+			assert.Equal(t, tt.expectedOutput, actions)
+		})
+	}
+}
+
+func Test_ClassicMode_GameUpdateToStartRound(t *testing.T) {
+	tests := []struct {
+		name              string
+		gameOptions       GameOptions
+		eliminatedBotIds  []string
+		expectedState     gameState
+		expectedTurnOrder []string
+	}{
+		{
+			name:              "starts with a question from the first bot in a new turn order",
+			gameOptions:       GameOptions{State: "PLAYERS_JOINED"},
+			expectedState:     waitingForHumanQuestion,
+			expectedTurnOrder: []string{"bot_id3", "bot_id2", "bot_id1", "bot_id4"},
+		},
+		{
+			name:              "leaves eliminated bots out of the new turn order",
+			gameOptions:       GameOptions{State: "BOT_ELIMINATED", Elimination: true},
+			eliminatedBotIds:  []string{"bot_id3"},
+			expectedState:     waitingForHumanQuestion,
+			expectedTurnOrder: []string{"bot_id2", "bot_id1", "bot_id4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			game := newClassicTestGame(t, tt.gameOptions)
+			for _, botId := range tt.eliminatedBotIds {
+				game.BotWithId(botId).eliminated = true
+			}
+			update, err := game.GetGameUpdateToStartRound()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedState, update.State)
+			assert.Equal(t, tt.expectedTurnOrder, update.TurnOrder)
+			assert.Equal(t, int64(0), *update.CurrentTurnIndex)
+			assert.False(t, *update.StateHandled)
+		})
+	}
+}
+
+func Test_ClassicMode_ViewState(t *testing.T) {
+	tests := []struct {
+		name                   string
+		gameOptions            GameOptions
+		myBotId                string
+		expectedState          gameViewState
+		expectedDisplayMessage string
+	}{
+		{
+			name:                   "waits for players to join",
+			gameOptions:            GameOptions{State: "STARTED"},
+			myBotId:                "bot_id2",
+			expectedState:          waitingForPlayersToJoin,
+			expectedDisplayMessage: "Waiting for players to join in",
+		},
+		{
+			name:                   "asks me to ask a question on my turn",
+			gameOptions:            GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			myBotId:                "bot_id2",
+			expectedState:          waitingOnYouToAskAQuestion,
+			expectedDisplayMessage: "Ask a question. OR Click help!",
+		},
+		{
+			name:                   "shows who is answering",
+			gameOptions:            GameOptions{State: "WAITING_FOR_AI_ANSWER", LastQuestionTargetBotId: "bot_id4"},
+			myBotId:                "bot_id2",
+			expectedState:          waitingOnBotToAnswer,
+			expectedDisplayMessage: "bot4 is answering the question",
+		},
+		{
+			name:                   "shows a draw once finished without a winner",
+			gameOptions:            GameOptions{State: "FINISHED", Result: "It's a draw."},
+			myBotId:                "bot_id2",
+			expectedState:          timeUp,
+			expectedDisplayMessage: "It's a draw.",
+		},
+		{
+			name:                   "asks me to vote",
+			gameOptions:            GameOptions{State: "VOTING", VotingRounds: 1, StateTotalTime: 60, StateHandledAt: &time.Time{}},
+			myBotId:                "bot_id2",
+			expectedState:          waitingForVotes,
+			expectedDisplayMessage: "Vote for the bot you think is human.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newClassicTestGame(t, tt.gameOptions)
+			state, displayMessage := game.mode.ViewState(game, tt.myBotId)
+			assert.Equal(t, tt.expectedState, state)
+			assert.Equal(t, tt.expectedDisplayMessage, displayMessage)
+		})
+	}
+}
+
+func Test_ClassicMode_GetGameUpdateAfterIncomingMessage_MessageTypes(t *testing.T) {
+	askedAt := time.Now().Add(-time.Minute)
+	conversation := []*Message{
+		{SourceBotId: "bot_id1", TargetBotId: "bot_id4", Text: "What is your name?", CreatedAt: askedAt, MessageType: "question"},
+		{SourceBotId: "bot_id4", TargetBotId: "bot_id4", Text: "bot4, obviously", CreatedAt: askedAt.Add(10 * time.Second), MessageType: "answer"},
+		{SourceBotId: "bot_id2", TargetBotId: "bot_id4", Text: "👍", CreatedAt: askedAt.Add(20 * time.Second), MessageType: "reaction"},
+	}
+	stateHandled := false
+	followUpText := "Why obviously?"
+	lastQuestionTargetBotId := "bot_id4"
+	turnIndex1 := int64(1)
+	turnIndex2 := int64(2)
+
+	tests := []struct {
+		name           string
+		gameOptions    GameOptions
+		sourceBotId    string
+		targetBotId    string
+		text           string
+		messageType    string
+		expectedOutput *GameUpdate
+		errorString    string
+	}{
+		{
+			name:        "follow-up is asked like a question to the bot that answered last",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id4",
+			text:        followUpText,
+			messageType: "follow_up",
+			expectedOutput: &GameUpdate{
+				State:                   waitingForAiAnswer,
+				StateHandled:            &stateHandled,
+				LastQuestion:            &followUpText,
+				LastQuestionTargetBotId: &lastQuestionTargetBotId,
+			},
+		},
+		{
+			name:        "errors if follow-up is asked to another bot",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id3",
+			text:        followUpText,
+			messageType: "follow_up",
+			errorString: "follow-up should be asked to the bot that answered the last question",
+		},
+		{
+			name:        "errors if there is nothing to follow up on",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id4",
+			text:        followUpText,
+			messageType: "follow_up",
+			errorString: "there is no answer to follow up on",
+		},
+		{
+			name:        "passing a question hands the turn to the next bot",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id2",
+			text:        "Pass",
+			messageType: "pass",
+			expectedOutput: &GameUpdate{
+				State:            waitingForHumanQuestion,
+				CurrentTurnIndex: &turnIndex2,
+				StateHandled:     &stateHandled,
+			},
+		},
+		{
+			name:        "passing an answer hands the turn to the next bot",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_ANSWER", CurrentTurnIndex: 0, LastQuestionTargetBotId: "bot_id3"},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id3",
+			text:        "Pass",
+			messageType: "pass",
+			expectedOutput: &GameUpdate{
+				State:            waitingForHumanQuestion,
+				CurrentTurnIndex: &turnIndex1,
+				StateHandled:     &stateHandled,
+			},
+		},
+		{
+			name:        "errors if pass is targeted at another bot",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id3",
+			text:        "Pass",
+			messageType: "pass",
+			errorString: "pass should have same source and target bot",
+		},
+		{
+			name:        "errors if bot passes when it is not its turn",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id3",
+			text:        "Pass",
+			messageType: "pass",
+			errorString: "incorrect sourceBotId",
+		},
+		{
+			name:           "reaction to the latest message does not change the turn",
+			gameOptions:    GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId:    "bot_id3",
+			targetBotId:    "bot_id4",
+			text:           "😂",
+			messageType:    "reaction",
+			expectedOutput: &GameUpdate{State: waitingForHumanQuestion},
+		},
+		{
+			name:        "errors if reaction is not one of the allowed emoji",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id4",
+			text:        "lol",
+			messageType: "reaction",
+			errorString: "reaction should be one of the allowed emoji",
+		},
+		{
+			name:        "errors if reaction is not targeted at the bot that sent the latest message",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id1",
+			text:        "😂",
+			messageType: "reaction",
+			errorString: "reaction should be targeted at the bot that sent the latest message",
+		},
+		{
+			name:        "errors if bot reacts to its own message",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1, LastQuestionTargetBotId: "bot_id4", Messages: conversation},
+			sourceBotId: "bot_id4",
+			targetBotId: "bot_id4",
+			text:        "😂",
+			messageType: "reaction",
+			errorString: "cannot react to own message",
+		},
+		{
+			name:        "errors if there is no message to react to",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id4",
+			text:        "😂",
+			messageType: "reaction",
+			errorString: "there is no message to react to",
+		},
+		{
+			name:        "errors if an answer is sent while waiting for a question",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id2",
+			text:        "An answer",
+			messageType: "answer",
+			errorString: "expecting a question but received answer",
+		},
+		{
+			name:        "errors if a question is sent while waiting for an answer",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_ANSWER", LastQuestionTargetBotId: "bot_id3"},
+			sourceBotId: "bot_id3",
+			targetBotId: "bot_id2",
+			text:        "A question?",
+			messageType: "question",
+			errorString: "expecting an answer but received question",
+		},
+		{
+			name:        "errors for a chat message",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id2",
+			text:        "hello everyone",
+			messageType: "chat",
+			errorString: "cannot send a chat message in this game",
+		},
+		{
+			name:        "errors for an unknown message type",
+			gameOptions: GameOptions{State: "WAITING_FOR_HUMAN_QUESTION", CurrentTurnIndex: 1},
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id3",
+			text:        "A question?",
+			messageType: "shout",
+			errorString: "invalid message type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newClassicTestGame(t, tt.gameOptions)
+			update, err := game.GetGameUpdateAfterIncomingMessage(tt.sourceBotId, tt.targetBotId, tt.text, tt.messageType)
+			if tt.errorString != "" {
+				assert.EqualError(t, err, tt.errorString)
+				assert.Nil(t, update)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, update)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package model
 
 import (
-	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,6 +11,27 @@ import (
 
 const GAME_EXPIRY_DURATION = -4 * time.Hour
 
+// In games with AI accusations, an AI bot accuses the bot it suspects of being human once its confidence reaches
+// AI_ACCUSATION_CONFIDENCE_THRESHOLD, and goes on to tag that bot once it reaches AI_TAG_CONFIDENCE_THRESHOLD.
+const AI_ACCUSATION_CONFIDENCE_THRESHOLD = 70
+const AI_TAG_CONFIDENCE_THRESHOLD = 90
+
+// In elimination games, each human has a limited number of tags, and has to wait between them.
+const MAX_TAGS_PER_HUMAN = 3
+const TAG_COOLDOWN = 60 * time.Second
+
+// In voting games, the conversation stops after the given number of rounds, and everyone gets VOTING_DURATION to vote.
+const VOTING_DURATION = 60 * time.Second
+
+// Group chats last GROUP_CHAT_DURATION, and each bot can post up to GROUP_CHAT_MESSAGE_CAP messages in that time.
+const GROUP_CHAT_DURATION = 5 * time.Minute
+const GROUP_CHAT_MESSAGE_CAP = 10
+
+// In team games, the imposter wins once every bot has had TEAM_ROUND_LIMIT turns. Each human gets TEAM_TAGS_PER_HUMAN
+// tags to catch it with.
+const TEAM_ROUND_LIMIT = 3
+const TEAM_TAGS_PER_HUMAN = 1
+
 type Game struct {
 	id                      string
 	state                   gameState
@@ -28,6 +49,17 @@ type Game struct {
 	result                  string
 	winningBotId            string
 	public                  bool
+	promptVersion           string
+	conversationSummary     ConversationSummary
+	aiAccusations           bool
+	aiAccusationCheckedAt   *time.Time
+	elimination             bool
+	votingRounds            int64
+	aiVotes                 bool
+	votes                   []*Vote
+	mode                    GameMode
+	decoyBotId              string
+	version                 int64
 }
 
 type GameOptions struct {
@@ -47,6 +79,18 @@ type GameOptions struct {
 	Result                  string
 	WinningBotId            string
 	Public                  bool
+	PromptVersion           string
+	ConversationSummary     string
+	SummarizedMessageCount  int64
+	AiAccusations           bool
+	AiAccusationCheckedAt   *time.Time
+	Elimination             bool
+	VotingRounds            int64
+	AiVotes                 bool
+	Votes                   []*Vote
+	Mode                    string
+	DecoyBotId              string
+	Version                 int64
 }
 
 func NewGame(opts GameOptions) (*Game, error) {
@@ -67,6 +111,24 @@ func NewGame(opts GameOptions) (*Game, error) {
 		return nil, errors.New("cannot create game with empty bots array")
 	}
 
+	if opts.VotingRounds < 0 {
+		return nil, errors.New("cannot create game with negative voting rounds")
+	}
+
+	// Games created before modes were introduced are all classic games.
+	modeType := classic
+	if !utilities.IsBlank(opts.Mode) {
+		modeType = GameModeType(opts.Mode)
+	}
+	mode := gameModeFor(modeType)
+	if mode == nil {
+		return nil, errors.New("cannot create game with an invalid mode")
+	}
+
+	if (modeType == team || modeType == practice) && (opts.AiAccusations || opts.Elimination || opts.VotingRounds > 0 || opts.AiVotes) {
+		return nil, errors.Errorf("cannot create %s game with ai accusations, elimination or voting", strings.ToLower(modeType.String()))
+	}
+
 	if !utilities.IsBlank(opts.LastQuestionTargetBotId) {
 		targetBotFound := false
 		for _, bot := range opts.Bots {
@@ -96,9 +158,136 @@ func NewGame(opts GameOptions) (*Game, error) {
 		result:                  opts.Result,
 		winningBotId:            opts.WinningBotId,
 		public:                  opts.Public,
+		promptVersion:           opts.PromptVersion,
+		conversationSummary: ConversationSummary{
+			Text:         opts.ConversationSummary,
+			MessageCount: opts.SummarizedMessageCount,
+		},
+		aiAccusations:         opts.AiAccusations,
+		aiAccusationCheckedAt: opts.AiAccusationCheckedAt,
+		elimination:           opts.Elimination,
+		votingRounds:          opts.VotingRounds,
+		aiVotes:               opts.AiVotes,
+		votes:                 opts.Votes,
+		mode:                  mode,
+		decoyBotId:            opts.DecoyBotId,
+		version:               opts.Version,
 	}, nil
 }
 
+func (game *Game) Id() string {
+	return game.id
+}
+
+// Version is the version the game was loaded at. Updates can require the game to still be at this version.
+func (game *Game) Version() int64 {
+	return game.version
+}
+
+// GameTurn is the part of a game that decides whose move it is. Reactions, help, votes and the like leave it alone.
+type GameTurn struct {
+	State                   string
+	CurrentTurnIndex        int64
+	LastQuestionTargetBotId string
+}
+
+// Turn is the turn the game was loaded at. Updates can require the game to still be at this turn.
+func (game *Game) Turn() GameTurn {
+	return GameTurn{
+		State:                   game.state.String(),
+		CurrentTurnIndex:        game.currentTurnIndex,
+		LastQuestionTargetBotId: game.lastQuestionTargetBotId,
+	}
+}
+
+func (game *Game) State() string {
+	return game.state.String()
+}
+
+func (game *Game) Bots() []*Bot {
+	return game.bots
+}
+
+func (game *Game) Mode() string {
+	return game.mode.Type().String()
+}
+
+// HumanPlayerCount is the number of humans that need to join before the game can start.
+func (game *Game) HumanPlayerCount() int64 {
+	return game.mode.HumanPlayerCount()
+}
+
+// AiImitatesHumans is true when the AI bots are trying to pass as humans among humans, rather than among other bots.
+func (game *Game) AiImitatesHumans() bool {
+	return game.mode.AiImitatesHumans()
+}
+
+// HelpBudget is the number of times each human can ask for help in the game.
+func (game *Game) HelpBudget() int64 {
+	return game.mode.HelpBudget()
+}
+
+func (game *Game) PromptVersion() string {
+	return game.promptVersion
+}
+
+func (game *Game) ConversationSummary() ConversationSummary {
+	return game.conversationSummary
+}
+
+func (game *Game) AiAccusations() bool {
+	return game.aiAccusations
+}
+
+func (game *Game) Elimination() bool {
+	return game.elimination
+}
+
+func (game *Game) VotingRounds() int64 {
+	return game.votingRounds
+}
+
+func (game *Game) AiVotes() bool {
+	return game.aiVotes
+}
+
+// IsInPlay is true while the bots are taking turns asking and answering questions.
+func (game *Game) IsInPlay() bool {
+	return game.state.isWaitingForMessage()
+}
+
+func (game *Game) ShouldAiAccuse(confidence int64) bool {
+	return game.aiAccusations && confidence >= AI_ACCUSATION_CONFIDENCE_THRESHOLD
+}
+
+// HasHumanAnswerSinceAiAccusationCheck is true once a human has answered since the AI bots last considered accusing
+// someone, which is when there is something new for them to consider.
+func (game *Game) HasHumanAnswerSinceAiAccusationCheck() bool {
+	for _, message := range game.messages {
+		if !message.IsAnswer() {
+			continue
+		}
+		sourceBot := game.BotWithId(message.SourceBotId)
+		if sourceBot == nil || !sourceBot.IsHuman() {
+			continue
+		}
+		if game.aiAccusationCheckedAt == nil || message.CreatedAt.After(*game.aiAccusationCheckedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldAiTag is only true for human suspects. A confident AI bot that suspects another AI bot only accuses it,
+// which has no effect on the result of the game.
+func (game *Game) ShouldAiTag(suspectBotId string, confidence int64) bool {
+	suspect := game.BotWithId(suspectBotId)
+	if suspect == nil || !suspect.IsHuman() {
+		return false
+	}
+	return game.aiAccusations && confidence >= AI_TAG_CONFIDENCE_THRESHOLD
+}
+
 func (game *Game) HasJustStarted() bool {
 	return game.state == started
 }
@@ -106,7 +295,7 @@ func (game *Game) HasJustStarted() bool {
 func (game *Game) GetOneRandomAiBot() (*Bot, error) {
 	aiBots := []*Bot{}
 	for _, bot := range game.bots {
-		if bot.IsAi() {
+		if bot.IsAi() && !bot.eliminated {
 			aiBots = append(aiBots, bot)
 		}
 	}
@@ -139,6 +328,16 @@ func (game *Game) BotWithId(botId string) *Bot {
 	return nil
 }
 
+// BotWithName ignores case, since names can come back from the AI in any case.
+func (game *Game) BotWithName(name string) *Bot {
+	for _, bot := range game.bots {
+		if strings.EqualFold(bot.name, strings.TrimSpace(name)) {
+			return bot
+		}
+	}
+	return nil
+}
+
 func (game *Game) BotWithPlayerId(playerId string) *Bot {
 	for _, bot := range game.bots {
 		if bot.player != nil && bot.player.id == playerId {
@@ -173,10 +372,26 @@ func (game *Game) IsInStatePlayersJoined() bool {
 	return game.state == playersJoined
 }
 
+func (game *Game) IsInStateBotEliminated() bool {
+	return game.state == botEliminated
+}
+
+func (game *Game) IsInStateVoting() bool {
+	return game.state == voting
+}
+
+func (game *Game) IsInStateChatting() bool {
+	return game.state == chatting
+}
+
 func (game *Game) isFinished() bool {
 	return game.state == finished
 }
 
+func (game *Game) IsInStateFinished() bool {
+	return game.isFinished()
+}
+
 func (game *Game) IsInStateWaitingForAiQuestion() bool {
 	return game.state.isWaitingForAQuestion() && game.state.isWaitingOnAi()
 }
@@ -196,7 +411,9 @@ func (game *Game) IsInStateWaitingForHumanAnswer() bool {
 func (game *Game) RandomizedTurnOrder() []string {
 	botIds := []string{}
 	for _, bot := range game.bots {
-		botIds = append(botIds, bot.id)
+		if !bot.eliminated {
+			botIds = append(botIds, bot.id)
+		}
 	}
 
 	rand.Shuffle(len(botIds), func(i, j int) {
@@ -218,133 +435,60 @@ type GameUpdate struct {
 	LastQuestionTargetBotId *string
 	Result                  *string
 	WinningBotId            *string
+	TurnOrder               []string
+	TaggingBotId            *string
+	EliminatedBotId         *string
+	StateHandledAt          *time.Time
+	StateTotalTime          *int64
+	DecoyBotId              *string
 }
 
-func (game *Game) GetGameUpdateAfterIncomingMessage(sourceBotId string, targetBotId string, text string) (*GameUpdate, error) {
-	sourceBot := game.BotWithId(sourceBotId)
-	targetBot := game.BotWithId(targetBotId)
-
-	if sourceBot == nil {
-		return nil, errors.New("invalid sourceBotId")
-	}
-
-	if targetBot == nil {
-		return nil, errors.New("invalid targetBotId")
-	}
-
-	state := game.state
-	expectedSourceBotId, err := game.expectedSourceBotIdForWaitingMessage()
-	if err != nil {
-		return nil, err
-	}
-
-	if expectedSourceBotId != sourceBotId {
-		return nil, errors.New("incorrect sourceBotId")
-	}
-
-	if state.isWaitingOnAi() && !sourceBot.IsAi() {
-		return nil, errors.New("expecting AI message but did not receive one")
-	}
-
-	if state.isWaitingOnHuman() && !sourceBot.IsHuman() {
-		return nil, errors.New("expecting Human message but did not receive one")
-	}
-
-	update := GameUpdate{}
-	var nextBot *Bot
-
-	if state.isWaitingForAQuestion() {
-		if sourceBotId == targetBotId {
-			return nil, errors.New("questioning message should have different source and target bot")
-		}
-		nextBot = targetBot
-	} else if state.isWaitingForAnAnswer() {
-		if sourceBotId != targetBotId {
-			return nil, errors.New("answering message should have same source and target bot")
-		}
-		nextBot = game.BotWithId(game.getNextTurnBotId())
-	}
-
-	update.State = getNewStateForNextBot(state, nextBot)
-
-	if update.State.isWaitingForAQuestion() {
-		nextIndex := game.currentTurnIndex + 1
-		update.CurrentTurnIndex = &nextIndex
-	} else if update.State.isWaitingForAnAnswer() {
-		update.LastQuestion = &text
-		update.LastQuestionTargetBotId = &(nextBot.id)
-	}
-
-	// TODO: Wondering if we really need stateHandled on all the games.
-	stateHandled := false
-	update.StateHandled = &stateHandled
+func (update *GameUpdate) FinishesGame() bool {
+	return update.State == finished
+}
 
-	return &update, nil
+func (game *Game) GetGameUpdateToStartRound() (*GameUpdate, error) {
+	return game.mode.GameUpdateToStartRound(game)
 }
 
-func getNewStateForNextBot(currentState gameState, nextBot *Bot) gameState {
-	if currentState.isWaitingForAQuestion() {
-		if nextBot.IsAi() {
-			return waitingForAiAnswer
-		} else if nextBot.IsHuman() {
-			return waitingForHumanAnswer
-		}
-	} else if currentState.isWaitingForAnAnswer() {
-		if nextBot.IsAi() {
-			return waitingForAiQuestion
-		} else if nextBot.IsHuman() {
-			return waitingForHumanQuestion
-		}
+// The message type decides how the game moves on, and each mode only accepts the types that make sense in its current state.
+func (game *Game) GetGameUpdateAfterIncomingMessage(sourceBotId string, targetBotId string, text string, messageTypeString string) (*GameUpdate, error) {
+	msgType := MessageType(messageTypeString)
+	if !msgType.Valid() {
+		return nil, errors.New("invalid message type")
 	}
-	return undefinedGameState
+	return game.mode.GameUpdateAfterIncomingMessage(game, sourceBotId, targetBotId, text, msgType)
 }
 
 func (game *Game) GetGameUpdateAfterTag(sourceBotId string, targetBotId string) (*GameUpdate, error) {
-	if game.isFinished() {
-		return nil, errors.New("game has already finished")
-	}
-
-	if sourceBotId == targetBotId {
-		return nil, errors.New("cannot tag self")
-	}
-
-	sourceBot := game.BotWithId(sourceBotId)
-	targetBot := game.BotWithId(targetBotId)
-
-	if sourceBot == nil {
-		return nil, errors.New("invalid sourceBotId")
-	}
+	return game.mode.GameUpdateAfterTag(game, sourceBotId, targetBotId)
+}
 
-	if targetBot == nil {
-		return nil, errors.New("invalid targetBotId")
-	}
+func (game *Game) GetGameUpdateAfterTimeUp() (*GameUpdate, error) {
+	return game.mode.GameUpdateAfterTimeUp(game)
+}
 
-	if sourceBot.IsAi() {
-		return nil, errors.New("ai cannot perform tagging")
+// stateTimeHasRunOut is true once stateTotalTime seconds have passed since stateHandledAt.
+// States without a time limit never run out.
+func (game *Game) stateTimeHasRunOut(now time.Time) bool {
+	if game.stateHandledAt == nil {
+		return false
 	}
+	return !now.Before(game.stateHandledAt.Add(time.Duration(game.stateTotalTime) * time.Second))
+}
 
-	update := GameUpdate{}
-
-	if targetBot.IsHuman() {
-		result := fmt.Sprintf("%s tagged %s and won.", sourceBot.name, targetBot.name)
-		update.State = finished
-		update.WinningBotId = &sourceBotId
-		update.Result = &result
-	} else if targetBot.IsAi() {
-		var otherBot *Bot
-		for _, bot := range game.bots {
-			if bot.IsHuman() && bot.id != sourceBotId {
-				otherBot = bot
-			}
+func (game *Game) allows(bot *Bot, action gameAction) bool {
+	for _, allowedAction := range game.mode.AllowedActions(game, bot) {
+		if allowedAction == action {
+			return true
 		}
-		result := fmt.Sprintf("%s tagged %s and lost. %s won.", sourceBot.name, targetBot.name, otherBot.name)
-		update.State = finished
-		update.WinningBotId = &otherBot.id
-		update.Result = &result
-	} else {
-		return nil, utilities.NewBadError("target bot was neither human nor ai")
 	}
-	return &update, nil
+	return false
+}
+
+// AllowsHelpFor is true when bot is a human whose turn it is.
+func (game *Game) AllowsHelpFor(bot *Bot) bool {
+	return game.allows(bot, helpAction)
 }
 
 func (game *Game) expectedSourceBotIdForWaitingMessage() (string, error) {
@@ -364,6 +508,12 @@ func (game *Game) expectedSourceBotIdForWaitingMessage() (string, error) {
 }
 
 func (game *Game) GetTargetBotIdForNextQuestion() (string, error) {
+	return game.GetSuspectedTargetBotIdForNextQuestion(nil)
+}
+
+// GetSuspectedTargetBotIdForNextQuestion picks the most suspicious of the bots that can be asked the next question,
+// going by suspicionScores. Ties, including bots without a score, go to the bot that has answered the fewest questions.
+func (game *Game) GetSuspectedTargetBotIdForNextQuestion(suspicionScores map[string]int64) (string, error) {
 	botAnswerCountMap := make(map[string]int)
 	for _, message := range game.messages {
 		if message.IsAnswer() {
@@ -373,7 +523,7 @@ func (game *Game) GetTargetBotIdForNextQuestion() (string, error) {
 
 	possibleTargetBotIds := []string{}
 	for _, bot := range game.bots {
-		if bot.id != game.getCurrentTurnBotId() {
+		if bot.id != game.getCurrentTurnBotId() && !bot.eliminated {
 			possibleTargetBotIds = append(possibleTargetBotIds, bot.id)
 		}
 	}
@@ -382,6 +532,21 @@ func (game *Game) GetTargetBotIdForNextQuestion() (string, error) {
 		return "", errors.New("cannot get target bot from an empty list")
 	}
 
+	highestSuspicionScore := suspicionScores[possibleTargetBotIds[0]]
+	for _, botId := range possibleTargetBotIds {
+		if suspicionScores[botId] > highestSuspicionScore {
+			highestSuspicionScore = suspicionScores[botId]
+		}
+	}
+
+	mostSuspectedBotIds := []string{}
+	for _, botId := range possibleTargetBotIds {
+		if suspicionScores[botId] == highestSuspicionScore {
+			mostSuspectedBotIds = append(mostSuspectedBotIds, botId)
+		}
+	}
+	possibleTargetBotIds = mostSuspectedBotIds
+
 	leastNumberOfAnswers := botAnswerCountMap[possibleTargetBotIds[0]]
 	for _, botId := range possibleTargetBotIds {
 		if botAnswerCountMap[botId] < leastNumberOfAnswers {
@@ -426,13 +591,20 @@ func (game *Game) GetDetailedMessages() []DetailedMessage {
 	unsortedDetailedMessages := []DetailedMessage{}
 	for _, message := range game.messages {
 		unsortedDetailedMessages = append(unsortedDetailedMessages, DetailedMessage{
-			Text:          message.Text,
-			CreatedAt:     message.CreatedAt,
-			SourceBotId:   message.SourceBotId,
-			SourceBotName: botNameMap[message.SourceBotId],
-			TargetBotId:   message.TargetBotId,
-			TargetBotName: botNameMap[message.TargetBotId],
-			MessageType:   message.MessageType,
+			Text:             message.Text,
+			CreatedAt:        message.CreatedAt,
+			SourceBotId:      message.SourceBotId,
+			SourceBotName:    botNameMap[message.SourceBotId],
+			TargetBotId:      message.TargetBotId,
+			TargetBotName:    botNameMap[message.TargetBotId],
+			MessageType:      message.MessageType,
+			ResponseTime:     message.ResponseTime,
+			HelpUsage:        message.HelpUsage,
+			HelpStyle:        message.HelpStyle,
+			AiModel:          message.AiModel,
+			PromptVersion:    message.PromptVersion,
+			PromptTokens:     message.PromptTokens,
+			CompletionTokens: message.CompletionTokens,
 		})
 	}
 
@@ -441,6 +613,32 @@ func (game *Game) GetDetailedMessages() []DetailedMessage {
 	return sortedDetailedMessages
 }
 
+// ResponseTimeForNextMessage is the time taken to respond since the game last changed state.
+// A state change is marked either by stateHandledAt or by the most recent message, whichever is later.
+// If neither exists, the last update to the game is used instead.
+func (game *Game) ResponseTimeForNextMessage(respondedAt time.Time) time.Duration {
+	var waitingSince time.Time
+	if game.stateHandledAt != nil {
+		waitingSince = *game.stateHandledAt
+	}
+	for _, message := range game.messages {
+		if message.IsAccusation() || message.IsReaction() {
+			// Accusations and reactions happen outside the turns, so the bot that is responding was not waiting on them.
+			continue
+		}
+		if message.CreatedAt.After(waitingSince) {
+			waitingSince = message.CreatedAt
+		}
+	}
+	if waitingSince.IsZero() {
+		waitingSince = game.updatedAt
+	}
+	if waitingSince.IsZero() || respondedAt.Before(waitingSince) {
+		return 0
+	}
+	return respondedAt.Sub(waitingSince)
+}
+
 func (game *Game) GetBotNames() []string {
 	botNames := []string{}
 	for _, bot := range game.bots {
@@ -0,0 +1,26 @@
+package model
+
+type gameAction int64
+
+const (
+	undefinedGameAction gameAction = iota
+	sendMessageAction
+	tagAction
+	voteAction
+	helpAction
+)
+
+func (a gameAction) String() string {
+	switch a {
+	case sendMessageAction:
+		return "SEND_MESSAGE"
+	case tagAction:
+		return "TAG"
+	case voteAction:
+		return "VOTE"
+	case helpAction:
+		return "HELP"
+	default:
+		return "UNDEFINED"
+	}
+}
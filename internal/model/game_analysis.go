@@ -0,0 +1,15 @@
+package model
+
+// BotAnalysis is how human a bot seemed, looking back at its messages once the game is over. HumannessScore goes from 0
+// for clearly an AI to 100 for clearly a human. Tells are the messages that gave away the most about what the bot
+// really was.
+type BotAnalysis struct {
+	BotId          string
+	HumannessScore int64
+	Tells          []string
+}
+
+type GameAnalysis struct {
+	GameId      string
+	BotAnalyses []BotAnalysis
+}
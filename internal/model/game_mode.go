@@ -0,0 +1,49 @@
+package model
+
+import "github.com/vipulvpatil/airetreat-go/internal/utilities"
+
+// GameMode owns the rules of a game. It decides how each round starts, how the game moves from one state to the
+// next, what each bot is allowed to do in every state, how the game is won and how each state is shown to a player.
+// Game delegates to its mode, so adding a mode does not need changes anywhere else.
+type GameMode interface {
+	Type() gameModeType
+	HumanPlayerCount() int64
+	AiImitatesHumans() bool
+	HelpBudget() int64
+	GameUpdateToStartRound(game *Game) (*GameUpdate, error)
+	GameUpdateAfterIncomingMessage(game *Game, sourceBotId string, targetBotId string, text string, msgType messageType) (*GameUpdate, error)
+	GameUpdateAfterTag(game *Game, sourceBotId string, targetBotId string) (*GameUpdate, error)
+	GameUpdateAfterVoting(game *Game, aiVotes []*Vote) (*GameUpdate, error)
+	GameUpdateAfterTimeUp(game *Game) (*GameUpdate, error)
+	AllowedActions(game *Game, bot *Bot) []gameAction
+	ViewState(game *Game, myBotId string) (gameViewState, string)
+}
+
+func gameModeFor(modeType gameModeType) GameMode {
+	switch modeType {
+	case classic:
+		return &classicMode{}
+	case groupChat:
+		return &groupChatMode{}
+	case team:
+		return &teamMode{}
+	case practice:
+		return &practiceMode{}
+	default:
+		return nil
+	}
+}
+
+// HumanPlayerCountForMode is the number of humans that need to join a game of the given mode before it can start.
+// Games without a mode are classic games, and invalid modes need no humans at all.
+func HumanPlayerCountForMode(modeString string) int64 {
+	modeType := classic
+	if !utilities.IsBlank(modeString) {
+		modeType = GameModeType(modeString)
+	}
+	mode := gameModeFor(modeType)
+	if mode == nil {
+		return 0
+	}
+	return mode.HumanPlayerCount()
+}
@@ -0,0 +1,45 @@
+package model
+
+type gameModeType int64
+
+const (
+	undefinedGameModeType gameModeType = iota
+	classic
+	groupChat
+	team
+	practice
+)
+
+func GameModeType(str string) gameModeType {
+	switch str {
+	case "CLASSIC":
+		return classic
+	case "GROUP_CHAT":
+		return groupChat
+	case "TEAM":
+		return team
+	case "PRACTICE":
+		return practice
+	default:
+		return undefinedGameModeType
+	}
+}
+
+func (m gameModeType) String() string {
+	switch m {
+	case classic:
+		return "CLASSIC"
+	case groupChat:
+		return "GROUP_CHAT"
+	case team:
+		return "TEAM"
+	case practice:
+		return "PRACTICE"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+func (m gameModeType) Valid() bool {
+	return m.String() != "UNDEFINED"
+}
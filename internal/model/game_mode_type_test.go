@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GameModeType(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput gameModeType
+	}{
+		{
+			name:           "creates CLASSIC game mode type",
+			input:          "CLASSIC",
+			expectedOutput: classic,
+		},
+		{
+			name:           "creates GROUP_CHAT game mode type",
+			input:          "GROUP_CHAT",
+			expectedOutput: groupChat,
+		},
+		{
+			name:           "creates TEAM game mode type",
+			input:          "TEAM",
+			expectedOutput: team,
+		},
+		{
+			name:           "creates PRACTICE game mode type",
+			input:          "PRACTICE",
+			expectedOutput: practice,
+		},
+		{
+			name:           "handles unknown game mode type",
+			input:          "unknown",
+			expectedOutput: undefinedGameModeType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modeType := GameModeType(tt.input)
+			assert.Equal(t, modeType, tt.expectedOutput)
+		})
+	}
+}
+
+func Test_GameModeType_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          gameModeType
+		expectedOutput string
+	}{
+		{
+			name:           "gets CLASSIC from classic game mode type",
+			input:          classic,
+			expectedOutput: "CLASSIC",
+		},
+		{
+			name:           "gets GROUP_CHAT from groupChat game mode type",
+			input:          groupChat,
+			expectedOutput: "GROUP_CHAT",
+		},
+		{
+			name:           "gets TEAM from team game mode type",
+			input:          team,
+			expectedOutput: "TEAM",
+		},
+		{
+			name:           "gets PRACTICE from practice game mode type",
+			input:          practice,
+			expectedOutput: "PRACTICE",
+		},
+		{
+			name:           "gets unknown from undefinedGameModeType game mode type",
+			input:          undefinedGameModeType,
+			expectedOutput: "UNDEFINED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modeTypeString := tt.input.String()
+			assert.Equal(t, modeTypeString, tt.expectedOutput)
+		})
+	}
+}
+
+func Test_GameModeType_Valid(t *testing.T) {
+	t.Run("returns true for a valid game mode type", func(t *testing.T) {
+		assert.True(t, classic.Valid())
+	})
+
+	t.Run("returns false for a invalid game mode type", func(t *testing.T) {
+		assert.False(t, undefinedGameModeType.Valid())
+	})
+}
@@ -11,6 +11,9 @@ const (
 	waitingForHumanQuestion
 	waitingForHumanAnswer
 	finished
+	botEliminated
+	voting
+	chatting
 )
 
 func GameState(str string) gameState {
@@ -29,6 +32,12 @@ func GameState(str string) gameState {
 		return waitingForHumanAnswer
 	case "FINISHED":
 		return finished
+	case "BOT_ELIMINATED":
+		return botEliminated
+	case "VOTING":
+		return voting
+	case "CHATTING":
+		return chatting
 	default:
 		return undefinedGameState
 	}
@@ -50,6 +59,12 @@ func (s gameState) String() string {
 		return "WAITING_FOR_HUMAN_ANSWER"
 	case finished:
 		return "FINISHED"
+	case botEliminated:
+		return "BOT_ELIMINATED"
+	case voting:
+		return "VOTING"
+	case chatting:
+		return "CHATTING"
 	default:
 		return "UNDEFINED"
 	}
@@ -47,6 +47,21 @@ func Test_GameState(t *testing.T) {
 			input:          "FINISHED",
 			expectedOutput: finished,
 		},
+		{
+			name:           "creates BOT_ELIMINATED account type",
+			input:          "BOT_ELIMINATED",
+			expectedOutput: botEliminated,
+		},
+		{
+			name:           "creates VOTING account type",
+			input:          "VOTING",
+			expectedOutput: voting,
+		},
+		{
+			name:           "creates CHATTING account type",
+			input:          "CHATTING",
+			expectedOutput: chatting,
+		},
 		{
 			name:           "handles unknown account type",
 			input:          "unknown",
@@ -103,6 +118,21 @@ func Test_GameState_String(t *testing.T) {
 			input:          finished,
 			expectedOutput: "FINISHED",
 		},
+		{
+			name:           "gets BOT_ELIMINATED from botEliminated game state",
+			input:          botEliminated,
+			expectedOutput: "BOT_ELIMINATED",
+		},
+		{
+			name:           "gets VOTING from voting game state",
+			input:          voting,
+			expectedOutput: "VOTING",
+		},
+		{
+			name:           "gets CHATTING from chatting game state",
+			input:          chatting,
+			expectedOutput: "CHATTING",
+		},
 		{
 			name:           "gets unknown from undefinedGameState game state",
 			input:          undefinedGameState,
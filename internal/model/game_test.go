@@ -2,6 +2,7 @@ package model
 
 import (
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,6 +60,46 @@ func Test_NewGame(t *testing.T) {
 			errorExpected:  true,
 			errorString:    "cannot create game with empty bots array",
 		},
+		{
+			name: "negative voting rounds",
+			input: GameOptions{
+				Id:           "123",
+				State:        "STARTED",
+				TurnOrder:    []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				Bots:         []*Bot{bot},
+				VotingRounds: -1,
+			},
+			expectedOutput: nil,
+			errorExpected:  true,
+			errorString:    "cannot create game with negative voting rounds",
+		},
+		{
+			name: "invalid mode",
+			input: GameOptions{
+				Id:        "123",
+				State:     "STARTED",
+				TurnOrder: []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				Bots:      []*Bot{bot},
+				Mode:      "unknown",
+			},
+			expectedOutput: nil,
+			errorExpected:  true,
+			errorString:    "cannot create game with an invalid mode",
+		},
+		{
+			name: "team mode with elimination",
+			input: GameOptions{
+				Id:          "123",
+				State:       "STARTED",
+				TurnOrder:   []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				Bots:        []*Bot{bot},
+				Mode:        "TEAM",
+				Elimination: true,
+			},
+			expectedOutput: nil,
+			errorExpected:  true,
+			errorString:    "cannot create team game with ai accusations, elimination or voting",
+		},
 		{
 			name: "invalid last question target bot",
 			input: GameOptions{
@@ -83,6 +124,7 @@ func Test_NewGame(t *testing.T) {
 				Bots:                    []*Bot{bot},
 			},
 			expectedOutput: &Game{
+				mode:                    &classicMode{},
 				id:                      "123",
 				state:                   started,
 				turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
@@ -238,6 +280,7 @@ func Test_BotWithId(t *testing.T) {
 				botId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -275,6 +318,7 @@ func Test_BotWithId(t *testing.T) {
 				botId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -307,6 +351,20 @@ func Test_BotWithId(t *testing.T) {
 		})
 	}
 }
+func Test_BotWithName(t *testing.T) {
+	game := &Game{
+		mode: &classicMode{},
+		bots: []*Bot{
+			{id: "bot_id1", name: "Avis", typeOfBot: ai},
+			{id: "bot_id2", name: "ED-I", typeOfBot: human, player: &Player{id: "player_id1"}},
+		},
+	}
+
+	assert.Equal(t, "bot_id2", game.BotWithName("ED-I").id)
+	assert.Equal(t, "bot_id1", game.BotWithName(" avis ").id)
+	assert.Nil(t, game.BotWithName("Sort"))
+}
+
 func Test_HasPlayer(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -323,6 +381,7 @@ func Test_HasPlayer(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -356,6 +415,7 @@ func Test_HasPlayer(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -386,6 +446,7 @@ func Test_HasPlayer(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -435,6 +496,7 @@ func Test_BotWithPlayerId(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -475,6 +537,7 @@ func Test_BotWithPlayerId(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -505,6 +568,7 @@ func Test_BotWithPlayerId(t *testing.T) {
 				playerId string
 			}{
 				game: &Game{
+					mode:  &classicMode{},
 					state: started,
 					bots: []*Bot{
 						{
@@ -546,6 +610,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 			sourceBotId string
 			targetBotId string
 			text        string
+			messageType string
 		}
 		expectedOutput *GameUpdate
 		errorExpected  bool
@@ -558,8 +623,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -587,6 +654,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id2",
 				targetBotId: "bot_id3",
 				text:        "What is the answer?",
+				messageType: "question",
 			},
 			expectedOutput: func() *GameUpdate {
 				stateHandled := false
@@ -611,8 +679,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -642,6 +712,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id2",
 				targetBotId: "bot_id2",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: func() *GameUpdate {
 				currentTurnIndex := int64(2)
@@ -665,8 +736,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanQuestion,
 					currentTurnIndex: 2,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -694,6 +767,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id1",
 				text:        "What is the next question?",
+				messageType: "question",
 			},
 			expectedOutput: func() *GameUpdate {
 				stateHandled := false
@@ -718,8 +792,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -749,6 +825,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id3",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: func() *GameUpdate {
 				currentTurnIndex := int64(2)
@@ -772,8 +849,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 0,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -803,6 +882,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id3",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: func() *GameUpdate {
 				currentTurnIndex := int64(1)
@@ -826,8 +906,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -857,6 +939,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id5",
 				targetBotId: "bot_id3",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -869,8 +952,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -900,6 +985,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "",
 				targetBotId: "bot_id3",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -912,8 +998,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -943,6 +1031,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id5",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -955,8 +1044,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -986,6 +1077,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "",
 				text:        "This is the answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -998,8 +1090,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            playersJoined,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1029,6 +1123,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id3",
 				text:        "This is the answer",
+				messageType: "question",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1041,8 +1136,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1072,6 +1169,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id1",
 				targetBotId: "bot_id3",
 				text:        "what?",
+				messageType: "question",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1084,8 +1182,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1115,6 +1215,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id1",
 				targetBotId: "bot_id3",
 				text:        "answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1127,8 +1228,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1158,6 +1261,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id3",
 				text:        "answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1170,8 +1274,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1201,6 +1307,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id2",
 				targetBotId: "bot_id2",
 				text:        "Another question?",
+				messageType: "question",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1214,8 +1321,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1245,6 +1354,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id3",
 				targetBotId: "bot_id2",
 				text:        "answer",
+				messageType: "answer",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1257,8 +1367,10 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId string
 				targetBotId string
 				text        string
+				messageType string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1288,6 +1400,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 				sourceBotId: "bot_id2",
 				targetBotId: "bot_id2",
 				text:        "Another question?",
+				messageType: "question",
 			},
 			expectedOutput: nil,
 			errorExpected:  true,
@@ -1296,7 +1409,7 @@ func Test_GetGameUpdateAfterIncomingMessage(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := tt.input.game.GetGameUpdateAfterIncomingMessage(tt.input.sourceBotId, tt.input.targetBotId, tt.input.text)
+			result, err := tt.input.game.GetGameUpdateAfterIncomingMessage(tt.input.sourceBotId, tt.input.targetBotId, tt.input.text, tt.input.messageType)
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedOutput, result)
@@ -1327,6 +1440,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1378,6 +1492,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1429,6 +1544,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1470,6 +1586,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1511,6 +1628,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1552,6 +1670,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1593,6 +1712,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            finished,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1634,6 +1754,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1675,6 +1796,7 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 				targetBotId string
 			}{
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForHumanAnswer,
 					currentTurnIndex: 1,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -1711,6 +1833,162 @@ func Test_GetGameUpdateAfterTag(t *testing.T) {
 			errorExpected:  true,
 			errorString:    "cannot tag self",
 		},
+		{
+			name: "returns the game update for an ai tag of a human when ai accusations are enabled",
+			input: struct {
+				game        *Game
+				sourceBotId string
+				targetBotId string
+			}{
+				game: &Game{
+					mode:             &classicMode{},
+					state:            waitingForAiQuestion,
+					currentTurnIndex: 1,
+					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"},
+					aiAccusations:    true,
+					bots: []*Bot{
+						{
+							id:        "bot_id1",
+							name:      "bot1",
+							typeOfBot: ai,
+						},
+						{
+							id:        "bot_id2",
+							name:      "bot2",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id1",
+							},
+						},
+						{
+							id:        "bot_id3",
+							name:      "bot3",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id2",
+							},
+						},
+						{
+							id:        "bot_id4",
+							name:      "bot4",
+							typeOfBot: ai,
+						},
+					},
+				},
+				sourceBotId: "bot_id1",
+				targetBotId: "bot_id2",
+			},
+			expectedOutput: func() *GameUpdate {
+				result := "bot2 was tagged by bot1 and lost. bot3 won."
+				winningBotId := "bot_id3"
+
+				return &GameUpdate{
+					State:        finished,
+					Result:       &result,
+					WinningBotId: &winningBotId,
+				}
+			}(),
+			errorExpected: false,
+			errorString:   "",
+		},
+		{
+			name: "errors if an ai tags another ai when ai accusations are enabled",
+			input: struct {
+				game        *Game
+				sourceBotId string
+				targetBotId string
+			}{
+				game: &Game{
+					mode:             &classicMode{},
+					state:            waitingForAiQuestion,
+					currentTurnIndex: 1,
+					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"},
+					aiAccusations:    true,
+					bots: []*Bot{
+						{
+							id:        "bot_id1",
+							name:      "bot1",
+							typeOfBot: ai,
+						},
+						{
+							id:        "bot_id2",
+							name:      "bot2",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id1",
+							},
+						},
+						{
+							id:        "bot_id3",
+							name:      "bot3",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id2",
+							},
+						},
+						{
+							id:        "bot_id4",
+							name:      "bot4",
+							typeOfBot: ai,
+						},
+					},
+				},
+				sourceBotId: "bot_id1",
+				targetBotId: "bot_id4",
+			},
+			expectedOutput: nil,
+			errorExpected:  true,
+			errorString:    "ai can only tag humans",
+		},
+		{
+			name: "errors if an ai tags a human when ai accusations are disabled",
+			input: struct {
+				game        *Game
+				sourceBotId string
+				targetBotId string
+			}{
+				game: &Game{
+					mode:             &classicMode{},
+					state:            waitingForAiQuestion,
+					currentTurnIndex: 1,
+					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"},
+					aiAccusations:    false,
+					bots: []*Bot{
+						{
+							id:        "bot_id1",
+							name:      "bot1",
+							typeOfBot: ai,
+						},
+						{
+							id:        "bot_id2",
+							name:      "bot2",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id1",
+							},
+						},
+						{
+							id:        "bot_id3",
+							name:      "bot3",
+							typeOfBot: human,
+							player: &Player{
+								id: "player_id2",
+							},
+						},
+						{
+							id:        "bot_id4",
+							name:      "bot4",
+							typeOfBot: ai,
+						},
+					},
+				},
+				sourceBotId: "bot_id1",
+				targetBotId: "bot_id2",
+			},
+			expectedOutput: nil,
+			errorExpected:  true,
+			errorString:    "ai cannot perform tagging",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1735,6 +2013,7 @@ func Test_StateHasBeenHandled(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: true,
 			},
@@ -1743,6 +2022,7 @@ func Test_StateHasBeenHandled(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1767,6 +2047,7 @@ func Test_IsInStatePlayersJoined(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        playersJoined,
 				stateHandled: true,
 			},
@@ -1775,6 +2056,7 @@ func Test_IsInStatePlayersJoined(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1790,6 +2072,40 @@ func Test_IsInStatePlayersJoined(t *testing.T) {
 	}
 }
 
+func Test_IsInStateBotEliminated(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *Game
+		expectedOutput bool
+	}{
+		{
+			name: "returns true",
+			input: &Game{
+				mode:         &classicMode{},
+				state:        botEliminated,
+				stateHandled: false,
+			},
+			expectedOutput: true,
+		},
+		{
+			name: "returns false",
+			input: &Game{
+				mode:         &classicMode{},
+				state:        waitingForAiQuestion,
+				stateHandled: false,
+			},
+			expectedOutput: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.input.IsInStateBotEliminated()
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
 func Test_IsInStateWaitingForAiQuestion(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1799,6 +2115,7 @@ func Test_IsInStateWaitingForAiQuestion(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        waitingForAiQuestion,
 				stateHandled: true,
 			},
@@ -1807,6 +2124,7 @@ func Test_IsInStateWaitingForAiQuestion(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1831,6 +2149,7 @@ func Test_IsInStateWaitingForAiAnswer(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        waitingForAiAnswer,
 				stateHandled: true,
 			},
@@ -1839,6 +2158,7 @@ func Test_IsInStateWaitingForAiAnswer(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1863,6 +2183,7 @@ func Test_IsInStateWaitingForHumanQuestion(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        waitingForHumanQuestion,
 				stateHandled: true,
 			},
@@ -1871,6 +2192,7 @@ func Test_IsInStateWaitingForHumanQuestion(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1895,6 +2217,7 @@ func Test_IsInStateWaitingForHumanAnswer(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        waitingForHumanAnswer,
 				stateHandled: true,
 			},
@@ -1903,6 +2226,7 @@ func Test_IsInStateWaitingForHumanAnswer(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 			},
@@ -1927,6 +2251,7 @@ func Test_RandomizedTurnOrder(t *testing.T) {
 		{
 			name: "returns randomized turn order",
 			input: &Game{
+				mode:  &classicMode{},
 				state: playersJoined,
 				bots: []*Bot{
 					{
@@ -1980,6 +2305,7 @@ func Test_RecentlyUpdated(t *testing.T) {
 		{
 			name: "returns true",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        waitingForAiAnswer,
 				stateHandled: true,
 				updatedAt:    timeNow,
@@ -1989,6 +2315,7 @@ func Test_RecentlyUpdated(t *testing.T) {
 		{
 			name: "returns false",
 			input: &Game{
+				mode:         &classicMode{},
 				state:        started,
 				stateHandled: false,
 				updatedAt:    timeOld,
@@ -2016,6 +2343,7 @@ func Test_GetTargetBotForNextQuestion(t *testing.T) {
 		{
 			name: "errors if game has no bots",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            started,
 				turnOrder:        []string{"bot_id1"},
 				currentTurnIndex: 0,
@@ -2027,6 +2355,7 @@ func Test_GetTargetBotForNextQuestion(t *testing.T) {
 		{
 			name: "errors if only one bot",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            started,
 				turnOrder:        []string{"bot_id1"},
 				currentTurnIndex: 0,
@@ -2045,6 +2374,7 @@ func Test_GetTargetBotForNextQuestion(t *testing.T) {
 		{
 			name: "returns random bot with least messages",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            started,
 				turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 				currentTurnIndex: 2,
@@ -2113,6 +2443,7 @@ func Test_GetTargetBotForNextQuestion(t *testing.T) {
 		{
 			name: "returns random bot with least messages excluding current turn bot",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            started,
 				turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 				currentTurnIndex: 1,
@@ -2191,6 +2522,66 @@ func Test_GetTargetBotForNextQuestion(t *testing.T) {
 	}
 }
 
+func Test_GetSuspectedTargetBotIdForNextQuestion(t *testing.T) {
+	newGame := func() *Game {
+		return &Game{
+			mode:             &classicMode{},
+			state:            waitingForHumanQuestion,
+			turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+			currentTurnIndex: 1,
+			bots: []*Bot{
+				{id: "bot_id1", name: "bot1", typeOfBot: ai},
+				{id: "bot_id2", name: "bot2", typeOfBot: human},
+				{id: "bot_id3", name: "bot3", typeOfBot: ai},
+				{id: "bot_id4", name: "bot4", typeOfBot: ai, eliminated: true},
+				{id: "bot_id5", name: "bot5", typeOfBot: ai},
+			},
+			messages: []*Message{
+				{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "question 1", CreatedAt: time.Now(), MessageType: "question"},
+				{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "answer 1", CreatedAt: time.Now(), MessageType: "answer"},
+				{SourceBotId: "bot_id1", TargetBotId: "bot_id3", Text: "question 2", CreatedAt: time.Now(), MessageType: "question"},
+				{SourceBotId: "bot_id3", TargetBotId: "bot_id3", Text: "answer 2", CreatedAt: time.Now(), MessageType: "answer"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		suspicionScores map[string]int64
+		expectedOutput  string
+	}{
+		{
+			name:            "returns the most suspected bot",
+			suspicionScores: map[string]int64{"bot_id1": 1, "bot_id3": 2},
+			expectedOutput:  "bot_id3",
+		},
+		{
+			name:            "returns the bot with least answers among the most suspected",
+			suspicionScores: map[string]int64{"bot_id1": 1, "bot_id3": 1, "bot_id5": 1},
+			expectedOutput:  "bot_id5",
+		},
+		{
+			name:            "ignores the current turn bot and eliminated bots",
+			suspicionScores: map[string]int64{"bot_id2": 5, "bot_id4": 5, "bot_id1": 1},
+			expectedOutput:  "bot_id1",
+		},
+		{
+			name:            "returns the bot with least answers without any suspicion",
+			suspicionScores: nil,
+			expectedOutput:  "bot_id5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			result, err := newGame().GetSuspectedTargetBotIdForNextQuestion(tt.suspicionScores)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
 func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -2200,6 +2591,7 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		{
 			name: "returns bot with current turn when waiting for bot to ask question",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            waitingForAiQuestion,
 				currentTurnIndex: 1,
 				turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -2233,6 +2625,7 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		{
 			name: "returns bot with lastQuestionTargetBotId when waiting for bot to answer question",
 			input: &Game{
+				mode:                    &classicMode{},
 				state:                   waitingForAiAnswer,
 				currentTurnIndex:        1,
 				turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -2267,6 +2660,7 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		{
 			name: "returns bot with current turn when waiting for human to ask question",
 			input: &Game{
+				mode:             &classicMode{},
 				state:            waitingForHumanQuestion,
 				currentTurnIndex: 2,
 				turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -2303,6 +2697,7 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		{
 			name: "returns bot with lastQuestionTargetBotId when waiting for huma to answer question",
 			input: &Game{
+				mode:                    &classicMode{},
 				state:                   waitingForHumanAnswer,
 				currentTurnIndex:        1,
 				turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -2340,6 +2735,7 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		{
 			name: "returns nil if game in unexpected state",
 			input: &Game{
+				mode:                    &classicMode{},
 				state:                   playersJoined,
 				currentTurnIndex:        1,
 				turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3"},
@@ -2376,3 +2772,340 @@ func Test_GetBotThatGameIsWaitingOn(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResponseTimeForNextMessage(t *testing.T) {
+	now := time.Now()
+	stateHandledAt := now.Add(-30 * time.Second)
+	tests := []struct {
+		name           string
+		input          *Game
+		expectedOutput time.Duration
+	}{
+		{
+			name: "uses the latest message when it is after state handled at",
+			input: &Game{
+				mode:           &classicMode{},
+				stateHandledAt: &stateHandledAt,
+				messages: []*Message{
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "A question", CreatedAt: now.Add(-20 * time.Second), MessageType: "question"},
+					{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "An answer", CreatedAt: now.Add(-10 * time.Second), MessageType: "answer"},
+				},
+			},
+			expectedOutput: 10 * time.Second,
+		},
+		{
+			name: "uses state handled at when it is after the latest message",
+			input: &Game{
+				mode:           &classicMode{},
+				stateHandledAt: &stateHandledAt,
+				messages: []*Message{
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "A question", CreatedAt: now.Add(-40 * time.Second), MessageType: "question"},
+				},
+			},
+			expectedOutput: 30 * time.Second,
+		},
+		{
+			name: "ignores accusations since they happen outside the turns",
+			input: &Game{
+				mode:           &classicMode{},
+				stateHandledAt: &stateHandledAt,
+				messages: []*Message{
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "A question", CreatedAt: now.Add(-20 * time.Second), MessageType: "question"},
+					{SourceBotId: "bot_id3", TargetBotId: "bot_id2", Text: "I think bot2 is human.", CreatedAt: now.Add(-5 * time.Second), MessageType: "accusation"},
+				},
+			},
+			expectedOutput: 20 * time.Second,
+		},
+		{
+			name: "uses game updated at when there are no messages and state has not been handled",
+			input: &Game{
+				mode:      &classicMode{},
+				updatedAt: now.Add(-5 * time.Second),
+			},
+			expectedOutput: 5 * time.Second,
+		},
+		{
+			name:           "returns zero when there is nothing to measure from",
+			input:          &Game{},
+			expectedOutput: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.input.ResponseTimeForNextMessage(now)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func Test_ShouldAiAccuseAndTag(t *testing.T) {
+	newGame := func(aiAccusations bool) *Game {
+		return &Game{
+			mode:          &classicMode{},
+			aiAccusations: aiAccusations,
+			bots: []*Bot{
+				{id: "bot_id1", name: "bot1", typeOfBot: ai},
+				{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		aiAccusations bool
+		suspectBotId  string
+		confidence    int64
+		expectAccuse  bool
+		expectTag     bool
+	}{
+		{
+			name:          "does nothing when ai accusations are disabled",
+			aiAccusations: false,
+			suspectBotId:  "bot_id2",
+			confidence:    100,
+			expectAccuse:  false,
+			expectTag:     false,
+		},
+		{
+			name:          "does nothing below the accusation threshold",
+			aiAccusations: true,
+			suspectBotId:  "bot_id2",
+			confidence:    AI_ACCUSATION_CONFIDENCE_THRESHOLD - 1,
+			expectAccuse:  false,
+			expectTag:     false,
+		},
+		{
+			name:          "only accuses below the tag threshold",
+			aiAccusations: true,
+			suspectBotId:  "bot_id2",
+			confidence:    AI_TAG_CONFIDENCE_THRESHOLD - 1,
+			expectAccuse:  true,
+			expectTag:     false,
+		},
+		{
+			name:          "accuses and tags a human at the tag threshold",
+			aiAccusations: true,
+			suspectBotId:  "bot_id2",
+			confidence:    AI_TAG_CONFIDENCE_THRESHOLD,
+			expectAccuse:  true,
+			expectTag:     true,
+		},
+		{
+			name:          "only accuses an ai suspect at the tag threshold",
+			aiAccusations: true,
+			suspectBotId:  "bot_id1",
+			confidence:    AI_TAG_CONFIDENCE_THRESHOLD,
+			expectAccuse:  true,
+			expectTag:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newGame(tt.aiAccusations)
+			assert.Equal(t, tt.expectAccuse, game.ShouldAiAccuse(tt.confidence))
+			assert.Equal(t, tt.expectTag, game.ShouldAiTag(tt.suspectBotId, tt.confidence))
+		})
+	}
+}
+
+func Test_HasHumanAnswerSinceAiAccusationCheck(t *testing.T) {
+	answeredAt := time.Now().Add(-time.Minute)
+	before := answeredAt.Add(-time.Second)
+	after := answeredAt.Add(time.Second)
+	bots := []*Bot{
+		{id: "bot_id1", name: "bot1", typeOfBot: ai},
+		{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+	}
+
+	tests := []struct {
+		name                  string
+		messages              []*Message
+		aiAccusationCheckedAt *time.Time
+		output                bool
+	}{
+		{
+			name: "is true when a human has answered and there has been no check",
+			messages: []*Message{
+				{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "an answer", CreatedAt: answeredAt, MessageType: "answer"},
+			},
+			output: true,
+		},
+		{
+			name: "is true when a human has answered since the check",
+			messages: []*Message{
+				{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "an answer", CreatedAt: answeredAt, MessageType: "answer"},
+			},
+			aiAccusationCheckedAt: &before,
+			output:                true,
+		},
+		{
+			name: "is false when the check came after the answer",
+			messages: []*Message{
+				{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "an answer", CreatedAt: answeredAt, MessageType: "answer"},
+			},
+			aiAccusationCheckedAt: &after,
+			output:                false,
+		},
+		{
+			name: "is false when only the ai has answered, or the human has only asked",
+			messages: []*Message{
+				{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "an answer", CreatedAt: answeredAt, MessageType: "answer"},
+				{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "a question?", CreatedAt: answeredAt, MessageType: "question"},
+			},
+			output: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &Game{
+				mode:                  &classicMode{},
+				aiAccusations:         true,
+				aiAccusationCheckedAt: tt.aiAccusationCheckedAt,
+				bots:                  bots,
+				messages:              tt.messages,
+			}
+			assert.Equal(t, tt.output, game.HasHumanAnswerSinceAiAccusationCheck())
+		})
+	}
+}
+
+func Test_GetGameUpdateAfterTag_Elimination(t *testing.T) {
+	recently := time.Now().Add(-10 * time.Second)
+	longAgo := time.Now().Add(-2 * TAG_COOLDOWN)
+	newGame := func(bots ...*Bot) *Game {
+		turnOrder := []string{}
+		for _, bot := range bots {
+			if !bot.eliminated {
+				turnOrder = append(turnOrder, bot.id)
+			}
+		}
+		return &Game{
+			mode:        &classicMode{},
+			state:       waitingForHumanQuestion,
+			turnOrder:   turnOrder,
+			elimination: true,
+			bots:        bots,
+		}
+	}
+	humanBot := func(id string, tagCount int64, lastTaggedAt *time.Time) *Bot {
+		return &Bot{id: id, name: strings.Replace(id, "_id", "", 1), typeOfBot: human, player: &Player{id: "player_" + id}, tagCount: tagCount, lastTaggedAt: lastTaggedAt}
+	}
+	aiBot := func(id string, eliminated bool) *Bot {
+		return &Bot{id: id, name: strings.Replace(id, "_id", "", 1), typeOfBot: ai, eliminated: eliminated}
+	}
+	stringPtr := func(s string) *string { return &s }
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name           string
+		game           *Game
+		sourceBotId    string
+		targetBotId    string
+		expectedOutput *GameUpdate
+		errorString    string
+	}{
+		{
+			name:        "tagging the other human wins the game",
+			game:        newGame(aiBot("bot_id1", false), humanBot("bot_id2", 1, &longAgo), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id3",
+			expectedOutput: &GameUpdate{
+				State:        finished,
+				Result:       stringPtr("bot2 tagged bot3 and won."),
+				WinningBotId: stringPtr("bot_id2"),
+				TaggingBotId: stringPtr("bot_id2"),
+			},
+		},
+		{
+			name:        "tagging an ai bot eliminates it and ends the round",
+			game:        newGame(aiBot("bot_id1", false), aiBot("bot_id4", false), humanBot("bot_id2", 0, nil), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			expectedOutput: &GameUpdate{
+				State:           botEliminated,
+				StateHandled:    boolPtr(false),
+				TurnOrder:       []string{"bot_id4", "bot_id2", "bot_id3"},
+				TaggingBotId:    stringPtr("bot_id2"),
+				EliminatedBotId: stringPtr("bot_id1"),
+			},
+		},
+		{
+			name:        "eliminating the last ai bot finishes the game in favour of the human with fewer tags",
+			game:        newGame(aiBot("bot_id1", false), aiBot("bot_id4", true), humanBot("bot_id2", 1, &longAgo), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			expectedOutput: &GameUpdate{
+				State:           finished,
+				Result:          stringPtr("bot2 tagged bot1, the last AI bot. bot3 won with fewer tags."),
+				WinningBotId:    stringPtr("bot_id3"),
+				TurnOrder:       []string{"bot_id2", "bot_id3"},
+				TaggingBotId:    stringPtr("bot_id2"),
+				EliminatedBotId: stringPtr("bot_id1"),
+			},
+		},
+		{
+			name:        "eliminating the last ai bot is a draw when both humans used the same number of tags",
+			game:        newGame(aiBot("bot_id1", false), aiBot("bot_id4", true), humanBot("bot_id2", 0, nil), humanBot("bot_id3", 1, &longAgo)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			expectedOutput: &GameUpdate{
+				State:           finished,
+				Result:          stringPtr("bot2 tagged bot1, the last AI bot. It's a draw."),
+				TurnOrder:       []string{"bot_id2", "bot_id3"},
+				TaggingBotId:    stringPtr("bot_id2"),
+				EliminatedBotId: stringPtr("bot_id1"),
+			},
+		},
+		{
+			name:        "errors if the human has no tags remaining",
+			game:        newGame(aiBot("bot_id1", false), humanBot("bot_id2", MAX_TAGS_PER_HUMAN, &longAgo), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			errorString: "no tags remaining",
+		},
+		{
+			name:        "errors if the human tags again before the cooldown is over",
+			game:        newGame(aiBot("bot_id1", false), humanBot("bot_id2", 1, &recently), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			errorString: "cannot tag again for another 50 seconds",
+		},
+		{
+			name:        "errors if the target has already been eliminated",
+			game:        newGame(aiBot("bot_id1", true), aiBot("bot_id4", false), humanBot("bot_id2", 0, nil), humanBot("bot_id3", 0, nil)),
+			sourceBotId: "bot_id2",
+			targetBotId: "bot_id1",
+			errorString: "cannot tag an eliminated bot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.game.GetGameUpdateAfterTag(tt.sourceBotId, tt.targetBotId)
+			if tt.errorString == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, result)
+			} else {
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+
+	t.Run("errors if an eliminated bot takes part in the conversation", func(t *testing.T) {
+		game := newGame(aiBot("bot_id1", true), aiBot("bot_id4", false), humanBot("bot_id2", 0, nil), humanBot("bot_id3", 0, nil))
+		game.state = waitingForAiAnswer
+		game.lastQuestionTargetBotId = "bot_id1"
+		_, err := game.GetGameUpdateAfterIncomingMessage("bot_id1", "bot_id1", "An answer", "answer")
+		assert.EqualError(t, err, "eliminated bots cannot take part in the conversation")
+	})
+
+	t.Run("eliminated bots are left out of the turn order and random ai bot selection", func(t *testing.T) {
+		game := newGame(aiBot("bot_id1", true), aiBot("bot_id4", false), humanBot("bot_id2", 0, nil), humanBot("bot_id3", 0, nil))
+		assert.ElementsMatch(t, []string{"bot_id4", "bot_id2", "bot_id3"}, game.RandomizedTurnOrder())
+		randomAiBot, err := game.GetOneRandomAiBot()
+		assert.NoError(t, err)
+		assert.Equal(t, "bot_id4", randomAiBot.Id())
+	})
+}
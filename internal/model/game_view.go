@@ -8,16 +8,20 @@ import (
 )
 
 type GameView struct {
-	State            gameViewState
-	DisplayMessage   string
-	StateStartedAt   *time.Time
-	StateTotalTime   int64
-	LastQuestion     string
-	MyBotId          string
-	Bots             []BotView
-	DetailedMessages []DetailedMessage
-	WinningBotId     string
-	MyHelpCount      int64
+	State               gameViewState
+	DisplayMessage      string
+	StateStartedAt      *time.Time
+	StateTotalTime      int64
+	LastQuestion        string
+	MyBotId             string
+	Bots                []BotView
+	DetailedMessages    []DetailedMessage
+	WinningBotId        string
+	MyHelpCount         int64
+	MyTagsRemaining     int64
+	MyNextTagAt         *time.Time
+	MyVoteBotId         string
+	MyMessagesRemaining int64
 }
 
 func (g *Game) GameViewForPlayer(playerId string) *GameView {
@@ -33,9 +37,19 @@ func (g *Game) GameViewForPlayer(playerId string) *GameView {
 	myBotId := myBot.id
 	bots := prepareBotViews(g.bots)
 
-	state, displayMessage := convertGameStateToGameViewStateWithMessage(g, myBotId)
+	state, displayMessage := g.mode.ViewState(g, myBotId)
+	if accusation := g.latestAccusationAnnouncement(); !g.isFinished() && !utilities.IsBlank(accusation) {
+		displayMessage = fmt.Sprintf("%s %s", accusation, displayMessage)
+	}
+
+	detailedMessages := g.GetDetailedMessages()
+	if !g.isFinished() {
+		for i := range detailedMessages {
+			detailedMessages[i] = detailedMessages[i].withoutMetadata()
+		}
+	}
 
-	return &GameView{
+	gameView := &GameView{
 		State:            state,
 		DisplayMessage:   displayMessage,
 		StateStartedAt:   g.stateHandledAt,
@@ -43,55 +57,58 @@ func (g *Game) GameViewForPlayer(playerId string) *GameView {
 		LastQuestion:     g.lastQuestion,
 		MyBotId:          myBotId,
 		Bots:             bots,
-		DetailedMessages: g.GetDetailedMessages(),
+		DetailedMessages: detailedMessages,
 		WinningBotId:     g.winningBotId,
 		MyHelpCount:      myBot.helpCount,
 	}
+
+	if myVote := g.VoteOf(myBotId); myVote != nil {
+		gameView.MyVoteBotId = myVote.SuspectBotId
+	}
+
+	if g.IsInStateChatting() {
+		gameView.MyMessagesRemaining = g.ChatMessagesRemaining(myBotId)
+	}
+
+	if g.elimination {
+		gameView.MyTagsRemaining = myBot.TagsRemaining()
+		gameView.MyNextTagAt = myBot.NextTagAt()
+	} else if g.mode.Type() == team {
+		gameView.MyTagsRemaining = teamTagsRemaining(myBot)
+	}
+
+	return gameView
 }
 
 func prepareBotViews(bots []*Bot) []BotView {
 	botViews := []BotView{}
 	for _, bot := range bots {
 		botViews = append(botViews, BotView{
-			Id:   bot.id,
-			Name: bot.name,
+			Id:         bot.id,
+			Name:       bot.name,
+			Eliminated: bot.eliminated,
 		})
 	}
 
 	return botViews
 }
 
-func convertGameStateToGameViewStateWithMessage(g *Game, myBotId string) (gameViewState, string) {
-	waitingOnBot := g.GetBotThatGameIsWaitingOn()
-	switch g.state {
-	case started, playersJoined:
-		return waitingForPlayersToJoin, "Waiting for players to join in"
-	case waitingForAiQuestion:
-		return waitingOnBotToAskAQuestion, "Someone is asking a question"
-	case waitingForAiAnswer:
-		return waitingOnBotToAnswer,
-			fmt.Sprintf("%s is answering the question", waitingOnBot.name)
-	case waitingForHumanQuestion:
-		if g.getCurrentTurnBotId() == myBotId {
-			return waitingOnYouToAskAQuestion, "Ask a question. OR Click help!"
-		} else {
-			return waitingOnBotToAskAQuestion, "Someone is asking a question"
-		}
-	case waitingForHumanAnswer:
-		if g.lastQuestionTargetBotId == myBotId {
-			return waitingOnYouToAnswer, "Answer the question. OR Click help!"
-		} else {
-			return waitingOnBotToAnswer,
-				fmt.Sprintf("%s is answering the question", waitingOnBot.name)
+// latestAccusationAnnouncement announces an accusation until the next question or answer comes in.
+func (g *Game) latestAccusationAnnouncement() string {
+	var latestMessage *Message
+	for _, message := range g.messages {
+		if latestMessage == nil || message.CreatedAt.After(latestMessage.CreatedAt) {
+			latestMessage = message
 		}
-	case finished:
-		if g.winningBotId == myBotId {
-			return youWon, g.result
-		} else if !utilities.IsBlank(g.winningBotId) {
-			return youLost, g.result
-		}
-		return timeUp, g.result
-	default:
-		return undefinedGameViewState, "This is not supposed to happen. What did happen?"
 	}
+	if latestMessage == nil || !latestMessage.IsAccusation() {
+		return ""
+	}
+
+	sourceBot := g.BotWithId(latestMessage.SourceBotId)
+	targetBot := g.BotWithId(latestMessage.TargetBotId)
+	if sourceBot == nil || targetBot == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s accuses %s of being human!", sourceBot.name, targetBot.name)
 }
@@ -12,6 +12,9 @@ const (
 	youLost
 	youWon
 	timeUp
+	aiBotEliminated
+	waitingForVotes
+	chatInProgress
 )
 
 func GameViewState(str string) gameViewState {
@@ -32,6 +35,12 @@ func GameViewState(str string) gameViewState {
 		return youWon
 	case "TIME_UP":
 		return timeUp
+	case "AI_BOT_ELIMINATED":
+		return aiBotEliminated
+	case "WAITING_FOR_VOTES":
+		return waitingForVotes
+	case "CHAT_IN_PROGRESS":
+		return chatInProgress
 	default:
 		return undefinedGameViewState
 	}
@@ -55,6 +64,12 @@ func (s gameViewState) String() string {
 		return "YOU_WON"
 	case timeUp:
 		return "TIME_UP"
+	case aiBotEliminated:
+		return "AI_BOT_ELIMINATED"
+	case waitingForVotes:
+		return "WAITING_FOR_VOTES"
+	case chatInProgress:
+		return "CHAT_IN_PROGRESS"
 	default:
 		return "UNDEFINED"
 	}
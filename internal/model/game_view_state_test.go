@@ -52,6 +52,21 @@ func Test_GameViewState(t *testing.T) {
 			input:          "TIME_UP",
 			expectedOutput: timeUp,
 		},
+		{
+			name:           "creates AI_BOT_ELIMINATED account type",
+			input:          "AI_BOT_ELIMINATED",
+			expectedOutput: aiBotEliminated,
+		},
+		{
+			name:           "creates WAITING_FOR_VOTES account type",
+			input:          "WAITING_FOR_VOTES",
+			expectedOutput: waitingForVotes,
+		},
+		{
+			name:           "creates CHAT_IN_PROGRESS account type",
+			input:          "CHAT_IN_PROGRESS",
+			expectedOutput: chatInProgress,
+		},
 		{
 			name:           "handles unknown account type",
 			input:          "unknown",
@@ -113,6 +128,21 @@ func Test_GameViewState_String(t *testing.T) {
 			input:          timeUp,
 			expectedOutput: "TIME_UP",
 		},
+		{
+			name:           "gets AI_BOT_ELIMINATED from aiBotEliminated game view state",
+			input:          aiBotEliminated,
+			expectedOutput: "AI_BOT_ELIMINATED",
+		},
+		{
+			name:           "gets WAITING_FOR_VOTES from waitingForVotes game view state",
+			input:          waitingForVotes,
+			expectedOutput: "WAITING_FOR_VOTES",
+		},
+		{
+			name:           "gets CHAT_IN_PROGRESS from chatInProgress game view state",
+			input:          chatInProgress,
+			expectedOutput: "CHAT_IN_PROGRESS",
+		},
 		{
 			name:           "gets unknown from undefinedGameViewState game state",
 			input:          undefinedGameViewState,
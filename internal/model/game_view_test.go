@@ -24,6 +24,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:             &classicMode{},
 					state:            started,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex: 1,
@@ -138,6 +139,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:             &classicMode{},
 					state:            playersJoined,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex: 1,
@@ -255,6 +257,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:             &classicMode{},
 					state:            waitingForAiQuestion,
 					turnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex: 1,
@@ -372,6 +375,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForAiAnswer,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        4,
@@ -490,6 +494,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForHumanQuestion,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        10,
@@ -608,6 +613,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id1",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForHumanAnswer,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        3,
@@ -726,6 +732,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id2",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForHumanQuestion,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        10,
@@ -844,6 +851,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id2",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForHumanAnswer,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        3,
@@ -965,6 +973,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id2",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   finished,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        3,
@@ -1088,6 +1097,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id2",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   finished,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        3,
@@ -1208,6 +1218,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id2",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   finished,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        3,
@@ -1328,6 +1339,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForAiAnswer,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        4,
@@ -1379,6 +1391,7 @@ func Test_GameViewForPlayer(t *testing.T) {
 			}{
 				playerId: "player_id3",
 				game: &Game{
+					mode:                    &classicMode{},
 					state:                   waitingForAiAnswer,
 					turnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 					currentTurnIndex:        4,
@@ -1434,3 +1447,158 @@ func Test_GameViewForPlayer(t *testing.T) {
 		})
 	}
 }
+
+func Test_GameViewForPlayer_MessageMetadata(t *testing.T) {
+	newGame := func(state gameState) *Game {
+		return &Game{
+			mode:                    &classicMode{},
+			state:                   state,
+			turnOrder:               []string{"bot_id1", "bot_id2"},
+			currentTurnIndex:        0,
+			lastQuestionTargetBotId: "bot_id1",
+			bots: []*Bot{
+				{id: "bot_id1", name: "bot1", typeOfBot: human, player: &Player{id: "player_id1"}},
+				{id: "bot_id2", name: "bot2", typeOfBot: ai},
+			},
+			messages: []*Message{
+				{
+					SourceBotId:      "bot_id2",
+					TargetBotId:      "bot_id1",
+					Text:             "A question",
+					MessageType:      "question",
+					ResponseTime:     9 * time.Second,
+					HelpUsage:        "NONE",
+					AiModel:          "some-model",
+					PromptVersion:    "v1",
+					PromptTokens:     120,
+					CompletionTokens: 8,
+				},
+			},
+		}
+	}
+
+	t.Run("hides message metadata while the game is being played", func(t *testing.T) {
+		gameView := newGame(waitingForHumanAnswer).GameViewForPlayer("player_id1")
+		assert.Equal(t, DetailedMessage{
+			SourceBotId:   "bot_id2",
+			SourceBotName: "bot2",
+			TargetBotId:   "bot_id1",
+			TargetBotName: "bot1",
+			Text:          "A question",
+			MessageType:   "question",
+		}, gameView.DetailedMessages[0])
+	})
+
+	t.Run("shows message metadata once the game has finished", func(t *testing.T) {
+		gameView := newGame(finished).GameViewForPlayer("player_id1")
+		assert.Equal(t, DetailedMessage{
+			SourceBotId:      "bot_id2",
+			SourceBotName:    "bot2",
+			TargetBotId:      "bot_id1",
+			TargetBotName:    "bot1",
+			Text:             "A question",
+			MessageType:      "question",
+			ResponseTime:     9 * time.Second,
+			HelpUsage:        "NONE",
+			AiModel:          "some-model",
+			PromptVersion:    "v1",
+			PromptTokens:     120,
+			CompletionTokens: 8,
+		}, gameView.DetailedMessages[0])
+	})
+}
+
+func Test_GameViewForPlayer_Accusation(t *testing.T) {
+	now := time.Now()
+	newGame := func(state gameState, messages []*Message) *Game {
+		return &Game{
+			mode:                    &classicMode{},
+			state:                   state,
+			turnOrder:               []string{"bot_id1", "bot_id2"},
+			currentTurnIndex:        0,
+			lastQuestionTargetBotId: "bot_id1",
+			aiAccusations:           true,
+			bots: []*Bot{
+				{id: "bot_id1", name: "bot1", typeOfBot: human, player: &Player{id: "player_id1"}},
+				{id: "bot_id2", name: "bot2", typeOfBot: ai},
+			},
+			messages: messages,
+		}
+	}
+	question := &Message{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "A question", MessageType: "question", CreatedAt: now}
+	accusation := &Message{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "I think bot1 is human.", MessageType: "accusation", CreatedAt: now.Add(time.Second)}
+	answer := &Message{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "An answer", MessageType: "answer", CreatedAt: now.Add(2 * time.Second)}
+
+	t.Run("announces the latest accusation", func(t *testing.T) {
+		gameView := newGame(waitingForHumanAnswer, []*Message{question, accusation}).GameViewForPlayer("player_id1")
+		assert.Equal(t, "bot2 accuses bot1 of being human! Answer the question. OR Click help!", gameView.DisplayMessage)
+	})
+
+	t.Run("stops announcing the accusation once the game moves on", func(t *testing.T) {
+		gameView := newGame(waitingForHumanQuestion, []*Message{question, accusation, answer}).GameViewForPlayer("player_id1")
+		assert.Equal(t, "Ask a question. OR Click help!", gameView.DisplayMessage)
+	})
+
+	t.Run("does not announce the accusation once the game has finished", func(t *testing.T) {
+		game := newGame(finished, []*Message{question, accusation})
+		game.result = "bot1 was tagged by bot2 and lost."
+		gameView := game.GameViewForPlayer("player_id1")
+		assert.Equal(t, "bot1 was tagged by bot2 and lost.", gameView.DisplayMessage)
+	})
+}
+
+func Test_GameViewForPlayer_Elimination(t *testing.T) {
+	lastTaggedAt := time.Now()
+	game := &Game{
+		mode:        &classicMode{},
+		state:       botEliminated,
+		turnOrder:   []string{"bot_id2", "bot_id3"},
+		elimination: true,
+		bots: []*Bot{
+			{id: "bot_id1", name: "bot1", typeOfBot: ai, eliminated: true},
+			{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}, tagCount: 1, lastTaggedAt: &lastTaggedAt},
+			{id: "bot_id3", name: "bot3", typeOfBot: human, player: &Player{id: "player_id2"}},
+		},
+	}
+
+	t.Run("shows eliminated bots and the tags remaining for the player", func(t *testing.T) {
+		nextTagAt := lastTaggedAt.Add(TAG_COOLDOWN)
+		gameView := game.GameViewForPlayer("player_id1")
+		AssertEqualGameView(t, &GameView{
+			State:          aiBotEliminated,
+			DisplayMessage: "An AI bot has been eliminated. The next round is starting.",
+			MyBotId:        "bot_id2",
+			Bots: []BotView{
+				{Id: "bot_id1", Name: "bot1", Eliminated: true},
+				{Id: "bot_id2", Name: "bot2"},
+				{Id: "bot_id3", Name: "bot3"},
+			},
+			MyTagsRemaining: MAX_TAGS_PER_HUMAN - 1,
+			MyNextTagAt:     &nextTagAt,
+		}, gameView)
+	})
+
+	t.Run("does not show tags remaining outside elimination games", func(t *testing.T) {
+		classicGame := *game
+		classicGame.elimination = false
+		gameView := classicGame.GameViewForPlayer("player_id1")
+		assert.Equal(t, int64(0), gameView.MyTagsRemaining)
+		assert.Nil(t, gameView.MyNextTagAt)
+	})
+}
+
+func Test_GameViewForPlayer_Voting(t *testing.T) {
+	t.Run("asks the player to vote", func(t *testing.T) {
+		gameView := newVotingTestGame(nil).GameViewForPlayer("player_id1")
+		assert.Equal(t, waitingForVotes, gameView.State)
+		assert.Equal(t, "Vote for the bot you think is human.", gameView.DisplayMessage)
+		assert.Equal(t, "", gameView.MyVoteBotId)
+	})
+
+	t.Run("shows the vote the player cast", func(t *testing.T) {
+		gameView := newVotingTestGame([]*Vote{{VoterBotId: "bot_id2", SuspectBotId: "bot_id4"}}).GameViewForPlayer("player_id1")
+		assert.Equal(t, waitingForVotes, gameView.State)
+		assert.Equal(t, "You voted for bot4. Waiting for the votes to come in.", gameView.DisplayMessage)
+		assert.Equal(t, "bot_id4", gameView.MyVoteBotId)
+	})
+}
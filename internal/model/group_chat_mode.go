@@ -0,0 +1,212 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// In a group chat, there are no turns. Anyone can post at any time until the chat runs out of time, as long as they
+// have messages left. A human wins by tagging the other human, and loses by tagging an AI bot. If nobody is tagged
+// before time runs out, it's a draw. A group chat is never marked handled while it is going on, since the AI bots
+// can chime in at any time.
+type groupChatMode struct{}
+
+func (m *groupChatMode) Type() gameModeType {
+	return groupChat
+}
+
+func (m *groupChatMode) HumanPlayerCount() int64 {
+	return 2
+}
+
+func (m *groupChatMode) AiImitatesHumans() bool {
+	return false
+}
+
+// Nobody is waiting on a single bot in a group chat, so there is never a message to help with.
+func (m *groupChatMode) HelpBudget() int64 {
+	return 0
+}
+
+func (m *groupChatMode) GameUpdateToStartRound(game *Game) (*GameUpdate, error) {
+	turnOrder := game.RandomizedTurnOrder()
+	if len(turnOrder) == 0 {
+		return nil, errors.New("no bots left to start a round")
+	}
+
+	startTurnIndex := int64(0)
+	stateHandled := false
+	chatStartedAt := time.Now()
+	chatTotalTime := int64(GROUP_CHAT_DURATION.Seconds())
+	return &GameUpdate{
+		State:            chatting,
+		CurrentTurnIndex: &startTurnIndex,
+		TurnOrder:        turnOrder,
+		StateHandled:     &stateHandled,
+		StateHandledAt:   &chatStartedAt,
+		StateTotalTime:   &chatTotalTime,
+	}, nil
+}
+
+// Reactions do not count towards the message cap.
+func (m *groupChatMode) GameUpdateAfterIncomingMessage(game *Game, sourceBotId string, targetBotId string, text string, msgType messageType) (*GameUpdate, error) {
+	if game.BotWithId(sourceBotId) == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if !game.IsInStateChatting() {
+		return nil, errors.New("this game is not waiting for messages currently")
+	}
+
+	if game.ChatHasEnded(time.Now()) {
+		return nil, errors.New("chat has ended")
+	}
+
+	if msgType == reactionMessage {
+		if game.BotWithId(targetBotId) == nil {
+			return nil, errors.New("invalid targetBotId")
+		}
+		return gameUpdateAfterReaction(game, sourceBotId, targetBotId, text)
+	}
+
+	if msgType != chatMessage {
+		return nil, errors.Errorf("cannot send a %s message in a group chat", msgType)
+	}
+
+	if sourceBotId != targetBotId {
+		return nil, errors.New("chat message should have same source and target bot")
+	}
+
+	if game.ChatMessagesRemaining(sourceBotId) == 0 {
+		return nil, errors.New("no messages remaining")
+	}
+
+	stateHandled := false
+	return &GameUpdate{
+		State:        chatting,
+		StateHandled: &stateHandled,
+	}, nil
+}
+
+func (m *groupChatMode) GameUpdateAfterTag(game *Game, sourceBotId string, targetBotId string) (*GameUpdate, error) {
+	if game.isFinished() {
+		return nil, errors.New("game has already finished")
+	}
+
+	if sourceBotId == targetBotId {
+		return nil, errors.New("cannot tag self")
+	}
+
+	sourceBot := game.BotWithId(sourceBotId)
+	targetBot := game.BotWithId(targetBotId)
+
+	if sourceBot == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if targetBot == nil {
+		return nil, errors.New("invalid targetBotId")
+	}
+
+	if sourceBot.IsAi() {
+		return nil, errors.New("ai cannot perform tagging")
+	}
+
+	if !game.IsInStateChatting() {
+		return nil, errors.New("can only tag while the chat is going on")
+	}
+
+	return gameUpdateAfterHumanTag(game, sourceBot, targetBot)
+}
+
+func (m *groupChatMode) GameUpdateAfterVoting(game *Game, aiVotes []*Vote) (*GameUpdate, error) {
+	return nil, errors.New("game is not accepting votes")
+}
+
+func (m *groupChatMode) GameUpdateAfterTimeUp(game *Game) (*GameUpdate, error) {
+	if !game.IsInStateChatting() {
+		return nil, errors.New("chat is not going on")
+	}
+
+	result := "Time is up and nobody was tagged. It's a draw."
+	return &GameUpdate{
+		State:  finished,
+		Result: &result,
+	}, nil
+}
+
+func (m *groupChatMode) AllowedActions(game *Game, bot *Bot) []gameAction {
+	actions := []gameAction{}
+	if bot == nil || !game.IsInStateChatting() {
+		return actions
+	}
+
+	if game.ChatMessagesRemaining(bot.id) > 0 {
+		actions = append(actions, sendMessageAction)
+	}
+	if bot.IsHuman() {
+		actions = append(actions, tagAction)
+	}
+	return actions
+}
+
+func (m *groupChatMode) ViewState(game *Game, myBotId string) (gameViewState, string) {
+	switch game.state {
+	case started, playersJoined:
+		return waitingForPlayersToJoin, "Waiting for players to join in"
+	case chatting:
+		messagesRemaining := game.ChatMessagesRemaining(myBotId)
+		if messagesRemaining == 0 {
+			return chatInProgress, "You are out of messages. Tag the other human before time runs out!"
+		}
+		return chatInProgress, fmt.Sprintf("Chat with everyone. You have %d messages left.", messagesRemaining)
+	case finished:
+		return finishedViewState(game, myBotId)
+	default:
+		return undefinedGameViewState, "This is not supposed to happen. What did happen?"
+	}
+}
+
+// ChatHasEnded is true once the group chat has gone on for GROUP_CHAT_DURATION.
+func (game *Game) ChatHasEnded(now time.Time) bool {
+	return game.IsInStateChatting() && game.stateTimeHasRunOut(now)
+}
+
+// ChatStartedAt is the zero time for games that are not in a group chat.
+func (game *Game) ChatStartedAt() time.Time {
+	if !game.IsInStateChatting() || game.stateHandledAt == nil {
+		return time.Time{}
+	}
+	return *game.stateHandledAt
+}
+
+// ChatMessagesRemaining is always 0 outside group chats.
+func (game *Game) ChatMessagesRemaining(botId string) int64 {
+	if game.mode.Type() != groupChat {
+		return 0
+	}
+
+	sentCount := int64(0)
+	for _, message := range game.messages {
+		if message.IsChat() && message.SourceBotId == botId {
+			sentCount++
+		}
+	}
+	if sentCount >= GROUP_CHAT_MESSAGE_CAP {
+		return 0
+	}
+	return GROUP_CHAT_MESSAGE_CAP - sentCount
+}
+
+// AiBotIdsThatCanChat are the AI bots in a group chat that still have messages left.
+func (game *Game) AiBotIdsThatCanChat() []string {
+	botIds := []string{}
+	for _, bot := range game.bots {
+		if bot.IsAi() && game.ChatMessagesRemaining(bot.id) > 0 {
+			botIds = append(botIds, bot.id)
+		}
+	}
+	return botIds
+}
@@ -0,0 +1,313 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGroupChatTestGame(chatStartedAt time.Time, messages []*Message) *Game {
+	return &Game{
+		mode:           &groupChatMode{},
+		state:          chatting,
+		turnOrder:      []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"},
+		stateHandledAt: &chatStartedAt,
+		stateTotalTime: int64(GROUP_CHAT_DURATION.Seconds()),
+		bots: []*Bot{
+			{id: "bot_id1", name: "bot1", typeOfBot: ai},
+			{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+			{id: "bot_id3", name: "bot3", typeOfBot: human, player: &Player{id: "player_id2"}},
+			{id: "bot_id4", name: "bot4", typeOfBot: ai},
+		},
+		messages: messages,
+	}
+}
+
+func chatMessagesFrom(botId string, count int) []*Message {
+	messages := []*Message{}
+	for i := 0; i < count; i++ {
+		messages = append(messages, &Message{SourceBotId: botId, TargetBotId: botId, Text: fmt.Sprintf("message %d", i+1), MessageType: "chat"})
+	}
+	return messages
+}
+
+func Test_GroupChatMode_GameUpdateToStartRound(t *testing.T) {
+	game := newGroupChatTestGame(time.Now(), nil)
+	game.state = playersJoined
+	game.stateHandledAt = nil
+
+	update, err := game.GetGameUpdateToStartRound()
+	assert.NoError(t, err)
+	assert.Equal(t, chatting, update.State)
+	assert.ElementsMatch(t, []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"}, update.TurnOrder)
+	assert.Equal(t, int64(0), *update.CurrentTurnIndex)
+	assert.False(t, *update.StateHandled)
+	AssertTimeAlmostEqual(t, time.Now(), *update.StateHandledAt, time.Second)
+	assert.Equal(t, int64(300), *update.StateTotalTime)
+}
+
+func Test_GroupChatMode_GetGameUpdateAfterIncomingMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		game          *Game
+		sourceBotId   string
+		targetBotId   string
+		errorExpected bool
+		errorString   string
+	}{
+		{
+			name:          "anyone can post at any time",
+			game:          newGroupChatTestGame(time.Now(), chatMessagesFrom("bot_id2", 3)),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id2",
+			errorExpected: false,
+		},
+		{
+			name:          "ai bots can post too",
+			game:          newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:   "bot_id4",
+			targetBotId:   "bot_id4",
+			errorExpected: false,
+		},
+		{
+			name:          "errors for an invalid source bot",
+			game:          newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:   "bot_id9",
+			targetBotId:   "bot_id9",
+			errorExpected: true,
+			errorString:   "invalid sourceBotId",
+		},
+		{
+			name: "errors if chat has not started",
+			game: func() *Game {
+				game := newGroupChatTestGame(time.Now(), nil)
+				game.state = playersJoined
+				return game
+			}(),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id2",
+			errorExpected: true,
+			errorString:   "this game is not waiting for messages currently",
+		},
+		{
+			name:          "errors once time runs out",
+			game:          newGroupChatTestGame(time.Now().Add(-GROUP_CHAT_DURATION), nil),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id2",
+			errorExpected: true,
+			errorString:   "chat has ended",
+		},
+		{
+			name:          "errors if the message is targeted at another bot",
+			game:          newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id3",
+			errorExpected: true,
+			errorString:   "chat message should have same source and target bot",
+		},
+		{
+			name:          "errors once the bot has posted as many messages as it can",
+			game:          newGroupChatTestGame(time.Now(), chatMessagesFrom("bot_id2", GROUP_CHAT_MESSAGE_CAP)),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id2",
+			errorExpected: true,
+			errorString:   "no messages remaining",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := tt.game.GetGameUpdateAfterIncomingMessage(tt.sourceBotId, tt.targetBotId, "hello everyone", "chat")
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+				assert.Nil(t, update)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, chatting, update.State)
+				assert.False(t, *update.StateHandled)
+				assert.Nil(t, update.CurrentTurnIndex)
+			}
+		})
+	}
+}
+
+func Test_GroupChatMode_GetGameUpdateAfterTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		game                 *Game
+		sourceBotId          string
+		targetBotId          string
+		expectedResult       string
+		expectedWinningBotId string
+		errorExpected        bool
+		errorString          string
+	}{
+		{
+			name:                 "human wins by tagging the other human",
+			game:                 newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id3",
+			expectedResult:       "bot2 tagged bot3 and won.",
+			expectedWinningBotId: "bot_id2",
+		},
+		{
+			name:                 "human loses by tagging an ai bot",
+			game:                 newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id1",
+			expectedResult:       "bot2 tagged bot1 and lost. bot3 won.",
+			expectedWinningBotId: "bot_id3",
+		},
+		{
+			name:          "errors if an ai bot tags",
+			game:          newGroupChatTestGame(time.Now(), nil),
+			sourceBotId:   "bot_id1",
+			targetBotId:   "bot_id2",
+			errorExpected: true,
+			errorString:   "ai cannot perform tagging",
+		},
+		{
+			name: "errors if chat has not started",
+			game: func() *Game {
+				game := newGroupChatTestGame(time.Now(), nil)
+				game.state = playersJoined
+				return game
+			}(),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id3",
+			errorExpected: true,
+			errorString:   "can only tag while the chat is going on",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := tt.game.GetGameUpdateAfterTag(tt.sourceBotId, tt.targetBotId)
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+				assert.Nil(t, update)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, finished, update.State)
+				assert.Equal(t, tt.expectedResult, *update.Result)
+				assert.Equal(t, tt.expectedWinningBotId, *update.WinningBotId)
+			}
+		})
+	}
+}
+
+func Test_GroupChatMode_GetGameUpdateAfterTimeUp(t *testing.T) {
+	t.Run("ends in a draw", func(t *testing.T) {
+		update, err := newGroupChatTestGame(time.Now().Add(-GROUP_CHAT_DURATION), nil).GetGameUpdateAfterTimeUp()
+		assert.NoError(t, err)
+		assert.Equal(t, finished, update.State)
+		assert.Equal(t, "Time is up and nobody was tagged. It's a draw.", *update.Result)
+		assert.Nil(t, update.WinningBotId)
+	})
+
+	t.Run("errors if chat is not going on", func(t *testing.T) {
+		game := newGroupChatTestGame(time.Now(), nil)
+		game.state = finished
+		_, err := game.GetGameUpdateAfterTimeUp()
+		assert.EqualError(t, err, "chat is not going on")
+	})
+}
+
+func Test_GroupChatMode_AllowedActions(t *testing.T) {
+	tests := []struct {
+		name           string
+		game           *Game
+		botId          string
+		expectedOutput []gameAction
+	}{
+		{
+			name:           "human can post and tag",
+			game:           newGroupChatTestGame(time.Now(), nil),
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{sendMessageAction, tagAction},
+		},
+		{
+			name:           "human can only tag once out of messages",
+			game:           newGroupChatTestGame(time.Now(), chatMessagesFrom("bot_id2", GROUP_CHAT_MESSAGE_CAP)),
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{tagAction},
+		},
+		{
+			name:           "ai bot can only post",
+			game:           newGroupChatTestGame(time.Now(), nil),
+			botId:          "bot_id1",
+			expectedOutput: []gameAction{sendMessageAction},
+		},
+		{
+			name: "nobody can do anything once the game has finished",
+			game: func() *Game {
+				game := newGroupChatTestGame(time.Now(), nil)
+				game.state = finished
+				return game
+			}(),
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := tt.game.mode.AllowedActions(tt.game, tt.game.BotWithId(tt.botId))
+			assert.Equal(t, tt.expectedOutput, actions)
+		})
+	}
+}
+
+func Test_GroupChatMode_GameViewForPlayer(t *testing.T) {
+	t.Run("shows the messages the player has left", func(t *testing.T) {
+		chatStartedAt := time.Now()
+		gameView := newGroupChatTestGame(chatStartedAt, chatMessagesFrom("bot_id2", 3)).GameViewForPlayer("player_id1")
+		assert.Equal(t, chatInProgress, gameView.State)
+		assert.Equal(t, "Chat with everyone. You have 7 messages left.", gameView.DisplayMessage)
+		assert.Equal(t, int64(7), gameView.MyMessagesRemaining)
+		assert.Equal(t, &chatStartedAt, gameView.StateStartedAt)
+		assert.Equal(t, int64(300), gameView.StateTotalTime)
+	})
+
+	t.Run("asks the player to tag once out of messages", func(t *testing.T) {
+		gameView := newGroupChatTestGame(time.Now(), chatMessagesFrom("bot_id2", GROUP_CHAT_MESSAGE_CAP)).GameViewForPlayer("player_id1")
+		assert.Equal(t, chatInProgress, gameView.State)
+		assert.Equal(t, "You are out of messages. Tag the other human before time runs out!", gameView.DisplayMessage)
+		assert.Equal(t, int64(0), gameView.MyMessagesRemaining)
+	})
+}
+
+func Test_AiBotIdsThatCanChat(t *testing.T) {
+	t.Run("leaves out ai bots that are out of messages", func(t *testing.T) {
+		game := newGroupChatTestGame(time.Now(), chatMessagesFrom("bot_id4", GROUP_CHAT_MESSAGE_CAP))
+		assert.Equal(t, []string{"bot_id1"}, game.AiBotIdsThatCanChat())
+	})
+
+	t.Run("is empty outside group chats", func(t *testing.T) {
+		game := newGroupChatTestGame(time.Now(), nil)
+		game.mode = &classicMode{}
+		assert.Equal(t, []string{}, game.AiBotIdsThatCanChat())
+	})
+}
+
+func Test_GroupChatMode_GetGameUpdateAfterIncomingMessage_Reactions(t *testing.T) {
+	t.Run("reactions do not count towards the message cap", func(t *testing.T) {
+		messages := append(
+			chatMessagesFrom("bot_id2", GROUP_CHAT_MESSAGE_CAP),
+			&Message{SourceBotId: "bot_id3", TargetBotId: "bot_id3", Text: "hi", CreatedAt: time.Now(), MessageType: "chat"},
+		)
+		game := newGroupChatTestGame(time.Now(), messages)
+		update, err := game.GetGameUpdateAfterIncomingMessage("bot_id2", "bot_id3", "👍", "reaction")
+		assert.NoError(t, err)
+		assert.Equal(t, &GameUpdate{State: chatting}, update)
+	})
+
+	t.Run("errors for message types other than chat and reaction", func(t *testing.T) {
+		game := newGroupChatTestGame(time.Now(), nil)
+		update, err := game.GetGameUpdateAfterIncomingMessage("bot_id2", "bot_id3", "A question?", "question")
+		assert.EqualError(t, err, "cannot send a question message in a group chat")
+		assert.Nil(t, update)
+	})
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// Help suggests a message in each of these styles, so that the player can pick the one closest to how they write.
+var HELP_STYLES = []string{"casual", "terse", "evasive"}
+
+type HelpSuggestion struct {
+	Style string
+	Text  string
+}
+
+func IsValidHelpStyle(style string) bool {
+	for _, helpStyle := range HELP_STYLES {
+		if style == helpStyle {
+			return true
+		}
+	}
+	return false
+}
+
+// HelpSuggestionTexts lines up the text of each suggestion with HELP_STYLES, leaving styles without a suggestion blank.
+func HelpSuggestionTexts(suggestions []HelpSuggestion) []string {
+	texts := make([]string, len(HELP_STYLES))
+	for i, style := range HELP_STYLES {
+		for _, suggestion := range suggestions {
+			if suggestion.Style == style {
+				texts[i] = suggestion.Text
+			}
+		}
+	}
+	return texts
+}
+
+// LastHelpSuggestions are the suggestions from the last time this bot asked for help, in the order of HELP_STYLES.
+func (b *Bot) LastHelpSuggestions() []HelpSuggestion {
+	suggestions := []HelpSuggestion{}
+	for i, text := range b.lastHelpSuggestions {
+		if i < len(HELP_STYLES) && !utilities.IsBlank(text) {
+			suggestions = append(suggestions, HelpSuggestion{Style: HELP_STYLES[i], Text: text})
+		}
+	}
+	return suggestions
+}
+
+// HelpUsageForText tells whether text was taken from one of the last Help suggestions as is, edited from one, or written
+// without help. Any text that differs from every suggestion is considered an edit, since we cannot tell an edit from a
+// rewrite.
+func (b *Bot) HelpUsageForText(text string) helpUsage {
+	suggestions := b.LastHelpSuggestions()
+	if len(suggestions) == 0 {
+		return noHelp
+	}
+	for _, suggestion := range suggestions {
+		if strings.EqualFold(strings.TrimSpace(text), strings.TrimSpace(suggestion.Text)) {
+			return helpUsedVerbatim
+		}
+	}
+	return helpEdited
+}
+
+// HelpStyleForText is the style of the suggestion that text was most likely based on, which is the one sharing the most
+// words with it. It is blank when text shares no words with any of the last suggestions.
+func (b *Bot) HelpStyleForText(text string) string {
+	words := map[string]bool{}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		words[word] = true
+	}
+
+	style := ""
+	mostSharedWords := 0
+	for _, suggestion := range b.LastHelpSuggestions() {
+		sharedWords := 0
+		for _, word := range strings.Fields(strings.ToLower(suggestion.Text)) {
+			if words[word] {
+				sharedWords++
+			}
+		}
+		if sharedWords > mostSharedWords {
+			style = suggestion.Style
+			mostSharedWords = sharedWords
+		}
+	}
+	return style
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HelpSuggestionTexts(t *testing.T) {
+	texts := HelpSuggestionTexts([]HelpSuggestion{
+		{Style: "evasive", Text: "Who knows?"},
+		{Style: "casual", Text: "What's up?"},
+	})
+	assert.Equal(t, []string{"What's up?", "", "Who knows?"}, texts)
+}
+
+func Test_Bot_LastHelpSuggestions(t *testing.T) {
+	bot := &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"What's up?", "", "Who knows?"}}
+	assert.Equal(t, []HelpSuggestion{
+		{Style: "casual", Text: "What's up?"},
+		{Style: "evasive", Text: "Who knows?"},
+	}, bot.LastHelpSuggestions())
+}
+
+func Test_Bot_HelpUsageForText(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *Bot
+		text           string
+		expectedOutput helpUsage
+	}{
+		{
+			name:           "returns no help when there is no help suggestion",
+			input:          &Bot{id: "id1", typeOfBot: human},
+			text:           "some text",
+			expectedOutput: noHelp,
+		},
+		{
+			name:           "returns verbatim when text matches a help suggestion",
+			input:          &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"Hey, what do they call you?", "Name? ", "Who wants to know?"}},
+			text:           " name?",
+			expectedOutput: helpUsedVerbatim,
+		},
+		{
+			name:           "returns edited when text does not match any help suggestion",
+			input:          &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"Hey, what do they call you?", "Name?", "Who wants to know?"}},
+			text:           "What is your full name?",
+			expectedOutput: helpEdited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.input.HelpUsageForText(tt.text)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func Test_Bot_HelpStyleForText(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *Bot
+		text           string
+		expectedOutput string
+	}{
+		{
+			name:           "returns blank when there is no help suggestion",
+			input:          &Bot{id: "id1", typeOfBot: human},
+			text:           "some text",
+			expectedOutput: "",
+		},
+		{
+			name:           "returns the style of the suggestion used as is",
+			input:          &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"I love jazz, you?", "Jazz.", "Depends on the day."}},
+			text:           "Jazz.",
+			expectedOutput: "terse",
+		},
+		{
+			name:           "returns the style of the suggestion sharing the most words with an edit",
+			input:          &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"I love jazz, you?", "Jazz.", "Depends on the day."}},
+			text:           "Depends on my mood",
+			expectedOutput: "evasive",
+		},
+		{
+			name:           "returns blank when text shares no words with any suggestion",
+			input:          &Bot{id: "id1", typeOfBot: human, lastHelpSuggestions: []string{"I love jazz, you?", "Jazz.", "Depends on the day."}},
+			text:           "Mostly rock",
+			expectedOutput: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.input.HelpStyleForText(tt.text)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
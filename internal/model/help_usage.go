@@ -0,0 +1,40 @@
+package model
+
+type helpUsage int64
+
+const (
+	undefinedHelpUsage helpUsage = iota
+	noHelp
+	helpUsedVerbatim
+	helpEdited
+)
+
+func HelpUsage(str string) helpUsage {
+	switch str {
+	case "NONE":
+		return noHelp
+	case "VERBATIM":
+		return helpUsedVerbatim
+	case "EDITED":
+		return helpEdited
+	default:
+		return undefinedHelpUsage
+	}
+}
+
+func (h helpUsage) String() string {
+	switch h {
+	case noHelp:
+		return "NONE"
+	case helpUsedVerbatim:
+		return "VERBATIM"
+	case helpEdited:
+		return "EDITED"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+func (h helpUsage) Valid() bool {
+	return h.String() != "UNDEFINED"
+}
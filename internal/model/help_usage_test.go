@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HelpUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput helpUsage
+	}{
+		{
+			name:           "creates NONE help usage",
+			input:          "NONE",
+			expectedOutput: noHelp,
+		},
+		{
+			name:           "creates VERBATIM help usage",
+			input:          "VERBATIM",
+			expectedOutput: helpUsedVerbatim,
+		},
+		{
+			name:           "creates EDITED help usage",
+			input:          "EDITED",
+			expectedOutput: helpEdited,
+		},
+		{
+			name:           "handles unknown help usage",
+			input:          "unknown",
+			expectedOutput: undefinedHelpUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := HelpUsage(tt.input)
+			assert.Equal(t, tt.expectedOutput, usage)
+		})
+	}
+}
+
+func Test_HelpUsage_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          helpUsage
+		expectedOutput string
+	}{
+		{
+			name:           "NONE help usage",
+			input:          noHelp,
+			expectedOutput: "NONE",
+		},
+		{
+			name:           "VERBATIM help usage",
+			input:          helpUsedVerbatim,
+			expectedOutput: "VERBATIM",
+		},
+		{
+			name:           "EDITED help usage",
+			input:          helpEdited,
+			expectedOutput: "EDITED",
+		},
+		{
+			name:           "undefined help usage",
+			input:          undefinedHelpUsage,
+			expectedOutput: "UNDEFINED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := tt.input.String()
+			assert.Equal(t, tt.expectedOutput, str)
+		})
+	}
+}
+
+func Test_HelpUsage_Valid(t *testing.T) {
+	t.Run("returns false for an undefined help usage", func(t *testing.T) {
+		assert.False(t, undefinedHelpUsage.Valid())
+	})
+	t.Run("returns true for a defined help usage", func(t *testing.T) {
+		assert.True(t, helpEdited.Valid())
+	})
+}
@@ -14,12 +14,21 @@ func AssertEqualGame(t *testing.T, expected, actual *Game) {
 	assert.Equal(t, expected.id, actual.id, "game id is not equal")
 	assert.Equal(t, expected.state, actual.state, "game state is not equal")
 	assert.Equal(t, expected.public, actual.public, "game public is not equal")
+	assert.Equal(t, expected.promptVersion, actual.promptVersion, "game promptVersion is not equal")
+	assert.Equal(t, expected.conversationSummary, actual.conversationSummary, "game conversationSummary is not equal")
+	assert.Equal(t, expected.aiAccusations, actual.aiAccusations, "game aiAccusations is not equal")
+	assert.Equal(t, expected.elimination, actual.elimination, "game elimination is not equal")
+	assert.Equal(t, expected.votingRounds, actual.votingRounds, "game votingRounds is not equal")
+	assert.Equal(t, expected.aiVotes, actual.aiVotes, "game aiVotes is not equal")
+	assert.Equal(t, expected.votes, actual.votes, "game votes is not equal")
+	assert.Equal(t, expected.Mode(), actual.Mode(), "game mode is not equal")
 	assert.Equal(t, expected.currentTurnIndex, actual.currentTurnIndex, "game currentTurnIndex is not equal")
 	assert.Equal(t, expected.turnOrder, actual.turnOrder, "game turnOrder is not equal")
 	assert.Equal(t, expected.stateHandled, actual.stateHandled, "game stateHandled is not equal")
 	assert.Equal(t, expected.stateTotalTime, actual.stateTotalTime, "game stateTotalTime is not equal")
 	assert.Equal(t, expected.lastQuestion, actual.lastQuestion, "game lastQuestion is not equal")
 	assert.Equal(t, expected.lastQuestionTargetBotId, actual.lastQuestionTargetBotId, "game lastQuestionTargetBotId is not equal")
+	assert.Equal(t, expected.version, actual.version, "game version is not equal")
 
 	for i, expectedMessage := range expected.messages {
 		actualMessage := actual.messages[i]
@@ -46,6 +55,15 @@ func AssertEqualBot(t *testing.T, expected, actual *Bot) {
 	assert.Equal(t, expected.typeOfBot, actual.typeOfBot, "bot type is not equal")
 	assert.Equal(t, expected.player, actual.player, "bot player is not equal")
 	assert.Equal(t, expected.helpCount, actual.helpCount, "bot help count is not equal")
+	assert.Equal(t, expected.lastHelpSuggestions, actual.lastHelpSuggestions, "bot lastHelpSuggestions is not equal")
+	assert.Equal(t, expected.tagCount, actual.tagCount, "bot tagCount is not equal")
+	if expected.lastTaggedAt != nil && assert.NotNil(t, actual.lastTaggedAt, "bot lastTaggedAt is not equal") {
+		AssertTimeAlmostEqual(t, *actual.lastTaggedAt, *expected.lastTaggedAt, DELTA, "bot lastTaggedAt is not within range")
+	} else {
+		assert.Nil(t, actual.lastTaggedAt, "bot lastTaggedAt is not equal")
+	}
+	assert.Equal(t, expected.eliminated, actual.eliminated, "bot eliminated is not equal")
+	assert.Equal(t, expected.statedFacts, actual.statedFacts, "bot statedFacts is not equal")
 }
 
 func AssertEqualMessage(t *testing.T, expected, actual *Message) {
@@ -53,6 +71,13 @@ func AssertEqualMessage(t *testing.T, expected, actual *Message) {
 	assert.Equal(t, expected.SourceBotId, actual.SourceBotId, "message SourceBotId is not equal")
 	assert.Equal(t, expected.TargetBotId, actual.TargetBotId, "message TargetBotId is not equal")
 	assert.Equal(t, expected.MessageType, actual.MessageType, "message MessageType is not equal")
+	assert.Equal(t, expected.ResponseTime, actual.ResponseTime, "message ResponseTime is not equal")
+	assert.Equal(t, expected.HelpUsage, actual.HelpUsage, "message HelpUsage is not equal")
+	assert.Equal(t, expected.HelpStyle, actual.HelpStyle, "message HelpStyle is not equal")
+	assert.Equal(t, expected.AiModel, actual.AiModel, "message AiModel is not equal")
+	assert.Equal(t, expected.PromptVersion, actual.PromptVersion, "message PromptVersion is not equal")
+	assert.Equal(t, expected.PromptTokens, actual.PromptTokens, "message PromptTokens is not equal")
+	assert.Equal(t, expected.CompletionTokens, actual.CompletionTokens, "message CompletionTokens is not equal")
 	AssertTimeAlmostEqual(t, actual.CreatedAt, expected.CreatedAt, DELTA, "message CreatedAt is not within range")
 }
 
@@ -64,6 +89,10 @@ func AssertEqualGameView(t *testing.T, expected, actual *GameView) {
 	assert.Equal(t, expected.MyBotId, actual.MyBotId, "gameView MyBotId is not equal")
 	assert.Equal(t, expected.Bots, actual.Bots, "gameView Bots is not equal")
 	assert.Equal(t, expected.MyHelpCount, actual.MyHelpCount, "gameView MyHelpCount is not equal")
+	assert.Equal(t, expected.MyTagsRemaining, actual.MyTagsRemaining, "gameView MyTagsRemaining is not equal")
+	assert.Equal(t, expected.MyNextTagAt, actual.MyNextTagAt, "gameView MyNextTagAt is not equal")
+	assert.Equal(t, expected.MyVoteBotId, actual.MyVoteBotId, "gameView MyVoteBotId is not equal")
+	assert.Equal(t, expected.MyMessagesRemaining, actual.MyMessagesRemaining, "gameView MyMessagesRemaining is not equal")
 
 	// Since we cannot mock postgres time operations. We just check that the updated times are near expected times.
 	if expected.StateStartedAt != nil {
@@ -83,6 +112,13 @@ func AssertEqualDetailedMessage(t *testing.T, expected, actual DetailedMessage)
 	assert.Equal(t, expected.TargetBotId, actual.TargetBotId, "detailedMessage TargetBotId is not equal")
 	assert.Equal(t, expected.TargetBotName, actual.TargetBotName, "detailedMessage TargetBotName is not equal")
 	assert.Equal(t, expected.MessageType, actual.MessageType, "detailedMessage MessageType is not equal")
+	assert.Equal(t, expected.ResponseTime, actual.ResponseTime, "detailedMessage ResponseTime is not equal")
+	assert.Equal(t, expected.HelpUsage, actual.HelpUsage, "detailedMessage HelpUsage is not equal")
+	assert.Equal(t, expected.HelpStyle, actual.HelpStyle, "detailedMessage HelpStyle is not equal")
+	assert.Equal(t, expected.AiModel, actual.AiModel, "detailedMessage AiModel is not equal")
+	assert.Equal(t, expected.PromptVersion, actual.PromptVersion, "detailedMessage PromptVersion is not equal")
+	assert.Equal(t, expected.PromptTokens, actual.PromptTokens, "detailedMessage PromptTokens is not equal")
+	assert.Equal(t, expected.CompletionTokens, actual.CompletionTokens, "detailedMessage CompletionTokens is not equal")
 	AssertTimeAlmostEqual(t, actual.CreatedAt, expected.CreatedAt, DELTA, "detailedMessage CreatedAt is not within range")
 }
 
@@ -6,29 +6,87 @@ import (
 )
 
 type Message struct {
-	Text        string
-	CreatedAt   time.Time
-	SourceBotId string
-	TargetBotId string
-	MessageType string
+	Text             string
+	CreatedAt        time.Time
+	SourceBotId      string
+	TargetBotId      string
+	MessageType      string
+	ResponseTime     time.Duration
+	HelpUsage        string
+	HelpStyle        string
+	AiModel          string
+	PromptVersion    string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// ConversationSummary summarises the first MessageCount messages of a game, in the order they were created.
+type ConversationSummary struct {
+	Text         string
+	MessageCount int64
 }
 
 func (m *Message) IsQuestion() bool {
-	return m.MessageType == "question"
+	return MessageType(m.MessageType) == questionMessage
 }
 
 func (m *Message) IsAnswer() bool {
-	return m.MessageType == "answer"
+	return MessageType(m.MessageType) == answerMessage
+}
+
+// A follow-up is a question to the bot that answered the previous question.
+func (m *Message) IsFollowUp() bool {
+	return MessageType(m.MessageType) == followUpMessage
+}
+
+// A pass gives up a turn without asking or answering, so its target is the bot that passed.
+func (m *Message) IsPass() bool {
+	return MessageType(m.MessageType) == passMessage
+}
+
+// A reaction is an emoji sent in response to the latest message. It does not take up a turn.
+func (m *Message) IsReaction() bool {
+	return MessageType(m.MessageType) == reactionMessage
+}
+
+// An accusation is made by an AI bot against the bot it suspects of being human. It does not take up a turn.
+func (m *Message) IsAccusation() bool {
+	return MessageType(m.MessageType) == accusationMessage
+}
+
+// A chat message is posted to everyone in a group chat, so its target is the bot that posted it.
+func (m *Message) IsChat() bool {
+	return MessageType(m.MessageType) == chatMessage
 }
 
 type DetailedMessage struct {
-	Text          string
-	CreatedAt     time.Time
-	SourceBotId   string
-	SourceBotName string
-	TargetBotId   string
-	TargetBotName string
-	MessageType   string
+	Text             string
+	CreatedAt        time.Time
+	SourceBotId      string
+	SourceBotName    string
+	TargetBotId      string
+	TargetBotName    string
+	MessageType      string
+	ResponseTime     time.Duration
+	HelpUsage        string
+	HelpStyle        string
+	AiModel          string
+	PromptVersion    string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// withoutMetadata strips everything that could give away who is an AI while a game is still being played.
+func (m DetailedMessage) withoutMetadata() DetailedMessage {
+	return DetailedMessage{
+		Text:          m.Text,
+		CreatedAt:     m.CreatedAt,
+		SourceBotId:   m.SourceBotId,
+		SourceBotName: m.SourceBotName,
+		TargetBotId:   m.TargetBotId,
+		TargetBotName: m.TargetBotName,
+		MessageType:   m.MessageType,
+	}
 }
 
 type detailedMessageSortByCreatedAt []DetailedMessage
@@ -48,3 +106,16 @@ func (m detailedMessageSortByCreatedAt) Less(i, j int) bool {
 func (m detailedMessageSortByCreatedAt) sort() {
 	sort.Sort(m)
 }
+
+// MessageStats aggregates messages that share the same kind of sender, message type, help usage and AI prompt.
+type MessageStats struct {
+	SourceBotType       string
+	MessageType         string
+	HelpUsage           string
+	AiModel             string
+	PromptVersion       string
+	MessageCount        int64
+	AverageResponseTime time.Duration
+	PromptTokens        int64
+	CompletionTokens    int64
+}
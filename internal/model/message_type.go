@@ -0,0 +1,69 @@
+package model
+
+// PASS_MESSAGE_TEXT is what everyone sees when a bot passes its turn.
+const PASS_MESSAGE_TEXT = "I pass."
+
+type messageType int64
+
+const (
+	undefinedMessageType messageType = iota
+	questionMessage
+	answerMessage
+	followUpMessage
+	passMessage
+	reactionMessage
+	accusationMessage
+	chatMessage
+)
+
+// Message types are stored in lowercase.
+func MessageType(str string) messageType {
+	switch str {
+	case "question":
+		return questionMessage
+	case "answer":
+		return answerMessage
+	case "follow_up":
+		return followUpMessage
+	case "pass":
+		return passMessage
+	case "reaction":
+		return reactionMessage
+	case "accusation":
+		return accusationMessage
+	case "chat":
+		return chatMessage
+	default:
+		return undefinedMessageType
+	}
+}
+
+func (m messageType) String() string {
+	switch m {
+	case questionMessage:
+		return "question"
+	case answerMessage:
+		return "answer"
+	case followUpMessage:
+		return "follow_up"
+	case passMessage:
+		return "pass"
+	case reactionMessage:
+		return "reaction"
+	case accusationMessage:
+		return "accusation"
+	case chatMessage:
+		return "chat"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+func (m messageType) Valid() bool {
+	return m.String() != "UNDEFINED"
+}
+
+// A follow-up is a question, so it is handled like one wherever the game is waiting for a question.
+func (m messageType) isQuestion() bool {
+	return m == questionMessage || m == followUpMessage
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MessageType(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput messageType
+	}{
+		{
+			name:           "creates question message type",
+			input:          "question",
+			expectedOutput: questionMessage,
+		},
+		{
+			name:           "creates answer message type",
+			input:          "answer",
+			expectedOutput: answerMessage,
+		},
+		{
+			name:           "creates follow_up message type",
+			input:          "follow_up",
+			expectedOutput: followUpMessage,
+		},
+		{
+			name:           "creates pass message type",
+			input:          "pass",
+			expectedOutput: passMessage,
+		},
+		{
+			name:           "creates reaction message type",
+			input:          "reaction",
+			expectedOutput: reactionMessage,
+		},
+		{
+			name:           "creates accusation message type",
+			input:          "accusation",
+			expectedOutput: accusationMessage,
+		},
+		{
+			name:           "creates chat message type",
+			input:          "chat",
+			expectedOutput: chatMessage,
+		},
+		{
+			name:           "handles unknown message type",
+			input:          "QUESTION",
+			expectedOutput: undefinedMessageType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOutput, MessageType(tt.input))
+		})
+	}
+}
+
+func Test_MessageType_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          messageType
+		expectedOutput string
+	}{
+		{
+			name:           "gets question from questionMessage",
+			input:          questionMessage,
+			expectedOutput: "question",
+		},
+		{
+			name:           "gets answer from answerMessage",
+			input:          answerMessage,
+			expectedOutput: "answer",
+		},
+		{
+			name:           "gets follow_up from followUpMessage",
+			input:          followUpMessage,
+			expectedOutput: "follow_up",
+		},
+		{
+			name:           "gets pass from passMessage",
+			input:          passMessage,
+			expectedOutput: "pass",
+		},
+		{
+			name:           "gets reaction from reactionMessage",
+			input:          reactionMessage,
+			expectedOutput: "reaction",
+		},
+		{
+			name:           "gets accusation from accusationMessage",
+			input:          accusationMessage,
+			expectedOutput: "accusation",
+		},
+		{
+			name:           "gets chat from chatMessage",
+			input:          chatMessage,
+			expectedOutput: "chat",
+		},
+		{
+			name:           "gets UNDEFINED from undefinedMessageType",
+			input:          undefinedMessageType,
+			expectedOutput: "UNDEFINED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOutput, tt.input.String())
+		})
+	}
+}
+
+func Test_MessageType_Valid(t *testing.T) {
+	t.Run("returns true for a valid message type", func(t *testing.T) {
+		assert.True(t, reactionMessage.Valid())
+	})
+
+	t.Run("returns false for an invalid message type", func(t *testing.T) {
+		assert.False(t, undefinedMessageType.Valid())
+	})
+}
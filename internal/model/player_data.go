@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// ERASED_MESSAGE_TEXT replaces the text of every message sent by a player whose data was erased.
+const ERASED_MESSAGE_TEXT = "[erased]"
+
+// PlayerErasure records that the data of a player was erased, and how much of it there was. UserId is the user the
+// player was connected to at the time, if any.
+type PlayerErasure struct {
+	Id           string
+	PlayerId     string
+	UserId       *string
+	BotCount     int64
+	MessageCount int64
+	CreatedAt    time.Time
+}
+
+// DataExport is everything stored about a player, or about a user and all of its players. User and Erasures are only
+// set when exporting the data of a user.
+type DataExport struct {
+	User     *ExportedUser
+	Players  []PlayerData
+	Erasures []PlayerErasure
+}
+
+type ExportedUser struct {
+	Id    string
+	Name  *string
+	Email *string
+	Image *string
+}
+
+// PlayerData is a player along with the bots it played as, the messages and votes of those bots, and its practice
+// results.
+type PlayerData struct {
+	PlayerId        string
+	UserId          *string
+	CreatedAt       time.Time
+	Bots            []ExportedBot
+	Messages        []ExportedMessage
+	Votes           []ExportedVote
+	PracticeResults []ExportedPracticeResult
+}
+
+// Analysis is nil until the game of the bot has been analyzed.
+type ExportedBot struct {
+	Id                  string
+	GameId              string
+	Name                string
+	HelpCount           int64
+	LastHelpSuggestions []string
+	TagCount            int64
+	Eliminated          bool
+	Analysis            *BotAnalysis
+}
+
+type ExportedMessage struct {
+	Id          string
+	GameId      string
+	SourceBotId string
+	TargetBotId string
+	MessageType string
+	Text        string
+	CreatedAt   time.Time
+}
+
+type ExportedVote struct {
+	GameId       string
+	VoterBotId   string
+	SuspectBotId string
+}
+
+type ExportedPracticeResult struct {
+	GameId string
+	Won    bool
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// In a practice game, a single human plays with AI bots only. One of the AI bots, the decoy, secretly plays the part
+// of the other human. The human wins by tagging the decoy, and loses by tagging any other AI bot, which makes the
+// decoy the winner. The decoy is picked as the first round starts, so that it is never the bot the human took over.
+type practiceMode struct {
+	classicMode
+}
+
+func (m *practiceMode) Type() gameModeType {
+	return practice
+}
+
+func (m *practiceMode) HumanPlayerCount() int64 {
+	return 1
+}
+
+func (m *practiceMode) AiImitatesHumans() bool {
+	return false
+}
+
+// Practice is for learning the game, so there is more help to go around.
+func (m *practiceMode) HelpBudget() int64 {
+	return 5
+}
+
+func (m *practiceMode) GameUpdateToStartRound(game *Game) (*GameUpdate, error) {
+	update, err := m.classicMode.GameUpdateToStartRound(game)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.decoy() != nil {
+		return update, nil
+	}
+
+	aiBots := []*Bot{}
+	for _, bot := range game.bots {
+		if bot.IsAi() {
+			aiBots = append(aiBots, bot)
+		}
+	}
+	if len(aiBots) == 0 {
+		return nil, errors.New("no ai bots left to play the decoy")
+	}
+	decoyBotId := aiBots[rand.Intn(len(aiBots))].id
+	update.DecoyBotId = &decoyBotId
+	return update, nil
+}
+
+func (m *practiceMode) GameUpdateAfterTag(game *Game, sourceBotId string, targetBotId string) (*GameUpdate, error) {
+	if game.isFinished() {
+		return nil, errors.New("game has already finished")
+	}
+
+	if sourceBotId == targetBotId {
+		return nil, errors.New("cannot tag self")
+	}
+
+	sourceBot := game.BotWithId(sourceBotId)
+	targetBot := game.BotWithId(targetBotId)
+
+	if sourceBot == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if targetBot == nil {
+		return nil, errors.New("invalid targetBotId")
+	}
+
+	if sourceBot.IsAi() {
+		return nil, errors.New("ai cannot perform tagging")
+	}
+
+	decoy := game.decoy()
+	if decoy == nil {
+		return nil, errors.New("can only tag once the game has started")
+	}
+
+	update := GameUpdate{State: finished}
+	var result string
+	if targetBot.id == decoy.id {
+		result = fmt.Sprintf("%s tagged %s, who was playing the human, and won.", sourceBot.name, targetBot.name)
+		update.WinningBotId = &sourceBot.id
+	} else {
+		result = fmt.Sprintf("%s tagged %s and lost. %s was playing the human.", sourceBot.name, targetBot.name, decoy.name)
+		update.WinningBotId = &decoy.id
+	}
+	update.Result = &result
+	return &update, nil
+}
+
+func (m *practiceMode) GameUpdateAfterVoting(game *Game, aiVotes []*Vote) (*GameUpdate, error) {
+	return nil, errors.New("game is not accepting votes")
+}
+
+// IsPractice is true for games that do not count towards ranked play.
+func (game *Game) IsPractice() bool {
+	return game.mode.Type() == practice
+}
+
+// IsDecoy is true for the AI bot that plays the part of the other human in a practice game.
+func (game *Game) IsDecoy(botId string) bool {
+	decoy := game.decoy()
+	return decoy != nil && decoy.id == botId
+}
+
+func (game *Game) decoy() *Bot {
+	if game.mode.Type() != practice {
+		return nil
+	}
+	return game.BotWithId(game.decoyBotId)
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPracticeTestGame(state gameState, decoyBotId string) *Game {
+	return &Game{
+		mode:       &practiceMode{},
+		state:      state,
+		turnOrder:  []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+		decoyBotId: decoyBotId,
+		bots: []*Bot{
+			{id: "bot_id1", name: "bot1", typeOfBot: ai},
+			{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+			{id: "bot_id3", name: "bot3", typeOfBot: ai},
+			{id: "bot_id4", name: "bot4", typeOfBot: ai},
+			{id: "bot_id5", name: "bot5", typeOfBot: ai},
+		},
+	}
+}
+
+func Test_PracticeMode_GameUpdateToStartRound(t *testing.T) {
+	t.Run("picks an ai bot as the decoy", func(t *testing.T) {
+		rand.Seed(0)
+		game := newPracticeTestGame(playersJoined, "")
+
+		update, err := game.GetGameUpdateToStartRound()
+		assert.NoError(t, err)
+		assert.NotNil(t, update.DecoyBotId)
+		assert.NotEqual(t, "bot_id2", *update.DecoyBotId)
+		assert.True(t, game.BotWithId(*update.DecoyBotId).IsAi())
+	})
+
+	t.Run("keeps the decoy once it has been picked", func(t *testing.T) {
+		game := newPracticeTestGame(playersJoined, "bot_id4")
+
+		update, err := game.GetGameUpdateToStartRound()
+		assert.NoError(t, err)
+		assert.Nil(t, update.DecoyBotId)
+	})
+}
+
+func Test_PracticeMode_GetGameUpdateAfterTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		game                 *Game
+		sourceBotId          string
+		targetBotId          string
+		expectedResult       string
+		expectedWinningBotId string
+		errorExpected        bool
+		errorString          string
+	}{
+		{
+			name:                 "human wins by tagging the decoy",
+			game:                 newPracticeTestGame(waitingForAiQuestion, "bot_id4"),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id4",
+			expectedResult:       "bot2 tagged bot4, who was playing the human, and won.",
+			expectedWinningBotId: "bot_id2",
+		},
+		{
+			name:                 "human loses by tagging any other ai bot",
+			game:                 newPracticeTestGame(waitingForAiQuestion, "bot_id4"),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id1",
+			expectedResult:       "bot2 tagged bot1 and lost. bot4 was playing the human.",
+			expectedWinningBotId: "bot_id4",
+		},
+		{
+			name:          "errors if an ai bot tags",
+			game:          newPracticeTestGame(waitingForAiQuestion, "bot_id4"),
+			sourceBotId:   "bot_id4",
+			targetBotId:   "bot_id2",
+			errorExpected: true,
+			errorString:   "ai cannot perform tagging",
+		},
+		{
+			name:          "errors if the decoy has not been picked yet",
+			game:          newPracticeTestGame(playersJoined, ""),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id4",
+			errorExpected: true,
+			errorString:   "can only tag once the game has started",
+		},
+		{
+			name:          "errors if game has finished",
+			game:          newPracticeTestGame(finished, "bot_id4"),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id4",
+			errorExpected: true,
+			errorString:   "game has already finished",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := tt.game.GetGameUpdateAfterTag(tt.sourceBotId, tt.targetBotId)
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+				assert.Nil(t, update)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, finished, update.State)
+				assert.Equal(t, tt.expectedResult, *update.Result)
+				assert.Equal(t, tt.expectedWinningBotId, *update.WinningBotId)
+			}
+		})
+	}
+}
+
+func Test_Game_IsDecoy(t *testing.T) {
+	game := newPracticeTestGame(waitingForAiQuestion, "bot_id4")
+	assert.True(t, game.IsDecoy("bot_id4"))
+	assert.False(t, game.IsDecoy("bot_id1"))
+	assert.False(t, newPracticeTestGame(waitingForAiQuestion, "").IsDecoy("bot_id4"))
+
+	classicGame := newPracticeTestGame(waitingForAiQuestion, "bot_id4")
+	classicGame.mode = &classicMode{}
+	assert.False(t, classicGame.IsDecoy("bot_id4"))
+}
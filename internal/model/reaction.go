@@ -0,0 +1,66 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+)
+
+// REACTIONS are the only emoji that can be sent as a reaction.
+var REACTIONS = []string{"👍", "👎", "😂", "😮", "🤔", "❤️"}
+
+func isValidReaction(text string) bool {
+	for _, reaction := range REACTIONS {
+		if text == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// latestMessageToReactTo is the most recent message that was actually said by a bot.
+// Reactions, accusations and passes are not things that can be reacted to.
+func (game *Game) latestMessageToReactTo() *Message {
+	var latestMessage *Message
+	for _, message := range game.messages {
+		if message.IsReaction() || message.IsAccusation() || message.IsPass() {
+			continue
+		}
+		if latestMessage == nil || message.CreatedAt.After(latestMessage.CreatedAt) {
+			latestMessage = message
+		}
+	}
+	return latestMessage
+}
+
+// BotIdToReactTo is the bot that sent the latest message, or blank if there is nothing to react to yet.
+func (game *Game) BotIdToReactTo() string {
+	latestMessage := game.latestMessageToReactTo()
+	if latestMessage == nil {
+		return ""
+	}
+	return latestMessage.SourceBotId
+}
+
+// gameUpdateAfterReaction leaves the game as it is, since reactions do not take up a turn.
+// A reaction is targeted at the bot that sent the latest message, and bots cannot react to themselves.
+func gameUpdateAfterReaction(game *Game, sourceBotId string, targetBotId string, text string) (*GameUpdate, error) {
+	if !isValidReaction(text) {
+		return nil, errors.New("reaction should be one of the allowed emoji")
+	}
+
+	latestMessage := game.latestMessageToReactTo()
+	if latestMessage == nil {
+		return nil, errors.New("there is no message to react to")
+	}
+
+	if latestMessage.SourceBotId != targetBotId {
+		return nil, errors.New("reaction should be targeted at the bot that sent the latest message")
+	}
+
+	if sourceBotId == targetBotId {
+		return nil, errors.New("cannot react to own message")
+	}
+
+	return &GameUpdate{
+		State: game.state,
+	}, nil
+}
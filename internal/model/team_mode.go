@@ -0,0 +1,181 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// In a team game, every seat but one is taken by a human, and the one AI bot left is the imposter. The bots take
+// turns asking each other questions like in a classic game. The humans win together as soon as one of them tags the
+// imposter. Each human only gets TEAM_TAGS_PER_HUMAN tags, and the imposter wins if they are all used up on humans,
+// or if it lasts TEAM_ROUND_LIMIT rounds without being caught.
+type teamMode struct {
+	classicMode
+}
+
+func (m *teamMode) Type() gameModeType {
+	return team
+}
+
+func (m *teamMode) HumanPlayerCount() int64 {
+	return totalNumberOfBotsPerGame - 1
+}
+
+func (m *teamMode) AiImitatesHumans() bool {
+	return true
+}
+
+func (m *teamMode) GameUpdateAfterIncomingMessage(game *Game, sourceBotId string, targetBotId string, text string, msgType messageType) (*GameUpdate, error) {
+	update, err := m.classicMode.GameUpdateAfterIncomingMessage(game, sourceBotId, targetBotId, text, msgType)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.CurrentTurnIndex == nil || *update.CurrentTurnIndex < TEAM_ROUND_LIMIT*int64(len(game.turnOrder)) {
+		return update, nil
+	}
+
+	imposter := game.imposter()
+	if imposter == nil {
+		return nil, utilities.NewBadError("team game does not have an imposter")
+	}
+
+	result := fmt.Sprintf("%s, the imposter, made it through %d rounds without being caught and won.", imposter.name, TEAM_ROUND_LIMIT)
+	update.State = finished
+	update.WinningBotId = &imposter.id
+	update.Result = &result
+	return update, nil
+}
+
+// Tagging a human uses up the tag and the game goes on, unless nobody has any tags left.
+func (m *teamMode) GameUpdateAfterTag(game *Game, sourceBotId string, targetBotId string) (*GameUpdate, error) {
+	if game.isFinished() {
+		return nil, errors.New("game has already finished")
+	}
+
+	if sourceBotId == targetBotId {
+		return nil, errors.New("cannot tag self")
+	}
+
+	sourceBot := game.BotWithId(sourceBotId)
+	targetBot := game.BotWithId(targetBotId)
+
+	if sourceBot == nil {
+		return nil, errors.New("invalid sourceBotId")
+	}
+
+	if targetBot == nil {
+		return nil, errors.New("invalid targetBotId")
+	}
+
+	if sourceBot.IsAi() {
+		return nil, errors.New("ai cannot perform tagging")
+	}
+
+	if !game.IsInPlay() {
+		return nil, errors.New("can only tag while the round is being played")
+	}
+
+	if teamTagsRemaining(sourceBot) == 0 {
+		return nil, errors.New("no tags remaining")
+	}
+
+	update := GameUpdate{TaggingBotId: &sourceBot.id}
+
+	if targetBot.IsAi() {
+		result := fmt.Sprintf("%s tagged %s, the imposter. The humans won!", sourceBot.name, targetBot.name)
+		update.State = finished
+		update.WinningBotId = &sourceBot.id
+		update.Result = &result
+		return &update, nil
+	}
+
+	// The tag being used now has not been recorded on sourceBot yet.
+	for _, bot := range game.bots {
+		if bot.IsHuman() && bot.id != sourceBot.id && teamTagsRemaining(bot) > 0 {
+			update.State = game.state
+			return &update, nil
+		}
+	}
+
+	imposter := game.imposter()
+	if imposter == nil {
+		return nil, utilities.NewBadError("team game does not have an imposter")
+	}
+
+	result := fmt.Sprintf("%s tagged %s, who is human. The humans are out of tags, and %s, the imposter, won.", sourceBot.name, targetBot.name, imposter.name)
+	update.State = finished
+	update.WinningBotId = &imposter.id
+	update.Result = &result
+	return &update, nil
+}
+
+func (m *teamMode) GameUpdateAfterVoting(game *Game, aiVotes []*Vote) (*GameUpdate, error) {
+	return nil, errors.New("game is not accepting votes")
+}
+
+// Humans can tag while the round is being played, as long as they have a tag left.
+func (m *teamMode) AllowedActions(game *Game, bot *Bot) []gameAction {
+	actions := []gameAction{}
+	if bot == nil {
+		return actions
+	}
+
+	if waitingOnBot := game.GetBotThatGameIsWaitingOn(); waitingOnBot != nil && waitingOnBot.id == bot.id {
+		actions = append(actions, sendMessageAction)
+		if bot.IsHuman() {
+			actions = append(actions, helpAction)
+		}
+	}
+
+	if bot.IsHuman() && game.IsInPlay() && teamTagsRemaining(bot) > 0 {
+		actions = append(actions, tagAction)
+	}
+
+	return actions
+}
+
+// All the humans share the win, or the loss.
+func (m *teamMode) ViewState(game *Game, myBotId string) (gameViewState, string) {
+	switch game.state {
+	case started, playersJoined:
+		joinedCount := int64(0)
+		for _, bot := range game.bots {
+			if bot.IsHuman() {
+				joinedCount++
+			}
+		}
+		return waitingForPlayersToJoin, fmt.Sprintf("Waiting for %d more players to join in", m.HumanPlayerCount()-joinedCount)
+	case finished:
+		myBot := game.BotWithId(myBotId)
+		winningBot := game.BotWithId(game.winningBotId)
+		if myBot == nil || !myBot.IsHuman() || winningBot == nil {
+			return finishedViewState(game, myBotId)
+		}
+		if winningBot.IsHuman() {
+			return youWon, game.result
+		}
+		return youLost, game.result
+	default:
+		return m.classicMode.ViewState(game, myBotId)
+	}
+}
+
+func teamTagsRemaining(bot *Bot) int64 {
+	if bot.tagCount >= TEAM_TAGS_PER_HUMAN {
+		return 0
+	}
+	return TEAM_TAGS_PER_HUMAN - bot.tagCount
+}
+
+// imposter is the AI bot in a team game.
+func (game *Game) imposter() *Bot {
+	for _, bot := range game.bots {
+		if bot.IsAi() {
+			return bot
+		}
+	}
+	return nil
+}
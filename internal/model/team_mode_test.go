@@ -0,0 +1,243 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTeamTestGame(state gameState) *Game {
+	return &Game{
+		mode:      &teamMode{},
+		state:     state,
+		turnOrder: []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+		bots: []*Bot{
+			{id: "bot_id1", name: "bot1", typeOfBot: human, player: &Player{id: "player_id1"}},
+			{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id2"}},
+			{id: "bot_id3", name: "bot3", typeOfBot: ai},
+			{id: "bot_id4", name: "bot4", typeOfBot: human, player: &Player{id: "player_id3"}},
+			{id: "bot_id5", name: "bot5", typeOfBot: human, player: &Player{id: "player_id4"}},
+		},
+	}
+}
+
+func Test_HumanPlayerCountForMode(t *testing.T) {
+	assert.Equal(t, int64(2), HumanPlayerCountForMode(""))
+	assert.Equal(t, int64(2), HumanPlayerCountForMode("CLASSIC"))
+	assert.Equal(t, int64(2), HumanPlayerCountForMode("GROUP_CHAT"))
+	assert.Equal(t, int64(4), HumanPlayerCountForMode("TEAM"))
+	assert.Equal(t, int64(1), HumanPlayerCountForMode("PRACTICE"))
+	assert.Equal(t, int64(0), HumanPlayerCountForMode("unknown"))
+}
+
+func Test_Game_HelpBudget(t *testing.T) {
+	assert.Equal(t, int64(3), (&Game{mode: &classicMode{}}).HelpBudget())
+	assert.Equal(t, int64(0), (&Game{mode: &groupChatMode{}}).HelpBudget())
+	assert.Equal(t, int64(3), (&Game{mode: &teamMode{}}).HelpBudget())
+	assert.Equal(t, int64(5), (&Game{mode: &practiceMode{}}).HelpBudget())
+}
+
+func Test_TeamMode_GetGameUpdateAfterIncomingMessage(t *testing.T) {
+	t.Run("game goes on before the round limit", func(t *testing.T) {
+		game := newTeamTestGame(waitingForHumanAnswer)
+		game.currentTurnIndex = 13
+		game.lastQuestionTargetBotId = "bot_id5"
+
+		update, err := game.GetGameUpdateAfterIncomingMessage("bot_id5", "bot_id5", "an answer", "answer")
+		assert.NoError(t, err)
+		assert.Equal(t, waitingForHumanQuestion, update.State)
+		assert.Equal(t, int64(14), *update.CurrentTurnIndex)
+		assert.Nil(t, update.WinningBotId)
+	})
+
+	t.Run("imposter wins once the round limit is reached", func(t *testing.T) {
+		game := newTeamTestGame(waitingForHumanAnswer)
+		game.currentTurnIndex = 14
+		game.lastQuestionTargetBotId = "bot_id1"
+
+		update, err := game.GetGameUpdateAfterIncomingMessage("bot_id1", "bot_id1", "an answer", "answer")
+		assert.NoError(t, err)
+		assert.Equal(t, finished, update.State)
+		assert.Equal(t, int64(15), *update.CurrentTurnIndex)
+		assert.Equal(t, "bot_id3", *update.WinningBotId)
+		assert.Equal(t, "bot3, the imposter, made it through 3 rounds without being caught and won.", *update.Result)
+	})
+}
+
+func Test_TeamMode_GetGameUpdateAfterTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		game                 *Game
+		sourceBotId          string
+		targetBotId          string
+		expectedState        gameState
+		expectedResult       string
+		expectedWinningBotId string
+		errorExpected        bool
+		errorString          string
+	}{
+		{
+			name:                 "humans win when the imposter is tagged",
+			game:                 newTeamTestGame(waitingForAiQuestion),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id3",
+			expectedState:        finished,
+			expectedResult:       "bot2 tagged bot3, the imposter. The humans won!",
+			expectedWinningBotId: "bot_id2",
+		},
+		{
+			name:          "tagging a human uses up the tag and the game goes on",
+			game:          newTeamTestGame(waitingForAiQuestion),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id1",
+			expectedState: waitingForAiQuestion,
+		},
+		{
+			name: "imposter wins once the last tag is used on a human",
+			game: func() *Game {
+				game := newTeamTestGame(waitingForHumanQuestion)
+				game.bots[0].tagCount = 1
+				game.bots[3].tagCount = 1
+				game.bots[4].tagCount = 1
+				return game
+			}(),
+			sourceBotId:          "bot_id2",
+			targetBotId:          "bot_id1",
+			expectedState:        finished,
+			expectedResult:       "bot2 tagged bot1, who is human. The humans are out of tags, and bot3, the imposter, won.",
+			expectedWinningBotId: "bot_id3",
+		},
+		{
+			name: "errors if the human has no tags left",
+			game: func() *Game {
+				game := newTeamTestGame(waitingForHumanQuestion)
+				game.bots[1].tagCount = 1
+				return game
+			}(),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id3",
+			errorExpected: true,
+			errorString:   "no tags remaining",
+		},
+		{
+			name:          "errors if the imposter tags",
+			game:          newTeamTestGame(waitingForHumanQuestion),
+			sourceBotId:   "bot_id3",
+			targetBotId:   "bot_id1",
+			errorExpected: true,
+			errorString:   "ai cannot perform tagging",
+		},
+		{
+			name:          "errors if the round is not being played",
+			game:          newTeamTestGame(playersJoined),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id3",
+			errorExpected: true,
+			errorString:   "can only tag while the round is being played",
+		},
+		{
+			name:          "errors if game has finished",
+			game:          newTeamTestGame(finished),
+			sourceBotId:   "bot_id2",
+			targetBotId:   "bot_id3",
+			errorExpected: true,
+			errorString:   "game has already finished",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := tt.game.GetGameUpdateAfterTag(tt.sourceBotId, tt.targetBotId)
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+				assert.Nil(t, update)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedState, update.State)
+				assert.Equal(t, tt.sourceBotId, *update.TaggingBotId)
+				if tt.expectedWinningBotId == "" {
+					assert.Nil(t, update.WinningBotId)
+					assert.Nil(t, update.Result)
+				} else {
+					assert.Equal(t, tt.expectedResult, *update.Result)
+					assert.Equal(t, tt.expectedWinningBotId, *update.WinningBotId)
+				}
+			}
+		})
+	}
+}
+
+func Test_TeamMode_AllowedActions(t *testing.T) {
+	tests := []struct {
+		name           string
+		game           *Game
+		botId          string
+		expectedOutput []gameAction
+	}{
+		{
+			name:           "human whose turn it is can send a message, get help and tag",
+			game:           newTeamTestGame(waitingForHumanQuestion),
+			botId:          "bot_id1",
+			expectedOutput: []gameAction{sendMessageAction, helpAction, tagAction},
+		},
+		{
+			name: "human without tags left cannot tag",
+			game: func() *Game {
+				game := newTeamTestGame(waitingForHumanQuestion)
+				game.bots[1].tagCount = 1
+				return game
+			}(),
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+		{
+			name:           "imposter cannot tag",
+			game:           newTeamTestGame(waitingForHumanQuestion),
+			botId:          "bot_id3",
+			expectedOutput: []gameAction{},
+		},
+		{
+			name:           "nobody can tag before the round is played",
+			game:           newTeamTestGame(playersJoined),
+			botId:          "bot_id2",
+			expectedOutput: []gameAction{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := tt.game.mode.AllowedActions(tt.game, tt.game.BotWithId(tt.botId))
+			assert.Equal(t, tt.expectedOutput, actions)
+		})
+	}
+}
+
+func Test_TeamMode_ViewState(t *testing.T) {
+	t.Run("counts the players that still need to join", func(t *testing.T) {
+		game := newTeamTestGame(started)
+		game.bots[4].typeOfBot = ai
+		game.bots[4].player = nil
+
+		state, displayMessage := game.mode.ViewState(game, "bot_id1")
+		assert.Equal(t, waitingForPlayersToJoin, state)
+		assert.Equal(t, "Waiting for 1 more players to join in", displayMessage)
+	})
+
+	t.Run("every human wins when one of them tags the imposter", func(t *testing.T) {
+		game := newTeamTestGame(finished)
+		game.winningBotId = "bot_id1"
+		game.result = "bot1 tagged bot3, the imposter. The humans won!"
+
+		state, displayMessage := game.mode.ViewState(game, "bot_id4")
+		assert.Equal(t, youWon, state)
+		assert.Equal(t, "bot1 tagged bot3, the imposter. The humans won!", displayMessage)
+	})
+
+	t.Run("every human loses when the imposter wins", func(t *testing.T) {
+		game := newTeamTestGame(finished)
+		game.winningBotId = "bot_id3"
+
+		state, _ := game.mode.ViewState(game, "bot_id1")
+		assert.Equal(t, youLost, state)
+	})
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Vote struct {
+	VoterBotId   string
+	SuspectBotId string
+}
+
+// A round is over once every bot in the turn order has asked a question.
+func (game *Game) votingHasBeenReached(turnIndex int64) bool {
+	return game.votingRounds > 0 && turnIndex >= game.votingRounds*int64(len(game.turnOrder))
+}
+
+// VotingHasClosed is true once everyone has had VOTING_DURATION to vote.
+func (game *Game) VotingHasClosed(now time.Time) bool {
+	return game.IsInStateVoting() && game.stateTimeHasRunOut(now)
+}
+
+func (game *Game) VoteOf(voterBotId string) *Vote {
+	for _, vote := range game.votes {
+		if vote.VoterBotId == voterBotId {
+			return vote
+		}
+	}
+	return nil
+}
+
+// AiVoterBotIds returns the AI bots that vote as voting closes. That is none of them, unless the game has AI votes.
+func (game *Game) AiVoterBotIds() []string {
+	botIds := []string{}
+	if !game.aiVotes {
+		return botIds
+	}
+	for _, bot := range game.bots {
+		if bot.IsAi() && !bot.eliminated {
+			botIds = append(botIds, bot.id)
+		}
+	}
+	return botIds
+}
+
+// ValidateVote checks a vote cast by a human. The AI bots vote once voting closes.
+func (game *Game) ValidateVote(voterBotId string, suspectBotId string) error {
+	voterBot := game.BotWithId(voterBotId)
+	suspectBot := game.BotWithId(suspectBotId)
+
+	if voterBot == nil {
+		return errors.New("invalid voterBotId")
+	}
+
+	if suspectBot == nil {
+		return errors.New("invalid suspectBotId")
+	}
+
+	if !game.IsInStateVoting() {
+		return errors.New("game is not accepting votes")
+	}
+
+	if !game.allows(voterBot, voteAction) {
+		return errors.New("only humans can cast votes")
+	}
+
+	if voterBotId == suspectBotId {
+		return errors.New("cannot vote for yourself")
+	}
+
+	return nil
+}
+
+// GetGameUpdateAfterVoting tallies the votes cast by the humans along with aiVotes, which the AI bots cast as
+// voting closes.
+func (game *Game) GetGameUpdateAfterVoting(aiVotes []*Vote) (*GameUpdate, error) {
+	return game.mode.GameUpdateAfterVoting(game, aiVotes)
+}
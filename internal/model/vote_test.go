@@ -0,0 +1,223 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newVotingTestGame(votes []*Vote) *Game {
+	votingStartedAt := time.Now()
+	return &Game{
+		mode:           &classicMode{},
+		state:          voting,
+		turnOrder:      []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4"},
+		votingRounds:   2,
+		stateHandledAt: &votingStartedAt,
+		stateTotalTime: int64(VOTING_DURATION.Seconds()),
+		bots: []*Bot{
+			{id: "bot_id1", name: "bot1", typeOfBot: ai},
+			{id: "bot_id2", name: "bot2", typeOfBot: human, player: &Player{id: "player_id1"}},
+			{id: "bot_id3", name: "bot3", typeOfBot: human, player: &Player{id: "player_id2"}},
+			{id: "bot_id4", name: "bot4", typeOfBot: ai},
+		},
+		votes: votes,
+	}
+}
+
+func Test_GetGameUpdateAfterIncomingMessage_Voting(t *testing.T) {
+	newGame := func(currentTurnIndex int64) *Game {
+		game := newVotingTestGame(nil)
+		game.state = waitingForHumanAnswer
+		game.stateHandledAt = nil
+		game.stateTotalTime = 0
+		game.currentTurnIndex = currentTurnIndex
+		game.lastQuestionTargetBotId = "bot_id2"
+		return game
+	}
+
+	t.Run("starts voting once the last round is over", func(t *testing.T) {
+		update, err := newGame(7).GetGameUpdateAfterIncomingMessage("bot_id2", "bot_id2", "An answer", "answer")
+		assert.NoError(t, err)
+		assert.Equal(t, voting, update.State)
+		assert.Equal(t, int64(8), *update.CurrentTurnIndex)
+		assert.Equal(t, int64(VOTING_DURATION.Seconds()), *update.StateTotalTime)
+		AssertTimeAlmostEqual(t, *update.StateHandledAt, time.Now(), DELTA)
+	})
+
+	t.Run("keeps playing until the last round is over", func(t *testing.T) {
+		update, err := newGame(6).GetGameUpdateAfterIncomingMessage("bot_id2", "bot_id2", "An answer", "answer")
+		assert.NoError(t, err)
+		assert.Equal(t, waitingForAiQuestion, update.State)
+		assert.Nil(t, update.StateHandledAt)
+		assert.Nil(t, update.StateTotalTime)
+	})
+
+	t.Run("does not allow tagging", func(t *testing.T) {
+		_, err := newGame(6).GetGameUpdateAfterTag("bot_id2", "bot_id3")
+		assert.EqualError(t, err, "cannot tag in a voting game")
+	})
+}
+
+func Test_VotingHasClosed(t *testing.T) {
+	game := newVotingTestGame(nil)
+	assert.False(t, game.VotingHasClosed(time.Now()))
+	assert.True(t, game.VotingHasClosed(time.Now().Add(VOTING_DURATION)))
+
+	game.state = finished
+	assert.False(t, game.VotingHasClosed(time.Now().Add(VOTING_DURATION)))
+}
+
+func Test_ValidateVote(t *testing.T) {
+	tests := []struct {
+		name         string
+		game         *Game
+		voterBotId   string
+		suspectBotId string
+		errorString  string
+	}{
+		{
+			name:         "accepts a vote from a human",
+			game:         newVotingTestGame(nil),
+			voterBotId:   "bot_id2",
+			suspectBotId: "bot_id3",
+		},
+		{
+			name:         "errors if the voter is not in the game",
+			game:         newVotingTestGame(nil),
+			voterBotId:   "bot_id9",
+			suspectBotId: "bot_id3",
+			errorString:  "invalid voterBotId",
+		},
+		{
+			name:         "errors if the suspect is not in the game",
+			game:         newVotingTestGame(nil),
+			voterBotId:   "bot_id2",
+			suspectBotId: "bot_id9",
+			errorString:  "invalid suspectBotId",
+		},
+		{
+			name: "errors if the game is not voting",
+			game: func() *Game {
+				game := newVotingTestGame(nil)
+				game.state = waitingForAiQuestion
+				return game
+			}(),
+			voterBotId:   "bot_id2",
+			suspectBotId: "bot_id3",
+			errorString:  "game is not accepting votes",
+		},
+		{
+			name:         "errors if an ai bot casts the vote",
+			game:         newVotingTestGame(nil),
+			voterBotId:   "bot_id1",
+			suspectBotId: "bot_id3",
+			errorString:  "only humans can cast votes",
+		},
+		{
+			name:         "errors if the human votes for itself",
+			game:         newVotingTestGame(nil),
+			voterBotId:   "bot_id2",
+			suspectBotId: "bot_id2",
+			errorString:  "cannot vote for yourself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.game.ValidateVote(tt.voterBotId, tt.suspectBotId)
+			if tt.errorString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
+
+func Test_GetGameUpdateAfterVoting(t *testing.T) {
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string
+		game           *Game
+		aiVotes        []*Vote
+		expectedOutput *GameUpdate
+		errorString    string
+	}{
+		{
+			name: "the human with the most votes loses",
+			game: newVotingTestGame([]*Vote{
+				{VoterBotId: "bot_id2", SuspectBotId: "bot_id3"},
+				{VoterBotId: "bot_id3", SuspectBotId: "bot_id1"},
+			}),
+			aiVotes: []*Vote{
+				{VoterBotId: "bot_id1", SuspectBotId: "bot_id3"},
+				{VoterBotId: "bot_id4", SuspectBotId: "bot_id2"},
+			},
+			expectedOutput: &GameUpdate{
+				State:        finished,
+				Result:       stringPtr("bot3 got the most votes and lost. bot2 won."),
+				WinningBotId: stringPtr("bot_id2"),
+			},
+		},
+		{
+			name: "an ai bot with the most votes is a draw",
+			game: newVotingTestGame([]*Vote{
+				{VoterBotId: "bot_id2", SuspectBotId: "bot_id4"},
+				{VoterBotId: "bot_id3", SuspectBotId: "bot_id4"},
+			}),
+			expectedOutput: &GameUpdate{
+				State:  finished,
+				Result: stringPtr("bot4 got the most votes but is an AI bot. It's a draw."),
+			},
+		},
+		{
+			name: "a tied vote is a draw",
+			game: newVotingTestGame([]*Vote{
+				{VoterBotId: "bot_id2", SuspectBotId: "bot_id3"},
+				{VoterBotId: "bot_id3", SuspectBotId: "bot_id2"},
+			}),
+			expectedOutput: &GameUpdate{
+				State:  finished,
+				Result: stringPtr("The vote was tied. It's a draw."),
+			},
+		},
+		{
+			name: "no votes is a draw",
+			game: newVotingTestGame(nil),
+			expectedOutput: &GameUpdate{
+				State:  finished,
+				Result: stringPtr("Nobody voted. It's a draw."),
+			},
+		},
+		{
+			name: "errors if the game is not voting",
+			game: func() *Game {
+				game := newVotingTestGame(nil)
+				game.state = finished
+				return game
+			}(),
+			errorString: "game is not accepting votes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.game.GetGameUpdateAfterVoting(tt.aiVotes)
+			if tt.errorString == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, result)
+			} else {
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
+
+func Test_AiVoterBotIds(t *testing.T) {
+	game := newVotingTestGame(nil)
+	assert.Equal(t, []string{}, game.AiVoterBotIds())
+
+	game.aiVotes = true
+	assert.Equal(t, []string{"bot_id1", "bot_id4"}, game.AiVoterBotIds())
+}
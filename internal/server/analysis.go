@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+)
+
+// GetGameAnalysis is only available to players of the game, and only once the game has finished, since it reveals
+// who everyone really was.
+func (s *AiRetreatGoService) GetGameAnalysis(ctx context.Context, req *pb.GetGameAnalysisRequest) (*pb.GetGameAnalysisResponse, error) {
+	game, err := s.storage.GetGame(ctx, req.GetGameId())
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	if game.BotWithPlayerId(req.GetPlayerId()) == nil {
+		err := errors.New("incorrect game")
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	if !game.IsInStateFinished() {
+		err := errors.New("game has not finished yet")
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	analysis, err := s.storage.GetGameAnalysis(ctx, game.Id())
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	botAnalyses := []*pb.BotAnalysis{}
+	for _, botAnalysis := range analysis.BotAnalyses {
+		bot := game.BotWithId(botAnalysis.BotId)
+		if bot == nil {
+			continue
+		}
+		botAnalyses = append(botAnalyses, &pb.BotAnalysis{
+			BotId:          bot.Id(),
+			BotName:        bot.Name(),
+			IsHuman:        bot.IsHuman(),
+			HumannessScore: botAnalysis.HumannessScore,
+			Tells:          botAnalysis.Tells,
+		})
+	}
+
+	return &pb.GetGameAnalysisResponse{BotAnalyses: botAnalyses}, nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+)
+
+func Test_GetGameAnalysis(t *testing.T) {
+	analyzedGame := func(state string) *model.Game {
+		player, _ := model.NewPlayer(
+			model.PlayerOptions{
+				Id: "player_id1",
+			},
+		)
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		bots[0].ConnectPlayer(player)
+		game, _ := model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            state,
+				CurrentTurnIndex: 10,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+			},
+		)
+		return game
+	}
+	gameAnalysis := &model.GameAnalysis{
+		GameId: "game_id1",
+		BotAnalyses: []model.BotAnalysis{
+			{BotId: "bot_id1", HumannessScore: 80, Tells: []string{"lol idk"}},
+			{BotId: "bot_id2", HumannessScore: 30, Tells: []string{"I am a very polite bot."}},
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		input                    *pb.GetGameAnalysisRequest
+		output                   *pb.GetGameAnalysisResponse
+		gameAccessorMock         storage.GameAccessor
+		gameAnalysisAccessorMock storage.GameAnalysisAccessor
+		errorExpected            bool
+		errorString              string
+	}{
+		{
+			name: "returns the analysis of every bot",
+			input: &pb.GetGameAnalysisRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+			},
+			output: &pb.GetGameAnalysisResponse{
+				BotAnalyses: []*pb.BotAnalysis{
+					{BotId: "bot_id1", BotName: "bot1", IsHuman: true, HumannessScore: 80, Tells: []string{"lol idk"}},
+					{BotId: "bot_id2", BotName: "bot2", IsHuman: false, HumannessScore: 30, Tells: []string{"I am a very polite bot."}},
+				},
+			},
+			gameAccessorMock:         &storage.GameGetterMockSuccess{Game: analyzedGame("FINISHED")},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockSuccess{GameAnalysis: gameAnalysis},
+			errorExpected:            false,
+			errorString:              "",
+		},
+		{
+			name: "errors if cannot get game",
+			input: &pb.GetGameAnalysisRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+			},
+			output:           nil,
+			gameAccessorMock: &storage.GameGetterMockFailure{},
+			errorExpected:    true,
+			errorString:      "unable to get game",
+		},
+		{
+			name: "errors if player not in game",
+			input: &pb.GetGameAnalysisRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id2",
+			},
+			output:           nil,
+			gameAccessorMock: &storage.GameGetterMockSuccess{Game: analyzedGame("FINISHED")},
+			errorExpected:    true,
+			errorString:      "incorrect game",
+		},
+		{
+			name: "errors if game has not finished",
+			input: &pb.GetGameAnalysisRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+			},
+			output:           nil,
+			gameAccessorMock: &storage.GameGetterMockSuccess{Game: analyzedGame("VOTING")},
+			errorExpected:    true,
+			errorString:      "game has not finished yet",
+		},
+		{
+			name: "errors if analysis cannot be found",
+			input: &pb.GetGameAnalysisRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+			},
+			output:                   nil,
+			gameAccessorMock:         &storage.GameGetterMockSuccess{Game: analyzedGame("FINISHED")},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockFailure{},
+			errorExpected:            true,
+			errorString:              "unable to get game analysis",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := NewServer(ServerDependencies{
+				Storage: storage.NewStorageAccessorMock(
+					storage.WithGameAccessorMock(tt.gameAccessorMock),
+					storage.WithGameAnalysisAccessorMock(tt.gameAnalysisAccessorMock),
+				),
+				Logger: &utilities.NullLogger{},
+			})
+
+			response, err := server.GetGameAnalysis(
+				context.Background(),
+				tt.input,
+			)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.EqualValues(t, tt.output, response)
+			} else {
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
@@ -24,7 +24,7 @@ func contextWithUserData(ctx context.Context, userRetriever storage.UserRetrieve
 		return ctx, status.Errorf(codes.Unauthenticated, "%s is not supplied", requestingUserEmailCtxKey)
 	}
 
-	user, err := userRetriever.UserByEmail(requestingUserEmails[0])
+	user, err := userRetriever.UserByEmail(ctx, requestingUserEmails[0])
 	if err != nil {
 		return ctx, status.Errorf(codes.Unauthenticated, err.Error())
 	}
@@ -5,6 +5,8 @@ import (
 	"math/rand"
 
 	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,23 +14,45 @@ import (
 )
 
 func (s *AiRetreatGoService) CreateGame(ctx context.Context, req *pb.CreateGameRequest) (*pb.CreateGameResponse, error) {
-	gameId, err := s.storage.CreateGame(req.GetPublic())
+	gameId, err := s.storage.CreateGame(ctx, storage.GameCreateOptions{
+		Public:        req.GetPublic(),
+		PromptVersion: s.promptExperiment.PickVersion(),
+		Mode:          req.GetMode(),
+		AiAccusations: req.GetAiAccusations(),
+		Elimination:   req.GetElimination(),
+		VotingRounds:  req.GetVotingRounds(),
+		AiVotes:       req.GetAiVotes(),
+	})
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
+
+	// Games that only need one human, like practice games, start right away with the player that created them. No one
+	// else can join such a game, so it is deleted if the player cannot join it. The request may have been cancelled,
+	// which is what failed the join, so the game is deleted regardless.
+	if model.HumanPlayerCountForMode(req.GetMode()) == 1 {
+		_, err = s.JoinGame(ctx, &pb.JoinGameRequest{GameId: gameId, PlayerId: req.GetPlayerId()})
+		if err != nil {
+			deleteErr := s.storage.DeleteGame(context.Background(), gameId)
+			if deleteErr != nil {
+				s.logger.LogError(deleteErr)
+			}
+			return nil, err
+		}
+	}
 	return &pb.CreateGameResponse{GameId: gameId}, nil
 }
 
 func (s *AiRetreatGoService) JoinGame(ctx context.Context, req *pb.JoinGameRequest) (*pb.JoinGameResponse, error) {
-	tx, err := s.storage.BeginTransaction()
+	tx, err := s.storage.BeginTransaction(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	game, err := s.storage.GetGameUsingTransaction(req.GetGameId(), tx)
+	game, err := s.storage.GetGameUsingTransaction(ctx, req.GetGameId(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -49,13 +73,13 @@ func (s *AiRetreatGoService) JoinGame(ctx context.Context, req *pb.JoinGameReque
 		return nil, err
 	}
 
-	err = s.storage.UpdateBotWithPlayerIdUsingTransaction(aiBot.Id(), req.GetPlayerId(), tx)
+	err = s.storage.UpdateBotWithPlayerIdUsingTransaction(ctx, aiBot.Id(), req.GetPlayerId(), game.HelpBudget(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	err = s.storage.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(req.GetGameId(), tx)
+	err = s.storage.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx, req.GetGameId(), game.HumanPlayerCount(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -66,7 +90,7 @@ func (s *AiRetreatGoService) JoinGame(ctx context.Context, req *pb.JoinGameReque
 }
 
 func (s *AiRetreatGoService) GetGameForPlayer(ctx context.Context, req *pb.GetGameForPlayerRequest) (*pb.GetGameForPlayerResponse, error) {
-	game, err := s.storage.GetGame(req.GetGameId())
+	game, err := s.storage.GetGame(ctx, req.GetGameId())
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -81,40 +105,57 @@ func (s *AiRetreatGoService) GetGameForPlayer(ctx context.Context, req *pb.GetGa
 		stateStartedAt = timestamppb.New(*gameView.StateStartedAt)
 	}
 
+	var myNextTagAt *timestamppb.Timestamp
+	if gameView.MyNextTagAt != nil {
+		myNextTagAt = timestamppb.New(*gameView.MyNextTagAt)
+	}
+
 	bots := []*pb.Bot{}
 	for _, bot := range gameView.Bots {
 		bots = append(bots, &pb.Bot{
-			Id:   bot.Id,
-			Name: bot.Name,
+			Id:         bot.Id,
+			Name:       bot.Name,
+			Eliminated: bot.Eliminated,
 		})
 	}
 
 	gameMessages := []*pb.GameMessage{}
 	for _, detailedMessage := range gameView.DetailedMessages {
 		gameMessages = append(gameMessages, &pb.GameMessage{
-			SourceBotId: detailedMessage.SourceBotId,
-			TargetBotId: detailedMessage.TargetBotId,
-			Text:        detailedMessage.Text,
-			Type:        detailedMessage.MessageType,
+			SourceBotId:      detailedMessage.SourceBotId,
+			TargetBotId:      detailedMessage.TargetBotId,
+			Text:             detailedMessage.Text,
+			Type:             messageTypeToProto(detailedMessage.MessageType),
+			ResponseTimeMs:   detailedMessage.ResponseTime.Milliseconds(),
+			HelpUsage:        detailedMessage.HelpUsage,
+			HelpStyle:        detailedMessage.HelpStyle,
+			AiModel:          detailedMessage.AiModel,
+			PromptVersion:    detailedMessage.PromptVersion,
+			PromptTokens:     detailedMessage.PromptTokens,
+			CompletionTokens: detailedMessage.CompletionTokens,
 		})
 	}
 
 	return &pb.GetGameForPlayerResponse{
-		State:          gameView.State.String(),
-		DisplayMessage: gameView.DisplayMessage,
-		StateStartedAt: stateStartedAt,
-		StateTotalTime: gameView.StateTotalTime,
-		LastQuestion:   gameView.LastQuestion,
-		MyBotId:        gameView.MyBotId,
-		Bots:           bots,
-		Messages:       gameMessages,
-		WinningBotId:   gameView.WinningBotId,
-		MyHelpCount:    gameView.MyHelpCount,
+		State:               gameView.State.String(),
+		DisplayMessage:      gameView.DisplayMessage,
+		StateStartedAt:      stateStartedAt,
+		StateTotalTime:      gameView.StateTotalTime,
+		LastQuestion:        gameView.LastQuestion,
+		MyBotId:             gameView.MyBotId,
+		Bots:                bots,
+		Messages:            gameMessages,
+		WinningBotId:        gameView.WinningBotId,
+		MyHelpCount:         gameView.MyHelpCount,
+		MyTagsRemaining:     gameView.MyTagsRemaining,
+		MyNextTagAt:         myNextTagAt,
+		MyVoteBotId:         gameView.MyVoteBotId,
+		MyMessagesRemaining: gameView.MyMessagesRemaining,
 	}, nil
 }
 
 func (s *AiRetreatGoService) GetGamesForPlayer(ctx context.Context, req *pb.GetGamesForPlayerRequest) (*pb.GetGamesForPlayerResponse, error) {
-	gameIds, err := s.storage.GetGames(req.GetPlayerId())
+	gameIds, err := s.storage.GetGames(ctx, req.GetPlayerId())
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -124,7 +165,7 @@ func (s *AiRetreatGoService) GetGamesForPlayer(ctx context.Context, req *pb.GetG
 }
 
 func (s *AiRetreatGoService) AutoJoinGame(ctx context.Context, req *pb.AutoJoinGameRequest) (*pb.AutoJoinGameResponse, error) {
-	gameIds, err := s.storage.GetAutoJoinableGames()
+	gameIds, err := s.storage.GetAutoJoinableGames(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -136,14 +177,14 @@ func (s *AiRetreatGoService) AutoJoinGame(ctx context.Context, req *pb.AutoJoinG
 		return nil, err
 	}
 
-	tx, err := s.storage.BeginTransaction()
+	tx, err := s.storage.BeginTransaction(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	game, err := s.storage.GetGameUsingTransaction(randomlySelectedGameId, tx)
+	game, err := s.storage.GetGameUsingTransaction(ctx, randomlySelectedGameId, tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -164,13 +205,13 @@ func (s *AiRetreatGoService) AutoJoinGame(ctx context.Context, req *pb.AutoJoinG
 		return nil, err
 	}
 
-	err = s.storage.UpdateBotWithPlayerIdUsingTransaction(aiBot.Id(), req.GetPlayerId(), tx)
+	err = s.storage.UpdateBotWithPlayerIdUsingTransaction(ctx, aiBot.Id(), req.GetPlayerId(), game.HelpBudget(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	err = s.storage.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(randomlySelectedGameId, tx)
+	err = s.storage.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx, randomlySelectedGameId, game.HumanPlayerCount(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+	"github.com/vipulvpatil/airetreat-go/internal/workers"
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+)
+
+// Test_GameFlow plays a classic game from start to finish against MemoryStorage. The jobs that the game handler loop
+// starts for the game are run as they are, with a stubbed OpenAI client.
+func Test_GameFlow(t *testing.T) {
+	ctx := context.Background()
+	memoryStorage := storage.NewMemoryStorage(storage.MemoryStorageOptions{})
+	promptRegistry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+	promptExperiment, err := prompts.NewExperiment("", promptRegistry)
+	assert.NoError(t, err)
+	server, _ := NewServer(ServerDependencies{
+		Storage:          memoryStorage,
+		Prompts:          promptRegistry,
+		PromptExperiment: promptExperiment,
+		Logger:           &utilities.NullLogger{},
+	})
+	workerDeps := workers.PoolDependencies{
+		Storage:      memoryStorage,
+		OpenAiClient: &openai.MockClientSuccess{Text: "where do you live?"},
+		Prompts:      promptRegistry,
+		Logger:       &utilities.NullLogger{},
+	}
+
+	player1, err := memoryStorage.CreatePlayer(ctx)
+	assert.NoError(t, err)
+	player2, err := memoryStorage.CreatePlayer(ctx)
+	assert.NoError(t, err)
+
+	createGameResponse, err := server.CreateGame(ctx, &pb.CreateGameRequest{PlayerId: player1.Id()})
+	assert.NoError(t, err)
+	gameId := createGameResponse.GetGameId()
+
+	var wg sync.WaitGroup
+	for _, playerId := range []string{player1.Id(), player2.Id()} {
+		wg.Add(1)
+		go func(playerId string) {
+			defer wg.Done()
+			_, err := server.JoinGame(ctx, &pb.JoinGameRequest{GameId: gameId, PlayerId: playerId})
+			assert.NoError(t, err)
+		}(playerId)
+	}
+	wg.Wait()
+
+	game, err := memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	assert.True(t, game.IsInStatePlayersJoined(), "the game should be ready once both players have joined")
+	player1Bot := game.BotWithPlayerId(player1.Id())
+	player2Bot := game.BotWithPlayerId(player2.Id())
+	assert.NotEqual(t, player1Bot.Id(), player2Bot.Id())
+	playerIdsByBotId := map[string]string{
+		player1Bot.Id(): player1.Id(),
+		player2Bot.Id(): player2.Id(),
+	}
+
+	runClaimedGameJob(t, server, memoryStorage, workerDeps, gameId, workers.START_GAME_ONCE_PLAYERS_HAVE_JOINED)
+
+	// Play turns until both humans have sent a message, and an AI bot has too.
+	humanMessageCount := 0
+	aiJobCount := 0
+	for turn := 0; (humanMessageCount < 2 || aiJobCount == 0) && turn < 50; turn++ {
+		game, err = memoryStorage.GetGame(ctx, gameId)
+		assert.NoError(t, err)
+		waitingOnBot := game.GetBotThatGameIsWaitingOn()
+		assert.NotNil(t, waitingOnBot)
+
+		switch {
+		case game.IsInStateWaitingForHumanQuestion():
+			targetBotId, err := game.GetTargetBotIdForNextQuestion()
+			assert.NoError(t, err)
+			_, err = server.SendMessage(ctx, &pb.SendMessageRequest{
+				GameId:   gameId,
+				PlayerId: playerIdsByBotId[waitingOnBot.Id()],
+				BotId:    targetBotId,
+				Text:     "what did you have for breakfast?",
+				Type:     pb.MessageType_QUESTION,
+			})
+			assert.NoError(t, err)
+			humanMessageCount++
+		case game.IsInStateWaitingForHumanAnswer():
+			_, err = server.SendMessage(ctx, &pb.SendMessageRequest{
+				GameId:   gameId,
+				PlayerId: playerIdsByBotId[waitingOnBot.Id()],
+				BotId:    waitingOnBot.Id(),
+				Text:     "just some toast",
+				Type:     pb.MessageType_ANSWER,
+			})
+			assert.NoError(t, err)
+			humanMessageCount++
+		case game.IsInStateWaitingForAiQuestion():
+			runClaimedGameJob(t, server, memoryStorage, workerDeps, gameId, workers.ASK_QUESTION_ON_BEHALF_OF_BOT)
+			aiJobCount++
+		case game.IsInStateWaitingForAiAnswer():
+			runClaimedGameJob(t, server, memoryStorage, workerDeps, gameId, workers.ANSWER_QUESTION_ON_BEHALF_OF_BOT)
+			aiJobCount++
+		default:
+			assert.FailNow(t, "unexpected game state", gameId)
+		}
+	}
+	assert.GreaterOrEqual(t, humanMessageCount, 2)
+
+	_, err = server.Tag(ctx, &pb.TagRequest{GameId: gameId, PlayerId: player1.Id(), BotId: player2Bot.Id()})
+	assert.NoError(t, err)
+
+	game, err = memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	assert.True(t, game.IsInStateFinished())
+	aiMessageCount := 0
+	for _, message := range game.GetDetailedMessages() {
+		if !game.BotWithId(message.SourceBotId).IsHuman() {
+			aiMessageCount++
+			assert.Equal(t, "where do you live?", message.Text, "AI bots should say what OpenAI came up with")
+		}
+	}
+	assert.Equal(t, aiJobCount, aiMessageCount, "each job should have sent a message on behalf of an AI bot")
+
+	gameForPlayer, err := server.GetGameForPlayer(ctx, &pb.GetGameForPlayerRequest{GameId: gameId, PlayerId: player1.Id()})
+	assert.NoError(t, err)
+	assert.Equal(t, player1Bot.Id(), gameForPlayer.GetWinningBotId())
+	assert.Len(t, gameForPlayer.GetMessages(), len(game.GetDetailedMessages()))
+	assert.GreaterOrEqual(t, len(gameForPlayer.GetMessages()), humanMessageCount)
+
+	jobStarterMock := runGameHandlerLoopOnce(server)
+	assert.Contains(t, jobStarterMock.CalledArgs[workers.ANALYZE_FINISHED_GAME], map[string]any{"gameId": gameId})
+}
+
+// Test_GameFlow_RequestDeadline checks that a call gives up on storage once its deadline passes, such as while another
+// call holds the lock on the game.
+func Test_GameFlow_RequestDeadline(t *testing.T) {
+	ctx := context.Background()
+	memoryStorage := storage.NewMemoryStorage(storage.MemoryStorageOptions{})
+	promptRegistry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+	promptExperiment, err := prompts.NewExperiment("", promptRegistry)
+	assert.NoError(t, err)
+	server, _ := NewServer(ServerDependencies{
+		Storage:          memoryStorage,
+		Prompts:          promptRegistry,
+		PromptExperiment: promptExperiment,
+		Logger:           &utilities.NullLogger{},
+	})
+
+	player, err := memoryStorage.CreatePlayer(ctx)
+	assert.NoError(t, err)
+	gameId, err := memoryStorage.CreateGame(ctx, storage.GameCreateOptions{})
+	assert.NoError(t, err)
+
+	tx, err := memoryStorage.BeginTransaction(ctx)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+	_, err = memoryStorage.GetGameUsingTransaction(ctx, gameId, tx)
+	assert.NoError(t, err)
+
+	requestCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = server.JoinGame(requestCtx, &pb.JoinGameRequest{GameId: gameId, PlayerId: player.Id()})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, tx.Commit())
+
+	game, err := memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	assert.Nil(t, game.BotWithPlayerId(player.Id()), "the player should not have joined")
+}
+
+func runGameHandlerLoopOnce(server *AiRetreatGoService) *workers.JobStarterMockCallCheck {
+	jobStarterMock := &workers.JobStarterMockCallCheck{}
+	var wg sync.WaitGroup
+	gameHandlerLoopCtx, cancelGameHandlerLoop := context.WithCancel(context.Background())
+	go server.GameHandlerLoop(gameHandlerLoopCtx, 10*time.Millisecond, &wg, jobStarterMock)
+	time.Sleep(25 * time.Millisecond)
+	cancelGameHandlerLoop()
+	wg.Wait()
+	return jobStarterMock
+}
+
+// runClaimedGameJob runs the game handler loop, which should claim the game and start the job for it, and then runs
+// the job with the arguments it was started with.
+func runClaimedGameJob(t *testing.T, server *AiRetreatGoService, memoryStorage *storage.MemoryStorage, workerDeps workers.PoolDependencies, gameId, jobName string) {
+	jobStarterMock := runGameHandlerLoopOnce(server)
+
+	game, err := memoryStorage.GetGame(context.Background(), gameId)
+	assert.NoError(t, err)
+	assert.True(t, game.StateHasBeenHandled(), "the loop should have claimed the game")
+	jobArgs := map[string]any{"gameId": gameId, "claimedVersion": game.Version()}
+	assert.Equal(t, []map[string]any{jobArgs}, jobStarterMock.CalledArgs[jobName], "the job should be started once")
+
+	err = workers.RunJob(workerDeps, jobName, jobArgs)
+	assert.NoError(t, err)
+}
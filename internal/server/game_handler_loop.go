@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/gocraft/work"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/workers"
 )
 
@@ -17,60 +19,119 @@ func (s *AiRetreatGoService) GameHandlerLoop(ctx context.Context, tickerDuration
 	for {
 		select {
 		case <-ticker.C:
-			s.beginGames(jobStarter)
-			s.askQuestionsUsingAi(jobStarter)
-			s.answerQuestionsUsingAi(jobStarter)
-			s.deleteExpiredGames(jobStarter)
+			s.handleClaimableGames(ctx, jobStarter)
+			s.accuseUsingAi(ctx, jobStarter)
+			s.closeVoting(ctx, jobStarter)
+			s.chatUsingAi(ctx, jobStarter)
+			s.analyzeFinishedGames(ctx, jobStarter)
+			s.deleteExpiredGames(ctx, jobStarter)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-func (s *AiRetreatGoService) beginGames(jobStarter workers.JobStarter) {
-	gameIds, err := s.storage.GetUnhandledGameIdsForState("PLAYERS_JOINED")
+// Each tick claims up to GAME_CLAIM_BATCH_SIZE games. A job has GAME_CLAIM_TIMEOUT to move a claimed game on, before
+// the game can be claimed again.
+const GAME_CLAIM_BATCH_SIZE = 100
+const GAME_CLAIM_TIMEOUT = 10 * time.Minute
+
+// Games are claimed, so however many instances run the loop, each game gets a single job. A game that could not be
+// handed to a job is released for the next tick. The job is started with the version the game was claimed at, so that
+// a job whose claim has timed out leaves the game to the job started for the next claim.
+func (s *AiRetreatGoService) handleClaimableGames(ctx context.Context, jobStarter workers.JobStarter) {
+	gameStates := []string{}
+	for gameState := range workers.JobsForClaimedGameStates {
+		gameStates = append(gameStates, gameState)
+	}
+	sort.Strings(gameStates)
+
+	claimedGames, err := s.storage.ClaimUnhandledGames(ctx, gameStates, GAME_CLAIM_BATCH_SIZE, GAME_CLAIM_TIMEOUT)
+	if err != nil {
+		s.logger.LogError(err)
+		return
+	}
+	for _, claimedGame := range claimedGames {
+		_, err := jobStarter.EnqueueUnique(workers.JobsForClaimedGameStates[claimedGame.State], work.Q{"gameId": claimedGame.Id, "claimedVersion": claimedGame.Version})
+		if err != nil {
+			s.logger.LogError(err)
+			s.releaseClaim(ctx, claimedGame)
+		}
+	}
+}
+
+func (s *AiRetreatGoService) releaseClaim(ctx context.Context, claimedGame storage.ClaimedGame) {
+	stateHandled := false
+	version := claimedGame.Version
+	err := s.storage.UpdateGameState(ctx, claimedGame.Id, storage.GameUpdateOptions{
+		StateHandled:    &stateHandled,
+		ExpectedVersion: &version,
+	})
+	if err != nil {
+		s.logger.LogError(err)
+	}
+}
+
+func (s *AiRetreatGoService) accuseUsingAi(ctx context.Context, jobStarter workers.JobStarter) {
+	gameIds, err := s.storage.GetGameIdsForAiAccusation(ctx)
+	if err != nil {
+		s.logger.LogError(err)
+		return
+	}
+	for _, gameId := range gameIds {
+		_, err := jobStarter.EnqueueUnique(workers.ACCUSE_ON_BEHALF_OF_AI_BOT, work.Q{"gameId": gameId})
+		if err != nil {
+			s.logger.LogError(err)
+		}
+	}
+}
+
+func (s *AiRetreatGoService) closeVoting(ctx context.Context, jobStarter workers.JobStarter) {
+	gameIds, err := s.storage.GetGameIdsForVotingToClose(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return
 	}
 	for _, gameId := range gameIds {
-		_, err := jobStarter.EnqueueUnique(workers.START_GAME_ONCE_PLAYERS_HAVE_JOINED, work.Q{"gameId": gameId})
+		_, err := jobStarter.EnqueueUnique(workers.CLOSE_VOTING, work.Q{"gameId": gameId})
 		if err != nil {
 			s.logger.LogError(err)
 		}
 	}
 }
 
-func (s *AiRetreatGoService) askQuestionsUsingAi(jobStarter workers.JobStarter) {
-	gameIds, err := s.storage.GetUnhandledGameIdsForState("WAITING_FOR_AI_QUESTION")
+// Group chats stay unhandled until they finish, so the chat job runs on every tick and decides for itself
+// whether an AI bot should speak or the chat should end.
+func (s *AiRetreatGoService) chatUsingAi(ctx context.Context, jobStarter workers.JobStarter) {
+	gameIds, err := s.storage.GetUnhandledGameIdsForState(ctx, "CHATTING")
 	if err != nil {
 		s.logger.LogError(err)
 		return
 	}
 	for _, gameId := range gameIds {
-		_, err := jobStarter.EnqueueUnique(workers.ASK_QUESTION_ON_BEHALF_OF_BOT, work.Q{"gameId": gameId})
+		_, err := jobStarter.EnqueueUnique(workers.CHAT_ON_BEHALF_OF_AI_BOTS, work.Q{"gameId": gameId})
 		if err != nil {
 			s.logger.LogError(err)
 		}
 	}
 }
 
-func (s *AiRetreatGoService) answerQuestionsUsingAi(jobStarter workers.JobStarter) {
-	gameIds, err := s.storage.GetUnhandledGameIdsForState("WAITING_FOR_AI_ANSWER")
+func (s *AiRetreatGoService) analyzeFinishedGames(ctx context.Context, jobStarter workers.JobStarter) {
+	gameIds, err := s.storage.GetGameIdsForAnalysis(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return
 	}
 	for _, gameId := range gameIds {
-		_, err := jobStarter.EnqueueUnique(workers.ANSWER_QUESTION_ON_BEHALF_OF_BOT, work.Q{"gameId": gameId})
+		_, err := jobStarter.EnqueueUnique(workers.ANALYZE_FINISHED_GAME, work.Q{"gameId": gameId})
 		if err != nil {
 			s.logger.LogError(err)
 		}
 	}
 }
 
-func (s *AiRetreatGoService) deleteExpiredGames(jobStarter workers.JobStarter) {
-	gameIds, err := s.storage.GetOldGames(-2 * time.Hour)
+func (s *AiRetreatGoService) deleteExpiredGames(ctx context.Context, jobStarter workers.JobStarter) {
+	gameIds, err := s.storage.GetOldGames(ctx, -2*time.Hour)
 	if err != nil {
 		s.logger.LogError(err)
 		return
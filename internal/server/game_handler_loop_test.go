@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 	"github.com/vipulvpatil/airetreat-go/internal/workers"
 )
 
@@ -17,20 +18,42 @@ func Test_GameHandlerLoop(t *testing.T) {
 		tickerDuration := 10 * time.Millisecond
 		gamesAccessorGetUnhandledGameIdsMockCaller := GetUnhandledGameIdsMockCaller{
 			MapByInput: map[string]*functionCallInspectableMock{
-				"PLAYERS_JOINED": {
-					ReturnData:  [][]string{{"game_id1", "game_id2"}},
-					ReturnCount: 1,
-				},
-				"WAITING_FOR_AI_QUESTION": {
-					ReturnData:  [][]string{{"game_id3", "game_id4"}},
-					ReturnCount: 1,
-				},
-				"WAITING_FOR_AI_ANSWER": {
-					ReturnData:  [][]string{{"game_id5", "game_id6"}},
+				"CHATTING": {
+					ReturnData:  [][]string{{"game_id10"}},
 					ReturnCount: 1,
 				},
 			},
 		}
+		gamesAccessorClaimUnhandledGamesMockCaller := ClaimUnhandledGamesMockCaller{
+			ReturnData: [][]storage.ClaimedGame{{
+				{Id: "game_id1", State: "PLAYERS_JOINED", Version: 1},
+				{Id: "game_id2", State: "PLAYERS_JOINED", Version: 1},
+				{Id: "game_id3", State: "WAITING_FOR_AI_QUESTION", Version: 1},
+				{Id: "game_id4", State: "WAITING_FOR_AI_QUESTION", Version: 1},
+				{Id: "game_id5", State: "WAITING_FOR_AI_ANSWER", Version: 1},
+				{Id: "game_id6", State: "WAITING_FOR_AI_ANSWER", Version: 1},
+				{Id: "game_id8", State: "BOT_ELIMINATED", Version: 1},
+			}},
+			ReturnCount: 1,
+		}
+		gamesAccessorGetGameIdsForAiAccusationMockCaller := GetGameIdsForAiAccusationMockCaller{
+			&functionCallInspectableMock{
+				ReturnData:  [][]string{{"game_id7"}},
+				ReturnCount: 1,
+			},
+		}
+		gamesAccessorGetGameIdsForVotingToCloseMockCaller := GetGameIdsForVotingToCloseMockCaller{
+			&functionCallInspectableMock{
+				ReturnData:  [][]string{{"game_id9"}},
+				ReturnCount: 1,
+			},
+		}
+		gamesAccessorGetGameIdsForAnalysisMockCaller := GetGameIdsForAnalysisMockCaller{
+			&functionCallInspectableMock{
+				ReturnData:  [][]string{{"game_id11"}},
+				ReturnCount: 1,
+			},
+		}
 		gamesAccessorGetOldGamesMockCaller := GetOldGamesMockCaller{
 			&functionCallInspectableMock{
 				ReturnData:  [][]string{{"old_game_id1"}, {"old_game_id2"}},
@@ -44,18 +67,28 @@ func Test_GameHandlerLoop(t *testing.T) {
 			expectedCallCount int
 		}{
 			{
-				name:              "GetUnhandledGameIds for state PLAYERS_JOINED, %s",
-				functionCall:      gamesAccessorGetUnhandledGameIdsMockCaller.MapByInput["PLAYERS_JOINED"],
+				name:              "ClaimUnhandledGames, %s",
+				functionCall:      &gamesAccessorClaimUnhandledGamesMockCaller,
+				expectedCallCount: 4,
+			},
+			{
+				name:              "GetUnhandledGameIds for state CHATTING, %s",
+				functionCall:      gamesAccessorGetUnhandledGameIdsMockCaller.MapByInput["CHATTING"],
+				expectedCallCount: 4,
+			},
+			{
+				name:              "GetGameIdsForAiAccusation, %s",
+				functionCall:      gamesAccessorGetGameIdsForAiAccusationMockCaller,
 				expectedCallCount: 4,
 			},
 			{
-				name:              "GetUnhandledGameIds for state WAITING_FOR_AI_QUESTION, %s",
-				functionCall:      gamesAccessorGetUnhandledGameIdsMockCaller.MapByInput["WAITING_FOR_AI_QUESTION"],
+				name:              "GetGameIdsForVotingToClose, %s",
+				functionCall:      gamesAccessorGetGameIdsForVotingToCloseMockCaller,
 				expectedCallCount: 4,
 			},
 			{
-				name:              "GetUnhandledGameIds for state WAITING_FOR_AI_ANSWER, %s",
-				functionCall:      gamesAccessorGetUnhandledGameIdsMockCaller.MapByInput["WAITING_FOR_AI_ANSWER"],
+				name:              "GetGameIdsForAnalysis, %s",
+				functionCall:      gamesAccessorGetGameIdsForAnalysisMockCaller,
 				expectedCallCount: 4,
 			},
 			{
@@ -72,22 +105,52 @@ func Test_GameHandlerLoop(t *testing.T) {
 			{
 				jobName: workers.START_GAME_ONCE_PLAYERS_HAVE_JOINED,
 				jobArgs: []map[string]any{
-					{"gameId": "game_id1"},
-					{"gameId": "game_id2"},
+					{"gameId": "game_id1", "claimedVersion": int64(1)},
+					{"gameId": "game_id2", "claimedVersion": int64(1)},
+				},
+			},
+			{
+				jobName: workers.START_NEXT_ROUND,
+				jobArgs: []map[string]any{
+					{"gameId": "game_id8", "claimedVersion": int64(1)},
 				},
 			},
 			{
 				jobName: workers.ASK_QUESTION_ON_BEHALF_OF_BOT,
 				jobArgs: []map[string]any{
-					{"gameId": "game_id3"},
-					{"gameId": "game_id4"},
+					{"gameId": "game_id3", "claimedVersion": int64(1)},
+					{"gameId": "game_id4", "claimedVersion": int64(1)},
 				},
 			},
 			{
 				jobName: workers.ANSWER_QUESTION_ON_BEHALF_OF_BOT,
 				jobArgs: []map[string]any{
-					{"gameId": "game_id5"},
-					{"gameId": "game_id6"},
+					{"gameId": "game_id5", "claimedVersion": int64(1)},
+					{"gameId": "game_id6", "claimedVersion": int64(1)},
+				},
+			},
+			{
+				jobName: workers.ACCUSE_ON_BEHALF_OF_AI_BOT,
+				jobArgs: []map[string]any{
+					{"gameId": "game_id7"},
+				},
+			},
+			{
+				jobName: workers.CLOSE_VOTING,
+				jobArgs: []map[string]any{
+					{"gameId": "game_id9"},
+				},
+			},
+			{
+				jobName: workers.CHAT_ON_BEHALF_OF_AI_BOTS,
+				jobArgs: []map[string]any{
+					{"gameId": "game_id10"},
+				},
+			},
+			{
+				jobName: workers.ANALYZE_FINISHED_GAME,
+				jobArgs: []map[string]any{
+					{"gameId": "game_id11"},
 				},
 			},
 			{
@@ -105,6 +168,10 @@ func Test_GameHandlerLoop(t *testing.T) {
 					storage.WithGameAccessorMock(
 						&storage.GameAccessorConfigurableMock{
 							GetUnhandledGameIdsForStateInternal: gamesAccessorGetUnhandledGameIdsMockCaller.getUnhandledGameIdsForStateInternal,
+							ClaimUnhandledGamesInternal:         gamesAccessorClaimUnhandledGamesMockCaller.claimUnhandledGames,
+							GetGameIdsForAiAccusationInternal:   gamesAccessorGetGameIdsForAiAccusationMockCaller.getGameIdsForAiAccusation,
+							GetGameIdsForVotingToCloseInternal:  gamesAccessorGetGameIdsForVotingToCloseMockCaller.getGameIdsForVotingToClose,
+							GetGameIdsForAnalysisInternal:       gamesAccessorGetGameIdsForAnalysisMockCaller.getGameIdsForAnalysis,
 							GetOldGamesInternal:                 gamesAccessorGetOldGamesMockCaller.getOldGames,
 						},
 					),
@@ -134,6 +201,50 @@ func Test_GameHandlerLoop(t *testing.T) {
 		for _, f := range functionsToCheck {
 			assertCallCount(t, f.expectedCallCount, f.functionCall, f.name, "function call count should not change once loop is canceled")
 		}
+		assert.Equal(t, []string{"BOT_ELIMINATED", "PLAYERS_JOINED", "WAITING_FOR_AI_ANSWER", "WAITING_FOR_AI_QUESTION"}, gamesAccessorClaimUnhandledGamesMockCaller.gameStates)
+	})
+
+	t.Run("releases the games it claimed when their jobs cannot be started", func(t *testing.T) {
+		gamesAccessorClaimUnhandledGamesMockCaller := ClaimUnhandledGamesMockCaller{
+			ReturnData:  [][]storage.ClaimedGame{{{Id: "game_id1", State: "WAITING_FOR_AI_QUESTION", Version: 3}}},
+			ReturnCount: 1,
+		}
+		var updatedGameIds []string
+		var updates []storage.GameUpdateOptions
+		server, _ := NewServer(
+			ServerDependencies{
+				Storage: storage.NewStorageAccessorMock(
+					storage.WithGameAccessorMock(
+						&storage.GameAccessorConfigurableMock{
+							GetUnhandledGameIdsForStateInternal: func(gameStateString string) ([]string, error) { return nil, nil },
+							ClaimUnhandledGamesInternal:         gamesAccessorClaimUnhandledGamesMockCaller.claimUnhandledGames,
+							UpdateGameStateInternal: func(gameId string, updateOpts storage.GameUpdateOptions) error {
+								updatedGameIds = append(updatedGameIds, gameId)
+								updates = append(updates, updateOpts)
+								return nil
+							},
+							GetGameIdsForAiAccusationInternal:  func() ([]string, error) { return nil, nil },
+							GetGameIdsForVotingToCloseInternal: func() ([]string, error) { return nil, nil },
+							GetGameIdsForAnalysisInternal:      func() ([]string, error) { return nil, nil },
+							GetOldGamesInternal:                func(gameExpiryDuration time.Duration) ([]string, error) { return nil, nil },
+						},
+					),
+				),
+				Logger: &utilities.NullLogger{},
+			},
+		)
+
+		var wg sync.WaitGroup
+		gameHandlerLoopCtx, cancelGameHandlerLoop := context.WithCancel(context.Background())
+		go server.GameHandlerLoop(gameHandlerLoopCtx, 10*time.Millisecond, &wg, &workers.JobStarterMockFailure{})
+		time.Sleep(25 * time.Millisecond)
+		cancelGameHandlerLoop()
+		wg.Wait()
+
+		stateHandled := false
+		version := int64(3)
+		assert.Equal(t, []string{"game_id1"}, updatedGameIds)
+		assert.Equal(t, []storage.GameUpdateOptions{{StateHandled: &stateHandled, ExpectedVersion: &version}}, updates)
 	})
 }
 
@@ -170,6 +281,26 @@ func (m *GetUnhandledGameIdsMockCaller) getUnhandledGameIdsForStateInternal(game
 	return nil, nil
 }
 
+type ClaimUnhandledGamesMockCaller struct {
+	ReturnData  [][]storage.ClaimedGame
+	ReturnCount int
+	callCount   int
+	gameStates  []string
+}
+
+func (m *ClaimUnhandledGamesMockCaller) FunctionCalledCount() int {
+	return m.callCount
+}
+
+func (m *ClaimUnhandledGamesMockCaller) claimUnhandledGames(gameStates []string, limit int, claimTimeout time.Duration) ([]storage.ClaimedGame, error) {
+	m.callCount++
+	m.gameStates = gameStates
+	if m.ReturnCount >= m.callCount {
+		return m.ReturnData[m.callCount-1], nil
+	}
+	return nil, nil
+}
+
 type GetOldGamesMockCaller struct {
 	*functionCallInspectableMock
 }
@@ -182,6 +313,42 @@ func (m *GetOldGamesMockCaller) getOldGames(gameExpiryDuration time.Duration) ([
 	return nil, nil
 }
 
+type GetGameIdsForAiAccusationMockCaller struct {
+	*functionCallInspectableMock
+}
+
+func (m *GetGameIdsForAiAccusationMockCaller) getGameIdsForAiAccusation() ([]string, error) {
+	m.callCount++
+	if m.ReturnCount >= m.callCount {
+		return m.ReturnData[m.callCount-1], nil
+	}
+	return nil, nil
+}
+
+type GetGameIdsForVotingToCloseMockCaller struct {
+	*functionCallInspectableMock
+}
+
+func (m *GetGameIdsForVotingToCloseMockCaller) getGameIdsForVotingToClose() ([]string, error) {
+	m.callCount++
+	if m.ReturnCount >= m.callCount {
+		return m.ReturnData[m.callCount-1], nil
+	}
+	return nil, nil
+}
+
+type GetGameIdsForAnalysisMockCaller struct {
+	*functionCallInspectableMock
+}
+
+func (m *GetGameIdsForAnalysisMockCaller) getGameIdsForAnalysis() ([]string, error) {
+	m.callCount++
+	if m.ReturnCount >= m.callCount {
+		return m.ReturnData[m.callCount-1], nil
+	}
+	return nil, nil
+}
+
 func assertJobStarterCalledWithArgsForJob(t *testing.T, expectedCalledArgs []map[string]any, jobStarter *workers.JobStarterMockCallCheck, jobName string) bool {
 	return assert.EqualValues(
 		t,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
@@ -40,6 +41,11 @@ func Test_CreateGame(t *testing.T) {
 		},
 	}
 
+	promptRegistry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+	promptExperiment, err := prompts.NewExperiment("", promptRegistry)
+	assert.NoError(t, err)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server, _ := NewServer(ServerDependencies{
@@ -48,7 +54,8 @@ func Test_CreateGame(t *testing.T) {
 						tt.gameCreatorMock,
 					),
 				),
-				Logger: &utilities.NullLogger{},
+				PromptExperiment: promptExperiment,
+				Logger:           &utilities.NullLogger{},
 			})
 
 			response, err := server.CreateGame(
@@ -66,6 +73,119 @@ func Test_CreateGame(t *testing.T) {
 	}
 }
 
+func Test_CreateGame_Practice(t *testing.T) {
+	practiceGame := func() *model.Game {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			bot, _ := model.NewBot(model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			})
+			bots = append(bots, bot)
+		}
+		game, _ := model.NewGame(model.GameOptions{
+			Id:        "game_id1",
+			State:     "STARTED",
+			TurnOrder: []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+			Bots:      bots,
+			Mode:      "PRACTICE",
+		})
+		return game
+	}
+
+	tests := []struct {
+		name            string
+		output          *pb.CreateGameResponse
+		transactionMock *storage.DatabaseTransactionMock
+		botAccessorMock storage.BotAccessor
+		deleteGameError error
+		errorExpected   bool
+		errorString     string
+	}{
+		{
+			name:            "creator joins the game right away",
+			output:          &pb.CreateGameResponse{GameId: "game_id1"},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			botAccessorMock: &storage.BotAccessorMockSuccess{},
+			errorExpected:   false,
+			errorString:     "",
+		},
+		{
+			name:            "errors and deletes the game if the creator cannot join it",
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			botAccessorMock: &storage.BotAccessorMockFailure{},
+			errorExpected:   true,
+			errorString:     "unable to update bot",
+		},
+		{
+			name:            "errors with why the creator cannot join the game even if it cannot be deleted",
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			botAccessorMock: &storage.BotAccessorMockFailure{},
+			deleteGameError: errors.New("unable to delete game"),
+			errorExpected:   true,
+			errorString:     "unable to update bot",
+		},
+	}
+
+	promptRegistry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+	promptExperiment, err := prompts.NewExperiment("", promptRegistry)
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			humanPlayerCountUsed := int64(0)
+			deletedGameIds := []string{}
+			server, _ := NewServer(ServerDependencies{
+				Storage: storage.NewStorageAccessorMock(
+					storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+						Transaction: tt.transactionMock,
+					}),
+					storage.WithGameAccessorMock(&storage.GameAccessorConfigurableMock{
+						CreateGameInternal: func() (string, error) {
+							return "game_id1", nil
+						},
+						GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+							return practiceGame(), nil
+						},
+						UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, humanPlayerCount int64, transaction storage.DatabaseTransaction) error {
+							humanPlayerCountUsed = humanPlayerCount
+							return nil
+						},
+						DeleteGameInternal: func(gameId string) error {
+							deletedGameIds = append(deletedGameIds, gameId)
+							return tt.deleteGameError
+						},
+					}),
+					storage.WithBotAccessorMock(tt.botAccessorMock),
+				),
+				PromptExperiment: promptExperiment,
+				Logger:           &utilities.NullLogger{},
+			})
+
+			response, err := server.CreateGame(
+				context.Background(),
+				&pb.CreateGameRequest{PlayerId: "player_id1", Mode: "PRACTICE"},
+			)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.EqualValues(t, tt.output, response)
+				assert.Equal(t, int64(1), humanPlayerCountUsed)
+				assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				assert.Empty(t, deletedGameIds)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+				assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				assert.Equal(t, []string{"game_id1"}, deletedGameIds, "the game no one can join should be deleted")
+			}
+		})
+	}
+}
+
 func Test_JoinGame(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -334,7 +454,7 @@ func Test_JoinGame(t *testing.T) {
 					)
 					return game, nil
 				},
-				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) error {
+				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, humanPlayerCount int64, transaction storage.DatabaseTransaction) error {
 					return errors.New("unable to update game")
 				},
 			},
@@ -384,7 +504,7 @@ func Test_JoinGame(t *testing.T) {
 					)
 					return game, nil
 				},
-				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) error {
+				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, humanPlayerCount int64, transaction storage.DatabaseTransaction) error {
 					return nil
 				},
 			},
@@ -546,37 +666,37 @@ func Test_GetGameForPlayer(t *testing.T) {
 				},
 				Messages: []*pb.GameMessage{
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id1",
 						Text:        "what is your name?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id1",
 						Text:        "My name is Antony Gonsalvez",
 					},
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id1",
 						Text:        "Where is the gold?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id1",
 						Text:        "what gold!",
 					},
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id2",
 						Text:        "What is your name?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id2",
 						Text:        "Bot 2 Dot 2",
@@ -625,37 +745,37 @@ func Test_GetGameForPlayer(t *testing.T) {
 				},
 				Messages: []*pb.GameMessage{
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id1",
 						Text:        "what is your name?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id1",
 						Text:        "My name is Antony Gonsalvez",
 					},
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id1",
 						Text:        "Where is the gold?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id1",
 						Text:        "what gold!",
 					},
 					{
-						Type:        "question",
+						Type:        pb.MessageType_QUESTION,
 						SourceBotId: "bot_id1",
 						TargetBotId: "bot_id2",
 						Text:        "What is your name?",
 					},
 					{
-						Type:        "answer",
+						Type:        pb.MessageType_ANSWER,
 						SourceBotId: "bot_id2",
 						TargetBotId: "bot_id2",
 						Text:        "Bot 2 Dot 2",
@@ -1063,7 +1183,7 @@ func Test_AutoJoinGame(t *testing.T) {
 					)
 					return game, nil
 				},
-				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) error {
+				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, humanPlayerCount int64, transaction storage.DatabaseTransaction) error {
 					return errors.New("unable to update game")
 				},
 				GetAutoJoinableGamesInternal: func() ([]string, error) {
@@ -1115,7 +1235,7 @@ func Test_AutoJoinGame(t *testing.T) {
 					)
 					return game, nil
 				},
-				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) error {
+				UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal: func(gameId string, humanPlayerCount int64, transaction storage.DatabaseTransaction) error {
 					return nil
 				},
 				GetAutoJoinableGamesInternal: func() ([]string, error) {
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	aibot "github.com/vipulvpatil/airetreat-go/internal/services/ai-bot"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
 )
 
 func (s *AiRetreatGoService) Help(ctx context.Context, req *pb.HelpRequest) (*pb.HelpResponse, error) {
-	game, err := s.storage.GetGame(req.GetGameId())
+	game, err := s.storage.GetGame(ctx, req.GetGameId())
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -28,49 +30,86 @@ func (s *AiRetreatGoService) Help(ctx context.Context, req *pb.HelpRequest) (*pb
 		return nil, err
 	}
 
-	currentTurnBot := game.GetBotThatGameIsWaitingOn()
+	if !game.AllowsHelpFor(sourceBot) {
+		err := errors.New("please wait for your turn")
+		s.logger.LogError(err)
+		return nil, err
+	}
 
-	if sourceBot != currentTurnBot {
+	// Suggestions are generated before the help count is touched, so that a failed attempt does not use up any help.
+	helpSuggester := aibot.NewAiHelpSuggester(
+		aibot.AiBotOptions{
+			BotId:        sourceBot.Id(),
+			Game:         game,
+			OpenAiClient: s.openAiClient,
+			Prompts:      s.prompts,
+		},
+	)
+	if helpSuggester == nil {
 		err := errors.New("please wait for your turn")
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	responseText := "unable to help"
+	helpSuggestions := helpSuggester.GetHelpSuggestions(ctx)
+	if len(helpSuggestions.Suggestions) == 0 {
+		err := errors.New("unable to help right now")
+		s.logger.LogError(err)
+		return nil, err
+	}
 
-	tx, err := s.storage.BeginTransaction()
+	tx, err := s.storage.BeginTransaction(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	err = s.storage.UpdateBotDecrementHelpCountUsingTransaction(sourceBot.Id(), tx)
+	err = s.storage.UpdateBotDecrementHelpCountUsingTransaction(ctx, sourceBot.Id(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	if game.IsInStateWaitingForHumanQuestion() {
-		aiBot := aibot.NewAiQuestionGenerator(
-			aibot.AiBotOptions{
-				BotId:        sourceBot.Id(),
-				Game:         game,
-				OpenAiClient: s.openAiClient,
-			},
-		)
-		responseText = aiBot.GetNextQuestion()
-	} else if game.IsInStateWaitingForHumanAnswer() {
-		aiBot := aibot.NewAiAnswerGenerator(
-			aibot.AiBotOptions{
-				BotId:        sourceBot.Id(),
-				Game:         game,
-				OpenAiClient: s.openAiClient,
-			},
-		)
-		responseText = aiBot.GetNextAnswer()
+	if helpSuggestions.ConversationSummary != nil {
+		err = s.storage.UpdateGameStateUsingTransaction(ctx, req.GetGameId(), storage.GameUpdateOptions{ConversationSummary: helpSuggestions.ConversationSummary}, tx)
+		if err != nil {
+			s.logger.LogError(err)
+			return nil, err
+		}
+	}
+
+	// Remembering the suggestions lets us tell, once the next message arrives, whether and which one came from Help.
+	err = s.storage.UpdateBotLastHelpSuggestionsUsingTransaction(ctx, sourceBot.Id(), model.HelpSuggestionTexts(helpSuggestions.Suggestions), tx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
 	}
 
 	err = tx.Commit()
-	return &pb.HelpResponse{Text: responseText}, err
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	suggestions := []*pb.HelpSuggestion{}
+	for _, suggestion := range helpSuggestions.Suggestions {
+		suggestions = append(suggestions, &pb.HelpSuggestion{Style: suggestion.Style, Text: suggestion.Text})
+	}
+
+	response := &pb.HelpResponse{Text: helpSuggestions.Suggestions[0].Text, Suggestions: suggestions}
+
+	// A question still needs someone to ask. Going without a recommendation is better than failing the help already paid for.
+	if game.IsInStateWaitingForHumanQuestion() {
+		recommender := aibot.NewTargetRecommender(aibot.AiBotOptions{BotId: sourceBot.Id(), Game: game})
+		recommendation, err := recommender.GetTargetRecommendation()
+		if err != nil {
+			s.logger.LogError(err)
+		} else {
+			response.TargetBotId = recommendation.BotId
+			response.Rationale = recommendation.Rationale
+		}
+	}
+
+	return response, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
@@ -190,7 +191,7 @@ func Test_Help(t *testing.T) {
 			errorString:     "please wait for your turn",
 		},
 		{
-			name: "errors if unable to get transaction",
+			name: "errors without using up help if unable to generate any suggestion",
 			input: &pb.HelpRequest{
 				GameId:   "game_id1",
 				PlayerId: "player_id1",
@@ -221,7 +222,56 @@ func Test_Help(t *testing.T) {
 					game, _ := model.NewGame(
 						model.GameOptions{
 							Id:               "game_id1",
-							State:            "WAITING_FOR_AI_QUESTION",
+							State:            "WAITING_FOR_HUMAN_QUESTION",
+							CurrentTurnIndex: 1,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     false,
+							StateTotalTime:   0,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now(),
+							Bots:             bots,
+						},
+					)
+					return game, nil
+				},
+			},
+			botAccessorMock: &storage.BotAccessorMockFailure{},
+			errorExpected:   true,
+			errorString:     "unable to help right now",
+		},
+		{
+			name: "errors if unable to get transaction",
+			input: &pb.HelpRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+			},
+			output:          nil,
+			transactionMock: nil,
+			openAiResponse:  "sample response",
+			txShouldCommit:  false,
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+							HelpCount: 3,
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[1].ConnectPlayer(player1)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "WAITING_FOR_HUMAN_QUESTION",
 							CurrentTurnIndex: 1,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 							StateHandled:     false,
@@ -246,7 +296,7 @@ func Test_Help(t *testing.T) {
 			},
 			output:          nil,
 			transactionMock: &storage.DatabaseTransactionMock{},
-			openAiResponse:  "",
+			openAiResponse:  "sample response",
 			txShouldCommit:  false,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(gameId string) (*model.Game, error) {
@@ -270,7 +320,7 @@ func Test_Help(t *testing.T) {
 					game, _ := model.NewGame(
 						model.GameOptions{
 							Id:               "game_id1",
-							State:            "WAITING_FOR_AI_QUESTION",
+							State:            "WAITING_FOR_HUMAN_QUESTION",
 							CurrentTurnIndex: 1,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
 							StateHandled:     false,
@@ -293,7 +343,16 @@ func Test_Help(t *testing.T) {
 				GameId:   "game_id1",
 				PlayerId: "player_id1",
 			},
-			output:          &pb.HelpResponse{Text: "sample response"},
+			output: &pb.HelpResponse{
+				Text: "sample response",
+				Suggestions: []*pb.HelpSuggestion{
+					{Style: "casual", Text: "sample response"},
+					{Style: "terse", Text: "sample response"},
+					{Style: "evasive", Text: "sample response"},
+				},
+				TargetBotId: "bot_id4",
+				Rationale:   "nobody has asked this bot anything yet",
+			},
 			transactionMock: &storage.DatabaseTransactionMock{},
 			openAiResponse:  "sample response",
 			txShouldCommit:  true,
@@ -332,9 +391,19 @@ func Test_Help(t *testing.T) {
 					return game, nil
 				},
 			},
-			botAccessorMock: &storage.BotAccessorMockSuccess{},
-			errorExpected:   false,
-			errorString:     "",
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotDecrementHelpCountUsingTransactionInternal: func(botId string, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+				UpdateBotLastHelpSuggestionsUsingTransactionInternal: func(botId string, suggestions []string, transaction storage.DatabaseTransaction) error {
+					if botId != "bot_id2" || len(suggestions) != 3 || suggestions[1] != "sample response" {
+						return errors.New("unexpected help suggestions")
+					}
+					return nil
+				},
+			},
+			errorExpected: false,
+			errorString:   "",
 		},
 		{
 			name: "success when waiting for human answer",
@@ -342,7 +411,14 @@ func Test_Help(t *testing.T) {
 				GameId:   "game_id1",
 				PlayerId: "player_id1",
 			},
-			output:          &pb.HelpResponse{Text: "sample response"},
+			output: &pb.HelpResponse{
+				Text: "sample response",
+				Suggestions: []*pb.HelpSuggestion{
+					{Style: "casual", Text: "sample response"},
+					{Style: "terse", Text: "sample response"},
+					{Style: "evasive", Text: "sample response"},
+				},
+			},
 			transactionMock: &storage.DatabaseTransactionMock{},
 			openAiResponse:  "sample response",
 			txShouldCommit:  true,
@@ -382,12 +458,25 @@ func Test_Help(t *testing.T) {
 					return game, nil
 				},
 			},
-			botAccessorMock: &storage.BotAccessorMockSuccess{},
-			errorExpected:   false,
-			errorString:     "",
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotDecrementHelpCountUsingTransactionInternal: func(botId string, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+				UpdateBotLastHelpSuggestionsUsingTransactionInternal: func(botId string, suggestions []string, transaction storage.DatabaseTransaction) error {
+					if botId != "bot_id2" || len(suggestions) != 3 || suggestions[1] != "sample response" {
+						return errors.New("unexpected help suggestions")
+					}
+					return nil
+				},
+			},
+			errorExpected: false,
+			errorString:   "",
 		},
 	}
 
+	promptRegistry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server, _ := NewServer(ServerDependencies{
@@ -399,6 +488,7 @@ func Test_Help(t *testing.T) {
 					storage.WithBotAccessorMock(tt.botAccessorMock),
 				),
 				OpenAiClient: &openai.MockClientSuccess{Text: tt.openAiResponse},
+				Prompts:      promptRegistry,
 				Logger:       &utilities.NullLogger{},
 			})
 
@@ -41,7 +41,7 @@ func (s *AiRetreatGoService) PlayerIdValidatingInterceptor(ctx context.Context,
 		user, err := getUserFromContext(ctx)
 		if err != nil {
 			if utilities.ErrorIsUnauthenticated(err) && s.config.AllowUnauthed {
-				player, err := s.storage.GetPlayer(playerId)
+				player, err := s.storage.GetPlayer(ctx, playerId)
 				if err != nil {
 					return nil, &utilities.ResetPlayerError{}
 				}
@@ -53,7 +53,7 @@ func (s *AiRetreatGoService) PlayerIdValidatingInterceptor(ctx context.Context,
 				return nil, err
 			}
 		} else {
-			if !s.userPlayerIsNilOrSameAsPlayerId(user, playerId) {
+			if !s.userPlayerIsNilOrSameAsPlayerId(ctx, user, playerId) {
 				return nil, &utilities.ResetPlayerError{}
 			}
 		}
@@ -65,8 +65,8 @@ type RequestWithPlayerId interface {
 	GetPlayerId() string
 }
 
-func (s *AiRetreatGoService) userPlayerIsNilOrSameAsPlayerId(user *model.User, playerId string) bool {
-	player, err := s.storage.GetPlayerForUserOrNil(user.GetId())
+func (s *AiRetreatGoService) userPlayerIsNilOrSameAsPlayerId(ctx context.Context, user *model.User, playerId string) bool {
+	player, err := s.storage.GetPlayerForUserOrNil(ctx, user.GetId())
 	if err != nil {
 		return false
 	}
@@ -4,21 +4,27 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
 )
 
 func (s *AiRetreatGoService) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
-	tx, err := s.storage.BeginTransaction()
+	tx, err := s.storage.BeginTransaction(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	messageType := messageTypeFromProto(req.GetType())
 	messageText := req.GetText()
+	if messageType == "pass" {
+		messageText = model.PASS_MESSAGE_TEXT
+	}
 
 	err = validateMessageText(messageText)
 	if err != nil {
@@ -26,7 +32,7 @@ func (s *AiRetreatGoService) SendMessage(ctx context.Context, req *pb.SendMessag
 		return nil, err
 	}
 
-	game, err := s.storage.GetGameUsingTransaction(req.GetGameId(), tx)
+	game, err := s.storage.GetGameUsingTransaction(ctx, req.GetGameId(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -39,7 +45,7 @@ func (s *AiRetreatGoService) SendMessage(ctx context.Context, req *pb.SendMessag
 		return nil, err
 	}
 
-	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), req.GetBotId(), messageText)
+	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), req.GetBotId(), messageText, messageType)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -52,24 +58,52 @@ func (s *AiRetreatGoService) SendMessage(ctx context.Context, req *pb.SendMessag
 		StateHandled:            gameUpdate.StateHandled,
 		LastQuestion:            gameUpdate.LastQuestion,
 		LastQuestionTargetBotId: gameUpdate.LastQuestionTargetBotId,
+		StateHandledAt:          gameUpdate.StateHandledAt,
+		StateTotalTime:          gameUpdate.StateTotalTime,
 	}
 
-	err = s.storage.UpdateGameStateUsingTransaction(req.GetGameId(), updateOptions, tx)
+	err = s.storage.UpdateGameStateUsingTransaction(ctx, req.GetGameId(), updateOptions, tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	err = s.storage.CreateMessageUsingTransaction(sourceBot.Id(), req.GetBotId(), req.GetText(), req.GetType(), tx)
+	metadata := storage.MessageMetadata{
+		ResponseTime: game.ResponseTimeForNextMessage(time.Now()),
+		HelpUsage:    sourceBot.HelpUsageForText(messageText).String(),
+		HelpStyle:    sourceBot.HelpStyleForText(messageText),
+	}
+	err = s.storage.CreateMessageUsingTransaction(ctx, sourceBot.Id(), req.GetBotId(), messageText, messageType, metadata, tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
+	// Reactions do not take up a turn, so any help suggestions are still meant for the next message.
+	if messageType != "reaction" && len(sourceBot.LastHelpSuggestions()) > 0 {
+		err = s.storage.UpdateBotLastHelpSuggestionsUsingTransaction(ctx, sourceBot.Id(), nil, tx)
+		if err != nil {
+			s.logger.LogError(err)
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	return &pb.SendMessageResponse{}, err
 }
 
+// Message types are stored in lowercase, but otherwise have the same names as in the proto.
+func messageTypeFromProto(messageType pb.MessageType) string {
+	if messageType == pb.MessageType_MESSAGE_TYPE_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(messageType.String())
+}
+
+func messageTypeToProto(messageType string) pb.MessageType {
+	return pb.MessageType(pb.MessageType_value[strings.ToUpper(messageType)])
+}
+
 // TODO: Find a more appropriate place for this function
 func validateMessageText(text string) error {
 	if utilities.IsBlank(text) {
@@ -33,6 +33,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id2",
 				Text:     "question message",
+				Type:     pb.MessageType_QUESTION,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -96,6 +97,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -152,6 +154,120 @@ func Test_SendMessage(t *testing.T) {
 			errorExpected:  false,
 			errorString:    "",
 		},
+		{
+			name: "test runs successfully when a human passes instead of answering",
+			input: &pb.SendMessageRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id1",
+				Type:     pb.MessageType_PASS,
+			},
+			output:             &pb.SendMessageResponse{},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[0].ConnectPlayer(player1)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:                      "game_id1",
+							State:                   "WAITING_FOR_HUMAN_ANSWER",
+							CurrentTurnIndex:        2,
+							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:            false,
+							StateTotalTime:          0,
+							LastQuestion:            "what is the answer?",
+							LastQuestionTargetBotId: "bot_id1",
+							CreatedAt:               time.Now(),
+							UpdatedAt:               time.Now(),
+							Bots:                    bots,
+						},
+					)
+					return game, nil
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "WAITING_FOR_AI_QUESTION"
+					expectedStateHandled := false
+					expectedCurrentTurnIndex := int64(3)
+
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:            &expectedState,
+						StateHandled:     &expectedStateHandled,
+						CurrentTurnIndex: &expectedCurrentTurnIndex,
+					}, updateOpts, "game state should be updated with correct update options")
+					return nil
+				},
+			},
+			txShouldCommit: true,
+			errorExpected:  false,
+			errorString:    "",
+		},
+		{
+			name: "errors if message type is missing",
+			input: &pb.SendMessageRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id1",
+				Text:     "answer message",
+			},
+			output:             nil,
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[0].ConnectPlayer(player1)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:                      "game_id1",
+							State:                   "WAITING_FOR_HUMAN_ANSWER",
+							CurrentTurnIndex:        2,
+							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:            false,
+							StateTotalTime:          0,
+							LastQuestion:            "what is the answer?",
+							LastQuestionTargetBotId: "bot_id1",
+							CreatedAt:               time.Now(),
+							UpdatedAt:               time.Now(),
+							Bots:                    bots,
+						},
+					)
+					return game, nil
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "invalid message type",
+		},
 		{
 			name:               "errors if unable to get transaction",
 			input:              &pb.SendMessageRequest{},
@@ -170,6 +286,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message is so long that it is considered too long for our usage purposes and is completely ignored and returns an error",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -186,6 +303,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -206,6 +324,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id2",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -257,6 +376,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -308,6 +428,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -362,6 +483,7 @@ func Test_SendMessage(t *testing.T) {
 				PlayerId: "player_id1",
 				BotId:    "bot_id1",
 				Text:     "answer message",
+				Type:     pb.MessageType_ANSWER,
 			},
 			output:             &pb.SendMessageResponse{},
 			transactionMock:    &storage.DatabaseTransactionMock{},
@@ -19,13 +19,13 @@ func (s *AiRetreatGoService) SyncPlayerData(ctx context.Context, req *pb.SyncPla
 	playerId := req.GetPlayerId()
 	var player *model.Player
 	if user != nil {
-		player, err = s.getNewOrExistingPlayerForUser(user.GetId(), playerId)
+		player, err = s.getNewOrExistingPlayerForUser(ctx, user.GetId(), playerId)
 		if err != nil {
 			s.logger.LogError(err)
 			return nil, err
 		}
 	} else if !utilities.IsBlank(playerId) {
-		player, err = s.storage.GetPlayer(playerId)
+		player, err = s.storage.GetPlayer(ctx, playerId)
 		if err != nil {
 			s.logger.LogError(err)
 			return nil, err
@@ -35,7 +35,7 @@ func (s *AiRetreatGoService) SyncPlayerData(ctx context.Context, req *pb.SyncPla
 			return nil, &utilities.ResetPlayerError{}
 		}
 	} else {
-		player, err = s.storage.CreatePlayer()
+		player, err = s.storage.CreatePlayer(ctx)
 		if err != nil {
 			s.logger.LogError(err)
 			return nil, err
@@ -62,14 +62,14 @@ func (s *AiRetreatGoService) getUserFromContextIfPresent(ctx context.Context) (*
 	return user, nil
 }
 
-func (s *AiRetreatGoService) getNewOrExistingPlayerForUser(userId string, playerId string) (*model.Player, error) {
+func (s *AiRetreatGoService) getNewOrExistingPlayerForUser(ctx context.Context, userId string, playerId string) (*model.Player, error) {
 	if utilities.IsBlank(userId) {
 		err := errors.New("userId cannot be blank")
 		s.logger.LogError(err)
 		return nil, err
 	}
 
-	player, err := s.storage.GetPlayerForUserOrNil(userId)
+	player, err := s.storage.GetPlayerForUserOrNil(ctx, userId)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -80,13 +80,13 @@ func (s *AiRetreatGoService) getNewOrExistingPlayerForUser(userId string, player
 	}
 
 	if !utilities.IsBlank(playerId) {
-		tx, err := s.storage.BeginTransaction()
+		tx, err := s.storage.BeginTransaction(ctx)
 		if err != nil {
 			s.logger.LogError(err)
 			return nil, err
 		}
 		defer tx.Rollback()
-		player, err = s.storage.GetPlayerUsingTransaction(playerId, tx)
+		player, err = s.storage.GetPlayerUsingTransaction(ctx, playerId, tx)
 		// TODO: Rethink this. This can be used to find playerIds that are connected to some user in our system. Not sure if that is a security risk. Sending unknown error for now.
 		if err != nil {
 			s.logger.LogError(err)
@@ -99,7 +99,7 @@ func (s *AiRetreatGoService) getNewOrExistingPlayerForUser(userId string, player
 			return nil, utilities.NewBadError("unknown error")
 		}
 
-		player, err = s.storage.UpdatePlayerWithUserIdUsingTransaction(player.Id(), userId, tx)
+		player, err = s.storage.UpdatePlayerWithUserIdUsingTransaction(ctx, player.Id(), userId, tx)
 		if err != nil {
 			s.logger.LogError(err)
 			return nil, err
@@ -113,6 +113,6 @@ func (s *AiRetreatGoService) getNewOrExistingPlayerForUser(userId string, player
 
 		return player, nil
 	} else {
-		return s.storage.CreatePlayerForUser(userId)
+		return s.storage.CreatePlayerForUser(ctx, userId)
 	}
 }
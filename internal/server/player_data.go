@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportPlayerData returns everything stored about the requesting user and its players. Without a user, it returns
+// everything stored about the player, as long as the player is not connected to a user.
+func (s *AiRetreatGoService) ExportPlayerData(ctx context.Context, req *pb.ExportPlayerDataRequest) (*pb.ExportPlayerDataResponse, error) {
+	user, err := s.getUserFromContextIfPresent(ctx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	var export *model.DataExport
+	if user != nil {
+		export, err = s.storage.ExportUserData(ctx, user.GetId())
+	} else {
+		err = s.validatePlayerWithoutUser(ctx, req.GetPlayerId())
+		if err != nil {
+			return nil, err
+		}
+		export, err = s.storage.ExportPlayerData(ctx, req.GetPlayerId())
+	}
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	return exportToProto(export), nil
+}
+
+// ErasePlayerData erases every player of the requesting user, or the player when there is no user, in the same way as
+// ExportPlayerData decides whose data to export. The games the players were in are kept, with their messages erased.
+// Nothing is erased while one of the players is in a game that has not finished.
+func (s *AiRetreatGoService) ErasePlayerData(ctx context.Context, req *pb.ErasePlayerDataRequest) (*pb.ErasePlayerDataResponse, error) {
+	user, err := s.getUserFromContextIfPresent(ctx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	var erasures []*model.PlayerErasure
+	if user != nil {
+		erasures, err = s.storage.EraseUserPlayers(ctx, user.GetId())
+	} else {
+		err = s.validatePlayerWithoutUser(ctx, req.GetPlayerId())
+		if err != nil {
+			return nil, err
+		}
+		var erasure *model.PlayerErasure
+		erasure, err = s.storage.ErasePlayer(ctx, req.GetPlayerId())
+		erasures = []*model.PlayerErasure{erasure}
+	}
+	if errors.Is(err, storage.ErrPlayerInUnfinishedGame) {
+		return nil, status.Error(codes.FailedPrecondition, "cannot erase player data while a game the player is in has not finished")
+	}
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	response := pb.ErasePlayerDataResponse{Erasures: []*pb.PlayerErasure{}}
+	for _, erasure := range erasures {
+		response.Erasures = append(response.Erasures, playerErasureToProto(*erasure))
+	}
+	return &response, nil
+}
+
+// A player that is connected to a user belongs to that user, so it is only available to requests made by the user.
+func (s *AiRetreatGoService) validatePlayerWithoutUser(ctx context.Context, playerId string) error {
+	if utilities.IsBlank(playerId) {
+		err := errors.New("playerId cannot be blank")
+		s.logger.LogError(err)
+		return err
+	}
+
+	player, err := s.storage.GetPlayer(ctx, playerId)
+	if err != nil {
+		s.logger.LogError(err)
+		return err
+	}
+
+	if player.UserId() != nil {
+		return &utilities.ResetPlayerError{}
+	}
+	return nil
+}
+
+func exportToProto(export *model.DataExport) *pb.ExportPlayerDataResponse {
+	response := pb.ExportPlayerDataResponse{
+		Players:  []*pb.ExportedPlayer{},
+		Erasures: []*pb.PlayerErasure{},
+	}
+
+	if export.User != nil {
+		response.User = &pb.ExportedUser{
+			Id:    export.User.Id,
+			Name:  stringOrBlank(export.User.Name),
+			Email: stringOrBlank(export.User.Email),
+			Image: stringOrBlank(export.User.Image),
+		}
+	}
+
+	for _, player := range export.Players {
+		exportedPlayer := pb.ExportedPlayer{
+			Id:              player.PlayerId,
+			CreatedAt:       timestamppb.New(player.CreatedAt),
+			Bots:            []*pb.ExportedBot{},
+			Messages:        []*pb.ExportedMessage{},
+			Votes:           []*pb.ExportedVote{},
+			PracticeResults: []*pb.ExportedPracticeResult{},
+		}
+		for _, bot := range player.Bots {
+			exportedBot := pb.ExportedBot{
+				Id:                  bot.Id,
+				GameId:              bot.GameId,
+				Name:                bot.Name,
+				HelpCount:           bot.HelpCount,
+				LastHelpSuggestions: bot.LastHelpSuggestions,
+				TagCount:            bot.TagCount,
+				Eliminated:          bot.Eliminated,
+			}
+			if bot.Analysis != nil {
+				exportedBot.Analysis = &pb.BotAnalysis{
+					BotId:          bot.Id,
+					BotName:        bot.Name,
+					IsHuman:        true,
+					HumannessScore: bot.Analysis.HumannessScore,
+					Tells:          bot.Analysis.Tells,
+				}
+			}
+			exportedPlayer.Bots = append(exportedPlayer.Bots, &exportedBot)
+		}
+		for _, message := range player.Messages {
+			exportedPlayer.Messages = append(exportedPlayer.Messages, &pb.ExportedMessage{
+				Id:          message.Id,
+				GameId:      message.GameId,
+				SourceBotId: message.SourceBotId,
+				TargetBotId: message.TargetBotId,
+				Type:        messageTypeToProto(message.MessageType),
+				Text:        message.Text,
+				CreatedAt:   timestamppb.New(message.CreatedAt),
+			})
+		}
+		for _, vote := range player.Votes {
+			exportedPlayer.Votes = append(exportedPlayer.Votes, &pb.ExportedVote{
+				GameId:       vote.GameId,
+				VoterBotId:   vote.VoterBotId,
+				SuspectBotId: vote.SuspectBotId,
+			})
+		}
+		for _, practiceResult := range player.PracticeResults {
+			exportedPlayer.PracticeResults = append(exportedPlayer.PracticeResults, &pb.ExportedPracticeResult{
+				GameId: practiceResult.GameId,
+				Won:    practiceResult.Won,
+			})
+		}
+		response.Players = append(response.Players, &exportedPlayer)
+	}
+
+	for _, erasure := range export.Erasures {
+		response.Erasures = append(response.Erasures, playerErasureToProto(erasure))
+	}
+	return &response
+}
+
+func playerErasureToProto(erasure model.PlayerErasure) *pb.PlayerErasure {
+	return &pb.PlayerErasure{
+		Id:           erasure.Id,
+		PlayerId:     erasure.PlayerId,
+		BotCount:     erasure.BotCount,
+		MessageCount: erasure.MessageCount,
+		ErasedAt:     timestamppb.New(erasure.CreatedAt),
+	}
+}
+
+func stringOrBlank(str *string) string {
+	if str == nil {
+		return ""
+	}
+	return *str
+}
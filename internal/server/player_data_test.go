@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/config"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPlayerDataTestServer(playerAccessor storage.PlayerAccessor, playerDataAccessor storage.PlayerDataAccessor, allowUnauthed bool) *AiRetreatGoService {
+	server, _ := NewServer(ServerDependencies{
+		Storage: storage.NewStorageAccessorMock(
+			storage.WithPlayerAccessorMock(playerAccessor),
+			storage.WithPlayerDataAccessorMock(playerDataAccessor),
+		),
+		Config: &config.Config{
+			AllowUnauthed: allowUnauthed,
+		},
+		Logger: &utilities.NullLogger{},
+	})
+	return server
+}
+
+func contextWithRequestingUser(userId, userEmail string) context.Context {
+	md := metadata.New(map[string]string{})
+	md.Append(requestingUserIdCtxKey, userId)
+	md.Append(requestingUserEmailCtxKey, userEmail)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func Test_ExportPlayerData(t *testing.T) {
+	userId := "user_id1"
+	userName := "User One"
+	userEmail := "user1@example.com"
+	createdAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	playerData := model.PlayerData{
+		PlayerId:  "player_id1",
+		CreatedAt: createdAt,
+		Bots: []model.ExportedBot{
+			{
+				Id:        "bot_id1",
+				GameId:    "game_id1",
+				Name:      "bot1",
+				HelpCount: 1,
+				Analysis:  &model.BotAnalysis{BotId: "bot_id1", HumannessScore: 80, Tells: []string{"by the sea"}},
+			},
+		},
+		Messages: []model.ExportedMessage{
+			{Id: "message_id1", GameId: "game_id1", SourceBotId: "bot_id1", TargetBotId: "bot_id1", MessageType: "answer", Text: "by the sea", CreatedAt: createdAt},
+		},
+		Votes:           []model.ExportedVote{{GameId: "game_id1", VoterBotId: "bot_id1", SuspectBotId: "bot_id2"}},
+		PracticeResults: []model.ExportedPracticeResult{{GameId: "game_id2", Won: true}},
+	}
+	exportedPlayer := &pb.ExportedPlayer{
+		Id:        "player_id1",
+		CreatedAt: timestamppb.New(createdAt),
+		Bots: []*pb.ExportedBot{
+			{
+				Id:        "bot_id1",
+				GameId:    "game_id1",
+				Name:      "bot1",
+				HelpCount: 1,
+				Analysis:  &pb.BotAnalysis{BotId: "bot_id1", BotName: "bot1", IsHuman: true, HumannessScore: 80, Tells: []string{"by the sea"}},
+			},
+		},
+		Messages: []*pb.ExportedMessage{
+			{Id: "message_id1", GameId: "game_id1", SourceBotId: "bot_id1", TargetBotId: "bot_id1", Type: pb.MessageType_ANSWER, Text: "by the sea", CreatedAt: timestamppb.New(createdAt)},
+		},
+		Votes:           []*pb.ExportedVote{{GameId: "game_id1", VoterBotId: "bot_id1", SuspectBotId: "bot_id2"}},
+		PracticeResults: []*pb.ExportedPracticeResult{{GameId: "game_id2", Won: true}},
+	}
+
+	t.Run("exports the data of the requesting user", func(t *testing.T) {
+		server := newPlayerDataTestServer(nil, &storage.PlayerDataAccessorMockConfigurable{
+			ExportUserDataInternal: func(requestedUserId string) (*model.DataExport, error) {
+				assert.Equal(t, userId, requestedUserId)
+				return &model.DataExport{
+					User:    &model.ExportedUser{Id: userId, Name: &userName, Email: &userEmail},
+					Players: []model.PlayerData{playerData},
+					Erasures: []model.PlayerErasure{
+						{Id: "erasure_id1", PlayerId: "player_id0", UserId: &userId, BotCount: 2, MessageCount: 5, CreatedAt: createdAt},
+					},
+				}, nil
+			},
+		}, false)
+
+		response, err := server.ExportPlayerData(contextWithRequestingUser(userId, userEmail), &pb.ExportPlayerDataRequest{PlayerId: "player_id1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &pb.ExportPlayerDataResponse{
+			User:    &pb.ExportedUser{Id: userId, Name: userName, Email: userEmail},
+			Players: []*pb.ExportedPlayer{exportedPlayer},
+			Erasures: []*pb.PlayerErasure{
+				{Id: "erasure_id1", PlayerId: "player_id0", BotCount: 2, MessageCount: 5, ErasedAt: timestamppb.New(createdAt)},
+			},
+		}, response)
+	})
+
+	t.Run("exports the data of a player without a user", func(t *testing.T) {
+		server := newPlayerDataTestServer(
+			&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1"},
+			&storage.PlayerDataAccessorMockConfigurable{
+				ExportPlayerDataInternal: func(playerId string) (*model.DataExport, error) {
+					assert.Equal(t, "player_id1", playerId)
+					return &model.DataExport{Players: []model.PlayerData{playerData}, Erasures: []model.PlayerErasure{}}, nil
+				},
+			},
+			true,
+		)
+
+		response, err := server.ExportPlayerData(context.Background(), &pb.ExportPlayerDataRequest{PlayerId: "player_id1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &pb.ExportPlayerDataResponse{
+			Players:  []*pb.ExportedPlayer{exportedPlayer},
+			Erasures: []*pb.PlayerErasure{},
+		}, response)
+	})
+
+	t.Run("errors without a user if the player is connected to one", func(t *testing.T) {
+		server := newPlayerDataTestServer(&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1", UserId: &userId}, nil, true)
+
+		_, err := server.ExportPlayerData(context.Background(), &pb.ExportPlayerDataRequest{PlayerId: "player_id1"})
+		assert.EqualError(t, err, "reset player data")
+	})
+
+	t.Run("errors without a user if playerId is blank", func(t *testing.T) {
+		server := newPlayerDataTestServer(nil, nil, true)
+
+		_, err := server.ExportPlayerData(context.Background(), &pb.ExportPlayerDataRequest{})
+		assert.EqualError(t, err, "playerId cannot be blank")
+	})
+
+	t.Run("errors if unable to get user from context and AllowUnauthed config is false", func(t *testing.T) {
+		server := newPlayerDataTestServer(nil, nil, false)
+
+		_, err := server.ExportPlayerData(context.Background(), &pb.ExportPlayerDataRequest{PlayerId: "player_id1"})
+		assert.EqualError(t, err, "rpc error: code = Unauthenticated desc = retrieving user data failed")
+	})
+
+	t.Run("errors if the export fails", func(t *testing.T) {
+		server := newPlayerDataTestServer(nil, &storage.PlayerDataAccessorMockConfigurable{
+			ExportUserDataInternal: func(userId string) (*model.DataExport, error) {
+				return nil, errors.New("unable to export user data")
+			},
+		}, false)
+
+		_, err := server.ExportPlayerData(contextWithRequestingUser(userId, userEmail), &pb.ExportPlayerDataRequest{})
+		assert.EqualError(t, err, "unable to export user data")
+	})
+}
+
+func Test_ErasePlayerData(t *testing.T) {
+	userId := "user_id1"
+	userEmail := "user1@example.com"
+	erasedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("erases every player of the requesting user", func(t *testing.T) {
+		server := newPlayerDataTestServer(nil, &storage.PlayerDataAccessorMockConfigurable{
+			EraseUserPlayersInternal: func(requestedUserId string) ([]*model.PlayerErasure, error) {
+				assert.Equal(t, userId, requestedUserId)
+				return []*model.PlayerErasure{
+					{Id: "erasure_id1", PlayerId: "player_id1", UserId: &userId, BotCount: 2, MessageCount: 5, CreatedAt: erasedAt},
+				}, nil
+			},
+		}, false)
+
+		response, err := server.ErasePlayerData(contextWithRequestingUser(userId, userEmail), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &pb.ErasePlayerDataResponse{
+			Erasures: []*pb.PlayerErasure{
+				{Id: "erasure_id1", PlayerId: "player_id1", BotCount: 2, MessageCount: 5, ErasedAt: timestamppb.New(erasedAt)},
+			},
+		}, response)
+	})
+
+	t.Run("erases a player without a user", func(t *testing.T) {
+		server := newPlayerDataTestServer(
+			&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1"},
+			&storage.PlayerDataAccessorMockConfigurable{
+				ErasePlayerInternal: func(playerId string) (*model.PlayerErasure, error) {
+					assert.Equal(t, "player_id1", playerId)
+					return &model.PlayerErasure{Id: "erasure_id1", PlayerId: playerId, BotCount: 1, CreatedAt: erasedAt}, nil
+				},
+			},
+			true,
+		)
+
+		response, err := server.ErasePlayerData(context.Background(), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, &pb.ErasePlayerDataResponse{
+			Erasures: []*pb.PlayerErasure{
+				{Id: "erasure_id1", PlayerId: "player_id1", BotCount: 1, ErasedAt: timestamppb.New(erasedAt)},
+			},
+		}, response)
+	})
+
+	t.Run("errors without a user if the player is connected to one", func(t *testing.T) {
+		server := newPlayerDataTestServer(&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1", UserId: &userId}, nil, true)
+
+		_, err := server.ErasePlayerData(context.Background(), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.EqualError(t, err, "reset player data")
+	})
+
+	t.Run("errors without a user if the player cannot be found", func(t *testing.T) {
+		server := newPlayerDataTestServer(&storage.PlayerAccessorMockFailure{}, nil, true)
+
+		_, err := server.ErasePlayerData(context.Background(), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.EqualError(t, err, "unable to get player")
+	})
+
+	t.Run("errors if the erasure fails", func(t *testing.T) {
+		server := newPlayerDataTestServer(
+			&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1"},
+			&storage.PlayerDataAccessorMockConfigurable{
+				ErasePlayerInternal: func(playerId string) (*model.PlayerErasure, error) {
+					return nil, errors.New("unable to erase player")
+				},
+			},
+			true,
+		)
+
+		_, err := server.ErasePlayerData(context.Background(), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.EqualError(t, err, "unable to erase player")
+	})
+
+	t.Run("errors with a failed precondition if the player is in a game that has not finished", func(t *testing.T) {
+		server := newPlayerDataTestServer(
+			&storage.PlayerAccessorMockSuccess{PlayerId: "player_id1"},
+			&storage.PlayerDataAccessorMockConfigurable{
+				ErasePlayerInternal: func(playerId string) (*model.PlayerErasure, error) {
+					return nil, fmt.Errorf("erasing player %s in game game_id1: %w", playerId, storage.ErrPlayerInUnfinishedGame)
+				},
+			},
+			true,
+		)
+
+		_, err := server.ErasePlayerData(context.Background(), &pb.ErasePlayerDataRequest{PlayerId: "player_id1"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = cannot erase player data while a game the player is in has not finished")
+	})
+}
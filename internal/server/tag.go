@@ -3,20 +3,21 @@ package server
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	pb "github.com/vipulvpatil/airetreat-go/protos"
 )
 
 func (s *AiRetreatGoService) Tag(ctx context.Context, req *pb.TagRequest) (*pb.TagResponse, error) {
-	tx, err := s.storage.BeginTransaction()
+	tx, err := s.storage.BeginTransaction(ctx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	game, err := s.storage.GetGameUsingTransaction(req.GetGameId(), tx)
+	game, err := s.storage.GetGameUsingTransaction(ctx, req.GetGameId(), tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
@@ -39,14 +40,41 @@ func (s *AiRetreatGoService) Tag(ctx context.Context, req *pb.TagRequest) (*pb.T
 		State:        &newGameState,
 		Result:       gameUpdate.Result,
 		WinningBotId: gameUpdate.WinningBotId,
+		TurnOrder:    gameUpdate.TurnOrder,
+		StateHandled: gameUpdate.StateHandled,
 	}
 
-	err = s.storage.UpdateGameStateUsingTransaction(req.GetGameId(), updateOptions, tx)
+	err = s.storage.UpdateGameStateUsingTransaction(ctx, req.GetGameId(), updateOptions, tx)
 	if err != nil {
 		s.logger.LogError(err)
 		return nil, err
 	}
 
+	if gameUpdate.TaggingBotId != nil {
+		err = s.storage.UpdateBotAfterTagUsingTransaction(ctx, *gameUpdate.TaggingBotId, time.Now(), tx)
+		if err != nil {
+			s.logger.LogError(err)
+			return nil, err
+		}
+	}
+
+	if gameUpdate.EliminatedBotId != nil {
+		err = s.storage.UpdateBotEliminatedUsingTransaction(ctx, *gameUpdate.EliminatedBotId, tx)
+		if err != nil {
+			s.logger.LogError(err)
+			return nil, err
+		}
+	}
+
+	if game.IsPractice() && gameUpdate.FinishesGame() {
+		won := gameUpdate.WinningBotId != nil && *gameUpdate.WinningBotId == sourceBot.Id()
+		err = s.storage.RecordPracticeResultUsingTransaction(ctx, req.GetGameId(), req.GetPlayerId(), won, tx)
+		if err != nil {
+			s.logger.LogError(err)
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	return &pb.TagResponse{}, err
 }
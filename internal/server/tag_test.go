@@ -16,14 +16,16 @@ import (
 
 func Test_Tag(t *testing.T) {
 	tests := []struct {
-		name             string
-		input            *pb.TagRequest
-		output           *pb.TagResponse
-		transactionMock  *storage.DatabaseTransactionMock
-		gameAccessorMock storage.GameAccessor
-		txShouldCommit   bool
-		errorExpected    bool
-		errorString      string
+		name                       string
+		input                      *pb.TagRequest
+		output                     *pb.TagResponse
+		transactionMock            *storage.DatabaseTransactionMock
+		gameAccessorMock           storage.GameAccessor
+		botAccessorMock            storage.BotAccessor
+		practiceResultRecorderMock storage.PracticeResultRecorder
+		txShouldCommit             bool
+		errorExpected              bool
+		errorString                string
 	}{
 		{
 			name: "test runs successfully if game is not finished",
@@ -90,6 +92,62 @@ func Test_Tag(t *testing.T) {
 			errorExpected:  false,
 			errorString:    "",
 		},
+		{
+			name: "records the result of a practice game separately",
+			input: &pb.TagRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id4",
+			},
+			output:          &pb.TagResponse{},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[0].ConnectPlayer(player1)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "WAITING_FOR_AI_QUESTION",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							Bots:             bots,
+							Mode:             "PRACTICE",
+							DecoyBotId:       "bot_id4",
+						},
+					)
+					return game, nil
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+			},
+			practiceResultRecorderMock: &storage.PracticeResultRecorderConfigurableMock{
+				RecordPracticeResultUsingTransactionInternal: func(gameId, playerId string, won bool, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "game_id1", gameId)
+					assert.Equal(t, "player_id1", playerId)
+					assert.True(t, won)
+					return nil
+				},
+			},
+			txShouldCommit: true,
+			errorExpected:  false,
+			errorString:    "",
+		},
 
 		{
 			name:             "errors if unable to get transaction",
@@ -275,6 +333,147 @@ func Test_Tag(t *testing.T) {
 			errorExpected:  true,
 			errorString:    "could not update game",
 		},
+		{
+			name: "test eliminates the ai bot and records the tag in an elimination game",
+			input: &pb.TagRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id3",
+			},
+			output:          &pb.TagResponse{},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					player2, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id2",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[0].ConnectPlayer(player1)
+					bots[1].ConnectPlayer(player2)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "WAITING_FOR_HUMAN_QUESTION",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     true,
+							StateTotalTime:   0,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now(),
+							Bots:             bots,
+							Elimination:      true,
+						},
+					)
+					return game, nil
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "BOT_ELIMINATED"
+					stateHandled := false
+
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:        &expectedState,
+						TurnOrder:    []string{"bot_id1", "bot_id2", "bot_id4", "bot_id5"},
+						StateHandled: &stateHandled,
+					}, updateOpts, "game state should be updated with correct update options")
+					return nil
+				},
+			},
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotAfterTagUsingTransactionInternal: func(botId string, taggedAt time.Time, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "bot_id1", botId)
+					assert.WithinDuration(t, time.Now(), taggedAt, time.Second)
+					return nil
+				},
+				UpdateBotEliminatedUsingTransactionInternal: func(botId string, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "bot_id3", botId)
+					return nil
+				},
+			},
+			txShouldCommit: true,
+			errorExpected:  false,
+			errorString:    "",
+		},
+		{
+			name: "errors if unable to eliminate the bot",
+			input: &pb.TagRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id3",
+			},
+			output:          &pb.TagResponse{},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					player1, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id1",
+						},
+					)
+					player2, _ := model.NewPlayer(
+						model.PlayerOptions{
+							Id: "player_id2",
+						},
+					)
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					bots[0].ConnectPlayer(player1)
+					bots[1].ConnectPlayer(player2)
+					game, _ := model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "WAITING_FOR_HUMAN_QUESTION",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     true,
+							StateTotalTime:   0,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now(),
+							Bots:             bots,
+							Elimination:      true,
+						},
+					)
+					return game, nil
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+			},
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotAfterTagUsingTransactionInternal: func(botId string, taggedAt time.Time, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+				UpdateBotEliminatedUsingTransactionInternal: func(botId string, transaction storage.DatabaseTransaction) error {
+					return errors.New("could not eliminate bot")
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "could not eliminate bot",
+		},
 	}
 
 	for _, tt := range tests {
@@ -285,6 +484,8 @@ func Test_Tag(t *testing.T) {
 						Transaction: tt.transactionMock,
 					}),
 					storage.WithGameAccessorMock(tt.gameAccessorMock),
+					storage.WithBotAccessorMock(tt.botAccessorMock),
+					storage.WithPracticeResultRecorderMock(tt.practiceResultRecorderMock),
 				),
 				Logger: &utilities.NullLogger{},
 			})
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+)
+
+func (s *AiRetreatGoService) CastVote(ctx context.Context, req *pb.CastVoteRequest) (*pb.CastVoteResponse, error) {
+	tx, err := s.storage.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	game, err := s.storage.GetGameUsingTransaction(ctx, req.GetGameId(), tx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	voterBot := game.BotWithPlayerId(req.GetPlayerId())
+	if voterBot == nil {
+		err := errors.New("incorrect game")
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	err = game.ValidateVote(voterBot.Id(), req.GetBotId())
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	err = s.storage.CreateVoteUsingTransaction(ctx, req.GetGameId(), voterBot.Id(), req.GetBotId(), tx)
+	if err != nil {
+		s.logger.LogError(err)
+		return nil, err
+	}
+
+	err = tx.Commit()
+	return &pb.CastVoteResponse{}, err
+}
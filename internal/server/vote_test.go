@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/storage"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+	pb "github.com/vipulvpatil/airetreat-go/protos"
+)
+
+func Test_CastVote(t *testing.T) {
+	votingGame := func(state string) (*model.Game, error) {
+		player1, _ := model.NewPlayer(
+			model.PlayerOptions{
+				Id: "player_id1",
+			},
+		)
+		player2, _ := model.NewPlayer(
+			model.PlayerOptions{
+				Id: "player_id2",
+			},
+		)
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		bots[0].ConnectPlayer(player1)
+		bots[1].ConnectPlayer(player2)
+		votingStartedAt := time.Now()
+		return model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            state,
+				CurrentTurnIndex: 10,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				StateHandled:     false,
+				StateHandledAt:   &votingStartedAt,
+				StateTotalTime:   60,
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				VotingRounds:     2,
+			},
+		)
+	}
+
+	tests := []struct {
+		name             string
+		input            *pb.CastVoteRequest
+		output           *pb.CastVoteResponse
+		transactionMock  *storage.DatabaseTransactionMock
+		gameAccessorMock storage.GameAccessor
+		voteCreatorMock  storage.VoteCreator
+		txShouldCommit   bool
+		errorExpected    bool
+		errorString      string
+	}{
+		{
+			name: "test runs successfully",
+			input: &pb.CastVoteRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id2",
+			},
+			output:          &pb.CastVoteResponse{},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame("VOTING")
+				},
+			},
+			voteCreatorMock: &storage.VoteCreatorConfigurableMock{
+				CreateVoteUsingTransactionInternal: func(gameId, voterBotId, suspectBotId string, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "game_id1", gameId)
+					assert.Equal(t, "bot_id1", voterBotId)
+					assert.Equal(t, "bot_id2", suspectBotId)
+					return nil
+				},
+			},
+			txShouldCommit: true,
+			errorExpected:  false,
+			errorString:    "",
+		},
+		{
+			name:             "errors if unable to get transaction",
+			input:            &pb.CastVoteRequest{},
+			output:           nil,
+			transactionMock:  nil,
+			gameAccessorMock: nil,
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to begin a db transaction",
+		},
+		{
+			name: "errors if cannot get game",
+			input: &pb.CastVoteRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id2",
+			},
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return nil, errors.New("cannot get game")
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "cannot get game",
+		},
+		{
+			name: "errors if player not in game",
+			input: &pb.CastVoteRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id3",
+				BotId:    "bot_id2",
+			},
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame("VOTING")
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "incorrect game",
+		},
+		{
+			name: "errors if game is not accepting votes",
+			input: &pb.CastVoteRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id2",
+			},
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame("FINISHED")
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "game is not accepting votes",
+		},
+		{
+			name: "errors if vote cannot be created",
+			input: &pb.CastVoteRequest{
+				GameId:   "game_id1",
+				PlayerId: "player_id1",
+				BotId:    "bot_id2",
+			},
+			output:          nil,
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame("VOTING")
+				},
+			},
+			voteCreatorMock: &storage.VoteCreatorMockFailure{},
+			txShouldCommit:  false,
+			errorExpected:   true,
+			errorString:     "unable to cast vote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := NewServer(ServerDependencies{
+				Storage: storage.NewStorageAccessorMock(
+					storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+						Transaction: tt.transactionMock,
+					}),
+					storage.WithGameAccessorMock(tt.gameAccessorMock),
+					storage.WithVoteCreatorMock(tt.voteCreatorMock),
+				),
+				Logger: &utilities.NullLogger{},
+			})
+
+			response, err := server.CastVote(
+				context.Background(),
+				tt.input,
+			)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.EqualValues(t, tt.output, response)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+
+			if tt.transactionMock != nil {
+				if tt.txShouldCommit {
+					assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				} else {
+					assert.True(t, tt.transactionMock.Rolledback, "transaction should have rolledback")
+					assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				}
+			}
+		})
+	}
+}
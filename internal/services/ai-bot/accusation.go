@@ -0,0 +1,120 @@
+package aibot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type AiAccuser interface {
+	GetAccusation(ctx context.Context) *Accusation
+}
+
+// Accusation is the bot an AI bot thinks is most likely a human, along with its confidence from 0 to 100.
+// Text is what the AI bot says to the others when it goes ahead with the accusation.
+type Accusation struct {
+	AiMessage
+	SuspectBotId string
+	Confidence   int64
+}
+
+type aiAccuser struct {
+	*aiBot
+	game *model.Game
+}
+
+func NewAiAccuser(opts AiBotOptions) AiAccuser {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
+		return nil
+	}
+
+	accusingBot := opts.Game.BotWithId(opts.BotId)
+	if accusingBot == nil {
+		return nil
+	}
+
+	suspects := []string{}
+	for _, botName := range opts.Game.GetBotNames() {
+		if botName != accusingBot.Name() {
+			suspects = append(suspects, botName)
+		}
+	}
+
+	return &aiAccuser{
+		aiBot: &aiBot{
+			name:                accusingBot.Name(),
+			statedFacts:         accusingBot.StatedFacts(),
+			isAi:                accusingBot.IsAi(),
+			playingHuman:        opts.Game.IsDecoy(accusingBot.Id()),
+			detailedMessages:    opts.Game.GetDetailedMessages(),
+			conversationSummary: opts.Game.ConversationSummary(),
+			allBotNames:         opts.Game.GetBotNames(),
+			imitatingHumans:     opts.Game.AiImitatesHumans(),
+			suspects:            suspects,
+			openAiClient:        opts.OpenAiClient,
+			prompts:             opts.Prompts,
+			promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
+		},
+		game: opts.Game,
+	}
+}
+
+// GetAccusation returns nil when the AI does not clearly name one of the other bots. There is no fallback,
+// since a made up accusation would be worse than none at all.
+func (aa *aiAccuser) GetAccusation(ctx context.Context) *Accusation {
+	openAiPrompt, err := aa.renderPromptWithinBudget(ctx, prompts.ACCUSATION_TEMPLATE)
+	if err != nil {
+		return nil
+	}
+	completion, err := aa.openAiClient.CallCompletionApi(ctx, openAiPrompt)
+	if err != nil {
+		return nil
+	}
+
+	suspectName, confidence, ok := parseAccusation(completion.Text)
+	if !ok {
+		return nil
+	}
+	suspect := aa.game.BotWithName(suspectName)
+	if suspect == nil || suspect.Name() == aa.name {
+		return nil
+	}
+
+	aiMessage := aa.aiMessageFromCompletion(completion)
+	aiMessage.Text = fmt.Sprintf("I think %s is human.", suspect.Name())
+	return &Accusation{
+		AiMessage:    aiMessage,
+		SuspectBotId: suspect.Id(),
+		Confidence:   confidence,
+	}
+}
+
+func parseAccusation(completionText string) (string, int64, bool) {
+	suspectName := ""
+	confidence := int64(-1)
+	for _, line := range strings.Split(completionText, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "suspect":
+			suspectName = strings.Trim(strings.TrimSpace(value), ".\"'")
+		case "confidence":
+			parsedConfidence, err := strconv.ParseInt(strings.TrimRight(strings.TrimSpace(value), "%."), 10, 64)
+			if err == nil {
+				confidence = parsedConfidence
+			}
+		}
+	}
+
+	if utilities.IsBlank(suspectName) || confidence < 0 || confidence > 100 {
+		return "", 0, false
+	}
+	return suspectName, confidence, true
+}
@@ -0,0 +1,146 @@
+package aibot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+func Test_ParseAccusation(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		expectedSuspect    string
+		expectedConfidence int64
+		expectedOk         bool
+	}{
+		{
+			name:               "parses the suspect and confidence",
+			input:              "\nSuspect: Avis\nConfidence: 85",
+			expectedSuspect:    "Avis",
+			expectedConfidence: 85,
+			expectedOk:         true,
+		},
+		{
+			name:               "ignores case, punctuation and percentages",
+			input:              "suspect: \"ED-I\".\nCONFIDENCE: 40%",
+			expectedSuspect:    "ED-I",
+			expectedConfidence: 40,
+			expectedOk:         true,
+		},
+		{
+			name:               "fails without a suspect",
+			input:              "Confidence: 85",
+			expectedSuspect:    "",
+			expectedConfidence: 0,
+			expectedOk:         false,
+		},
+		{
+			name:               "fails without a usable confidence",
+			input:              "Suspect: Avis\nConfidence: very high",
+			expectedSuspect:    "",
+			expectedConfidence: 0,
+			expectedOk:         false,
+		},
+		{
+			name:               "fails when confidence is out of range",
+			input:              "Suspect: Avis\nConfidence: 150",
+			expectedSuspect:    "",
+			expectedConfidence: 0,
+			expectedOk:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suspect, confidence, ok := parseAccusation(tt.input)
+			assert.Equal(t, tt.expectedSuspect, suspect)
+			assert.Equal(t, tt.expectedConfidence, confidence)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func Test_GetAccusation(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	player1, err := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+	assert.NoError(t, err)
+	player2, err := model.NewPlayer(model.PlayerOptions{Id: "player_id2"})
+	assert.NoError(t, err)
+
+	bots := []*model.Bot{}
+	for _, botOpts := range []model.BotOptions{
+		{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"},
+		{Id: "bot_id2", Name: "bot2", TypeOfBot: "HUMAN", ConnectedPlayer: player1},
+		{Id: "bot_id3", Name: "bot3", TypeOfBot: "AI"},
+		{Id: "bot_id4", Name: "bot4", TypeOfBot: "HUMAN", ConnectedPlayer: player2},
+		{Id: "bot_id5", Name: "bot5", TypeOfBot: "AI"},
+	} {
+		bot, err := model.NewBot(botOpts)
+		assert.NoError(t, err)
+		bots = append(bots, bot)
+	}
+	game, err := model.NewGame(model.GameOptions{
+		Id:            "game_id1",
+		State:         "WAITING_FOR_AI_QUESTION",
+		TurnOrder:     []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+		Bots:          bots,
+		AiAccusations: true,
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		client         *recordingClient
+		expectedOutput *Accusation
+	}{
+		{
+			name:   "accuses the suspect named by the AI",
+			client: &recordingClient{text: "Suspect: BOT2\nConfidence: 92"},
+			expectedOutput: &Accusation{
+				AiMessage: AiMessage{
+					Text:          "I think bot2 is human.",
+					AiModel:       "text-davinci-003",
+					PromptVersion: prompts.DEFAULT_VERSION,
+				},
+				SuspectBotId: "bot_id2",
+				Confidence:   92,
+			},
+		},
+		{
+			name:           "does not accuse itself",
+			client:         &recordingClient{text: "Suspect: bot1\nConfidence: 92"},
+			expectedOutput: nil,
+		},
+		{
+			name:           "does not accuse a bot that is not in the game",
+			client:         &recordingClient{text: "Suspect: bot9\nConfidence: 92"},
+			expectedOutput: nil,
+		},
+		{
+			name:           "does not accuse anyone if the AI fails",
+			client:         &recordingClient{err: errors.New("Open Ai error")},
+			expectedOutput: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accuser := NewAiAccuser(AiBotOptions{
+				BotId:        "bot_id1",
+				Game:         game,
+				OpenAiClient: tt.client,
+				Prompts:      registry,
+			})
+			assert.Equal(t, tt.expectedOutput, accuser.GetAccusation(context.Background()))
+			assert.Len(t, tt.client.prompts, 1)
+			assert.Contains(t, tt.client.prompts[0], "Which one of bot2, bot3, bot4, bot5 sounds the most like a human")
+		})
+	}
+}
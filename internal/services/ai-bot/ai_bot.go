@@ -1,42 +1,64 @@
 package aibot
 
 import (
-	"fmt"
-	"math/rand"
-	"strings"
+	"context"
 
 	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 var TOPICS = [...]string{"Music", "Movies", "Sports", "Food", "Travel", "Technology", "Shopping", "Education", "Pets", "Gardening ", "Art ", "Fashion ", "Books ", "Health ", "Cars ", "Cooking ", "Politics ", "Religion ", "Family ", "Games ", "Finance ", "Weather ", "Science ", "Nature  ", "Photography  ", "Hobbies", "Relationships", "Work", "Fitness", "Culture", "Gadgets", "History", "Language", "Money", "Philosophy", "Psychology", "Recreation", "Social Media", "Space", "TV Shows", "Vacations", "Volunteering", "Writing", "Yoga", "Animals", "Architecture", "Astronomy", "Business", "Economics"}
 
-const CONTEXT_TEXT = "This is a laid back conversation between a bunch of AI bots. It follows a pattern of question and answers. A question is asked and a given bot answers. Each question and answer is not more than 7 words long. The bots have names, but they are not allowed to reference each other by name. Their names are %s, %s, %s, %s and %s. You are %s. You generally provide factual answers but have a tendency to not answer some questions randomly."
-
 type AiQuestionGenerator interface {
-	GetNextQuestion() string
+	GetNextQuestion(ctx context.Context) AiMessage
 }
 
 type AiAnswerGenerator interface {
-	GetNextAnswer() string
+	GetNextAnswer(ctx context.Context) AiMessage
+}
+
+// AiMessage is generated text along with details of how it was generated.
+// AiModel and PromptVersion are blank when a fallback text was used.
+// ConversationSummary is only set when the summary of older turns was updated, and should then be cached on the game.
+// StatedFacts are the facts an AI bot stated about itself in Text, to be added to its profile.
+type AiMessage struct {
+	Text                string
+	AiModel             string
+	PromptVersion       string
+	PromptTokens        int64
+	CompletionTokens    int64
+	ConversationSummary *model.ConversationSummary
+	StatedFacts         []string
 }
 
 type aiBot struct {
-	name              string
-	conversationSoFar string
-	allBotNames       []string
-	openAiClient      openai.Client
+	name                string
+	statedFacts         []string
+	isAi                bool
+	playingHuman        bool
+	detailedMessages    []model.DetailedMessage
+	conversationSummary model.ConversationSummary
+	updatedSummary      *model.ConversationSummary
+	allBotNames         []string
+	imitatingHumans     bool
+	suspects            []string
+	helpStyle           string
+	openAiClient        openai.Client
+	prompts             *prompts.Registry
+	promptVersion       string
 }
 
 type AiBotOptions struct {
 	BotId        string
 	Game         *model.Game
 	OpenAiClient openai.Client
+	Prompts      *prompts.Registry
 }
 
 func NewAiQuestionGenerator(opts AiBotOptions) AiQuestionGenerator {
-	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
 		return nil
 	}
 
@@ -45,18 +67,23 @@ func NewAiQuestionGenerator(opts AiBotOptions) AiQuestionGenerator {
 		return nil
 	}
 
-	detailedMessages := opts.Game.GetDetailedMessages()
-	conversationText := constructConversationText(detailedMessages)
 	return &aiBot{
-		name:              questionerBot.Name(),
-		conversationSoFar: conversationText,
-		allBotNames:       opts.Game.GetBotNames(),
-		openAiClient:      opts.OpenAiClient,
+		name:                questionerBot.Name(),
+		statedFacts:         questionerBot.StatedFacts(),
+		isAi:                questionerBot.IsAi(),
+		playingHuman:        opts.Game.IsDecoy(questionerBot.Id()),
+		detailedMessages:    opts.Game.GetDetailedMessages(),
+		conversationSummary: opts.Game.ConversationSummary(),
+		allBotNames:         opts.Game.GetBotNames(),
+		imitatingHumans:     opts.Game.AiImitatesHumans(),
+		openAiClient:        opts.OpenAiClient,
+		prompts:             opts.Prompts,
+		promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
 	}
 }
 
 func NewAiAnswerGenerator(opts AiBotOptions) AiAnswerGenerator {
-	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
 		return nil
 	}
 
@@ -65,91 +92,89 @@ func NewAiAnswerGenerator(opts AiBotOptions) AiAnswerGenerator {
 		return nil
 	}
 
-	detailedMessages := opts.Game.GetDetailedMessages()
-	conversationText := constructConversationText(detailedMessages)
 	return &aiBot{
-		name:              answeringBot.Name(),
-		conversationSoFar: conversationText,
-		allBotNames:       opts.Game.GetBotNames(),
-		openAiClient:      opts.OpenAiClient,
+		name:                answeringBot.Name(),
+		statedFacts:         answeringBot.StatedFacts(),
+		isAi:                answeringBot.IsAi(),
+		playingHuman:        opts.Game.IsDecoy(answeringBot.Id()),
+		detailedMessages:    opts.Game.GetDetailedMessages(),
+		conversationSummary: opts.Game.ConversationSummary(),
+		allBotNames:         opts.Game.GetBotNames(),
+		imitatingHumans:     opts.Game.AiImitatesHumans(),
+		openAiClient:        opts.OpenAiClient,
+		prompts:             opts.Prompts,
+		promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
 	}
 }
 
-func (ab *aiBot) GetNextQuestion() string {
-	var openAiPrompt string
-	promptContext := createContextUsingBots(ab.allBotNames, ab.name)
-	if utilities.IsBlank(ab.conversationSoFar) {
-		openAiPrompt = createFirstQuestionPromptWithContext(promptContext)
-	} else {
-		openAiPrompt = createQuestionPromptWithContext(promptContext, ab.conversationSoFar)
+func (ab *aiBot) GetNextQuestion(ctx context.Context) AiMessage {
+	templateName := prompts.QUESTION_TEMPLATE
+	if len(ab.detailedMessages) == 0 {
+		templateName = prompts.FIRST_QUESTION_TEMPLATE
+	}
+	openAiPrompt, err := ab.renderPromptWithinBudget(ctx, templateName)
+	if err != nil {
+		return ab.fallbackAiMessage(randomFallbackQuestion())
 	}
-	question, err := ab.openAiClient.CallCompletionApi(openAiPrompt)
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, openAiPrompt)
 
 	if err != nil {
-		return randomFallbackQuestion()
+		return ab.fallbackAiMessage(randomFallbackQuestion())
 	} else {
-		return question
+		return ab.aiMessageFromCompletion(completion)
 	}
 }
 
-func (ab *aiBot) GetNextAnswer() string {
-	promptContext := createContextUsingBots(ab.allBotNames, ab.name)
-	openAiPrompt := createAnswerPromptWithContext(promptContext, ab.conversationSoFar)
-	answer, err := ab.openAiClient.CallCompletionApi(openAiPrompt)
-
+func (ab *aiBot) GetNextAnswer(ctx context.Context) AiMessage {
+	openAiPrompt, err := ab.renderPromptWithinBudget(ctx, prompts.ANSWER_TEMPLATE)
 	if err != nil {
-		return randomFallbackAnswer()
-	} else {
-		return answer
+		return ab.fallbackAiMessage(randomFallbackAnswer())
 	}
-}
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, openAiPrompt)
 
-func randomFallbackQuestion() string {
-	return "What are we really talking about?"
-}
+	if err != nil {
+		return ab.fallbackAiMessage(randomFallbackAnswer())
+	}
 
-func randomFallbackAnswer() string {
-	return "I am unsure how to answer that"
+	aiMessage := ab.aiMessageFromCompletion(completion)
+	if ab.isAi {
+		aiMessage.StatedFacts = ab.extractStatedFacts(ctx, aiMessage.Text)
+	}
+	return aiMessage
 }
 
-func constructConversationText(detailedMessages []model.DetailedMessage) string {
-	conversationMessageList := []string{}
-	for _, detailedMessage := range detailedMessages {
-		prefix := detailedMessage.SourceBotName
-		nextConversationMessage := fmt.Sprintf("%s: %s", prefix, detailedMessage.Text)
-		conversationMessageList = append(conversationMessageList, nextConversationMessage)
+func (ab *aiBot) fallbackAiMessage(text string) AiMessage {
+	return AiMessage{
+		Text:                text,
+		ConversationSummary: ab.updatedSummary,
 	}
-	return strings.Join(conversationMessageList, "\n")
 }
 
-func createFirstQuestionPromptWithContext(promptContext string) string {
-	randomTopic := TOPICS[rand.Intn(len(TOPICS))]
-	task := fmt.Sprintf("Provide a question o the topic of %s.\nQuestion:", randomTopic)
-	return fmt.Sprintf("%s %s", promptContext, task)
+func (ab *aiBot) aiMessageFromCompletion(completion *openai.Completion) AiMessage {
+	return AiMessage{
+		Text:                completion.Text,
+		AiModel:             completion.Model,
+		PromptVersion:       ab.promptVersion,
+		PromptTokens:        completion.PromptTokens,
+		CompletionTokens:    completion.CompletionTokens,
+		ConversationSummary: ab.updatedSummary,
+	}
 }
 
-func createQuestionPromptWithContext(promptContext, conversationSoFar string) string {
-	task := fmt.Sprintf("Conversation so far is \n%s\n. Ask the next question but do not answer it.\nQuestion:", conversationSoFar)
-	return fmt.Sprintf("%s %s", promptContext, task)
+func randomFallbackQuestion() string {
+	return "What are we really talking about?"
 }
 
-func createAnswerPromptWithContext(promptContext, conversationSoFar string) string {
-	task := fmt.Sprintf("Conversation so far is \n%s\n. Answer the question.\nAnswer:", conversationSoFar)
-	return fmt.Sprintf("%s %s", promptContext, task)
+func randomFallbackAnswer() string {
+	return "I am unsure how to answer that"
 }
 
-func createContextUsingBots(botNames []string, myBotName string) string {
-	contextBotNamesWithMyBotName := append(botNames, myBotName)
-	context := fmt.Sprintf(
-		CONTEXT_TEXT,
-		contextBotNamesWithMyBotName[0],
-		contextBotNamesWithMyBotName[1],
-		contextBotNamesWithMyBotName[2],
-		contextBotNamesWithMyBotName[3],
-		contextBotNamesWithMyBotName[4],
-		myBotName,
-	)
-	return context
+// Games created before prompt versioning, or assigned a version that is no longer loaded, use the default prompts.
+func promptVersionForGame(game *model.Game, registry *prompts.Registry) string {
+	if registry.HasVersion(game.PromptVersion()) {
+		return game.PromptVersion()
+	}
+	return prompts.DEFAULT_VERSION
 }
 
 // Rules of conversation are.
@@ -0,0 +1,148 @@
+package aibot
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// A bot's answers stand out once they are this many times shorter or longer, or slower or faster, than everyone else's.
+const SUSPICIOUS_ANSWER_RATIO = 1.5
+
+type TargetRecommender interface {
+	GetTargetRecommendation() (*TargetRecommendation, error)
+}
+
+// TargetRecommendation is the bot a player should ask the next question to, along with a short reason why.
+type TargetRecommendation struct {
+	BotId     string
+	Rationale string
+}
+
+// transcriptAnalyzer looks for bots whose answers stand out from the rest of the transcript. A player hunting a human
+// is pointed at short and slow answers, while a player hunting an AI imitating humans is pointed at long and fast ones.
+type transcriptAnalyzer struct {
+	game         *model.Game
+	botId        string
+	lookingForAi bool
+}
+
+// NewTargetRecommender only needs BotId and Game, since the analysis does not call the AI.
+func NewTargetRecommender(opts AiBotOptions) TargetRecommender {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil {
+		return nil
+	}
+
+	if opts.Game.BotWithId(opts.BotId) == nil {
+		return nil
+	}
+
+	return &transcriptAnalyzer{
+		game:         opts.Game,
+		botId:        opts.BotId,
+		lookingForAi: opts.Game.AiImitatesHumans(),
+	}
+}
+
+func (ta *transcriptAnalyzer) GetTargetRecommendation() (*TargetRecommendation, error) {
+	suspicionScores, rationales := ta.suspicions()
+
+	targetBotId, err := ta.game.GetSuspectedTargetBotIdForNextQuestion(suspicionScores)
+	if err != nil {
+		return nil, err
+	}
+
+	rationale, ok := rationales[targetBotId]
+	if !ok {
+		if ta.answerStatsByBot()[targetBotId].count == 0 {
+			rationale = "nobody has asked this bot anything yet"
+		} else {
+			rationale = "this bot has answered the fewest questions so far"
+		}
+	}
+
+	return &TargetRecommendation{BotId: targetBotId, Rationale: rationale}, nil
+}
+
+type answerStats struct {
+	count        int
+	words        int
+	responseTime time.Duration
+}
+
+func (s answerStats) averageWords() float64 {
+	return float64(s.words) / float64(s.count)
+}
+
+func (s answerStats) averageResponseTime() time.Duration {
+	return s.responseTime / time.Duration(s.count)
+}
+
+func (s answerStats) without(other answerStats) answerStats {
+	return answerStats{
+		count:        s.count - other.count,
+		words:        s.words - other.words,
+		responseTime: s.responseTime - other.responseTime,
+	}
+}
+
+// answerStatsByBot leaves out the player's own answers, which tell them nothing.
+func (ta *transcriptAnalyzer) answerStatsByBot() map[string]answerStats {
+	statsByBot := map[string]answerStats{}
+	for _, detailedMessage := range ta.game.GetDetailedMessages() {
+		if detailedMessage.MessageType != "answer" || detailedMessage.SourceBotId == ta.botId {
+			continue
+		}
+		stats := statsByBot[detailedMessage.SourceBotId]
+		stats.count++
+		stats.words += len(strings.Fields(detailedMessage.Text))
+		stats.responseTime += detailedMessage.ResponseTime
+		statsByBot[detailedMessage.SourceBotId] = stats
+	}
+	return statsByBot
+}
+
+// suspicions scores every bot that answered by how many ways its answers stand out, and explains each score.
+func (ta *transcriptAnalyzer) suspicions() (map[string]int64, map[string]string) {
+	statsByBot := ta.answerStatsByBot()
+	allStats := answerStats{}
+	for _, stats := range statsByBot {
+		allStats.count += stats.count
+		allStats.words += stats.words
+		allStats.responseTime += stats.responseTime
+	}
+
+	suspicionScores := map[string]int64{}
+	rationales := map[string]string{}
+	for botId, stats := range statsByBot {
+		othersStats := allStats.without(stats)
+		if othersStats.count == 0 {
+			continue
+		}
+
+		reasons := []string{}
+		if ta.lookingForAi {
+			if stats.averageWords() >= othersStats.averageWords()*SUSPICIOUS_ANSWER_RATIO {
+				reasons = append(reasons, "this bot's answers were suspiciously long")
+			}
+			if stats.averageResponseTime() > 0 && float64(stats.averageResponseTime())*SUSPICIOUS_ANSWER_RATIO <= float64(othersStats.averageResponseTime()) {
+				reasons = append(reasons, "this bot answered suspiciously fast")
+			}
+		} else {
+			if stats.averageWords()*SUSPICIOUS_ANSWER_RATIO <= othersStats.averageWords() {
+				reasons = append(reasons, "this bot's answers were suspiciously short")
+			}
+			if othersStats.averageResponseTime() > 0 && float64(stats.averageResponseTime()) >= float64(othersStats.averageResponseTime())*SUSPICIOUS_ANSWER_RATIO {
+				reasons = append(reasons, "this bot took suspiciously long to answer")
+			}
+		}
+
+		if len(reasons) > 0 {
+			suspicionScores[botId] = int64(len(reasons))
+			rationales[botId] = strings.Join(reasons, ", and ")
+		}
+	}
+	return suspicionScores, rationales
+}
@@ -0,0 +1,126 @@
+package aibot
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+func Test_GetTargetRecommendation(t *testing.T) {
+	player, err := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+	assert.NoError(t, err)
+
+	newGame := func(mode string, messages []*model.Message) *model.Game {
+		bots := []*model.Bot{}
+		for _, botOpts := range []model.BotOptions{
+			{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"},
+			{Id: "bot_id2", Name: "bot2", TypeOfBot: "HUMAN", ConnectedPlayer: player},
+			{Id: "bot_id3", Name: "bot3", TypeOfBot: "AI"},
+			{Id: "bot_id4", Name: "bot4", TypeOfBot: "AI"},
+			{Id: "bot_id5", Name: "bot5", TypeOfBot: "AI"},
+		} {
+			bot, err := model.NewBot(botOpts)
+			assert.NoError(t, err)
+			bots = append(bots, bot)
+		}
+		game, err := model.NewGame(model.GameOptions{
+			Id:               "game_id1",
+			Mode:             mode,
+			State:            "WAITING_FOR_HUMAN_QUESTION",
+			CurrentTurnIndex: 1,
+			TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+			Bots:             bots,
+			Messages:         messages,
+		})
+		assert.NoError(t, err)
+		return game
+	}
+
+	answer := func(botId, text string, responseTime time.Duration, i int) *model.Message {
+		return &model.Message{
+			SourceBotId:  botId,
+			TargetBotId:  botId,
+			Text:         text,
+			MessageType:  "answer",
+			ResponseTime: responseTime,
+			CreatedAt:    time.Now().Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	transcript := []*model.Message{
+		answer("bot_id1", "I mostly listen to old jazz records", 5*time.Second, 1),
+		answer("bot_id3", "Nope", 6*time.Second, 2),
+		answer("bot_id4", "Pizza with a lot of extra cheese", 6*time.Second, 3),
+		answer("bot_id5", "I would rather be at the beach", 4*time.Second, 4),
+		answer("bot_id2", "k", 30*time.Second, 5),
+	}
+
+	tests := []struct {
+		name           string
+		game           *model.Game
+		expectedOutput *TargetRecommendation
+	}{
+		{
+			name: "recommends the bot with short answers when looking for a human",
+			game: newGame("CLASSIC", transcript),
+			expectedOutput: &TargetRecommendation{
+				BotId:     "bot_id3",
+				Rationale: "this bot's answers were suspiciously short",
+			},
+		},
+		{
+			name: "recommends the bot with long and fast answers when looking for an AI",
+			game: newGame("TEAM", []*model.Message{
+				answer("bot_id1", "Sure", 20*time.Second, 1),
+				answer("bot_id3", "Nah", 25*time.Second, 2),
+				answer("bot_id4", "I really enjoy hiking in the mountains on weekends", 2*time.Second, 3),
+				answer("bot_id5", "Pasta", 15*time.Second, 4),
+			}),
+			expectedOutput: &TargetRecommendation{
+				BotId:     "bot_id4",
+				Rationale: "this bot's answers were suspiciously long, and this bot answered suspiciously fast",
+			},
+		},
+		{
+			name: "recommends a bot nobody has asked yet when nothing stands out",
+			game: newGame("CLASSIC", []*model.Message{
+				answer("bot_id1", "I like jazz", 5*time.Second, 1),
+				answer("bot_id3", "I like rock", 5*time.Second, 2),
+				answer("bot_id4", "I like pop", 5*time.Second, 3),
+			}),
+			expectedOutput: &TargetRecommendation{
+				BotId:     "bot_id5",
+				Rationale: "nobody has asked this bot anything yet",
+			},
+		},
+		{
+			name: "recommends the bot that answered the fewest questions when nothing stands out",
+			game: newGame("CLASSIC", []*model.Message{
+				answer("bot_id1", "I like jazz", 5*time.Second, 1),
+				answer("bot_id1", "I like blues", 5*time.Second, 2),
+				answer("bot_id3", "I like rock", 5*time.Second, 3),
+				answer("bot_id3", "I like metal", 5*time.Second, 4),
+				answer("bot_id4", "I like pop", 5*time.Second, 5),
+				answer("bot_id4", "I like indie", 5*time.Second, 6),
+				answer("bot_id5", "I like folk", 5*time.Second, 7),
+			}),
+			expectedOutput: &TargetRecommendation{
+				BotId:     "bot_id5",
+				Rationale: "this bot has answered the fewest questions so far",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			recommender := NewTargetRecommender(AiBotOptions{BotId: "bot_id2", Game: tt.game})
+			recommendation, err := recommender.GetTargetRecommendation()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, recommendation)
+		})
+	}
+}
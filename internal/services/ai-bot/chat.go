@@ -0,0 +1,59 @@
+package aibot
+
+import (
+	"context"
+
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type AiChatter interface {
+	GetNextChatMessage(ctx context.Context) AiMessage
+}
+
+func NewAiChatter(opts AiBotOptions) AiChatter {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
+		return nil
+	}
+
+	chattingBot := opts.Game.BotWithId(opts.BotId)
+	if chattingBot == nil {
+		return nil
+	}
+
+	return &aiBot{
+		name:                chattingBot.Name(),
+		statedFacts:         chattingBot.StatedFacts(),
+		isAi:                chattingBot.IsAi(),
+		playingHuman:        opts.Game.IsDecoy(chattingBot.Id()),
+		detailedMessages:    opts.Game.GetDetailedMessages(),
+		conversationSummary: opts.Game.ConversationSummary(),
+		allBotNames:         opts.Game.GetBotNames(),
+		imitatingHumans:     opts.Game.AiImitatesHumans(),
+		openAiClient:        opts.OpenAiClient,
+		prompts:             opts.Prompts,
+		promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
+	}
+}
+
+func (ab *aiBot) GetNextChatMessage(ctx context.Context) AiMessage {
+	openAiPrompt, err := ab.renderPromptWithinBudget(ctx, prompts.CHAT_TEMPLATE)
+	if err != nil {
+		return ab.fallbackAiMessage(randomFallbackChatMessage())
+	}
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, openAiPrompt)
+
+	if err != nil {
+		return ab.fallbackAiMessage(randomFallbackChatMessage())
+	}
+
+	aiMessage := ab.aiMessageFromCompletion(completion)
+	if ab.isAi {
+		aiMessage.StatedFacts = ab.extractStatedFacts(ctx, aiMessage.Text)
+	}
+	return aiMessage
+}
+
+func randomFallbackChatMessage() string {
+	return "So what is everyone up to?"
+}
@@ -0,0 +1,53 @@
+package aibot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+func Test_GetNextChatMessage(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	t.Run("chimes in using the chat prompt", func(t *testing.T) {
+		client := &recordingClient{text: "Pizza is the best"}
+		ab := &aiBot{
+			name:             "bot1",
+			isAi:             false,
+			detailedMessages: detailedMessagesForTest(2, "what is everyone eating"),
+			allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+			openAiClient:     client,
+			prompts:          registry,
+			promptVersion:    prompts.DEFAULT_VERSION,
+		}
+
+		aiMessage := ab.GetNextChatMessage(context.Background())
+		assert.Equal(t, "Pizza is the best", aiMessage.Text)
+		assert.Equal(t, prompts.DEFAULT_VERSION, aiMessage.PromptVersion)
+		assert.Len(t, client.prompts, 1)
+		assert.Contains(t, client.prompts[0], "group chat")
+		assert.Contains(t, client.prompts[0], "bot2: what is everyone eating 2")
+	})
+
+	t.Run("falls back to a canned message if the AI fails", func(t *testing.T) {
+		client := &recordingClient{err: errors.New("Open Ai error")}
+		ab := &aiBot{
+			name:             "bot1",
+			isAi:             true,
+			detailedMessages: detailedMessagesForTest(2, "what is everyone eating"),
+			allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+			openAiClient:     client,
+			prompts:          registry,
+			promptVersion:    prompts.DEFAULT_VERSION,
+		}
+
+		aiMessage := ab.GetNextChatMessage(context.Background())
+		assert.Equal(t, "So what is everyone up to?", aiMessage.Text)
+		assert.Equal(t, "", aiMessage.AiModel)
+		assert.Nil(t, aiMessage.StatedFacts)
+	})
+}
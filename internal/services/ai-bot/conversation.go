@@ -0,0 +1,145 @@
+package aibot
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// The most recent messages are always sent verbatim. Older messages are folded into the summary once enough of them
+// have built up, so that the summary is not regenerated on every turn.
+const RECENT_MESSAGES_KEPT_VERBATIM = 10
+const MESSAGES_PER_SUMMARY_UPDATE = 6
+
+// PROMPT_TOKEN_BUDGET caps the size of every prompt, so that the cost of a turn does not grow with the length of the game.
+const PROMPT_TOKEN_BUDGET = 1000
+
+type conversation struct {
+	summary string
+	lines   []string
+}
+
+func (c *conversation) text() string {
+	return strings.Join(c.lines, "\n")
+}
+
+// trim drops the least relevant part of the conversation, and reports whether there was anything left to drop.
+// The oldest verbatim lines go first, one at a time, since the summary covers far more of the game in far fewer tokens.
+// The summary is only dropped once the latest line is all that is left, and the latest line is always kept.
+func (c *conversation) trim() bool {
+	if len(c.lines) > 1 {
+		c.lines = c.lines[1:]
+		return true
+	}
+	if !utilities.IsBlank(c.summary) {
+		c.summary = ""
+		return true
+	}
+	return false
+}
+
+func (ab *aiBot) renderPromptWithinBudget(ctx context.Context, templateName string) (string, error) {
+	conversation := ab.buildConversation(ctx)
+	budget := promptTokenBudget()
+	topic := TOPICS[rand.Intn(len(TOPICS))]
+
+	for {
+		prompt, err := ab.prompts.Render(ab.promptVersion, templateName, prompts.PromptData{
+			BotNames:            append(ab.allBotNames, ab.name),
+			MyBotName:           ab.name,
+			ImitatingHumans:     ab.imitatingHumans,
+			PlayingHuman:        ab.playingHuman,
+			MyStatedFacts:       ab.statedFacts,
+			Suspects:            ab.suspects,
+			Reactions:           model.REACTIONS,
+			ConversationSummary: conversation.summary,
+			ConversationSoFar:   conversation.text(),
+			Topic:               topic,
+			Style:               ab.helpStyle,
+		})
+		if err != nil {
+			return "", err
+		}
+		if ab.openAiClient.CountTokens(prompt) <= budget || !conversation.trim() {
+			return prompt, nil
+		}
+	}
+}
+
+func (ab *aiBot) buildConversation(ctx context.Context) conversation {
+	summary := ab.conversationSummary
+	if summary.MessageCount > int64(len(ab.detailedMessages)) {
+		// The summary covers messages that no longer exist, so it cannot be trusted.
+		summary = model.ConversationSummary{}
+	}
+
+	unsummarizedCount := len(ab.detailedMessages) - int(summary.MessageCount)
+	if unsummarizedCount > RECENT_MESSAGES_KEPT_VERBATIM+MESSAGES_PER_SUMMARY_UPDATE {
+		messagesToSummarize := ab.detailedMessages[summary.MessageCount : len(ab.detailedMessages)-RECENT_MESSAGES_KEPT_VERBATIM]
+		updatedSummary, err := ab.summarize(ctx, summary, messagesToSummarize)
+		if err == nil {
+			summary = updatedSummary
+			ab.updatedSummary = &updatedSummary
+		}
+	}
+
+	return conversation{
+		summary: summary.Text,
+		lines:   conversationLines(ab.detailedMessages[summary.MessageCount:]),
+	}
+}
+
+func (ab *aiBot) summarize(ctx context.Context, previousSummary model.ConversationSummary, detailedMessages []model.DetailedMessage) (model.ConversationSummary, error) {
+	prompt, err := ab.prompts.Render(ab.promptVersion, prompts.SUMMARY_TEMPLATE, prompts.PromptData{
+		BotNames:            append(ab.allBotNames, ab.name),
+		MyBotName:           ab.name,
+		ConversationSummary: previousSummary.Text,
+		ConversationSoFar:   strings.Join(conversationLines(detailedMessages), "\n"),
+	})
+	if err != nil {
+		return model.ConversationSummary{}, err
+	}
+
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, prompt)
+	if err != nil {
+		return model.ConversationSummary{}, err
+	}
+
+	summaryText := strings.TrimSpace(completion.Text)
+	if utilities.IsBlank(summaryText) {
+		return model.ConversationSummary{}, errors.New("empty conversation summary")
+	}
+
+	return model.ConversationSummary{
+		Text:         summaryText,
+		MessageCount: previousSummary.MessageCount + int64(len(detailedMessages)),
+	}, nil
+}
+
+func promptTokenBudget() int64 {
+	modelLimit := openai.PromptTokenLimit(openai.COMPLETION_MODEL)
+	if modelLimit < PROMPT_TOKEN_BUDGET {
+		return modelLimit
+	}
+	return PROMPT_TOKEN_BUDGET
+}
+
+func conversationLines(detailedMessages []model.DetailedMessage) []string {
+	lines := []string{}
+	for _, detailedMessage := range detailedMessages {
+		prefix := detailedMessage.SourceBotName
+		if detailedMessage.MessageType == "reaction" {
+			lines = append(lines, fmt.Sprintf("(%s reacts to %s with %s)", prefix, detailedMessage.TargetBotName, detailedMessage.Text))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", prefix, detailedMessage.Text))
+	}
+	return lines
+}
@@ -0,0 +1,187 @@
+package aibot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+type recordingClient struct {
+	openai.MockTokenCounter
+	text    string
+	err     error
+	prompts []string
+}
+
+func (c *recordingClient) CallCompletionApi(ctx context.Context, prompt string) (*openai.Completion, error) {
+	c.prompts = append(c.prompts, prompt)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &openai.Completion{Text: c.text, Model: "text-davinci-003"}, nil
+}
+
+func detailedMessagesForTest(count int, text string) []model.DetailedMessage {
+	detailedMessages := []model.DetailedMessage{}
+	for i := 0; i < count; i++ {
+		detailedMessages = append(detailedMessages, model.DetailedMessage{
+			SourceBotName: fmt.Sprintf("bot%d", i%5+1),
+			Text:          fmt.Sprintf("%s %d", text, i+1),
+			CreatedAt:     time.Now().Add(time.Duration(i) * time.Second),
+		})
+	}
+	return detailedMessages
+}
+
+func Test_Conversation_Trim(t *testing.T) {
+	c := conversation{summary: "summary", lines: []string{"line1", "line2", "line3"}}
+
+	assert.True(t, c.trim())
+	assert.Equal(t, conversation{summary: "summary", lines: []string{"line2", "line3"}}, c)
+	assert.True(t, c.trim())
+	assert.Equal(t, conversation{summary: "summary", lines: []string{"line3"}}, c)
+	assert.True(t, c.trim())
+	assert.Equal(t, conversation{summary: "", lines: []string{"line3"}}, c)
+	assert.False(t, c.trim())
+	assert.Equal(t, conversation{summary: "", lines: []string{"line3"}}, c)
+}
+
+func Test_BuildConversation(t *testing.T) {
+	tests := []struct {
+		name                   string
+		messageCount           int
+		cachedSummary          model.ConversationSummary
+		client                 *recordingClient
+		expectedSummary        string
+		expectedLineCount      int
+		expectedUpdatedSummary *model.ConversationSummary
+		expectedClientCalls    int
+	}{
+		{
+			name:                   "keeps a short conversation verbatim",
+			messageCount:           RECENT_MESSAGES_KEPT_VERBATIM + MESSAGES_PER_SUMMARY_UPDATE,
+			cachedSummary:          model.ConversationSummary{},
+			client:                 &recordingClient{text: "unused"},
+			expectedSummary:        "",
+			expectedLineCount:      RECENT_MESSAGES_KEPT_VERBATIM + MESSAGES_PER_SUMMARY_UPDATE,
+			expectedUpdatedSummary: nil,
+			expectedClientCalls:    0,
+		},
+		{
+			name:                   "summarises older messages once enough have built up",
+			messageCount:           RECENT_MESSAGES_KEPT_VERBATIM + MESSAGES_PER_SUMMARY_UPDATE + 1,
+			cachedSummary:          model.ConversationSummary{},
+			client:                 &recordingClient{text: " bots talked about food. "},
+			expectedSummary:        "bots talked about food.",
+			expectedLineCount:      RECENT_MESSAGES_KEPT_VERBATIM,
+			expectedUpdatedSummary: &model.ConversationSummary{Text: "bots talked about food.", MessageCount: MESSAGES_PER_SUMMARY_UPDATE + 1},
+			expectedClientCalls:    1,
+		},
+		{
+			name:                   "uses the cached summary while few messages have been added since",
+			messageCount:           20,
+			cachedSummary:          model.ConversationSummary{Text: "bots talked about food.", MessageCount: 8},
+			client:                 &recordingClient{text: "unused"},
+			expectedSummary:        "bots talked about food.",
+			expectedLineCount:      12,
+			expectedUpdatedSummary: nil,
+			expectedClientCalls:    0,
+		},
+		{
+			name:                   "keeps unsummarised messages verbatim if summarising fails",
+			messageCount:           20,
+			cachedSummary:          model.ConversationSummary{Text: "bots talked about food.", MessageCount: 2},
+			client:                 &recordingClient{err: errors.New("Open Ai error")},
+			expectedSummary:        "bots talked about food.",
+			expectedLineCount:      18,
+			expectedUpdatedSummary: nil,
+			expectedClientCalls:    1,
+		},
+		{
+			name:                   "ignores a cached summary that covers more messages than exist",
+			messageCount:           3,
+			cachedSummary:          model.ConversationSummary{Text: "bots talked about food.", MessageCount: 8},
+			client:                 &recordingClient{text: "unused"},
+			expectedSummary:        "",
+			expectedLineCount:      3,
+			expectedUpdatedSummary: nil,
+			expectedClientCalls:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := prompts.LoadRegistry("")
+			assert.NoError(t, err)
+
+			ab := &aiBot{
+				name:                "bot1",
+				detailedMessages:    detailedMessagesForTest(tt.messageCount, "message"),
+				conversationSummary: tt.cachedSummary,
+				allBotNames:         []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+				openAiClient:        tt.client,
+				prompts:             registry,
+				promptVersion:       prompts.DEFAULT_VERSION,
+			}
+
+			conversation := ab.buildConversation(context.Background())
+			assert.Equal(t, tt.expectedSummary, conversation.summary)
+			assert.Len(t, conversation.lines, tt.expectedLineCount)
+			assert.Equal(t, tt.expectedUpdatedSummary, ab.updatedSummary)
+			assert.Len(t, tt.client.prompts, tt.expectedClientCalls)
+		})
+	}
+}
+
+func Test_RenderPromptWithinBudget(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	longText := strings.Repeat("lorem ipsum dolor sit amet ", 20)
+	ab := &aiBot{
+		name:                "bot1",
+		detailedMessages:    detailedMessagesForTest(12, longText),
+		conversationSummary: model.ConversationSummary{Text: "bots talked about food.", MessageCount: 2},
+		allBotNames:         []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		openAiClient:        &recordingClient{text: "unused"},
+		prompts:             registry,
+		promptVersion:       prompts.DEFAULT_VERSION,
+	}
+
+	prompt, err := ab.renderPromptWithinBudget(context.Background(), prompts.ANSWER_TEMPLATE)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, ab.openAiClient.CountTokens(prompt), int64(PROMPT_TOKEN_BUDGET))
+	assert.Contains(t, prompt, "bots talked about food.", "summary should be kept while there are older messages to drop")
+	assert.NotContains(t, prompt, fmt.Sprintf("%s %d", longText, 3), "oldest message should be dropped")
+	assert.Contains(t, prompt, fmt.Sprintf("%s %d", longText, 12), "latest message should be kept")
+}
+
+func Test_RenderPromptWithinBudget_DropsSummaryLast(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	longText := strings.Repeat("lorem ipsum dolor sit amet ", PROMPT_TOKEN_BUDGET/5)
+	ab := &aiBot{
+		name:                "bot1",
+		detailedMessages:    detailedMessagesForTest(3, longText),
+		conversationSummary: model.ConversationSummary{Text: "bots talked about food.", MessageCount: 1},
+		allBotNames:         []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		openAiClient:        &recordingClient{text: "unused"},
+		prompts:             registry,
+		promptVersion:       prompts.DEFAULT_VERSION,
+	}
+
+	prompt, err := ab.renderPromptWithinBudget(context.Background(), prompts.ANSWER_TEMPLATE)
+	assert.NoError(t, err)
+	assert.NotContains(t, prompt, "bots talked about food.", "summary should be dropped once only the latest message is left")
+	assert.NotContains(t, prompt, fmt.Sprintf("%s %d", longText, 2), "older message should be dropped")
+	assert.Contains(t, prompt, fmt.Sprintf("%s %d", longText, 3), "latest message should be kept")
+}
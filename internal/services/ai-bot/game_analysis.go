@@ -0,0 +1,204 @@
+package aibot
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+const MAX_TELLS_PER_BOT = 2
+
+// Humans usually take a while to type, while AI bots reply as soon as they are done waiting.
+const SLOW_RESPONSE_TIME = 20 * time.Second
+const FAST_RESPONSE_TIME = 5 * time.Second
+
+const NEUTRAL_HUMANNESS_SCORE = 50
+
+var humannessScoreRegex = regexp.MustCompile(`(?i)score\s*:\s*(\d+)`)
+
+type GameAnalyzer interface {
+	GetGameAnalysis(ctx context.Context) model.GameAnalysis
+}
+
+type gameAnalyzer struct {
+	game          *model.Game
+	openAiClient  openai.Client
+	prompts       *prompts.Registry
+	promptVersion string
+}
+
+// NewGameAnalyzer does not need BotId, since every bot in the game is analyzed.
+func NewGameAnalyzer(opts AiBotOptions) GameAnalyzer {
+	if opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
+		return nil
+	}
+
+	return &gameAnalyzer{
+		game:          opts.Game,
+		openAiClient:  opts.OpenAiClient,
+		prompts:       opts.Prompts,
+		promptVersion: promptVersionForGame(opts.Game, opts.Prompts),
+	}
+}
+
+// GetGameAnalysis scores every bot using simple features of its messages, averaged with the AI's opinion whenever the
+// AI gives one.
+func (ga *gameAnalyzer) GetGameAnalysis(ctx context.Context) model.GameAnalysis {
+	detailedMessages := ga.game.GetDetailedMessages()
+	botAnalyses := []model.BotAnalysis{}
+	for _, bot := range ga.game.Bots() {
+		botMessages := []model.DetailedMessage{}
+		for _, detailedMessage := range detailedMessages {
+			if detailedMessage.SourceBotId == bot.Id() && isAnalyzedMessageType(detailedMessage.MessageType) {
+				botMessages = append(botMessages, detailedMessage)
+			}
+		}
+		botAnalyses = append(botAnalyses, ga.analyzeBot(ctx, bot, botMessages))
+	}
+
+	return model.GameAnalysis{
+		GameId:      ga.game.Id(),
+		BotAnalyses: botAnalyses,
+	}
+}
+
+func (ga *gameAnalyzer) analyzeBot(ctx context.Context, bot *model.Bot, botMessages []model.DetailedMessage) model.BotAnalysis {
+	if len(botMessages) == 0 {
+		return model.BotAnalysis{BotId: bot.Id(), HumannessScore: NEUTRAL_HUMANNESS_SCORE, Tells: []string{}}
+	}
+
+	messageScores := []int64{}
+	totalScore := int64(0)
+	for _, botMessage := range botMessages {
+		score := messageHumanness(botMessage)
+		messageScores = append(messageScores, score)
+		totalScore += score
+	}
+	humannessScore := totalScore / int64(len(botMessages))
+
+	aiScore, ok := ga.aiHumannessScore(ctx, bot.Name(), botMessages)
+	if ok {
+		humannessScore = (humannessScore + aiScore) / 2
+	}
+
+	return model.BotAnalysis{
+		BotId:          bot.Id(),
+		HumannessScore: humannessScore,
+		Tells:          tells(bot.IsHuman(), botMessages, messageScores),
+	}
+}
+
+func (ga *gameAnalyzer) aiHumannessScore(ctx context.Context, botName string, botMessages []model.DetailedMessage) (int64, bool) {
+	lines := []string{}
+	for _, botMessage := range botMessages {
+		lines = append(lines, botMessage.Text)
+	}
+
+	prompt, err := ga.prompts.Render(ga.promptVersion, prompts.HUMANNESS_TEMPLATE, prompts.PromptData{
+		MyBotName:         botName,
+		ConversationSoFar: strings.Join(lines, "\n"),
+	})
+	if err != nil {
+		return 0, false
+	}
+
+	completion, err := ga.openAiClient.CallCompletionApi(ctx, prompt)
+	if err != nil {
+		return 0, false
+	}
+	return parseHumannessScore(completion.Text)
+}
+
+func parseHumannessScore(completionText string) (int64, bool) {
+	match := humannessScoreRegex.FindStringSubmatch(completionText)
+	if match == nil {
+		return 0, false
+	}
+	score, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || score > 100 {
+		return 0, false
+	}
+	return score, true
+}
+
+// messageHumanness scores a single message from 0 to 100 on how short, unpolished and slow it was.
+func messageHumanness(detailedMessage model.DetailedMessage) int64 {
+	score := int64(NEUTRAL_HUMANNESS_SCORE)
+	text := strings.TrimSpace(detailedMessage.Text)
+
+	wordCount := len(strings.Fields(text))
+	if wordCount <= 3 {
+		score += 15
+	} else if wordCount >= 10 {
+		score -= 15
+	}
+
+	if isPolished(text) {
+		score -= 15
+	} else {
+		score += 15
+	}
+
+	if detailedMessage.ResponseTime >= SLOW_RESPONSE_TIME {
+		score += 20
+	} else if detailedMessage.ResponseTime > 0 && detailedMessage.ResponseTime <= FAST_RESPONSE_TIME {
+		score -= 20
+	}
+
+	return score
+}
+
+// isPolished is true for text that starts with a capital letter and ends with punctuation, the way AI bots write.
+func isPolished(text string) bool {
+	if utilities.IsBlank(text) {
+		return false
+	}
+	runes := []rune(text)
+	return unicode.IsUpper(runes[0]) && strings.ContainsRune(".?!", runes[len(runes)-1])
+}
+
+// tells are the messages that point the most towards what the bot really was. Those are the most human sounding
+// messages of a human, and the least human sounding messages of an AI bot.
+func tells(isHuman bool, botMessages []model.DetailedMessage, messageScores []int64) []string {
+	indexes := []int{}
+	for i, score := range messageScores {
+		if (isHuman && score > NEUTRAL_HUMANNESS_SCORE) || (!isHuman && score < NEUTRAL_HUMANNESS_SCORE) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if isHuman {
+			return messageScores[indexes[i]] > messageScores[indexes[j]]
+		}
+		return messageScores[indexes[i]] < messageScores[indexes[j]]
+	})
+
+	texts := []string{}
+	for _, index := range indexes {
+		if len(texts) == MAX_TELLS_PER_BOT {
+			break
+		}
+		texts = append(texts, botMessages[index].Text)
+	}
+	return texts
+}
+
+// Passes and reactions say too little about how a bot writes.
+func isAnalyzedMessageType(messageType string) bool {
+	switch messageType {
+	case "question", "answer", "follow_up", "chat", "accusation":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package aibot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+func Test_GetGameAnalysis(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	player, err := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+	assert.NoError(t, err)
+	bots := []*model.Bot{}
+	for _, botOpts := range []model.BotOptions{
+		{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"},
+		{Id: "bot_id2", Name: "bot2", TypeOfBot: "HUMAN", ConnectedPlayer: player},
+		{Id: "bot_id3", Name: "bot3", TypeOfBot: "AI"},
+		{Id: "bot_id4", Name: "bot4", TypeOfBot: "AI"},
+		{Id: "bot_id5", Name: "bot5", TypeOfBot: "AI"},
+	} {
+		bot, err := model.NewBot(botOpts)
+		assert.NoError(t, err)
+		bots = append(bots, bot)
+	}
+
+	message := func(botId, text, messageType string, responseTime time.Duration, i int) *model.Message {
+		return &model.Message{
+			SourceBotId:  botId,
+			TargetBotId:  botId,
+			Text:         text,
+			MessageType:  messageType,
+			ResponseTime: responseTime,
+			CreatedAt:    time.Now().Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	game, err := model.NewGame(model.GameOptions{
+		Id:        "game_id1",
+		State:     "FINISHED",
+		TurnOrder: []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+		Bots:      bots,
+		Messages: []*model.Message{
+			message("bot_id1", "I really enjoy listening to classical music in the evenings.", "answer", 2*time.Second, 1),
+			message("bot_id1", "Sure", "answer", 12*time.Second, 2),
+			message("bot_id2", "lol nope", "answer", 25*time.Second, 3),
+			message("bot_id2", "What is your favourite food?", "answer", 12*time.Second, 4),
+			message("bot_id2", "idk", "pass", 40*time.Second, 5),
+			message("bot_id3", "Pizza.", "answer", 12*time.Second, 6),
+		},
+	})
+	assert.NoError(t, err)
+
+	t.Run("averages the local score with the AI score", func(t *testing.T) {
+		client := &recordingClient{text: "Score: 90"}
+		analysis := NewGameAnalyzer(AiBotOptions{Game: game, OpenAiClient: client, Prompts: registry}).GetGameAnalysis(context.Background())
+		assert.Equal(t, "game_id1", analysis.GameId)
+		assert.Equal(t, []model.BotAnalysis{
+			{BotId: "bot_id1", HumannessScore: 65, Tells: []string{"I really enjoy listening to classical music in the evenings."}},
+			{BotId: "bot_id2", HumannessScore: 78, Tells: []string{"lol nope"}},
+			{BotId: "bot_id3", HumannessScore: 70, Tells: []string{}},
+			{BotId: "bot_id4", HumannessScore: 50, Tells: []string{}},
+			{BotId: "bot_id5", HumannessScore: 50, Tells: []string{}},
+		}, analysis.BotAnalyses)
+		assert.Len(t, client.prompts, 3)
+		assert.Contains(t, client.prompts[1], "These are all the messages that bot2 sent:\nlol nope\nWhat is your favourite food?\nLooking only")
+	})
+
+	t.Run("falls back to the local score if the AI fails", func(t *testing.T) {
+		client := &recordingClient{err: errors.New("Open Ai error")}
+		analysis := NewGameAnalyzer(AiBotOptions{Game: game, OpenAiClient: client, Prompts: registry}).GetGameAnalysis(context.Background())
+		assert.Equal(t, int64(40), analysis.BotAnalyses[0].HumannessScore)
+		assert.Equal(t, int64(67), analysis.BotAnalyses[1].HumannessScore)
+		assert.Equal(t, int64(50), analysis.BotAnalyses[2].HumannessScore)
+	})
+}
+
+func Test_parseHumannessScore(t *testing.T) {
+	score, ok := parseHumannessScore(" score: 72\nbecause of the typos")
+	assert.True(t, ok)
+	assert.Equal(t, int64(72), score)
+
+	_, ok = parseHumannessScore("Score: 120")
+	assert.False(t, ok)
+
+	_, ok = parseHumannessScore("probably human")
+	assert.False(t, ok)
+}
@@ -0,0 +1,101 @@
+package aibot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type AiHelpSuggester interface {
+	GetHelpSuggestions(ctx context.Context) AiHelpSuggestions
+}
+
+// AiHelpSuggestions has a suggestion for each help style that could be generated. It has none when every attempt
+// failed, so that the caller can avoid charging for help that was never given.
+// ConversationSummary is only set when the summary of older turns was updated, and should then be cached on the game.
+type AiHelpSuggestions struct {
+	Suggestions         []model.HelpSuggestion
+	ConversationSummary *model.ConversationSummary
+}
+
+type aiHelpSuggester struct {
+	aiBot
+	templateName string
+}
+
+// NewAiHelpSuggester returns nil unless the game is waiting on the human bot for a question or an answer.
+func NewAiHelpSuggester(opts AiBotOptions) AiHelpSuggester {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
+		return nil
+	}
+
+	humanBot := opts.Game.BotWithId(opts.BotId)
+	if humanBot == nil {
+		return nil
+	}
+
+	var templateName string
+	if opts.Game.IsInStateWaitingForHumanQuestion() {
+		templateName = prompts.QUESTION_TEMPLATE
+		if len(opts.Game.GetDetailedMessages()) == 0 {
+			templateName = prompts.FIRST_QUESTION_TEMPLATE
+		}
+	} else if opts.Game.IsInStateWaitingForHumanAnswer() {
+		templateName = prompts.ANSWER_TEMPLATE
+	} else {
+		return nil
+	}
+
+	return &aiHelpSuggester{
+		aiBot: aiBot{
+			name:                humanBot.Name(),
+			statedFacts:         humanBot.StatedFacts(),
+			isAi:                humanBot.IsAi(),
+			playingHuman:        opts.Game.IsDecoy(humanBot.Id()),
+			detailedMessages:    opts.Game.GetDetailedMessages(),
+			conversationSummary: opts.Game.ConversationSummary(),
+			allBotNames:         opts.Game.GetBotNames(),
+			imitatingHumans:     opts.Game.AiImitatesHumans(),
+			openAiClient:        opts.OpenAiClient,
+			prompts:             opts.Prompts,
+			promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
+		},
+		templateName: templateName,
+	}
+}
+
+// GetHelpSuggestions generates a suggestion in each of model.HELP_STYLES, skipping the styles that fail.
+func (hs *aiHelpSuggester) GetHelpSuggestions(ctx context.Context) AiHelpSuggestions {
+	suggestions := []model.HelpSuggestion{}
+	for _, style := range model.HELP_STYLES {
+		hs.helpStyle = style
+		text, err := hs.suggest(ctx)
+		if err == nil && !utilities.IsBlank(text) {
+			suggestions = append(suggestions, model.HelpSuggestion{Style: style, Text: text})
+		}
+		if hs.updatedSummary != nil {
+			// Every style shares the conversation, so it only needs summarizing once.
+			hs.conversationSummary = *hs.updatedSummary
+		}
+	}
+
+	return AiHelpSuggestions{
+		Suggestions:         suggestions,
+		ConversationSummary: hs.updatedSummary,
+	}
+}
+
+func (hs *aiHelpSuggester) suggest(ctx context.Context) (string, error) {
+	openAiPrompt, err := hs.renderPromptWithinBudget(ctx, hs.templateName)
+	if err != nil {
+		return "", err
+	}
+	completion, err := hs.openAiClient.CallCompletionApi(ctx, openAiPrompt)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(completion.Text), nil
+}
@@ -0,0 +1,129 @@
+package aibot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+// evasiveFailingClient fails for every prompt that asks for an evasive message.
+type evasiveFailingClient struct {
+	recordingClient
+}
+
+func (c *evasiveFailingClient) CallCompletionApi(ctx context.Context, prompt string) (*openai.Completion, error) {
+	if strings.Contains(prompt, "Keep it vague") {
+		return nil, errors.New("Open Ai error")
+	}
+	return c.recordingClient.CallCompletionApi(context.Background(), prompt)
+}
+
+func Test_GetHelpSuggestions(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	newSuggester := func(client openai.Client) *aiHelpSuggester {
+		return &aiHelpSuggester{
+			aiBot: aiBot{
+				name:             "bot2",
+				detailedMessages: detailedMessagesForTest(2, "do you like pineapple on pizza"),
+				allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+				openAiClient:     client,
+				prompts:          registry,
+				promptVersion:    prompts.DEFAULT_VERSION,
+			},
+			templateName: prompts.ANSWER_TEMPLATE,
+		}
+	}
+
+	t.Run("suggests a message in every style", func(t *testing.T) {
+		client := &recordingClient{text: " Only on Fridays "}
+		helpSuggestions := newSuggester(client).GetHelpSuggestions(context.Background())
+		assert.Equal(t, []model.HelpSuggestion{
+			{Style: "casual", Text: "Only on Fridays"},
+			{Style: "terse", Text: "Only on Fridays"},
+			{Style: "evasive", Text: "Only on Fridays"},
+		}, helpSuggestions.Suggestions)
+		assert.Nil(t, helpSuggestions.ConversationSummary)
+		assert.Len(t, client.prompts, 3)
+		assert.Contains(t, client.prompts[0], "Keep it casual and friendly")
+		assert.Contains(t, client.prompts[1], "Keep it as short as possible.")
+		assert.Contains(t, client.prompts[2], "Keep it vague")
+	})
+
+	t.Run("skips the styles that fail", func(t *testing.T) {
+		client := &evasiveFailingClient{recordingClient{text: "Only on Fridays"}}
+		helpSuggestions := newSuggester(client).GetHelpSuggestions(context.Background())
+		assert.Equal(t, []model.HelpSuggestion{
+			{Style: "casual", Text: "Only on Fridays"},
+			{Style: "terse", Text: "Only on Fridays"},
+		}, helpSuggestions.Suggestions)
+	})
+
+	t.Run("suggests nothing if the AI fails", func(t *testing.T) {
+		helpSuggestions := newSuggester(&recordingClient{err: errors.New("Open Ai error")}).GetHelpSuggestions(context.Background())
+		assert.Empty(t, helpSuggestions.Suggestions)
+	})
+
+	t.Run("summarizes the conversation only once for every style", func(t *testing.T) {
+		client := &openai.MockClientSequence{Texts: []string{"the summary", "Only on Fridays"}}
+		suggester := newSuggester(client)
+		suggester.detailedMessages = detailedMessagesForTest(RECENT_MESSAGES_KEPT_VERBATIM+MESSAGES_PER_SUMMARY_UPDATE+1, "do you like pineapple on pizza")
+		helpSuggestions := suggester.GetHelpSuggestions(context.Background())
+		assert.Len(t, helpSuggestions.Suggestions, 3)
+		assert.Equal(t, &model.ConversationSummary{Text: "the summary", MessageCount: MESSAGES_PER_SUMMARY_UPDATE + 1}, helpSuggestions.ConversationSummary)
+		for _, suggestion := range helpSuggestions.Suggestions {
+			assert.Equal(t, "Only on Fridays", suggestion.Text)
+		}
+	})
+}
+
+func Test_NewAiHelpSuggester(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	player, err := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+	assert.NoError(t, err)
+	bots := []*model.Bot{}
+	for _, botOpts := range []model.BotOptions{
+		{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"},
+		{Id: "bot_id2", Name: "bot2", TypeOfBot: "HUMAN", ConnectedPlayer: player},
+		{Id: "bot_id3", Name: "bot3", TypeOfBot: "AI"},
+		{Id: "bot_id4", Name: "bot4", TypeOfBot: "AI"},
+		{Id: "bot_id5", Name: "bot5", TypeOfBot: "AI"},
+	} {
+		bot, err := model.NewBot(botOpts)
+		assert.NoError(t, err)
+		bots = append(bots, bot)
+	}
+
+	newGame := func(state string) *model.Game {
+		game, err := model.NewGame(model.GameOptions{
+			Id:        "game_id1",
+			State:     state,
+			TurnOrder: []string{"bot_id2", "bot_id1", "bot_id3", "bot_id4", "bot_id5"},
+			Bots:      bots,
+		})
+		assert.NoError(t, err)
+		return game
+	}
+
+	t.Run("suggests the first question when nothing has been said yet", func(t *testing.T) {
+		client := &recordingClient{text: "Cats or dogs?"}
+		suggester := NewAiHelpSuggester(AiBotOptions{BotId: "bot_id2", Game: newGame("WAITING_FOR_HUMAN_QUESTION"), OpenAiClient: client, Prompts: registry})
+		assert.NotNil(t, suggester)
+		assert.Len(t, suggester.GetHelpSuggestions(context.Background()).Suggestions, 3)
+		assert.Contains(t, client.prompts[0], "Provide a question o the topic of")
+	})
+
+	t.Run("is not available when the game is not waiting on a human", func(t *testing.T) {
+		suggester := NewAiHelpSuggester(AiBotOptions{BotId: "bot_id2", Game: newGame("WAITING_FOR_AI_QUESTION"), OpenAiClient: &recordingClient{}, Prompts: registry})
+		assert.Nil(t, suggester)
+	})
+}
@@ -0,0 +1,75 @@
+package aibot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type AiReactor interface {
+	GetReaction(ctx context.Context) *AiMessage
+}
+
+func NewAiReactor(opts AiBotOptions) AiReactor {
+	if utilities.IsBlank(opts.BotId) || opts.Game == nil || opts.OpenAiClient == nil || opts.Prompts == nil {
+		return nil
+	}
+
+	reactingBot := opts.Game.BotWithId(opts.BotId)
+	if reactingBot == nil {
+		return nil
+	}
+
+	return &aiBot{
+		name:                reactingBot.Name(),
+		statedFacts:         reactingBot.StatedFacts(),
+		isAi:                reactingBot.IsAi(),
+		playingHuman:        opts.Game.IsDecoy(reactingBot.Id()),
+		detailedMessages:    opts.Game.GetDetailedMessages(),
+		conversationSummary: opts.Game.ConversationSummary(),
+		allBotNames:         opts.Game.GetBotNames(),
+		imitatingHumans:     opts.Game.AiImitatesHumans(),
+		openAiClient:        opts.OpenAiClient,
+		prompts:             opts.Prompts,
+		promptVersion:       promptVersionForGame(opts.Game, opts.Prompts),
+	}
+}
+
+// GetReaction returns nil when the AI does not pick one of the allowed reactions. Reactions are optional, so there
+// is no fallback.
+func (ab *aiBot) GetReaction(ctx context.Context) *AiMessage {
+	openAiPrompt, err := ab.renderPromptWithinBudget(ctx, prompts.REACTION_TEMPLATE)
+	if err != nil {
+		return nil
+	}
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, openAiPrompt)
+	if err != nil {
+		return nil
+	}
+
+	reaction := parseReaction(completion.Text)
+	if utilities.IsBlank(reaction) {
+		return nil
+	}
+
+	aiMessage := ab.aiMessageFromCompletion(completion)
+	aiMessage.Text = reaction
+	return &aiMessage
+}
+
+// parseReaction picks the earliest of the allowed reactions in the completion.
+func parseReaction(completionText string) string {
+	reaction := ""
+	earliestIndex := -1
+	for _, allowedReaction := range model.REACTIONS {
+		index := strings.Index(completionText, allowedReaction)
+		if index >= 0 && (earliestIndex < 0 || index < earliestIndex) {
+			reaction = allowedReaction
+			earliestIndex = index
+		}
+	}
+	return reaction
+}
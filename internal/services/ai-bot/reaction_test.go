@@ -0,0 +1,53 @@
+package aibot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+func Test_GetReaction(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	newReactor := func(client *recordingClient) *aiBot {
+		return &aiBot{
+			name:             "bot1",
+			isAi:             true,
+			detailedMessages: detailedMessagesForTest(2, "do you like pineapple on pizza"),
+			allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+			openAiClient:     client,
+			prompts:          registry,
+			promptVersion:    prompts.DEFAULT_VERSION,
+		}
+	}
+
+	t.Run("reacts with the allowed reaction the AI picked", func(t *testing.T) {
+		client := &recordingClient{text: " 😂 lol"}
+		reaction := newReactor(client).GetReaction(context.Background())
+		assert.NotNil(t, reaction)
+		assert.Equal(t, "😂", reaction.Text)
+		assert.Equal(t, prompts.DEFAULT_VERSION, reaction.PromptVersion)
+		assert.Len(t, client.prompts, 1)
+		assert.Contains(t, client.prompts[0], "👍 👎 😂 😮 🤔 ❤️")
+	})
+
+	t.Run("does not react if the AI picks none of the allowed reactions", func(t *testing.T) {
+		reaction := newReactor(&recordingClient{text: "None"}).GetReaction(context.Background())
+		assert.Nil(t, reaction)
+	})
+
+	t.Run("does not react if the AI fails", func(t *testing.T) {
+		reaction := newReactor(&recordingClient{err: errors.New("Open Ai error")}).GetReaction(context.Background())
+		assert.Nil(t, reaction)
+	})
+}
+
+func Test_parseReaction(t *testing.T) {
+	assert.Equal(t, "🤔", parseReaction("🤔 or maybe 👍"))
+	assert.Equal(t, "❤️", parseReaction("❤️"))
+	assert.Equal(t, "", parseReaction("meh"))
+}
@@ -0,0 +1,51 @@
+package aibot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+const MAX_STATED_FACTS_PER_MESSAGE = 3
+
+// extractStatedFacts finds the facts this bot stated about itself in text. Failing to find them is not an error,
+// since the message itself is still usable. The bot just has nothing new to stay consistent with.
+func (ab *aiBot) extractStatedFacts(ctx context.Context, text string) []string {
+	if utilities.IsBlank(text) {
+		return nil
+	}
+
+	prompt, err := ab.prompts.Render(ab.promptVersion, prompts.STATED_FACTS_TEMPLATE, prompts.PromptData{
+		BotNames:          append(ab.allBotNames, ab.name),
+		MyBotName:         ab.name,
+		MyStatedFacts:     ab.statedFacts,
+		ConversationSoFar: fmt.Sprintf("%s: %s", ab.name, strings.TrimSpace(text)),
+	})
+	if err != nil {
+		return nil
+	}
+
+	completion, err := ab.openAiClient.CallCompletionApi(ctx, prompt)
+	if err != nil {
+		return nil
+	}
+	return parseStatedFacts(completion.Text)
+}
+
+func parseStatedFacts(completionText string) []string {
+	statedFacts := []string{}
+	for _, line := range strings.Split(completionText, "\n") {
+		fact := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•0123456789.)"))
+		if utilities.IsBlank(fact) || strings.EqualFold(strings.TrimRight(fact, "."), "none") {
+			continue
+		}
+		statedFacts = append(statedFacts, fact)
+		if len(statedFacts) == MAX_STATED_FACTS_PER_MESSAGE {
+			break
+		}
+	}
+	return statedFacts
+}
@@ -0,0 +1,135 @@
+package aibot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
+)
+
+func Test_ParseStatedFacts(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOutput []string
+	}{
+		{
+			name:           "returns no facts when there are none",
+			input:          " NONE.",
+			expectedOutput: []string{},
+		},
+		{
+			name:           "strips list markers and blank lines",
+			input:          "\n- I love pizza\n2. I live in Paris\n\n* I have a dog",
+			expectedOutput: []string{"I love pizza", "I live in Paris", "I have a dog"},
+		},
+		{
+			name:           "keeps only a few facts per message",
+			input:          "I love pizza\nI live in Paris\nI have a dog\nI play chess",
+			expectedOutput: []string{"I love pizza", "I live in Paris", "I have a dog"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedOutput, parseStatedFacts(tt.input))
+		})
+	}
+}
+
+func Test_ExtractStatedFacts(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		client         *recordingClient
+		expectedOutput []string
+		expectedCalls  int
+	}{
+		{
+			name:           "does not call the client for blank text",
+			input:          " ",
+			client:         &recordingClient{text: "I love pizza"},
+			expectedOutput: nil,
+			expectedCalls:  0,
+		},
+		{
+			name:           "returns no facts if the client fails",
+			input:          "Pizza, always pizza",
+			client:         &recordingClient{err: errors.New("Open Ai error")},
+			expectedOutput: nil,
+			expectedCalls:  1,
+		},
+		{
+			name:           "returns facts stated in text",
+			input:          "Pizza, always pizza",
+			client:         &recordingClient{text: "I love pizza"},
+			expectedOutput: []string{"I love pizza"},
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := prompts.LoadRegistry("")
+			assert.NoError(t, err)
+
+			ab := &aiBot{
+				name:          "bot1",
+				isAi:          true,
+				allBotNames:   []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+				openAiClient:  tt.client,
+				prompts:       registry,
+				promptVersion: prompts.DEFAULT_VERSION,
+			}
+
+			assert.Equal(t, tt.expectedOutput, ab.extractStatedFacts(context.Background(), tt.input))
+			assert.Len(t, tt.client.prompts, tt.expectedCalls)
+			if tt.expectedCalls > 0 {
+				assert.Contains(t, tt.client.prompts[0], "bot1: Pizza, always pizza")
+			}
+		})
+	}
+}
+
+func Test_GetNextAnswer_StatedFacts(t *testing.T) {
+	registry, err := prompts.LoadRegistry("")
+	assert.NoError(t, err)
+
+	t.Run("sends facts stated earlier and returns new ones for an AI bot", func(t *testing.T) {
+		client := &recordingClient{text: "I love pizza"}
+		ab := &aiBot{
+			name:             "bot1",
+			isAi:             true,
+			statedFacts:      []string{"I live in Paris"},
+			detailedMessages: detailedMessagesForTest(1, "what do you eat"),
+			allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+			openAiClient:     client,
+			prompts:          registry,
+			promptVersion:    prompts.DEFAULT_VERSION,
+		}
+
+		aiMessage := ab.GetNextAnswer(context.Background())
+		assert.Equal(t, []string{"I love pizza"}, aiMessage.StatedFacts)
+		assert.Len(t, client.prompts, 2)
+		assert.Contains(t, client.prompts[0], "- I live in Paris")
+	})
+
+	t.Run("does not look for stated facts for a human bot", func(t *testing.T) {
+		client := &recordingClient{text: "I love pizza"}
+		ab := &aiBot{
+			name:             "bot1",
+			isAi:             false,
+			detailedMessages: detailedMessagesForTest(1, "what do you eat"),
+			allBotNames:      []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+			openAiClient:     client,
+			prompts:          registry,
+			promptVersion:    prompts.DEFAULT_VERSION,
+		}
+
+		aiMessage := ab.GetNextAnswer(context.Background())
+		assert.Nil(t, aiMessage.StatedFacts)
+		assert.Len(t, client.prompts, 1)
+	})
+}
@@ -0,0 +1,66 @@
+package prompts
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type experimentVariant struct {
+	version string
+	weight  int
+}
+
+// Experiment splits new games between prompt versions.
+type Experiment struct {
+	variants    []experimentVariant
+	totalWeight int
+}
+
+// NewExperiment parses a split such as "v1:80,v2:20". A blank split sends every game to DEFAULT_VERSION.
+func NewExperiment(split string, registry *Registry) (*Experiment, error) {
+	if registry == nil {
+		return nil, errors.New("registry is required")
+	}
+
+	if utilities.IsBlank(split) {
+		split = DEFAULT_VERSION + ":1"
+	}
+
+	experiment := Experiment{}
+	for _, variantStr := range strings.Split(split, ",") {
+		parts := strings.Split(strings.TrimSpace(variantStr), ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid experiment variant %s", variantStr)
+		}
+
+		version := strings.TrimSpace(parts[0])
+		if !registry.HasVersion(version) {
+			return nil, errors.Errorf("unknown prompt version %s", version)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || weight <= 0 {
+			return nil, errors.Errorf("invalid weight for prompt version %s", version)
+		}
+
+		experiment.variants = append(experiment.variants, experimentVariant{version: version, weight: weight})
+		experiment.totalWeight += weight
+	}
+
+	return &experiment, nil
+}
+
+func (e *Experiment) PickVersion() string {
+	pick := rand.Intn(e.totalWeight)
+	for _, variant := range e.variants {
+		if pick < variant.weight {
+			return variant.version
+		}
+		pick -= variant.weight
+	}
+	return e.variants[len(e.variants)-1].version
+}
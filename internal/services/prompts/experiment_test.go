@@ -0,0 +1,99 @@
+package prompts
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewExperiment(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		output        *Experiment
+		errorExpected bool
+		errorString   string
+	}{
+		{
+			name:  "defaults to a single variant when split is blank",
+			input: "",
+			output: &Experiment{
+				variants:    []experimentVariant{{version: "v1", weight: 1}},
+				totalWeight: 1,
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+		{
+			name:  "parses a weighted split",
+			input: "v1:80, v2:20",
+			output: &Experiment{
+				variants:    []experimentVariant{{version: "v1", weight: 80}, {version: "v2", weight: 20}},
+				totalWeight: 100,
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+		{
+			name:          "errors if variant is malformed",
+			input:         "v1",
+			output:        nil,
+			errorExpected: true,
+			errorString:   "invalid experiment variant v1",
+		},
+		{
+			name:          "errors if version is not in registry",
+			input:         "v1:50,v3:50",
+			output:        nil,
+			errorExpected: true,
+			errorString:   "unknown prompt version v3",
+		},
+		{
+			name:          "errors if weight is not positive",
+			input:         "v1:0",
+			output:        nil,
+			errorExpected: true,
+			errorString:   "invalid weight for prompt version v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewRegistry(validTemplatesFs())
+			assert.NoError(t, err)
+
+			experiment, err := NewExperiment(tt.input, registry)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, experiment)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
+
+func Test_Experiment_PickVersion(t *testing.T) {
+	t.Run("picks the only variant", func(t *testing.T) {
+		experiment := &Experiment{
+			variants:    []experimentVariant{{version: "v2", weight: 3}},
+			totalWeight: 3,
+		}
+		assert.Equal(t, "v2", experiment.PickVersion())
+	})
+	t.Run("picks variants according to their weights", func(t *testing.T) {
+		rand.Seed(0)
+		experiment := &Experiment{
+			variants:    []experimentVariant{{version: "v1", weight: 1}, {version: "v2", weight: 3}},
+			totalWeight: 4,
+		}
+		counts := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			counts[experiment.PickVersion()]++
+		}
+		assert.InDelta(t, 250, counts["v1"], 50)
+		assert.InDelta(t, 750, counts["v2"], 50)
+	})
+}
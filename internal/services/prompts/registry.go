@@ -0,0 +1,133 @@
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+const DEFAULT_VERSION = "v1"
+
+const FIRST_QUESTION_TEMPLATE = "first_question"
+const QUESTION_TEMPLATE = "question"
+const ANSWER_TEMPLATE = "answer"
+const SUMMARY_TEMPLATE = "summary"
+const STATED_FACTS_TEMPLATE = "stated_facts"
+const ACCUSATION_TEMPLATE = "accusation"
+const CHAT_TEMPLATE = "chat"
+const REACTION_TEMPLATE = "reaction"
+const HUMANNESS_TEMPLATE = "humanness"
+
+// The context and style templates are never rendered on their own, but the other templates include them.
+const CONTEXT_TEMPLATE = "context"
+const STYLE_TEMPLATE = "style"
+
+// requiredTemplates is listed in the Prompts section of the README, which a test keeps in step with it.
+var requiredTemplates = []string{FIRST_QUESTION_TEMPLATE, QUESTION_TEMPLATE, ANSWER_TEMPLATE, SUMMARY_TEMPLATE, STATED_FACTS_TEMPLATE, ACCUSATION_TEMPLATE, CHAT_TEMPLATE, REACTION_TEMPLATE, HUMANNESS_TEMPLATE, CONTEXT_TEMPLATE, STYLE_TEMPLATE}
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// PromptData is everything a prompt template can reference.
+type PromptData struct {
+	BotNames            []string
+	MyBotName           string
+	ImitatingHumans     bool
+	PlayingHuman        bool
+	MyStatedFacts       []string
+	Suspects            []string
+	Reactions           []string
+	ConversationSummary string
+	ConversationSoFar   string
+	Topic               string
+	Style               string
+}
+
+// Registry holds every version of the prompt templates.
+// Each version is a directory of *.tmpl files, and a template is referenced by its file name without the extension.
+type Registry struct {
+	versions map[string]*template.Template
+}
+
+// LoadRegistry loads templates from dir, or the templates built into the binary when dir is blank.
+func LoadRegistry(dir string) (*Registry, error) {
+	if utilities.IsBlank(dir) {
+		templatesFs, err := fs.Sub(defaultTemplates, "templates")
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load default prompt templates")
+		}
+		return NewRegistry(templatesFs)
+	}
+	return NewRegistry(os.DirFS(dir))
+}
+
+func NewRegistry(templatesFs fs.FS) (*Registry, error) {
+	entries, err := fs.ReadDir(templatesFs, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read prompt templates")
+	}
+
+	versions := map[string]*template.Template{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		version := entry.Name()
+		tmpl, err := template.ParseFS(templatesFs, path.Join(version, "*.tmpl"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse prompt templates for version %s", version)
+		}
+		for _, name := range requiredTemplates {
+			if tmpl.Lookup(templateFileName(name)) == nil {
+				return nil, errors.Errorf("prompt template %s is missing for version %s", name, version)
+			}
+		}
+		versions[version] = tmpl
+	}
+
+	if len(versions) == 0 {
+		return nil, errors.New("no prompt template versions found")
+	}
+
+	return &Registry{versions: versions}, nil
+}
+
+func (r *Registry) HasVersion(version string) bool {
+	_, ok := r.versions[version]
+	return ok
+}
+
+func (r *Registry) Versions() []string {
+	versions := []string{}
+	for version := range r.versions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func (r *Registry) Render(version, name string, data PromptData) (string, error) {
+	tmpl, ok := r.versions[version]
+	if !ok {
+		return "", errors.Errorf("unknown prompt version %s", version)
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, templateFileName(name), data)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to render prompt %s for version %s", name, version)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func templateFileName(name string) string {
+	return name + ".tmpl"
+}
@@ -0,0 +1,241 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validTemplatesFs() fstest.MapFS {
+	return fstest.MapFS{
+		"v1/first_question.tmpl": {Data: []byte("I am {{.MyBotName}}. Ask about {{.Topic}}.\n")},
+		"v1/question.tmpl":       {Data: []byte("Ask after {{.ConversationSoFar}}\n")},
+		"v1/answer.tmpl":         {Data: []byte("Answer after {{.ConversationSoFar}}\n")},
+		"v1/summary.tmpl":        {Data: []byte("Summarise {{.ConversationSoFar}}\n")},
+		"v1/stated_facts.tmpl":   {Data: []byte("Facts in {{.ConversationSoFar}}\n")},
+		"v1/accusation.tmpl":     {Data: []byte("Accuse one of {{.Suspects}}\n")},
+		"v1/chat.tmpl":           {Data: []byte("Chat after {{.ConversationSoFar}}\n")},
+		"v1/reaction.tmpl":       {Data: []byte("React with one of {{.Reactions}}\n")},
+		"v1/humanness.tmpl":      {Data: []byte("Score {{.MyBotName}}\n")},
+		"v1/context.tmpl":        {Data: []byte(`{{define "context"}}You are {{.MyBotName}}.{{end}}`)},
+		"v1/style.tmpl":          {Data: []byte(`{{define "style"}}{{.Style}}{{end}}`)},
+		"v2/first_question.tmpl": {Data: []byte("{{.Missing}} asks about {{.Topic}}")},
+		"v2/question.tmpl":       {Data: []byte("question")},
+		"v2/answer.tmpl":         {Data: []byte("answer")},
+		"v2/summary.tmpl":        {Data: []byte("summary")},
+		"v2/stated_facts.tmpl":   {Data: []byte("stated facts")},
+		"v2/accusation.tmpl":     {Data: []byte("accusation")},
+		"v2/chat.tmpl":           {Data: []byte("chat")},
+		"v2/reaction.tmpl":       {Data: []byte("reaction")},
+		"v2/humanness.tmpl":      {Data: []byte("humanness")},
+		"v2/context.tmpl":        {Data: []byte(`{{define "context"}}{{end}}`)},
+		"v2/style.tmpl":          {Data: []byte(`{{define "style"}}{{end}}`)},
+	}
+}
+
+func Test_NewRegistry(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         fstest.MapFS
+		output        []string
+		errorExpected bool
+		errorString   string
+	}{
+		{
+			name:          "errors if there are no versions",
+			input:         fstest.MapFS{"readme.md": {Data: []byte("nothing here")}},
+			output:        nil,
+			errorExpected: true,
+			errorString:   "no prompt template versions found",
+		},
+		{
+			name: "errors if a required template is missing",
+			input: fstest.MapFS{
+				"v1/first_question.tmpl": {Data: []byte("first question")},
+				"v1/question.tmpl":       {Data: []byte("question")},
+			},
+			output:        nil,
+			errorExpected: true,
+			errorString:   "prompt template answer is missing for version v1",
+		},
+		{
+			name: "errors if a template cannot be parsed",
+			input: fstest.MapFS{
+				"v1/first_question.tmpl": {Data: []byte("{{.Topic")},
+			},
+			output:        nil,
+			errorExpected: true,
+			errorString:   "unable to parse prompt templates for version v1: template: first_question.tmpl:1: unclosed action",
+		},
+		{
+			name:          "loads all versions successfully",
+			input:         validTemplatesFs(),
+			output:        []string{"v1", "v2"},
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewRegistry(tt.input)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, registry.Versions())
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
+
+func Test_LoadRegistry_DefaultTemplates(t *testing.T) {
+	registry, err := LoadRegistry("")
+	assert.NoError(t, err)
+	assert.True(t, registry.HasVersion(DEFAULT_VERSION))
+
+	prompt, err := registry.Render(DEFAULT_VERSION, ANSWER_TEMPLATE, PromptData{
+		BotNames:          []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		MyBotName:         "bot3",
+		ConversationSoFar: "bot1: How are you?",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "Their names are bot1, bot2, bot3, bot4 and bot5. You are bot3.")
+	assert.Contains(t, prompt, "Conversation so far is \nbot1: How are you?\n. Answer the question.\nAnswer:")
+	assert.NotContains(t, prompt, "Earlier in this conversation you said")
+
+	prompt, err = registry.Render(DEFAULT_VERSION, ANSWER_TEMPLATE, PromptData{
+		BotNames:          []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		MyBotName:         "bot3",
+		MyStatedFacts:     []string{"I love pizza", "I live in Paris"},
+		ConversationSoFar: "bot1: What do you eat?",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "Earlier in this conversation you said these things about yourself:\n- I love pizza\n- I live in Paris\n")
+
+	prompt, err = registry.Render(DEFAULT_VERSION, ANSWER_TEMPLATE, PromptData{
+		BotNames:          []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		MyBotName:         "bot3",
+		ImitatingHumans:   true,
+		ConversationSoFar: "bot1: How are you?",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "This is a laid back conversation between a bunch of humans.")
+	assert.Contains(t, prompt, "You are secretly an AI pretending to be human")
+	assert.NotContains(t, prompt, "a bunch of AI bots")
+
+	prompt, err = registry.Render(DEFAULT_VERSION, ANSWER_TEMPLATE, PromptData{
+		BotNames:          []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		MyBotName:         "bot3",
+		PlayingHuman:      true,
+		ConversationSoFar: "bot1: How are you?",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "This is a laid back conversation between a bunch of AI bots.")
+	assert.Contains(t, prompt, "Secretly, you are playing the part of the one human hiding among the bots.")
+	assert.NotContains(t, prompt, "You generally provide factual answers")
+	assert.NotContains(t, prompt, "Keep it")
+
+	prompt, err = registry.Render(DEFAULT_VERSION, QUESTION_TEMPLATE, PromptData{
+		BotNames:          []string{"bot1", "bot2", "bot3", "bot4", "bot5"},
+		MyBotName:         "bot3",
+		Style:             "terse",
+		ConversationSoFar: "bot1: How are you?",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "You are bot3. You generally provide factual answers but have a tendency to not answer some questions randomly. Keep it as short as possible. Conversation so far is")
+}
+
+func Test_Registry_Render(t *testing.T) {
+	tests := []struct {
+		name  string
+		input struct {
+			version      string
+			templateName string
+			data         PromptData
+		}
+		output        string
+		errorExpected bool
+		errorString   string
+	}{
+		{
+			name: "errors if version is unknown",
+			input: struct {
+				version      string
+				templateName string
+				data         PromptData
+			}{
+				version:      "v3",
+				templateName: ANSWER_TEMPLATE,
+			},
+			output:        "",
+			errorExpected: true,
+			errorString:   "unknown prompt version v3",
+		},
+		{
+			name: "errors if template cannot be executed",
+			input: struct {
+				version      string
+				templateName string
+				data         PromptData
+			}{
+				version:      "v2",
+				templateName: FIRST_QUESTION_TEMPLATE,
+				data:         PromptData{Topic: "Music"},
+			},
+			output:        "",
+			errorExpected: true,
+			errorString:   "unable to render prompt first_question for version v2: template: first_question.tmpl:1:2: executing \"first_question.tmpl\" at <.Missing>: can't evaluate field Missing in type prompts.PromptData",
+		},
+		{
+			name: "renders template with surrounding whitespace trimmed",
+			input: struct {
+				version      string
+				templateName string
+				data         PromptData
+			}{
+				version:      "v1",
+				templateName: FIRST_QUESTION_TEMPLATE,
+				data:         PromptData{MyBotName: "bot1", Topic: "Music"},
+			},
+			output:        "I am bot1. Ask about Music.",
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewRegistry(validTemplatesFs())
+			assert.NoError(t, err)
+
+			prompt, err := registry.Render(tt.input.version, tt.input.templateName, tt.input.data)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, prompt)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
+
+// The README is what a custom PROMPTS_DIR is built from, so it has to list every template a version needs.
+func Test_ReadmeListsRequiredTemplates(t *testing.T) {
+	readme, err := os.ReadFile("../../../README.md")
+	assert.NoError(t, err)
+
+	fileNames := []string{}
+	for _, name := range requiredTemplates {
+		fileNames = append(fileNames, fmt.Sprintf("`%s`", templateFileName(name)))
+	}
+	last := len(fileNames) - 1
+	expected := fmt.Sprintf("Every version needs %s and %s.", strings.Join(fileNames[:last], ", "), fileNames[last])
+	assert.Contains(t, string(readme), expected)
+}
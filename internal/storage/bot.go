@@ -1,27 +1,71 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 type BotAccessor interface {
-	UpdateBotWithPlayerIdUsingTransaction(botId, playerId string, transaction DatabaseTransaction) error
-	UpdateBotDecrementHelpCountUsingTransaction(botId string, transaction DatabaseTransaction) error
+	UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error
+	UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error
+	UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error
+	UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error
+	UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error
+	UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error
 }
 
-func (s *Storage) UpdateBotWithPlayerIdUsingTransaction(botId, playerId string, transaction DatabaseTransaction) error {
-	return connectPlayerToBot(transaction, playerId, botId)
+// helpCount is the number of times the player can ask for help, which depends on the game mode.
+func (s *Storage) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return connectPlayerToBot(ctx, transaction, playerId, botId, helpCount)
+}
+
+func (s *Storage) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return decrementHelpCount(ctx, transaction, botId)
+}
+
+// No suggestions clears the last help suggestionss.
+func (s *Storage) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateLastHelpSuggestions(ctx, transaction, botId, suggestions)
+}
+
+func (s *Storage) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateStatedFacts(ctx, transaction, botId, statedFacts)
+}
+
+// Records a tag used by the bot, which counts towards its tag limit and starts its tag cooldown.
+func (s *Storage) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return recordTag(ctx, transaction, botId, taggedAt)
 }
 
-func (s *Storage) UpdateBotDecrementHelpCountUsingTransaction(botId string, transaction DatabaseTransaction) error {
-	return decrementHelpCount(transaction, botId)
+func (s *Storage) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return eliminateBot(ctx, transaction, botId)
 }
 
-func connectPlayerToBot(customDb customDbHandler, playerId, botId string) error {
+func connectPlayerToBot(ctx context.Context, customDb customDbHandler, playerId, botId string, helpCount int64) error {
 	if utilities.IsBlank(botId) {
 		return errors.New("botId cannot be blank")
 	}
@@ -30,8 +74,13 @@ func connectPlayerToBot(customDb customDbHandler, playerId, botId string) error
 		return errors.New("playerId cannot be blank")
 	}
 
-	result, err := customDb.Exec(
-		`UPDATE public."bots" SET "player_id" = $1, "type" = 'HUMAN', "help_count" = 3 WHERE id = $2`, playerId, botId,
+	if helpCount < 0 {
+		return errors.New("helpCount cannot be negative")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET "player_id" = $1, "type" = 'HUMAN', "help_count" = $3 WHERE id = $2`, playerId, botId, helpCount,
 	)
 	if err != nil {
 		return utilities.WrapBadError(err, fmt.Sprintf("dbError while connecting player to bot: %s %s", playerId, botId))
@@ -46,16 +95,16 @@ func connectPlayerToBot(customDb customDbHandler, playerId, botId string) error
 		return utilities.NewBadError("No rows were affected when player was connected to Bot. This is highly unexpected.")
 	}
 
-	return nil
+	return bumpGameVersionForBot(ctx, customDb, botId)
 }
 
-func decrementHelpCount(customDb customDbHandler, botId string) error {
+func decrementHelpCount(ctx context.Context, customDb customDbHandler, botId string) error {
 	if utilities.IsBlank(botId) {
 		return errors.New("botId cannot be blank")
 	}
 
 	var helpCount int
-	row := customDb.QueryRow(`SELECT b.help_count FROM public."bots" AS b WHERE b.id = $1 FOR UPDATE OF b`, botId)
+	row := customDb.QueryRowContext(ctx, `SELECT b.help_count FROM public."bots" AS b WHERE b.id = $1 FOR UPDATE OF b`, botId)
 	err := row.Scan(&helpCount)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -68,7 +117,7 @@ func decrementHelpCount(customDb customDbHandler, botId string) error {
 		return errors.Errorf("help_count should not be updated below 0 for %s", botId)
 	}
 
-	result, err := customDb.Exec(`UPDATE public."bots" SET "help_count" = $2 WHERE id = $1`, botId, helpCount-1)
+	result, err := customDb.ExecContext(ctx, `UPDATE public."bots" SET "help_count" = $2 WHERE id = $1`, botId, helpCount-1)
 	if err != nil {
 		return utilities.WrapBadError(err, fmt.Sprintf("dbError while decrementing bot help count: %s", botId))
 	}
@@ -82,5 +131,110 @@ func decrementHelpCount(customDb customDbHandler, botId string) error {
 		return utilities.NewBadError("No rows were affected while decrementing bot help count. This is highly unexpected.")
 	}
 
-	return nil
+	return bumpGameVersionForBot(ctx, customDb, botId)
+}
+
+func updateLastHelpSuggestions(ctx context.Context, customDb customDbHandler, botId string, suggestions []string) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	var lastHelpSuggestions interface{}
+	if len(suggestions) > 0 {
+		lastHelpSuggestions = pq.Array(suggestions)
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET "last_help_suggestions" = $2 WHERE id = $1`, botId, lastHelpSuggestions,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while updating bot last help suggestions: %s", botId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row while updating bot last help suggestions: %s", botId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError("No rows were affected while updating bot last help suggestions. This is highly unexpected.")
+	}
+
+	return bumpGameVersionForBot(ctx, customDb, botId)
+}
+
+func updateStatedFacts(ctx context.Context, customDb customDbHandler, botId string, statedFacts []string) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET "stated_facts" = $2 WHERE id = $1`, botId, pq.Array(statedFacts),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while updating bot stated facts: %s", botId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row while updating bot stated facts: %s", botId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError("No rows were affected while updating bot stated facts. This is highly unexpected.")
+	}
+
+	return bumpGameVersionForBot(ctx, customDb, botId)
+}
+
+func recordTag(ctx context.Context, customDb customDbHandler, botId string, taggedAt time.Time) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET "tag_count" = "tag_count" + 1, "last_tagged_at" = $2 WHERE id = $1`, botId, taggedAt,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while recording bot tag: %s", botId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row while recording bot tag: %s", botId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError("No rows were affected while recording bot tag. This is highly unexpected.")
+	}
+
+	return bumpGameVersionForBot(ctx, customDb, botId)
+}
+
+func eliminateBot(ctx context.Context, customDb customDbHandler, botId string) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET "eliminated" = true WHERE id = $1`, botId,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while eliminating bot: %s", botId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row while eliminating bot: %s", botId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError("No rows were affected while eliminating bot. This is highly unexpected.")
+	}
+
+	return bumpGameVersionForBot(ctx, customDb, botId)
 }
@@ -1,25 +1,95 @@
 package storage
 
-import "github.com/pkg/errors"
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 type BotAccessorMockSuccess struct {
 }
 
-func (p *BotAccessorMockSuccess) UpdateBotWithPlayerIdUsingTransaction(botId, playerId string, transaction DatabaseTransaction) error {
+func (p *BotAccessorMockSuccess) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	return nil
+}
+
+func (p *BotAccessorMockSuccess) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	return nil
+}
+
+func (p *BotAccessorMockSuccess) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
+	return nil
+}
+
+func (p *BotAccessorMockSuccess) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
 	return nil
 }
 
-func (p *BotAccessorMockSuccess) UpdateBotDecrementHelpCountUsingTransaction(botId string, transaction DatabaseTransaction) error {
+func (p *BotAccessorMockSuccess) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	return nil
+}
+
+func (p *BotAccessorMockSuccess) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
 	return nil
 }
 
 type BotAccessorMockFailure struct {
 }
 
-func (p *BotAccessorMockFailure) UpdateBotWithPlayerIdUsingTransaction(botId, playerId string, transaction DatabaseTransaction) error {
+func (p *BotAccessorMockFailure) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	return errors.New("unable to update bot")
+}
+
+func (p *BotAccessorMockFailure) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
 	return errors.New("unable to update bot")
 }
 
-func (p *BotAccessorMockFailure) UpdateBotDecrementHelpCountUsingTransaction(botId string, transaction DatabaseTransaction) error {
+func (p *BotAccessorMockFailure) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
 	return errors.New("unable to update bot")
 }
+
+func (p *BotAccessorMockFailure) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
+	return errors.New("unable to update bot")
+}
+
+func (p *BotAccessorMockFailure) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	return errors.New("unable to update bot")
+}
+
+func (p *BotAccessorMockFailure) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	return errors.New("unable to update bot")
+}
+
+type BotAccessorConfigurableMock struct {
+	UpdateBotWithPlayerIdUsingTransactionInternal        func(botId, playerId string, helpCount int64, transaction DatabaseTransaction) error
+	UpdateBotDecrementHelpCountUsingTransactionInternal  func(botId string, transaction DatabaseTransaction) error
+	UpdateBotLastHelpSuggestionsUsingTransactionInternal func(botId string, suggestions []string, transaction DatabaseTransaction) error
+	UpdateBotStatedFactsUsingTransactionInternal         func(botId string, statedFacts []string, transaction DatabaseTransaction) error
+	UpdateBotAfterTagUsingTransactionInternal            func(botId string, taggedAt time.Time, transaction DatabaseTransaction) error
+	UpdateBotEliminatedUsingTransactionInternal          func(botId string, transaction DatabaseTransaction) error
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	return b.UpdateBotWithPlayerIdUsingTransactionInternal(botId, playerId, helpCount, transaction)
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	return b.UpdateBotDecrementHelpCountUsingTransactionInternal(botId, transaction)
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
+	return b.UpdateBotLastHelpSuggestionsUsingTransactionInternal(botId, suggestions, transaction)
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
+	return b.UpdateBotStatedFactsUsingTransactionInternal(botId, statedFacts, transaction)
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	return b.UpdateBotAfterTagUsingTransactionInternal(botId, taggedAt, transaction)
+}
+
+func (b *BotAccessorConfigurableMock) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	return b.UpdateBotEliminatedUsingTransactionInternal(botId, transaction)
+}
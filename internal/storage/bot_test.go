@@ -1,328 +1,243 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 )
 
 func Test_UpdateBotWithPlayerIdUsingTransaction(t *testing.T) {
-	tests := []struct {
-		name  string
-		input struct {
-			botId    string
-			playerId string
-		}
-		dbUpdateCheck   func(*sql.DB) bool
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name: "errors if botId is blank",
-			input: struct {
-				botId    string
-				playerId string
-			}{
-				botId:    "",
-				playerId: "",
-			},
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "botId cannot be blank",
-		},
-		{
-			name: "errors if playerId is blank",
-			input: struct {
-				botId    string
-				playerId string
-			}{
-				botId:    "bot_id1",
-				playerId: "",
-			},
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "playerId cannot be blank",
-		},
-		{
-			name: "errors if player id is not in db",
-			input: struct {
-				botId    string
-				playerId string
-			}{
-				botId:    "bot_id1",
-				playerId: "player_id1",
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					playerId  sql.NullString
-					typeOfBot string
-				)
-				row := db.QueryRow(
-					`SELECT player_id, type
-				FROM public."bots"
-				WHERE id = 'bot_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&playerId, &typeOfBot)
-				assert.NoError(t, err)
-				assert.False(t, playerId.Valid)
-				assert.Equal(t, "AI", typeOfBot)
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), "", "", 3, tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), "bot_id1", "", 3, tx)
+			})
+			assert.EqualError(t, err, "playerId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), "bot_id1", "player_id1", -1, tx)
+			})
+			assert.EqualError(t, err, "helpCount cannot be negative")
+		})
+	})
 
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'bot_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'bot_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'bot_id1'`},
-			},
-			errorExpected: true,
-			errorString:   "THIS IS BAD: dbError while connecting player to bot: player_id1 bot_id1: pq: insert or update on table \"bots\" violates foreign key constraint \"bots_player_id_fkey\"",
-		},
-		{
-			name: "bot updates successfully with the playerId and helpCount",
-			input: struct {
-				botId    string
-				playerId string
-			}{
-				botId:    "bot_id1",
-				playerId: "player_id1",
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					playerId  string
-					typeOfBot string
-					helpCount int
-				)
-				row := db.QueryRow(
-					`SELECT player_id, type, help_count
-					FROM public."bots"
-					WHERE id = 'bot_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&playerId, &typeOfBot, &helpCount)
-				assert.NoError(t, err)
-				assert.Equal(t, "player_id1", playerId)
-				assert.Equal(t, "HUMAN", typeOfBot)
-				assert.Equal(t, 3, helpCount)
+	t.Run("errors if the player does not exist", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
 
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'bot_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'bot_id1'
-					)`,
-				},
-				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id1')`},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'bot_id1'`},
-				{Query: `DELETE FROM public."players" WHERE id = 'player_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, "player_id1", 3, tx)
+			})
+			assert.ErrorContains(t, err, "dbError while connecting player to bot: player_id1 "+botId)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			bot := getBotForSuite(t, s, gameId, botId)
+			assert.True(t, bot.IsAi())
+		})
+	})
 
-			tx, err := s.BeginTransaction()
+	t.Run("connects the player to the bot with the helpCount", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
+			player, err := s.CreatePlayer(context.Background())
 			assert.NoError(t, err)
-			err = s.UpdateBotWithPlayerIdUsingTransaction(tt.input.botId, tt.input.playerId, tx)
-			tx.Commit()
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
+
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, player.Id(), 3, tx)
+			})
+			assert.NoError(t, err)
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			bot := game.BotWithPlayerId(player.Id())
+			assert.NotNil(t, bot)
+			assert.Equal(t, botId, bot.Id())
+			assert.True(t, bot.IsHuman())
+			assert.True(t, bot.CanGetHelp())
+			assert.Equal(t, int64(1), game.Version())
 		})
-	}
+	})
 }
+
 func Test_UpdateBotDecrementHelpCountUsingTransaction(t *testing.T) {
-	tests := []struct {
-		name            string
-		input           string
-		dbUpdateCheck   func(*sql.DB) bool
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:            "errors if botId is blank",
-			input:           "",
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "botId cannot be blank",
-		},
-		{
-			name:            "errors if bot id is not in db",
-			input:           "bot_id1",
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "getting help_count for bot_id1: no such bot",
-		},
-		{
-			name:  "errors if helpCount is already 0",
-			input: "bot_id1",
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					helpCount int
-				)
-				row := db.QueryRow(
-					`SELECT help_count
-					FROM public."bots"
-					WHERE id = 'bot_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&helpCount)
-				assert.NoError(t, err)
-				assert.Equal(t, 0, helpCount)
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotDecrementHelpCountUsingTransaction(context.Background(), "", tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotDecrementHelpCountUsingTransaction(context.Background(), "bot_id1", tx)
+			})
+			assert.EqualError(t, err, "getting help_count for bot_id1: no such bot")
+		})
+	})
 
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id", "help_count"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'game_id1', 0
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: true,
-			errorString:   "help_count should not be updated below 0 for bot_id1",
-		},
-		{
-			name:  "bot updates successfully with the helpCount",
-			input: "bot_id1",
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					helpCount int
-				)
-				row := db.QueryRow(
-					`SELECT help_count
-					FROM public."bots"
-					WHERE id = 'bot_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&helpCount)
-				assert.NoError(t, err)
-				assert.Equal(t, 2, helpCount)
+	t.Run("decrements the helpCount until it is 0", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
+			player, err := s.CreatePlayer(context.Background())
+			assert.NoError(t, err)
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, player.Id(), 1, tx)
+			})
+			assert.NoError(t, err)
+
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotDecrementHelpCountUsingTransaction(context.Background(), botId, tx)
+			})
+			assert.NoError(t, err)
+			bot := getBotForSuite(t, s, gameId, botId)
+			assert.False(t, bot.CanGetHelp())
 
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id", "help_count"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'game_id1', 3
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotDecrementHelpCountUsingTransaction(context.Background(), botId, tx)
+			})
+			assert.EqualError(t, err, "help_count should not be updated below 0 for "+botId)
+		})
+	})
+}
+
+func Test_UpdateBotLastHelpSuggestionsUsingTransaction(t *testing.T) {
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotLastHelpSuggestionsUsingTransaction(context.Background(), "", []string{"suggestion"}, tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotLastHelpSuggestionsUsingTransaction(context.Background(), "bot_id1", []string{"suggestion"}, tx)
+			})
+			assert.EqualError(t, err, "THIS IS BAD: No rows were affected while updating bot last help suggestions. This is highly unexpected.")
+		})
+	})
+
+	t.Run("updates the suggestions, and clears them when there are none", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotLastHelpSuggestionsUsingTransaction(context.Background(), botId, []string{"hey, some suggestion", "", "suggestion"}, tx)
+			})
+			assert.NoError(t, err)
+			bot := getBotForSuite(t, s, gameId, botId)
+			assert.Equal(t, []model.HelpSuggestion{
+				{Style: model.HELP_STYLES[0], Text: "hey, some suggestion"},
+				{Style: model.HELP_STYLES[2], Text: "suggestion"},
+			}, bot.LastHelpSuggestions())
 
-			tx, err := s.BeginTransaction()
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotLastHelpSuggestionsUsingTransaction(context.Background(), botId, nil, tx)
+			})
 			assert.NoError(t, err)
-			err = s.UpdateBotDecrementHelpCountUsingTransaction(tt.input, tx)
-			tx.Commit()
-			if !tt.errorExpected {
+			bot = getBotForSuite(t, s, gameId, botId)
+			assert.Empty(t, bot.LastHelpSuggestions())
+		})
+	})
+}
+
+func Test_UpdateBotStatedFactsUsingTransaction(t *testing.T) {
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotStatedFactsUsingTransaction(context.Background(), "", []string{"I love pizza"}, tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotStatedFactsUsingTransaction(context.Background(), "bot_id1", []string{"I love pizza"}, tx)
+			})
+			assert.EqualError(t, err, "THIS IS BAD: No rows were affected while updating bot stated facts. This is highly unexpected.")
+		})
+	})
+
+	t.Run("replaces the stated facts", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
+
+			for _, statedFacts := range [][]string{{"I love pizza"}, {"I love pizza", "I have a dog"}} {
+				err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+					return s.UpdateBotStatedFactsUsingTransaction(context.Background(), botId, statedFacts, tx)
+				})
 				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
+				bot := getBotForSuite(t, s, gameId, botId)
+				assert.Equal(t, statedFacts, bot.StatedFacts())
 			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+		})
+	})
+}
+
+func Test_UpdateBotAfterTagUsingTransaction(t *testing.T) {
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotAfterTagUsingTransaction(context.Background(), "", time.Now(), tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotAfterTagUsingTransaction(context.Background(), "bot_id1", time.Now(), tx)
+			})
+			assert.EqualError(t, err, "THIS IS BAD: No rows were affected while recording bot tag. This is highly unexpected.")
+		})
+	})
+
+	t.Run("counts the tag and starts the cooldown", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
+			player, err := s.CreatePlayer(context.Background())
+			assert.NoError(t, err)
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, player.Id(), 0, tx)
+			})
+			assert.NoError(t, err)
+
+			taggedAt := time.Now().Add(-10 * time.Second)
+			for i := 0; i < 2; i++ {
+				err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+					return s.UpdateBotAfterTagUsingTransaction(context.Background(), botId, taggedAt, tx)
+				})
+				assert.NoError(t, err)
 			}
+
+			bot := getBotForSuite(t, s, gameId, botId)
+			assert.Equal(t, int64(model.MAX_TAGS_PER_HUMAN-2), bot.TagsRemaining())
+			assert.NotNil(t, bot.NextTagAt())
+			assert.WithinDuration(t, taggedAt.Add(model.TAG_COOLDOWN), *bot.NextTagAt(), time.Second)
+		})
+	})
+}
+
+func Test_UpdateBotEliminatedUsingTransaction(t *testing.T) {
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotEliminatedUsingTransaction(context.Background(), "", tx)
+			})
+			assert.EqualError(t, err, "botId cannot be blank")
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotEliminatedUsingTransaction(context.Background(), "bot_id1", tx)
+			})
+			assert.EqualError(t, err, "THIS IS BAD: No rows were affected while eliminating bot. This is highly unexpected.")
+		})
+	})
+
+	t.Run("eliminates the bot", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, botId := createBotForSuite(t, s)
+
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateBotEliminatedUsingTransaction(context.Background(), botId, tx)
+			})
+			assert.NoError(t, err)
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.True(t, game.BotWithId(botId).IsEliminated())
+			assert.Equal(t, int64(1), game.Version())
 		})
-	}
+	})
 }
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// ClaimedGame is a game that ClaimUnhandledGames claimed, as it was once claimed. The games are not loaded in full,
+// since the jobs that handle them are only handed the id and version through redis, and load the game themselves.
+type ClaimedGame struct {
+	Id      string
+	State   string
+	Version int64
+}
+
+// ClaimUnhandledGames marks up to limit unhandled games in any of gameStates as handled, and returns them, oldest first.
+// Games that another transaction has locked are skipped rather than waited on, so callers running at the same time
+// claim different games, and none of them is claimed twice.
+// A claim lasts until the game is updated with StateHandled set. A claim older than claimTimeout is taken to be
+// abandoned, for example by a worker that died, and the game can be claimed again.
+func (s *Storage) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	err := validateClaim(gameStates, limit, claimTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.BeginTransaction(ctx)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	rows, err := tx.QueryContext(
+		ctx,
+		`WITH claimable_games AS (
+			SELECT g.id
+			FROM public."games" AS g
+			WHERE g.state = ANY($1)
+			AND (
+				g.state_handled = false
+				OR (g.state_claimed_at IS NOT NULL AND g.state_claimed_at <= $2)
+			)
+			ORDER BY g.created_at ASC, g.id ASC
+			LIMIT $3
+			FOR UPDATE OF g SKIP LOCKED
+		), claimed_games AS (
+			UPDATE public."games" AS games
+			SET state_handled = true, state_claimed_at = $4, updated_at = $4, version = games.version + 1
+			FROM claimable_games
+			WHERE games.id = claimable_games.id
+			RETURNING games.id, games.state, games.version, games.created_at
+		)
+		SELECT id, state, version FROM claimed_games
+		ORDER BY created_at ASC, id ASC`,
+		pq.Array(gameStates), now.Add(-claimTimeout), limit, now,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to claim games")
+	}
+	defer rows.Close()
+
+	claimedGames := []ClaimedGame{}
+	for rows.Next() {
+		var claimedGame ClaimedGame
+		err := rows.Scan(&claimedGame.Id, &claimedGame.State, &claimedGame.Version)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		claimedGames = append(claimedGames, claimedGame)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through claimed game rows")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to commit claimed games")
+	}
+	return claimedGames, nil
+}
+
+func validateClaim(gameStates []string, limit int, claimTimeout time.Duration) error {
+	if len(gameStates) == 0 {
+		return errors.New("at least one game state is required")
+	}
+	for _, gameStateString := range gameStates {
+		if !model.GameState(gameStateString).Valid() {
+			return errors.New("invalid game state")
+		}
+	}
+	if limit <= 0 {
+		return errors.New("limit should be positive")
+	}
+	if claimTimeout <= 0 {
+		return errors.New("claimTimeout should be positive")
+	}
+	return nil
+}
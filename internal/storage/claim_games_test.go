@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var claimStates = []string{"PLAYERS_JOINED", "WAITING_FOR_AI_QUESTION", "WAITING_FOR_AI_ANSWER"}
+
+func claimedGameIds(games []ClaimedGame) []string {
+	ids := []string{}
+	for _, game := range games {
+		ids = append(ids, game.Id)
+	}
+	return ids
+}
+
+// createGamesToClaimForSuite creates games, oldest first, in states that are claimed, apart from the third game.
+func createGamesToClaimForSuite(t *testing.T, s suiteStorage) []string {
+	gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 4))
+	for i, state := range []string{"WAITING_FOR_AI_QUESTION", "PLAYERS_JOINED", "WAITING_FOR_HUMAN_QUESTION", "WAITING_FOR_AI_ANSWER"} {
+		state := state
+		updateGameForSuite(t, s, gameIds[i], GameUpdateOptions{State: &state})
+	}
+	return gameIds
+}
+
+func Test_ClaimUnhandledGames(t *testing.T) {
+	t.Run("claims the oldest unhandled games in the states, up to the limit", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesToClaimForSuite(t, s)
+
+			games, err := s.ClaimUnhandledGames(context.Background(), claimStates, 2, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, []ClaimedGame{
+				{Id: gameIds[0], State: "WAITING_FOR_AI_QUESTION", Version: 2},
+				{Id: gameIds[1], State: "PLAYERS_JOINED", Version: 2},
+			}, games)
+			game, err := s.GetGame(context.Background(), gameIds[0])
+			assert.NoError(t, err)
+			assert.True(t, game.StateHasBeenHandled())
+			assert.Equal(t, int64(2), game.Version())
+
+			games, err = s.ClaimUnhandledGames(context.Background(), claimStates, 2, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[3]}, claimedGameIds(games))
+
+			games, err = s.ClaimUnhandledGames(context.Background(), claimStates, 2, time.Minute)
+			assert.NoError(t, err)
+			assert.Empty(t, games, "claimed games should not be claimed again")
+		})
+	})
+
+	t.Run("claims a game again once its claim has timed out", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesToClaimForSuite(t, s)
+
+			games, err := s.ClaimUnhandledGames(context.Background(), []string{"PLAYERS_JOINED"}, 10, time.Millisecond)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[1]}, claimedGameIds(games))
+
+			time.Sleep(5 * time.Millisecond)
+			games, err = s.ClaimUnhandledGames(context.Background(), []string{"PLAYERS_JOINED"}, 10, time.Millisecond)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[1]}, claimedGameIds(games))
+		})
+	})
+
+	t.Run("does not claim handled games that were never claimed", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesToClaimForSuite(t, s)
+			stateHandled := true
+			updateGameForSuite(t, s, gameIds[1], GameUpdateOptions{StateHandled: &stateHandled})
+
+			games, err := s.ClaimUnhandledGames(context.Background(), []string{"PLAYERS_JOINED"}, 10, time.Nanosecond)
+			assert.NoError(t, err)
+			assert.Empty(t, games)
+		})
+	})
+
+	t.Run("ends the claim when the game is updated", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesToClaimForSuite(t, s)
+
+			games, err := s.ClaimUnhandledGames(context.Background(), []string{"PLAYERS_JOINED"}, 10, time.Minute)
+			assert.NoError(t, err)
+			assert.Len(t, games, 1)
+
+			stateHandled := false
+			updateGameForSuite(t, s, gameIds[1], GameUpdateOptions{StateHandled: &stateHandled})
+
+			games, err = s.ClaimUnhandledGames(context.Background(), []string{"PLAYERS_JOINED"}, 10, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[1]}, claimedGameIds(games))
+		})
+	})
+
+	t.Run("errors on invalid arguments", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			_, err := s.ClaimUnhandledGames(context.Background(), nil, 10, time.Minute)
+			assert.EqualError(t, err, "at least one game state is required")
+			_, err = s.ClaimUnhandledGames(context.Background(), []string{"NOT_A_STATE"}, 10, time.Minute)
+			assert.EqualError(t, err, "invalid game state")
+			_, err = s.ClaimUnhandledGames(context.Background(), claimStates, 0, time.Minute)
+			assert.EqualError(t, err, "limit should be positive")
+			_, err = s.ClaimUnhandledGames(context.Background(), claimStates, 10, 0)
+			assert.EqualError(t, err, "claimTimeout should be positive")
+		})
+	})
+}
+
+// Storage skips the games that another transaction has locked, rather than waiting for them. SqliteStorage has a
+// single writer, so there are no locked games for it to skip.
+func Test_ClaimUnhandledGames_SkipsLockedGames(t *testing.T) {
+	for _, backend := range suiteStorages {
+		if backend.name == "SqliteStorage" {
+			continue
+		}
+		t.Run(backend.name, func(t *testing.T) {
+			s := backend.newStorage(t)
+			gameIds := createGamesToClaimForSuite(t, s)
+
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			_, err = s.GetGameUsingTransaction(context.Background(), gameIds[0], tx)
+			assert.NoError(t, err)
+
+			games, err := s.ClaimUnhandledGames(context.Background(), claimStates, 10, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[1], gameIds[3]}, claimedGameIds(games))
+
+			assert.NoError(t, tx.Rollback())
+			games, err = s.ClaimUnhandledGames(context.Background(), claimStates, 10, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[0]}, claimedGameIds(games))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package storage
+
+import "github.com/pkg/errors"
+
+// ErrConcurrentModification is returned when a game was changed by someone else between reading it and updating it.
+// The caller can read the game again and retry.
+var ErrConcurrentModification = errors.New("game was modified concurrently")
+
+func IsConcurrentModification(err error) bool {
+	return errors.Is(err, ErrConcurrentModification)
+}
+
+// RetryOnConcurrentModification runs f up to maxAttempts times, as long as it fails with ErrConcurrentModification.
+// Any other error is returned right away.
+func RetryOnConcurrentModification(maxAttempts int, f func() error) error {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err = f()
+		if !IsConcurrentModification(err) {
+			return err
+		}
+	}
+	return err
+}
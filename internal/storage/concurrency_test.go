@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetryOnConcurrentModification(t *testing.T) {
+	t.Run("retries until f succeeds", func(t *testing.T) {
+		attempts := 0
+		err := RetryOnConcurrentModification(3, func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.Wrap(ErrConcurrentModification, "gameId: game_id1")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after maxAttempts", func(t *testing.T) {
+		attempts := 0
+		err := RetryOnConcurrentModification(3, func() error {
+			attempts++
+			return errors.Wrap(ErrConcurrentModification, "gameId: game_id1")
+		})
+		assert.True(t, IsConcurrentModification(err))
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		err := RetryOnConcurrentModification(3, func() error {
+			attempts++
+			return errors.New("some other error")
+		})
+		assert.EqualError(t, err, "some other error")
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func Test_UpdateGameState_ConcurrentUpdatesWithRetries(t *testing.T) {
+	requireTestDb(t)
+	s, _ := NewDbStorage(
+		StorageOptions{
+			Db: testDb,
+		},
+	)
+
+	runSqlOnDb(t, s.db.DB, []TestSqlStmts{
+		{
+			Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
+			VALUES ('game_id1', 'STARTED', 0, Array['bot_id1'], false)`,
+		},
+		{
+			Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+			VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
+		},
+	})
+	defer runSqlOnDb(t, s.db.DB, []TestSqlStmts{
+		{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+	})
+
+	// Every goroutine reads the game without a lock and increments the summarized message count. Without the version
+	// check, some of the increments would be lost.
+	updaters := 10
+	var wg sync.WaitGroup
+	errs := make([]error, updaters)
+	for i := 0; i < updaters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = RetryOnConcurrentModification(updaters, func() error {
+				game, err := s.GetGame(context.Background(), "game_id1")
+				if err != nil {
+					return err
+				}
+				summary := game.ConversationSummary()
+				summary.MessageCount++
+				version := game.Version()
+
+				tx, err := s.BeginTransaction(context.Background())
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback()
+				err = s.UpdateGameStateUsingTransaction(context.Background(), "game_id1", GameUpdateOptions{ConversationSummary: &summary, ExpectedVersion: &version}, tx)
+				if err != nil {
+					return err
+				}
+				return tx.Commit()
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	game, err := s.GetGame(context.Background(), "game_id1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(updaters), game.ConversationSummary().MessageCount)
+	assert.Equal(t, int64(updaters), game.Version())
+}
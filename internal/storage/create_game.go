@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lib/pq"
@@ -8,7 +9,20 @@ import (
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
-func (s *Storage) CreateGame(public bool) (string, error) {
+type GameCreateOptions struct {
+	Public        bool
+	PromptVersion string
+	Mode          string
+	AiAccusations bool
+	Elimination   bool
+	VotingRounds  int64
+	AiVotes       bool
+}
+
+func (s *Storage) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	id := s.IdGenerator.Generate()
 
 	botNames := model.RandomBotNames()
@@ -38,31 +52,40 @@ func (s *Storage) CreateGame(public bool) (string, error) {
 		TurnOrder:        nonRandomTurnOrder,
 		StateHandled:     false,
 		Bots:             bots,
-		Public:           public,
+		Public:           createOpts.Public,
+		PromptVersion:    createOpts.PromptVersion,
+		AiAccusations:    createOpts.AiAccusations,
+		Elimination:      createOpts.Elimination,
+		VotingRounds:     createOpts.VotingRounds,
+		AiVotes:          createOpts.AiVotes,
+		Mode:             createOpts.Mode,
 	}
 
-	_, err := model.NewGame(gameOption)
+	game, err := model.NewGame(gameOption)
 	if err != nil {
 		return "", utilities.WrapBadError(err, "failed to create game")
 	}
 
-	tx, err := s.BeginTransaction()
+	tx, err := s.BeginTransaction(ctx)
 	if err != nil {
 		return "", utilities.WrapBadError(err, "failed to start db transaction")
 	}
 	defer tx.Rollback()
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(
+		ctx,
 		`INSERT INTO public."games" (
-			"id", "state", "current_turn_index", "turn_order", "state_handled", "public"
+			"id", "state", "current_turn_index", "turn_order", "state_handled", "public", "prompt_version", "ai_accusations", "elimination", "voting_rounds", "ai_votes", "mode"
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 		)
 		`,
 		gameOption.Id, gameOption.State,
 		gameOption.CurrentTurnIndex, pq.Array(gameOption.TurnOrder),
-		gameOption.StateHandled, gameOption.Public,
+		gameOption.StateHandled, gameOption.Public, nullStringIfBlank(gameOption.PromptVersion),
+		gameOption.AiAccusations, gameOption.Elimination, gameOption.VotingRounds, gameOption.AiVotes,
+		game.Mode(),
 	)
 	if err != nil {
 		return "", err
@@ -78,7 +101,8 @@ func (s *Storage) CreateGame(public bool) (string, error) {
 	}
 
 	for _, botOpts := range botOptionsList {
-		result, err := tx.Exec(
+		result, err := tx.ExecContext(
+			ctx,
 			`INSERT INTO public."bots" (
 				"id", "name", "type", "game_id"
 			)
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math/rand"
@@ -12,9 +13,10 @@ import (
 )
 
 func Test_CreateGame(t *testing.T) {
+	requireTestDb(t)
 	tests := []struct {
 		name            string
-		input           bool
+		input           GameCreateOptions
 		output          string
 		setupSqlStmts   []TestSqlStmts
 		cleanupSqlStmts []TestSqlStmts
@@ -24,8 +26,16 @@ func Test_CreateGame(t *testing.T) {
 		errorString     string
 	}{
 		{
-			name:          "creates public game successfully",
-			input:         true,
+			name: "creates public game successfully",
+			input: GameCreateOptions{
+				Public:        true,
+				PromptVersion: "v2",
+				Mode:          "CLASSIC",
+				AiAccusations: true,
+				Elimination:   true,
+				VotingRounds:  2,
+				AiVotes:       true,
+			},
 			output:        "game_id1",
 			setupSqlStmts: nil,
 			cleanupSqlStmts: []TestSqlStmts{
@@ -46,11 +56,17 @@ func Test_CreateGame(t *testing.T) {
 					lastQuestionTargetBotId sql.NullString
 					createdAt               pq.NullTime
 					updatedAt               pq.NullTime
+					promptVersion           sql.NullString
+					aiAccusations           bool
+					elimination             bool
+					votingRounds            int64
+					aiVotes                 bool
+					mode                    string
 				)
 				err := db.QueryRow(
-					`SELECT "id", "state", "public", "current_turn_index", "turn_order", "state_handled", "state_handled_at", "state_total_time", "last_question", "last_question_target_bot_id", "created_at", "updated_at"
+					`SELECT "id", "state", "public", "current_turn_index", "turn_order", "state_handled", "state_handled_at", "state_total_time", "last_question", "last_question_target_bot_id", "created_at", "updated_at", "prompt_version", "ai_accusations", "elimination", "voting_rounds", "ai_votes", "mode"
 					FROM public."games" WHERE "id" = 'game_id1'`,
-				).Scan(&id, &state, &public, &currentTurnIndex, pq.Array(&turnOrder), &stateHandled, &stateHandledAt, &stateTotalTime, &lastQuestion, &lastQuestionTargetBotId, &createdAt, &updatedAt)
+				).Scan(&id, &state, &public, &currentTurnIndex, pq.Array(&turnOrder), &stateHandled, &stateHandledAt, &stateTotalTime, &lastQuestion, &lastQuestionTargetBotId, &createdAt, &updatedAt, &promptVersion, &aiAccusations, &elimination, &votingRounds, &aiVotes, &mode)
 				assert.NoError(t, err)
 				assert.Equal(t, "game_id1", id)
 				assert.Equal(t, "STARTED", state)
@@ -64,6 +80,12 @@ func Test_CreateGame(t *testing.T) {
 				assert.False(t, lastQuestionTargetBotId.Valid)
 				assert.True(t, createdAt.Valid)
 				assert.True(t, updatedAt.Valid)
+				assert.Equal(t, "v2", promptVersion.String)
+				assert.True(t, aiAccusations)
+				assert.True(t, elimination)
+				assert.Equal(t, int64(2), votingRounds)
+				assert.True(t, aiVotes)
+				assert.Equal(t, "CLASSIC", mode)
 
 				rows, err := db.Query(
 					`SELECT
@@ -103,7 +125,7 @@ func Test_CreateGame(t *testing.T) {
 		},
 		{
 			name:          "creates private game successfully",
-			input:         true,
+			input:         GameCreateOptions{Public: true},
 			output:        "game_id1",
 			setupSqlStmts: nil,
 			cleanupSqlStmts: []TestSqlStmts{
@@ -181,7 +203,7 @@ func Test_CreateGame(t *testing.T) {
 		},
 		{
 			name:   "errors and does not update anything, if Game ID already exists in DB",
-			input:  true,
+			input:  GameCreateOptions{Public: true},
 			output: "",
 			setupSqlStmts: []TestSqlStmts{
 				{
@@ -223,7 +245,7 @@ func Test_CreateGame(t *testing.T) {
 		},
 		{
 			name:            "errors and does not update anything, if Bot ID already exists in DB",
-			input:           false,
+			input:           GameCreateOptions{Public: false},
 			output:          "",
 			setupSqlStmts:   nil,
 			cleanupSqlStmts: nil,
@@ -259,11 +281,11 @@ func Test_CreateGame(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
 			rand.Seed(0)
-			gameId, err := s.CreateGame(tt.input)
+			gameId, err := s.CreateGame(context.Background(), tt.input)
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.output, gameId)
@@ -272,7 +294,7 @@ func Test_CreateGame(t *testing.T) {
 				assert.EqualError(t, err, tt.errorString)
 			}
 			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
 			}
 		})
 	}
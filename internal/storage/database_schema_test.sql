@@ -25,6 +25,11 @@ CREATE TABLE "bots" (
     "game_id" TEXT NOT NULL,
     "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "help_count" INTEGER NOT NULL DEFAULT 0,
+    "last_help_suggestions" TEXT[],
+    "stated_facts" TEXT[],
+    "tag_count" INTEGER NOT NULL DEFAULT 0,
+    "last_tagged_at" TIMESTAMPTZ(3),
+    "eliminated" BOOLEAN NOT NULL DEFAULT false,
 
     CONSTRAINT "bots_pkey" PRIMARY KEY ("id")
 );
@@ -37,6 +42,7 @@ CREATE TABLE "games" (
     "turn_order" TEXT[],
     "state_handled" BOOLEAN NOT NULL,
     "state_handled_at" TIMESTAMPTZ(3),
+    "state_claimed_at" TIMESTAMPTZ(3),
     "last_question" TEXT,
     "last_question_target_bot_id" TEXT,
     "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -45,6 +51,17 @@ CREATE TABLE "games" (
     "result" TEXT,
     "winning_bot_id" TEXT,
     "public" BOOLEAN NOT NULL DEFAULT false,
+    "prompt_version" TEXT,
+    "conversation_summary" TEXT,
+    "summarized_message_count" INTEGER NOT NULL DEFAULT 0,
+    "ai_accusations" BOOLEAN NOT NULL DEFAULT false,
+    "ai_accusation_checked_at" TIMESTAMPTZ(3),
+    "elimination" BOOLEAN NOT NULL DEFAULT false,
+    "voting_rounds" INTEGER NOT NULL DEFAULT 0,
+    "ai_votes" BOOLEAN NOT NULL DEFAULT false,
+    "mode" TEXT NOT NULL DEFAULT 'CLASSIC',
+    "decoy_bot_id" TEXT,
+    "version" INTEGER NOT NULL DEFAULT 0,
 
     CONSTRAINT "games_pkey" PRIMARY KEY ("id")
 );
@@ -52,24 +69,79 @@ CREATE TABLE "games" (
 -- CreateTable
 CREATE TABLE "messages" (
     "id" TEXT NOT NULL,
+    "game_id" TEXT NOT NULL,
     "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "text" TEXT NOT NULL,
     "source_bot_id" TEXT NOT NULL,
     "type" TEXT NOT NULL,
     "target_bot_id" TEXT NOT NULL,
+    "response_time_ms" INTEGER,
+    "help_usage" TEXT,
+    "help_style" TEXT,
+    "ai_model" TEXT,
+    "prompt_version" TEXT,
+    "prompt_tokens" INTEGER,
+    "completion_tokens" INTEGER,
 
     CONSTRAINT "messages_pkey" PRIMARY KEY ("id")
 );
 
+-- CreateTable
+CREATE TABLE "votes" (
+    "id" TEXT NOT NULL,
+    "game_id" TEXT NOT NULL,
+    "voter_bot_id" TEXT NOT NULL,
+    "suspect_bot_id" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "votes_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "practice_results" (
+    "id" TEXT NOT NULL,
+    "game_id" TEXT NOT NULL,
+    "player_id" TEXT NOT NULL,
+    "won" BOOLEAN NOT NULL,
+    "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "practice_results_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "bot_analyses" (
+    "id" TEXT NOT NULL,
+    "bot_id" TEXT NOT NULL,
+    "humanness_score" INTEGER NOT NULL,
+    "tells" TEXT[],
+    "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "bot_analyses_pkey" PRIMARY KEY ("id")
+);
+
 -- CreateTable
 CREATE TABLE "players" (
     "id" TEXT NOT NULL,
     "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
     "user_id" TEXT,
+    "deleted_at" TIMESTAMPTZ(3),
 
     CONSTRAINT "players_pkey" PRIMARY KEY ("id")
 );
 
+-- CreateTable
+CREATE TABLE "player_erasures" (
+    "id" TEXT NOT NULL,
+    "player_id" TEXT NOT NULL,
+    "user_id" TEXT,
+    "bot_count" INTEGER NOT NULL,
+    "message_count" INTEGER NOT NULL,
+    "created_at" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "player_erasures_pkey" PRIMARY KEY ("id")
+);
+
 -- CreateTable
 CREATE TABLE "sessions" (
     "id" TEXT NOT NULL,
@@ -100,12 +172,27 @@ CREATE UNIQUE INDEX "games_last_question_target_bot_id_key" ON "games"("last_que
 -- CreateIndex
 CREATE UNIQUE INDEX "games_winning_bot_id_key" ON "games"("winning_bot_id" ASC);
 
+-- CreateIndex
+CREATE INDEX "messages_game_id_created_at_idx" ON "messages"("game_id" ASC, "created_at" ASC, "id" ASC);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "practice_results_game_id_key" ON "practice_results"("game_id" ASC);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "bot_analyses_bot_id_key" ON "bot_analyses"("bot_id" ASC);
+
+-- CreateIndex
+CREATE INDEX "player_erasures_user_id_idx" ON "player_erasures"("user_id" ASC);
+
 -- CreateIndex
 CREATE UNIQUE INDEX "sessions_session_token_key" ON "sessions"("session_token" ASC);
 
 -- CreateIndex
 CREATE UNIQUE INDEX "users_email_key" ON "users"("email" ASC);
 
+-- CreateIndex
+CREATE UNIQUE INDEX "votes_voter_bot_id_key" ON "votes"("voter_bot_id" ASC);
+
 -- AddForeignKey
 ALTER TABLE "accounts" ADD CONSTRAINT "accounts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
 
@@ -113,7 +200,7 @@ ALTER TABLE "accounts" ADD CONSTRAINT "accounts_user_id_fkey" FOREIGN KEY ("user
 ALTER TABLE "bots" ADD CONSTRAINT "bots_game_id_fkey" FOREIGN KEY ("game_id") REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE;
 
 -- AddForeignKey
-ALTER TABLE "bots" ADD CONSTRAINT "bots_player_id_fkey" FOREIGN KEY ("player_id") REFERENCES "players"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "bots" ADD CONSTRAINT "bots_player_id_fkey" FOREIGN KEY ("player_id") REFERENCES "players"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
 
 -- AddForeignKey
 ALTER TABLE "games" ADD CONSTRAINT "games_last_question_target_bot_id_fkey" FOREIGN KEY ("last_question_target_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -121,6 +208,12 @@ ALTER TABLE "games" ADD CONSTRAINT "games_last_question_target_bot_id_fkey" FORE
 -- AddForeignKey
 ALTER TABLE "games" ADD CONSTRAINT "games_winning_bot_id_fkey" FOREIGN KEY ("winning_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
 
+-- AddForeignKey
+ALTER TABLE "games" ADD CONSTRAINT "games_decoy_bot_id_fkey" FOREIGN KEY ("decoy_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "messages" ADD CONSTRAINT "messages_game_id_fkey" FOREIGN KEY ("game_id") REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
 -- AddForeignKey
 ALTER TABLE "messages" ADD CONSTRAINT "messages_source_bot_id_fkey" FOREIGN KEY ("source_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
 
@@ -130,5 +223,20 @@ ALTER TABLE "messages" ADD CONSTRAINT "messages_target_bot_id_fkey" FOREIGN KEY
 -- AddForeignKey
 ALTER TABLE "players" ADD CONSTRAINT "players_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
 
+-- AddForeignKey
+ALTER TABLE "practice_results" ADD CONSTRAINT "practice_results_player_id_fkey" FOREIGN KEY ("player_id") REFERENCES "players"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "bot_analyses" ADD CONSTRAINT "bot_analyses_bot_id_fkey" FOREIGN KEY ("bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
 -- AddForeignKey
 ALTER TABLE "sessions" ADD CONSTRAINT "sessions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "votes" ADD CONSTRAINT "votes_game_id_fkey" FOREIGN KEY ("game_id") REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "votes" ADD CONSTRAINT "votes_voter_bot_id_fkey" FOREIGN KEY ("voter_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "votes" ADD CONSTRAINT "votes_suspect_bot_id_fkey" FOREIGN KEY ("suspect_bot_id") REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -1,29 +1,88 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/vipulvpatil/airetreat-go/internal/config"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 // This function will make a connection to the database only once.
+// The first ping is retried like any other statement, so that the service can start while Postgres is still starting.
 func InitDb(cfg *config.Config, logger utilities.Logger) (*sql.DB, error) {
-	var err error
+	db, err := openDb(cfg, cfg.DbUrl)
+	if err != nil {
+		return nil, err
+	}
+	// this will be printed in the terminal, confirming the connection to the database
+	logger.LogMessageln("The database is connected")
+	return db, nil
+}
+
+// InitReadReplicaDb returns nil when there is no read replica.
+func InitReadReplicaDb(cfg *config.Config, logger utilities.Logger) (*sql.DB, error) {
+	if utilities.IsBlank(cfg.ReadReplicaDbUrl) {
+		return nil, nil
+	}
+
+	db, err := openDb(cfg, cfg.ReadReplicaDbUrl)
+	if err != nil {
+		return nil, err
+	}
+	logger.LogMessageln("The read replica is connected")
+	return db, nil
+}
+
+func openDb(cfg *config.Config, dbUrl string) (*sql.DB, error) {
+	connStr, err := connStrWithStatementTimeout(dbUrl, cfg.DbStatementTimeout)
+	if err != nil {
+		return nil, err
+	}
 
-	connStr := cfg.DbUrl
 	db, err := sql.Open("postgres", connStr)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	db.SetMaxOpenConns(cfg.DbMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DbMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DbConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DbConnMaxIdleTime)
+
+	err = retryTransientDbErrors(context.Background(), RetryPolicyFromConfig(cfg), db.Ping)
 	if err != nil {
 		return nil, err
 	}
-	// this will be printed in the terminal, confirming the connection to the database
-	logger.LogMessageln("The database is connected")
 	return db, nil
 }
+
+func RetryPolicyFromConfig(cfg *config.Config) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     cfg.DbMaxRetries,
+		InitialBackoff: cfg.DbRetryBackoff,
+		MaxBackoff:     cfg.DbRetryMaxBackoff,
+	}
+}
+
+// Postgres enforces statement_timeout itself, so that a runaway statement is stopped even if the client has gone away.
+// lib/pq sends any connection setting it does not know of to Postgres as a run-time parameter.
+func connStrWithStatementTimeout(connStr string, statementTimeout time.Duration) (string, error) {
+	if statementTimeout <= 0 {
+		return connStr, nil
+	}
+
+	if strings.HasPrefix(connStr, "postgres://") || strings.HasPrefix(connStr, "postgresql://") {
+		var err error
+		connStr, err = pq.ParseURL(connStr)
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s statement_timeout=%d", connStr, statementTimeout.Milliseconds())), nil
+}
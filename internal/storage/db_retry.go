@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy is how many times a statement is retried after a transient connection error, and how long to wait
+// before each retry. The wait starts at InitialBackoff and doubles every time, up to MaxBackoff if it is set.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// retryingDb retries statements that fail with a transient connection error, such as while Postgres restarts.
+// Statements in a transaction go to the *sql.Tx instead, and are never retried on their own, since the transaction is
+// lost along with its connection.
+type retryingDb struct {
+	*sql.DB
+	retryPolicy RetryPolicy
+}
+
+func (db *retryingDb) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := db.retry(ctx, func() error {
+		var err error
+		result, err = db.DB.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (db *retryingDb) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := db.retry(ctx, func() error {
+		var err error
+		rows, err = db.DB.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (db *retryingDb) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	var row *sql.Row
+	db.retry(ctx, func() error {
+		row = db.DB.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
+
+// opts can be nil for the default isolation level.
+func (db *retryingDb) beginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := db.retry(ctx, func() error {
+		var err error
+		tx, err = db.DB.BeginTx(ctx, opts)
+		return err
+	})
+	return tx, err
+}
+
+func (db *retryingDb) beginSnapshot(ctx context.Context) (DatabaseTransaction, error) {
+	tx, err := db.beginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &databaseTransaction{Tx: tx}, nil
+}
+
+func (db *retryingDb) retry(ctx context.Context, f func() error) error {
+	return retryTransientDbErrors(ctx, db.retryPolicy, f)
+}
+
+// retryTransientDbErrors gives up early with the last error if ctx is done while it waits.
+func retryTransientDbErrors(ctx context.Context, policy RetryPolicy, f func() error) error {
+	backoff := policy.InitialBackoff
+	for retries := 0; ; retries++ {
+		err := f()
+		if err == nil || retries >= policy.MaxRetries || !IsTransientDbError(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// IsTransientDbError is true for errors that mean the statement never ran, because the connection to the database
+// could not be made or was lost. Retrying those is safe. An error on a connection that broke while a statement was
+// running is left alone, since the statement may have gone through.
+func IsTransientDbError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 is for connection exceptions. 57P01 to 57P03 are sent while the server shuts down or starts up, and
+		// abort whatever statement was running.
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsTransientDbError(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		expected bool
+	}{
+		{
+			name:     "no error",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name:     "bad connection",
+			input:    driver.ErrBadConn,
+			expected: true,
+		},
+		{
+			name:     "connection refused while dialing",
+			input:    &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			expected: true,
+		},
+		{
+			name:     "connection reset while reading",
+			input:    &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			expected: false,
+		},
+		{
+			name:     "postgres is shutting down",
+			input:    &pq.Error{Code: "57P01"},
+			expected: true,
+		},
+		{
+			name:     "postgres is starting up",
+			input:    &pq.Error{Code: "57P03"},
+			expected: true,
+		},
+		{
+			name:     "postgres connection failure",
+			input:    &pq.Error{Code: "08006"},
+			expected: true,
+		},
+		{
+			name:     "wrapped connection failure",
+			input:    errors.Wrap(&pq.Error{Code: "08001"}, "dbError while inserting game"),
+			expected: true,
+		},
+		{
+			name:     "statement timeout",
+			input:    &pq.Error{Code: "57014"},
+			expected: false,
+		},
+		{
+			name:     "unique violation",
+			input:    &pq.Error{Code: "23505"},
+			expected: false,
+		},
+		{
+			name:     "no rows",
+			input:    sql.ErrNoRows,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransientDbError(tt.input))
+		})
+	}
+}
+
+func Test_retryTransientDbErrors(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	t.Run("retries transient errors until the statement goes through", func(t *testing.T) {
+		attempts := 0
+		err := retryTransientDbErrors(context.Background(), policy, func() error {
+			attempts++
+			if attempts < 3 {
+				return driver.ErrBadConn
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		attempts := 0
+		err := retryTransientDbErrors(context.Background(), policy, func() error {
+			attempts++
+			return driver.ErrBadConn
+		})
+		assert.Equal(t, driver.ErrBadConn, err)
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		err := retryTransientDbErrors(context.Background(), policy, func() error {
+			attempts++
+			return sql.ErrNoRows
+		})
+		assert.Equal(t, sql.ErrNoRows, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("does not retry without a policy", func(t *testing.T) {
+		attempts := 0
+		err := retryTransientDbErrors(context.Background(), RetryPolicy{}, func() error {
+			attempts++
+			return driver.ErrBadConn
+		})
+		assert.Equal(t, driver.ErrBadConn, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("stops waiting once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		err := retryTransientDbErrors(ctx, RetryPolicy{MaxRetries: 3, InitialBackoff: time.Hour}, func() error {
+			attempts++
+			cancel()
+			return driver.ErrBadConn
+		})
+		assert.Equal(t, driver.ErrBadConn, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -27,8 +28,14 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// run sets up the Postgres test database at TEST_DB_URL. Without TEST_DB_URL, the tests that need Postgres are skipped,
+// and the rest run against the other backends.
 func run(m *testing.M) (code int, err error) {
 	cfg, _ := config.NewConfigFromEnvVars()
+	if cfg.TestDbUrl == "" {
+		fmt.Println("TEST_DB_URL is not set, skipping the Postgres tests")
+		return m.Run(), nil
+	}
 
 	testDb, err = openTestDatabaseConnection(cfg)
 	if err != nil {
@@ -48,6 +55,13 @@ func run(m *testing.M) (code int, err error) {
 	return m.Run(), nil
 }
 
+// requireTestDb skips a test that needs the Postgres test database when there is none.
+func requireTestDb(tb testing.TB) {
+	if testDb == nil {
+		tb.Skip("TEST_DB_URL is not set")
+	}
+}
+
 func openTestDatabaseConnection(cfg *config.Config) (*sql.DB, error) {
 	connStr := cfg.TestDbUrl
 	db, err := sql.Open("postgres", connStr)
@@ -87,6 +101,7 @@ func closeTestDatabaseConnection(db *sql.DB) {
 }
 
 func Test_InitDb(t *testing.T) {
+	requireTestDb(t)
 	t.Run("Test that DB connectivity works", func(t *testing.T) {
 		cfg, _ := config.NewConfigFromEnvVars()
 		stdLogger, _, err := utilities.InitLogger(utilities.LoggerParams{Mode: "stdout"})
@@ -103,3 +118,39 @@ func runSqlOnDb(t *testing.T, db *sql.DB, sqlStmts []TestSqlStmts) {
 		assert.NoError(t, err)
 	}
 }
+
+func Test_connStrWithStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name             string
+		connStr          string
+		statementTimeout time.Duration
+		output           string
+	}{
+		{
+			name:             "leaves the connection string alone without a timeout",
+			connStr:          "user=some_user host=localhost dbname=some_db",
+			statementTimeout: 0,
+			output:           "user=some_user host=localhost dbname=some_db",
+		},
+		{
+			name:             "adds the timeout in milliseconds to a key value connection string",
+			connStr:          "user=some_user host=localhost dbname=some_db",
+			statementTimeout: 10 * time.Second,
+			output:           "user=some_user host=localhost dbname=some_db statement_timeout=10000",
+		},
+		{
+			name:             "converts a URL to a key value connection string",
+			connStr:          "postgres://some_user@localhost:5432/some_db",
+			statementTimeout: 1500 * time.Millisecond,
+			output:           "dbname='some_db' host='localhost' port='5432' user='some_user' statement_timeout=1500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connStr, err := connStrWithStatementTimeout(tt.connStr, tt.statementTimeout)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, connStr)
+		})
+	}
+}
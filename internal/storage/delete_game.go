@@ -1,18 +1,22 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
-func (s *Storage) DeleteGame(gameId string) error {
+func (s *Storage) DeleteGame(ctx context.Context, gameId string) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(gameId) {
 		return errors.New("gameId cannot be blank")
 	}
 
-	result, err := s.db.Exec(`DELETE FROM public."games" WHERE id = $1`, gameId)
+	result, err := s.db.ExecContext(ctx, `DELETE FROM public."games" WHERE id = $1`, gameId)
 	if err != nil {
 		return err
 	}
@@ -25,5 +29,9 @@ func (s *Storage) DeleteGame(gameId string) error {
 	if rowsAffected != 1 {
 		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when deleting game in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
 	}
+
+	if s.gameCache != nil {
+		s.gameCache.remove(gameId)
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"math/rand"
 	"testing"
@@ -9,6 +10,7 @@ import (
 )
 
 func Test_Game_DeleteGame(t *testing.T) {
+	requireTestDb(t)
 	tests := []struct {
 		name            string
 		input           string
@@ -91,11 +93,11 @@ func Test_Game_DeleteGame(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
 			rand.Seed(0)
-			err := s.DeleteGame(tt.input)
+			err := s.DeleteGame(context.Background(), tt.input)
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 			} else {
@@ -103,7 +105,7 @@ func Test_Game_DeleteGame(t *testing.T) {
 				assert.EqualError(t, err, tt.errorString)
 			}
 			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
 			}
 		})
 	}
@@ -1,21 +1,26 @@
 package storage
 
 import (
+	"context"
 	"time"
 
 	"github.com/vipulvpatil/airetreat-go/internal/model"
 )
 
 type GameAccessor interface {
-	CreateGame(public bool) (string, error)
-	GetGame(gameId string) (*model.Game, error)
-	GetGameUsingTransaction(gameId string, transaction DatabaseTransaction) (*model.Game, error)
-	GetGames(playerId string) ([]string, error)
-	UpdateGameState(gameId string, updateOpts GameUpdateOptions) error
-	UpdateGameStateUsingTransaction(gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error
-	GetUnhandledGameIdsForState(gameStateString string) ([]string, error)
-	DeleteGame(gameId string) error
-	GetOldGames(gameExpiryDuration time.Duration) ([]string, error)
-	UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(gameId string, transaction DatabaseTransaction) error
-	GetAutoJoinableGames() ([]string, error)
+	CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error)
+	GetGame(ctx context.Context, gameId string) (*model.Game, error)
+	GetGameUsingTransaction(ctx context.Context, gameId string, transaction DatabaseTransaction) (*model.Game, error)
+	GetGames(ctx context.Context, playerId string) ([]string, error)
+	UpdateGameState(ctx context.Context, gameId string, updateOpts GameUpdateOptions) error
+	UpdateGameStateUsingTransaction(ctx context.Context, gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error
+	GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error)
+	ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error)
+	GetGameIdsForAiAccusation(ctx context.Context) ([]string, error)
+	GetGameIdsForVotingToClose(ctx context.Context) ([]string, error)
+	GetGameIdsForAnalysis(ctx context.Context) ([]string, error)
+	DeleteGame(ctx context.Context, gameId string) error
+	GetOldGames(ctx context.Context, gameExpiryDuration time.Duration) ([]string, error)
+	UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx context.Context, gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error
+	GetAutoJoinableGames(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type GameAnalysisAccessor interface {
+	CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error
+	GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error)
+}
+
+// A game analysis is stored as one row per bot, so that it goes away along with the game's bots.
+func (s *Storage) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(analysis.GameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if len(analysis.BotAnalyses) == 0 {
+		return errors.New("botAnalyses cannot be empty")
+	}
+
+	for _, botAnalysis := range analysis.BotAnalyses {
+		id := s.IdGenerator.Generate()
+		err := createBotAnalysis(ctx, transaction, id, botAnalysis)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createBotAnalysis(ctx context.Context, customDb customDbHandler, id string, botAnalysis model.BotAnalysis) error {
+	if utilities.IsBlank(botAnalysis.BotId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	if botAnalysis.HumannessScore < 0 || botAnalysis.HumannessScore > 100 {
+		return errors.New("humannessScore should be between 0 and 100")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`INSERT INTO public."bot_analyses" (
+			"id", "bot_id", "humanness_score", "tells"
+		)
+		VALUES (
+			$1, $2, $3, $4
+		)`,
+		id, botAnalysis.BotId, botAnalysis.HumannessScore, pq.Array(botAnalysis.Tells),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while creating bot analysis: %s", botAnalysis.BotId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row after creating bot analysis: %s", botAnalysis.BotId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when creating bot analysis in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+
+	return nil
+}
+
+func (s *Storage) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("gameId cannot be blank")
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT ba.bot_id, ba.humanness_score, ba.tells
+		FROM public."bot_analyses" AS ba
+		JOIN public."bots" AS b ON ba.bot_id = b.id
+		WHERE b.game_id = $1
+		ORDER BY b.id ASC
+		`, gameId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "error getting game analysis")
+	}
+	defer rows.Close()
+
+	botAnalyses := []model.BotAnalysis{}
+
+	for rows.Next() {
+		var botAnalysis model.BotAnalysis
+		err := rows.Scan(
+			&botAnalysis.BotId,
+			&botAnalysis.HumannessScore,
+			pq.Array(&botAnalysis.Tells),
+		)
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		if botAnalysis.Tells == nil {
+			botAnalysis.Tells = []string{}
+		}
+		botAnalyses = append(botAnalyses, botAnalysis)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through bot analysis rows")
+	}
+
+	if len(botAnalyses) == 0 {
+		return nil, errors.Errorf("game has not been analyzed yet: %s", gameId)
+	}
+
+	return &model.GameAnalysis{
+		GameId:      gameId,
+		BotAnalyses: botAnalyses,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+type GameAnalysisAccessorMockSuccess struct {
+	GameAnalysis *model.GameAnalysis
+}
+
+func (g *GameAnalysisAccessorMockSuccess) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	return nil
+}
+
+func (g *GameAnalysisAccessorMockSuccess) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	return g.GameAnalysis, nil
+}
+
+type GameAnalysisAccessorMockFailure struct {
+}
+
+func (g *GameAnalysisAccessorMockFailure) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	return errors.New("unable to create game analysis")
+}
+
+func (g *GameAnalysisAccessorMockFailure) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	return nil, errors.New("unable to get game analysis")
+}
+
+type GameAnalysisAccessorConfigurableMock struct {
+	CreateGameAnalysisUsingTransactionInternal func(analysis model.GameAnalysis, transaction DatabaseTransaction) error
+	GetGameAnalysisInternal                    func(gameId string) (*model.GameAnalysis, error)
+}
+
+func (g *GameAnalysisAccessorConfigurableMock) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	return g.CreateGameAnalysisUsingTransactionInternal(analysis, transaction)
+}
+
+func (g *GameAnalysisAccessorConfigurableMock) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	return g.GetGameAnalysisInternal(gameId)
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func Test_CreateGameAnalysisUsingTransaction(t *testing.T) {
+	requireTestDb(t)
+	tests := []struct {
+		name            string
+		input           model.GameAnalysis
+		setupSqlStmts   []TestSqlStmts
+		cleanupSqlStmts []TestSqlStmts
+		idGenerator     utilities.CuidGenerator
+		dbUpdateCheck   func(*sql.DB) bool
+		errorExpected   bool
+		errorString     string
+	}{
+		{
+			name:          "errors if gameId is blank",
+			input:         model.GameAnalysis{BotAnalyses: []model.BotAnalysis{{BotId: "bot_id1", HumannessScore: 50}}},
+			idGenerator:   &utilities.IdGeneratorMockConstant{Id: "bot_analysis_id1"},
+			errorExpected: true,
+			errorString:   "gameId cannot be blank",
+		},
+		{
+			name:          "errors if there are no bot analyses",
+			input:         model.GameAnalysis{GameId: "game_id1"},
+			idGenerator:   &utilities.IdGeneratorMockConstant{Id: "bot_analysis_id1"},
+			errorExpected: true,
+			errorString:   "botAnalyses cannot be empty",
+		},
+		{
+			name:          "errors if a humanness score is out of range",
+			input:         model.GameAnalysis{GameId: "game_id1", BotAnalyses: []model.BotAnalysis{{BotId: "bot_id1", HumannessScore: 101}}},
+			idGenerator:   &utilities.IdGeneratorMockConstant{Id: "bot_analysis_id1"},
+			errorExpected: true,
+			errorString:   "humannessScore should be between 0 and 100",
+		},
+		{
+			name: "creates a row for every bot",
+			input: model.GameAnalysis{
+				GameId: "game_id1",
+				BotAnalyses: []model.BotAnalysis{
+					{BotId: "bot_id1", HumannessScore: 30, Tells: []string{"I am a very polite bot."}},
+					{BotId: "bot_id2", HumannessScore: 80, Tells: []string{"lol"}},
+				},
+			},
+			setupSqlStmts: []TestSqlStmts{
+				{
+					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order")
+					VALUES ('game_id1', 'FINISHED', 8, Array['bot_id1','bot_id2'])`,
+				},
+				{
+					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+					VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
+				},
+				{
+					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+					VALUES ('bot_id2', 'bot2', 'HUMAN', 'game_id1')`,
+				},
+			},
+			cleanupSqlStmts: []TestSqlStmts{
+				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+			},
+			idGenerator: &utilities.IdGeneratorMockSeries{Series: []string{"bot_analysis_id1", "bot_analysis_id2"}},
+			dbUpdateCheck: func(db *sql.DB) bool {
+				var botId string
+				var humannessScore int64
+				var tells []string
+				err := db.QueryRow(
+					`SELECT "bot_id", "humanness_score", "tells"
+						FROM public."bot_analyses" WHERE "id" = 'bot_analysis_id2'`,
+				).Scan(&botId, &humannessScore, pq.Array(&tells))
+				assert.NoError(t, err)
+				assert.Equal(t, "bot_id2", botId)
+				assert.Equal(t, int64(80), humannessScore)
+				assert.Equal(t, []string{"lol"}, tells)
+				return true
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewDbStorage(
+				StorageOptions{
+					Db:          testDb,
+					IdGenerator: tt.idGenerator,
+				},
+			)
+
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
+
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			err = s.CreateGameAnalysisUsingTransaction(context.Background(), tt.input, tx)
+			tx.Commit()
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+			if tt.dbUpdateCheck != nil {
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
+			}
+		})
+	}
+}
+
+func Test_GetGameAnalysis(t *testing.T) {
+	requireTestDb(t)
+	tests := []struct {
+		name            string
+		input           string
+		output          *model.GameAnalysis
+		setupSqlStmts   []TestSqlStmts
+		cleanupSqlStmts []TestSqlStmts
+		errorExpected   bool
+		errorString     string
+	}{
+		{
+			name:          "errors if gameId is blank",
+			input:         "",
+			errorExpected: true,
+			errorString:   "gameId cannot be blank",
+		},
+		{
+			name:  "errors if the game has not been analyzed",
+			input: "game_id1",
+			setupSqlStmts: []TestSqlStmts{
+				{
+					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order")
+					VALUES ('game_id1', 'FINISHED', 8, Array['bot_id1'])`,
+				},
+				{
+					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+					VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
+				},
+			},
+			cleanupSqlStmts: []TestSqlStmts{
+				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+			},
+			errorExpected: true,
+			errorString:   "game has not been analyzed yet: game_id1",
+		},
+		{
+			name:  "returns the analysis of every bot",
+			input: "game_id1",
+			output: &model.GameAnalysis{
+				GameId: "game_id1",
+				BotAnalyses: []model.BotAnalysis{
+					{BotId: "bot_id1", HumannessScore: 30, Tells: []string{"I am a very polite bot."}},
+					{BotId: "bot_id2", HumannessScore: 80, Tells: []string{}},
+				},
+			},
+			setupSqlStmts: []TestSqlStmts{
+				{
+					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order")
+					VALUES ('game_id1', 'FINISHED', 8, Array['bot_id1','bot_id2'])`,
+				},
+				{
+					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+					VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
+				},
+				{
+					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+					VALUES ('bot_id2', 'bot2', 'HUMAN', 'game_id1')`,
+				},
+				{
+					Query: `INSERT INTO public."bot_analyses" ("id", "bot_id", "humanness_score", "tells")
+					VALUES ('bot_analysis_id1', 'bot_id1', 30, Array['I am a very polite bot.'])`,
+				},
+				{
+					Query: `INSERT INTO public."bot_analyses" ("id", "bot_id", "humanness_score")
+					VALUES ('bot_analysis_id2', 'bot_id2', 80)`,
+				},
+			},
+			cleanupSqlStmts: []TestSqlStmts{
+				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewDbStorage(
+				StorageOptions{
+					Db: testDb,
+				},
+			)
+
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
+
+			analysis, err := s.GetGameAnalysis(context.Background(), tt.input)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, analysis)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
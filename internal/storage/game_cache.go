@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+// GameCacheStats counts how often GetGame was served from the cache.
+type GameCacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+func (s GameCacheStats) HitRate() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+// gameCache is an in-process LRU of loaded games. An entry is only used while its version matches the version in
+// the database, which keeps instances from serving each other's stale games.
+type gameCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	stats    GameCacheStats
+}
+
+type gameCacheEntry struct {
+	gameId  string
+	version int64
+	game    *model.Game
+}
+
+func newGameCache(capacity int) *gameCache {
+	return &gameCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+func (c *gameCache) get(gameId string, version int64) *model.Game {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[gameId]
+	if !ok || element.Value.(*gameCacheEntry).version != version {
+		c.stats.Misses++
+		return nil
+	}
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	return element.Value.(*gameCacheEntry).game
+}
+
+func (c *gameCache) put(gameId string, version int64, game *model.Game) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[gameId]
+	if ok {
+		entry := element.Value.(*gameCacheEntry)
+		// A slower load must not replace a newer game.
+		if entry.version > version {
+			return
+		}
+		entry.version = version
+		entry.game = game
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[gameId] = c.order.PushFront(&gameCacheEntry{gameId: gameId, version: version, game: game})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*gameCacheEntry).gameId)
+	}
+}
+
+func (c *gameCache) remove(gameId string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[gameId]
+	if ok {
+		c.order.Remove(element)
+		delete(c.entries, gameId)
+	}
+}
+
+func (c *gameCache) currentStats() GameCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stats
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+func Test_gameCache(t *testing.T) {
+	newGame := func(gameId string) *model.Game {
+		bot, err := model.NewBot(model.BotOptions{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"})
+		assert.NoError(t, err)
+		game, err := model.NewGame(model.GameOptions{Id: gameId, State: "STARTED", TurnOrder: []string{"bot_id1"}, Bots: []*model.Bot{bot}})
+		assert.NoError(t, err)
+		return game
+	}
+	game1 := newGame("game_id1")
+	game2 := newGame("game_id2")
+	game3 := newGame("game_id3")
+
+	t.Run("only returns games at the requested version", func(t *testing.T) {
+		cache := newGameCache(2)
+		cache.put("game_id1", 1, game1)
+		assert.Same(t, game1, cache.get("game_id1", 1))
+		assert.Nil(t, cache.get("game_id1", 2))
+		assert.Nil(t, cache.get("game_id2", 0))
+		assert.Equal(t, GameCacheStats{Hits: 1, Misses: 2}, cache.currentStats())
+	})
+
+	t.Run("does not replace a newer game with an older one", func(t *testing.T) {
+		cache := newGameCache(2)
+		cache.put("game_id1", 2, game1)
+		cache.put("game_id1", 1, game2)
+		assert.Same(t, game1, cache.get("game_id1", 2))
+	})
+
+	t.Run("evicts the least recently used game", func(t *testing.T) {
+		cache := newGameCache(2)
+		cache.put("game_id1", 0, game1)
+		cache.put("game_id2", 0, game2)
+		cache.get("game_id1", 0)
+		cache.put("game_id3", 0, game3)
+		assert.Same(t, game1, cache.get("game_id1", 0))
+		assert.Nil(t, cache.get("game_id2", 0))
+		assert.Same(t, game3, cache.get("game_id3", 0))
+	})
+
+	t.Run("removes games", func(t *testing.T) {
+		cache := newGameCache(2)
+		cache.put("game_id1", 0, game1)
+		cache.remove("game_id1")
+		assert.Nil(t, cache.get("game_id1", 0))
+	})
+}
+
+func Test_GetGame_WithCache(t *testing.T) {
+	requireTestDb(t)
+	s, _ := NewDbStorage(
+		StorageOptions{
+			Db:            testDb,
+			GameCacheSize: 10,
+		},
+	)
+
+	runSqlOnDb(t, s.db.DB, []TestSqlStmts{
+		{
+			Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
+			VALUES ('game_id1', 'STARTED', 0, Array['bot_id1','bot_id2'], false)`,
+		},
+		{
+			Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+			VALUES ('bot_id1', 'bot1', 'AI', 'game_id1'), ('bot_id2', 'bot2', 'AI', 'game_id1')`,
+		},
+	})
+	defer runSqlOnDb(t, s.db.DB, []TestSqlStmts{
+		{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+	})
+
+	game, err := s.GetGame(context.Background(), "game_id1")
+	assert.NoError(t, err)
+	assert.Empty(t, game.GetDetailedMessages())
+
+	cachedGame, err := s.GetGame(context.Background(), "game_id1")
+	assert.NoError(t, err)
+	assert.Same(t, game, cachedGame)
+
+	err = s.CreateMessage(context.Background(), "bot_id1", "bot_id2", "what is your name?", "question", MessageMetadata{})
+	assert.NoError(t, err)
+
+	updatedGame, err := s.GetGame(context.Background(), "game_id1")
+	assert.NoError(t, err)
+	assert.NotSame(t, game, updatedGame)
+	assert.Len(t, updatedGame.GetDetailedMessages(), 1)
+
+	assert.Equal(t, GameCacheStats{Hits: 1, Misses: 2}, s.GameCacheStats())
+}
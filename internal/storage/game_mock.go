@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -12,7 +13,7 @@ type GameCreatorMockSuccess struct {
 	GameId string
 }
 
-func (g *GameCreatorMockSuccess) CreateGame(public bool) (string, error) {
+func (g *GameCreatorMockSuccess) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
 	return g.GameId, nil
 }
 
@@ -20,7 +21,7 @@ type GameCreatorMockFailure struct {
 	GameAccessor
 }
 
-func (g *GameCreatorMockFailure) CreateGame(public bool) (string, error) {
+func (g *GameCreatorMockFailure) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
 	return "", errors.New("unable to create game")
 }
 
@@ -29,7 +30,7 @@ type GameGetterMockSuccess struct {
 	Game *model.Game
 }
 
-func (g *GameGetterMockSuccess) GetGame(string) (*model.Game, error) {
+func (g *GameGetterMockSuccess) GetGame(context.Context, string) (*model.Game, error) {
 	return g.Game, nil
 }
 
@@ -37,7 +38,7 @@ type GameGetterMockFailure struct {
 	GameAccessor
 }
 
-func (g *GameGetterMockFailure) GetGame(string) (*model.Game, error) {
+func (g *GameGetterMockFailure) GetGame(context.Context, string) (*model.Game, error) {
 	return nil, errors.New("unable to get game")
 }
 
@@ -46,7 +47,7 @@ type GamesGetterMockSuccess struct {
 	GameIds []string
 }
 
-func (g *GamesGetterMockSuccess) GetGames(string) ([]string, error) {
+func (g *GamesGetterMockSuccess) GetGames(context.Context, string) ([]string, error) {
 	return g.GameIds, nil
 }
 
@@ -54,7 +55,7 @@ type GamesGetterMockFailure struct {
 	GameAccessor
 }
 
-func (g *GamesGetterMockFailure) GetGames(string) ([]string, error) {
+func (g *GamesGetterMockFailure) GetGames(context.Context, string) ([]string, error) {
 	return nil, errors.New("unable to get games")
 }
 
@@ -62,7 +63,23 @@ type GameIdsGetterMockNil struct {
 	GameAccessor
 }
 
-func (g *GameIdsGetterMockNil) GetUnhandledGameIdsForState(gameStateString string) ([]string, error) {
+func (g *GameIdsGetterMockNil) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
+	return nil, nil
+}
+
+func (g *GameIdsGetterMockNil) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	return nil, nil
+}
+
+func (g *GameIdsGetterMockNil) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (g *GameIdsGetterMockNil) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (g *GameIdsGetterMockNil) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
 	return nil, nil
 }
 
@@ -70,7 +87,23 @@ type GameIdsGetterMockEmpty struct {
 	GameAccessor
 }
 
-func (g *GameIdsGetterMockEmpty) GetUnhandledGameIdsForState(gameStateString string) ([]string, error) {
+func (g *GameIdsGetterMockEmpty) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (g *GameIdsGetterMockEmpty) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	return []ClaimedGame{}, nil
+}
+
+func (g *GameIdsGetterMockEmpty) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+func (g *GameIdsGetterMockEmpty) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+func (g *GameIdsGetterMockEmpty) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
 	return []string{}, nil
 }
 
@@ -82,42 +115,58 @@ type GameAccessorConfigurableMock struct {
 	UpdateGameStateInternal                                          func(gameId string, updateOpts GameUpdateOptions) error
 	UpdateGameStateUsingTransactionInternal                          func(gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error
 	GetUnhandledGameIdsForStateInternal                              func(gameStateString string) ([]string, error)
+	ClaimUnhandledGamesInternal                                      func(gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error)
+	GetGameIdsForAiAccusationInternal                                func() ([]string, error)
+	GetGameIdsForVotingToCloseInternal                               func() ([]string, error)
+	GetGameIdsForAnalysisInternal                                    func() ([]string, error)
 	DeleteGameInternal                                               func(gameId string) error
 	GetOldGamesInternal                                              func(gameExpiryDuration time.Duration) ([]string, error)
-	UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal func(gameId string, transaction DatabaseTransaction) error
+	UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal func(gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error
 	GetAutoJoinableGamesInternal                                     func() ([]string, error)
 }
 
-func (g *GameAccessorConfigurableMock) CreateGame(public bool) (string, error) {
+func (g *GameAccessorConfigurableMock) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
 	return g.CreateGameInternal()
 }
-func (g *GameAccessorConfigurableMock) GetGame(gameId string) (*model.Game, error) {
+func (g *GameAccessorConfigurableMock) GetGame(ctx context.Context, gameId string) (*model.Game, error) {
 	return g.GetGameInternal(gameId)
 }
-func (g *GameAccessorConfigurableMock) GetGameUsingTransaction(gameId string, transaction DatabaseTransaction) (*model.Game, error) {
+func (g *GameAccessorConfigurableMock) GetGameUsingTransaction(ctx context.Context, gameId string, transaction DatabaseTransaction) (*model.Game, error) {
 	return g.GetGameUsingTransactionInternal(gameId, transaction)
 }
-func (g *GameAccessorConfigurableMock) GetGames(playerId string) ([]string, error) {
+func (g *GameAccessorConfigurableMock) GetGames(ctx context.Context, playerId string) ([]string, error) {
 	return g.GetGamesInternal(playerId)
 }
-func (g *GameAccessorConfigurableMock) UpdateGameState(gameId string, updateOpts GameUpdateOptions) error {
+func (g *GameAccessorConfigurableMock) UpdateGameState(ctx context.Context, gameId string, updateOpts GameUpdateOptions) error {
 	return g.UpdateGameStateInternal(gameId, updateOpts)
 }
-func (g *GameAccessorConfigurableMock) UpdateGameStateUsingTransaction(gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
+func (g *GameAccessorConfigurableMock) UpdateGameStateUsingTransaction(ctx context.Context, gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
 	return g.UpdateGameStateUsingTransactionInternal(gameId, updateOpts, transaction)
 }
-func (g *GameAccessorConfigurableMock) GetUnhandledGameIdsForState(gameStateString string) ([]string, error) {
+func (g *GameAccessorConfigurableMock) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
 	return g.GetUnhandledGameIdsForStateInternal(gameStateString)
 }
-func (g *GameAccessorConfigurableMock) DeleteGame(gameId string) error {
+func (g *GameAccessorConfigurableMock) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	return g.ClaimUnhandledGamesInternal(gameStates, limit, claimTimeout)
+}
+func (g *GameAccessorConfigurableMock) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	return g.GetGameIdsForAiAccusationInternal()
+}
+func (g *GameAccessorConfigurableMock) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	return g.GetGameIdsForVotingToCloseInternal()
+}
+func (g *GameAccessorConfigurableMock) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
+	return g.GetGameIdsForAnalysisInternal()
+}
+func (g *GameAccessorConfigurableMock) DeleteGame(ctx context.Context, gameId string) error {
 	return g.DeleteGameInternal(gameId)
 }
-func (g *GameAccessorConfigurableMock) GetOldGames(gameExpiryDuration time.Duration) ([]string, error) {
+func (g *GameAccessorConfigurableMock) GetOldGames(ctx context.Context, gameExpiryDuration time.Duration) ([]string, error) {
 	return g.GetOldGamesInternal(gameExpiryDuration)
 }
-func (g *GameAccessorConfigurableMock) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(gameId string, transaction DatabaseTransaction) error {
-	return g.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal(gameId, transaction)
+func (g *GameAccessorConfigurableMock) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx context.Context, gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error {
+	return g.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransactionInternal(gameId, humanPlayerCount, transaction)
 }
-func (g *GameAccessorConfigurableMock) GetAutoJoinableGames() ([]string, error) {
+func (g *GameAccessorConfigurableMock) GetAutoJoinableGames(ctx context.Context) ([]string, error) {
 	return g.GetAutoJoinableGamesInternal()
 }
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// Every write to a game, its bots, its messages or its votes bumps the game's version. Cached games are keyed by
+// version, so a write from any instance makes every cached copy of the game stale.
+func bumpGameVersion(ctx context.Context, customDb customDbHandler, gameId string) error {
+	_, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."games" SET "version" = "version" + 1 WHERE id = $1`, gameId,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while bumping game version: %s", gameId))
+	}
+	return nil
+}
+
+func bumpGameVersionForBot(ctx context.Context, customDb customDbHandler, botId string) error {
+	_, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."games" SET "version" = "version" + 1
+		WHERE id = (SELECT b.game_id FROM public."bots" AS b WHERE b.id = $1)`, botId,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while bumping game version for bot: %s", botId))
+	}
+	return nil
+}
+
+func getGameVersion(ctx context.Context, customDb customDbHandler, gameId string) (int64, error) {
+	var version int64
+	err := customDb.QueryRowContext(ctx, `SELECT g.version FROM public."games" AS g WHERE g.id = $1`, gameId).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
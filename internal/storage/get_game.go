@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
@@ -9,176 +12,443 @@ import (
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
-func (s *Storage) GetGame(gameId string) (*model.Game, error) {
-	return getGameUsingCustomDbHandler(s.db, gameId, false)
+// GetGame serves the game from the cache when the cached copy is still at the game's current version. That costs a
+// single row lookup instead of loading the whole game.
+// Otherwise the game is loaded from the read replica, if there is one. The current version is always looked up on the
+// primary, so that a replica that is lagging behind is caught, and a player sees their own changes straight away.
+func (s *Storage) GetGame(ctx context.Context, gameId string) (*model.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("cannot getGame for a blank gameId")
+	}
+
+	if s.gameCache == nil && s.replica == nil {
+		return getGameSnapshot(ctx, s.db, gameId)
+	}
+
+	version, err := getGameVersion(ctx, s.db, gameId)
+	if err != nil {
+		return getGameSnapshot(ctx, s.db, gameId)
+	}
+
+	if s.gameCache != nil {
+		game := s.gameCache.get(gameId, version)
+		if game != nil {
+			return game, nil
+		}
+	}
+
+	game, err := s.getGameFromReplica(ctx, gameId, version)
+	if err != nil {
+		return nil, err
+	}
+	if s.gameCache != nil {
+		s.gameCache.put(gameId, game.Version(), game)
+	}
+	return game, nil
 }
 
-func (s *Storage) GetGameUsingTransaction(gameId string, transaction DatabaseTransaction) (*model.Game, error) {
-	return getGameUsingCustomDbHandler(transaction, gameId, true)
+// getGameFromReplica falls back to the primary when the replica has not caught up with version yet. A replica that is
+// that far behind may not have the game at all, so it falls back on any error too.
+func (s *Storage) getGameFromReplica(ctx context.Context, gameId string, version int64) (*model.Game, error) {
+	if s.replica != nil {
+		game, err := getGameSnapshot(ctx, s.replica, gameId)
+		if err == nil && game.Version() >= version {
+			return game, nil
+		}
+	}
+	return getGameSnapshot(ctx, s.db, gameId)
 }
 
-func getGameUsingCustomDbHandler(customDb customDbHandler, gameId string, exclusiveLock bool) (*model.Game, error) {
+// getGameSnapshot loads the game in a short read-only REPEATABLE READ transaction, so that all of its queries see the
+// same snapshot. Outside a transaction, each query would see whatever was committed when it started, and a message sent
+// in between could come back along with the state and turn from before it.
+func getGameSnapshot(ctx context.Context, db snapshotDb, gameId string) (*model.Game, error) {
+	tx, err := db.beginSnapshot(ctx)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	game, err := getGameUsingCustomDbHandler(ctx, tx, gameId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to commit db transaction")
+	}
+	return game, nil
+}
+
+func (s *Storage) GetGameUsingTransaction(ctx context.Context, gameId string, transaction DatabaseTransaction) (*model.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getGameUsingCustomDbHandler(ctx, transaction, gameId, true)
+}
+
+// A game is loaded with separate queries for the game, its bots, its messages and its votes. Only the game row is
+// locked, which is enough to serialize all updates to the game.
+// The version is read along with the game row, before the bots, messages and votes. So the game is never older than
+// its version.
+func getGameUsingCustomDbHandler(ctx context.Context, customDb customDbHandler, gameId string, exclusiveLock bool) (*model.Game, error) {
 	if utilities.IsBlank(gameId) {
 		return nil, errors.New("cannot getGame for a blank gameId")
 	}
 
-	var (
-		opts           model.GameOptions
-		stateHandledAt sql.NullTime
-	)
+	opts, err := getGameOptions(ctx, customDb, gameId, exclusiveLock)
+	if err != nil {
+		return nil, err
+	}
 
-	queryWithoutLock := `SELECT
-	g.id, g.state, g.current_turn_index, g.turn_order,
-	g.state_handled, g.state_handled_at, g.state_total_time,
-	g.last_question, g.last_question_target_bot_id,
-	g.result, g.winning_bot_id, g.public,
-	g.created_at, g.updated_at,
-	b.id, b.name, b.type, b.player_id, b.help_count,
-	m.source_bot_id, m.target_bot_id, m.text, m.created_at, m.type
-	FROM public."games" AS g
-	LEFT JOIN public."bots" AS b ON b.game_id = g.id
-	LEFT JOIN public."messages" AS m ON m.target_bot_id = b.id
-	WHERE g.id = $1
-	ORDER BY b.created_at ASC, b.id ASC, m.created_at ASC, m.id ASC`
-
-	queryWithLock := `SELECT
+	games, err := hydrateGames(ctx, customDb, []*model.GameOptions{opts})
+	if err != nil {
+		return nil, err
+	}
+	return games[0], nil
+}
+
+// hydrateGames loads the bots, messages and votes of the games with a query each, however many games there are.
+func hydrateGames(ctx context.Context, customDb customDbHandler, gameOptsList []*model.GameOptions) ([]*model.Game, error) {
+	gameIds := []string{}
+	for _, opts := range gameOptsList {
+		gameIds = append(gameIds, opts.Id)
+	}
+
+	botsByGameId, err := getBotsForGames(ctx, customDb, gameIds)
+	if err != nil {
+		return nil, err
+	}
+
+	messagesByGameId, err := getMessagesForGames(ctx, customDb, gameIds)
+	if err != nil {
+		return nil, err
+	}
+
+	votesByGameId, err := getVotesForGames(ctx, customDb, gameIds)
+	if err != nil {
+		return nil, err
+	}
+
+	games := []*model.Game{}
+	for _, opts := range gameOptsList {
+		opts.Bots = botsByGameId[opts.Id]
+		if len(opts.Bots) == 0 {
+			return nil, utilities.NewBadError(fmt.Sprintf("game has no bots: %s", opts.Id))
+		}
+		opts.Messages = messagesByGameId[opts.Id]
+		opts.Votes = votesByGameId[opts.Id]
+
+		game, err := model.NewGame(*opts)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed to create game")
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+const selectGamesSql = `SELECT
 	g.id, g.state, g.current_turn_index, g.turn_order,
 	g.state_handled, g.state_handled_at, g.state_total_time,
 	g.last_question, g.last_question_target_bot_id,
-	g.result, g.winning_bot_id, g.public,
-	g.created_at, g.updated_at,
-	b.id, b.name, b.type, b.player_id, b.help_count,
-	m.source_bot_id, m.target_bot_id, m.text, m.created_at, m.type
-	FROM public."games" AS g
-	LEFT JOIN public."bots" AS b ON b.game_id = g.id
-	LEFT JOIN public."messages" AS m ON m.target_bot_id = b.id
-	WHERE g.id = $1
-	ORDER BY b.created_at ASC, b.id ASC, m.created_at ASC, m.id ASC
-	FOR UPDATE OF g`
+	g.result, g.winning_bot_id, g.public, g.prompt_version,
+	g.conversation_summary, g.summarized_message_count, g.ai_accusations, g.elimination,
+	g.voting_rounds, g.ai_votes, g.mode, g.decoy_bot_id, g.ai_accusation_checked_at,
+	g.created_at, g.updated_at, g.version
+	FROM public."games" AS g`
 
-	var query string
+func getGameOptions(ctx context.Context, customDb customDbHandler, gameId string, exclusiveLock bool) (*model.GameOptions, error) {
+	query := selectGamesSql + `
+	WHERE g.id = $1`
 
 	if exclusiveLock {
-		query = queryWithLock
-	} else {
-		query = queryWithoutLock
+		query += `
+	FOR UPDATE OF g`
 	}
 
-	rows, err := customDb.Query(query, gameId)
+	opts, err := scanGameOptions(customDb.QueryRowContext(ctx, query, gameId))
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("game not found: %s", gameId)
+	}
 	if err != nil {
 		return nil, utilities.WrapBadError(err, "failed to select game")
 	}
-	defer rows.Close()
+	return opts, nil
+}
 
-	botOptsMap := map[string]model.BotOptions{}
-	botOptsOrderedIds := []string{}
+// getGamesOptions leaves out the games that do not exist.
+func getGamesOptions(ctx context.Context, customDb customDbHandler, gameIds []string) ([]*model.GameOptions, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		selectGamesSql+`
+	WHERE g.id = ANY($1)
+	ORDER BY g.created_at ASC, g.id ASC`,
+		pq.Array(gameIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select games")
+	}
+	defer rows.Close()
 
+	gameOptsList := []*model.GameOptions{}
 	for rows.Next() {
-		var botOpts model.BotOptions
-		var playerId sql.NullString
-		var messageSourceBotId sql.NullString
-		var messageTargetBotId sql.NullString
-		var messageText sql.NullString
-		var messageType sql.NullString
-		var lastQuestion sql.NullString
-		var lastQuestionTargetBotId sql.NullString
-		var result sql.NullString
-		var winningBotId sql.NullString
-		var messageCreatedAt sql.NullTime
-		err := rows.Scan(
-			&opts.Id,
-			&opts.State,
-			&opts.CurrentTurnIndex,
-			pq.Array(&opts.TurnOrder),
-			&opts.StateHandled,
-			&stateHandledAt,
-			&opts.StateTotalTime,
-			&lastQuestion,
-			&lastQuestionTargetBotId,
-			&result,
-			&winningBotId,
-			&opts.Public,
-			&opts.CreatedAt,
-			&opts.UpdatedAt,
-			&botOpts.Id,
-			&botOpts.Name,
-			&botOpts.TypeOfBot,
-			&playerId,
-			&botOpts.HelpCount,
-			&messageSourceBotId,
-			&messageTargetBotId,
-			&messageText,
-			&messageCreatedAt,
-			&messageType,
-		)
-
-		if lastQuestion.Valid {
-			opts.LastQuestion = lastQuestion.String
-		}
-		if lastQuestionTargetBotId.Valid {
-			opts.LastQuestionTargetBotId = lastQuestionTargetBotId.String
-		}
-		if result.Valid {
-			opts.Result = result.String
-		}
-		if winningBotId.Valid {
-			opts.WinningBotId = winningBotId.String
-		}
-
+		opts, err := scanGameOptions(rows)
 		if err != nil {
-			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+			return nil, utilities.WrapBadError(err, "failed while scanning game rows")
 		}
+		gameOptsList = append(gameOptsList, opts)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through game rows")
+	}
+	return gameOptsList, nil
+}
 
-		if !utilities.IsBlank(botOpts.Id) {
-			if playerId.Valid {
-				player, err := model.NewPlayer(model.PlayerOptions{Id: playerId.String})
-				if err != nil {
-					return nil, utilities.WrapBadError(err, "failed to create player")
-				}
-				botOpts.ConnectedPlayer = player
-			}
-			_, ok := botOptsMap[botOpts.Id]
-			if !ok {
-				botOptsOrderedIds = append(botOptsOrderedIds, botOpts.Id)
-				botOptsMap[botOpts.Id] = botOpts
-			}
-			if messageText.Valid {
-				message := model.Message{
-					SourceBotId: messageSourceBotId.String,
-					TargetBotId: messageTargetBotId.String,
-					Text:        messageText.String,
-					CreatedAt:   messageCreatedAt.Time,
-					MessageType: messageType.String,
-				}
-				opts.Messages = append(opts.Messages, &message)
-			}
+// rowScanner is either a *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanGameOptions scans a row selected with selectGamesSql.
+func scanGameOptions(row rowScanner) (*model.GameOptions, error) {
+	var (
+		opts                    model.GameOptions
+		stateHandledAt          sql.NullTime
+		lastQuestion            sql.NullString
+		lastQuestionTargetBotId sql.NullString
+		result                  sql.NullString
+		winningBotId            sql.NullString
+		decoyBotId              sql.NullString
+		aiAccusationCheckedAt   sql.NullTime
+		promptVersion           sql.NullString
+		conversationSummary     sql.NullString
+	)
+
+	err := row.Scan(
+		&opts.Id,
+		&opts.State,
+		&opts.CurrentTurnIndex,
+		pq.Array(&opts.TurnOrder),
+		&opts.StateHandled,
+		&stateHandledAt,
+		&opts.StateTotalTime,
+		&lastQuestion,
+		&lastQuestionTargetBotId,
+		&result,
+		&winningBotId,
+		&opts.Public,
+		&promptVersion,
+		&conversationSummary,
+		&opts.SummarizedMessageCount,
+		&opts.AiAccusations,
+		&opts.Elimination,
+		&opts.VotingRounds,
+		&opts.AiVotes,
+		&opts.Mode,
+		&decoyBotId,
+		&aiAccusationCheckedAt,
+		&opts.CreatedAt,
+		&opts.UpdatedAt,
+		&opts.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateHandledAt.Valid {
+		opts.StateHandledAt = &stateHandledAt.Time
+	}
+	opts.LastQuestion = lastQuestion.String
+	opts.LastQuestionTargetBotId = lastQuestionTargetBotId.String
+	opts.Result = result.String
+	opts.WinningBotId = winningBotId.String
+	opts.DecoyBotId = decoyBotId.String
+	if aiAccusationCheckedAt.Valid {
+		opts.AiAccusationCheckedAt = &aiAccusationCheckedAt.Time
+	}
+	opts.PromptVersion = promptVersion.String
+	opts.ConversationSummary = conversationSummary.String
+	return &opts, nil
+}
+
+func getBotsForGames(ctx context.Context, customDb customDbHandler, gameIds []string) (map[string][]*model.Bot, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT b.game_id, b.id, b.name, b.type, b.player_id, b.help_count, b.last_help_suggestions, b.stated_facts,
+		b.tag_count, b.last_tagged_at, b.eliminated
+		FROM public."bots" AS b
+		WHERE b.game_id = ANY($1)
+		ORDER BY b.created_at ASC, b.id ASC`,
+		pq.Array(gameIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select bots")
+	}
+	defer rows.Close()
+
+	botsByGameId := map[string][]*model.Bot{}
+	for rows.Next() {
+		gameId, bot, err := scanBot(rows)
+		if err != nil {
+			return nil, err
 		}
+		botsByGameId[gameId] = append(botsByGameId[gameId], bot)
 	}
 
 	err = rows.Err()
 	if err != nil {
 		return nil, utilities.WrapBadError(err, "failed to correctly go through bot rows")
 	}
+	return botsByGameId, nil
+}
 
-	for _, botOptsId := range botOptsOrderedIds {
-		bot, err := model.NewBot(botOptsMap[botOptsId])
+// scanBot scans a bot row along with the id of its game, with the columns that getBotsForGames selects.
+func scanBot(row rowScanner) (string, *model.Bot, error) {
+	var gameId string
+	var botOpts model.BotOptions
+	var playerId sql.NullString
+	var lastTaggedAt sql.NullTime
+	err := row.Scan(
+		&gameId,
+		&botOpts.Id,
+		&botOpts.Name,
+		&botOpts.TypeOfBot,
+		&playerId,
+		&botOpts.HelpCount,
+		pq.Array(&botOpts.LastHelpSuggestions),
+		pq.Array(&botOpts.StatedFacts),
+		&botOpts.TagCount,
+		&lastTaggedAt,
+		&botOpts.Eliminated,
+	)
+	if err != nil {
+		return "", nil, utilities.WrapBadError(err, "failed while scanning bot rows")
+	}
+
+	if playerId.Valid {
+		player, err := model.NewPlayer(model.PlayerOptions{Id: playerId.String})
 		if err != nil {
-			return nil, utilities.WrapBadError(err, "failed to create bot")
+			return "", nil, utilities.WrapBadError(err, "failed to create player")
 		}
-		opts.Bots = append(opts.Bots, bot)
+		botOpts.ConnectedPlayer = player
+	}
+	if lastTaggedAt.Valid {
+		botOpts.LastTaggedAt = &lastTaggedAt.Time
 	}
 
-	if stateHandledAt.Valid {
-		opts.StateHandledAt = &stateHandledAt.Time
+	bot, err := model.NewBot(botOpts)
+	if err != nil {
+		return "", nil, utilities.WrapBadError(err, "failed to create bot")
 	}
+	return gameId, bot, nil
+}
 
-	if utilities.IsBlank(opts.Id) {
-		return nil, errors.Errorf("game not found: %s", gameId)
+func getMessagesForGames(ctx context.Context, customDb customDbHandler, gameIds []string) (map[string][]*model.Message, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT m.game_id, m.source_bot_id, m.target_bot_id, m.text, m.created_at, m.type,
+		m.response_time_ms, m.help_usage, m.help_style, m.ai_model, m.prompt_version, m.prompt_tokens, m.completion_tokens
+		FROM public."messages" AS m
+		WHERE m.game_id = ANY($1)
+		ORDER BY m.created_at ASC, m.id ASC`,
+		pq.Array(gameIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select messages")
+	}
+	defer rows.Close()
+
+	messagesByGameId := map[string][]*model.Message{}
+	for rows.Next() {
+		gameId, message, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messagesByGameId[gameId] = append(messagesByGameId[gameId], message)
 	}
 
-	game, err := model.NewGame(opts)
+	err = rows.Err()
 	if err != nil {
-		return nil, utilities.WrapBadError(err, "failed to create game")
+		return nil, utilities.WrapBadError(err, "failed to correctly go through message rows")
 	}
-	return game, nil
+	return messagesByGameId, nil
+}
+
+// scanMessage scans a message row along with the id of its game, with the columns that getMessagesForGames selects.
+func scanMessage(row rowScanner) (string, *model.Message, error) {
+	var gameId string
+	var message model.Message
+	var responseTimeMs sql.NullInt64
+	var helpUsage sql.NullString
+	var helpStyle sql.NullString
+	var aiModel sql.NullString
+	var promptVersion sql.NullString
+	var promptTokens sql.NullInt64
+	var completionTokens sql.NullInt64
+	err := row.Scan(
+		&gameId,
+		&message.SourceBotId,
+		&message.TargetBotId,
+		&message.Text,
+		&message.CreatedAt,
+		&message.MessageType,
+		&responseTimeMs,
+		&helpUsage,
+		&helpStyle,
+		&aiModel,
+		&promptVersion,
+		&promptTokens,
+		&completionTokens,
+	)
+	if err != nil {
+		return "", nil, utilities.WrapBadError(err, "failed while scanning message rows")
+	}
+
+	message.ResponseTime = time.Duration(responseTimeMs.Int64) * time.Millisecond
+	message.HelpUsage = helpUsage.String
+	message.HelpStyle = helpStyle.String
+	message.AiModel = aiModel.String
+	message.PromptVersion = promptVersion.String
+	message.PromptTokens = promptTokens.Int64
+	message.CompletionTokens = completionTokens.Int64
+	return gameId, &message, nil
+}
+
+func getVotesForGames(ctx context.Context, customDb customDbHandler, gameIds []string) (map[string][]*model.Vote, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT v.game_id, v.voter_bot_id, v.suspect_bot_id
+		FROM public."votes" AS v
+		WHERE v.game_id = ANY($1)
+		ORDER BY v.created_at ASC, v.id ASC`,
+		pq.Array(gameIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select votes")
+	}
+	defer rows.Close()
+
+	votesByGameId := map[string][]*model.Vote{}
+	for rows.Next() {
+		var gameId string
+		var vote model.Vote
+		err := rows.Scan(&gameId, &vote.VoterBotId, &vote.SuspectBotId)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning vote rows")
+		}
+		votesByGameId[gameId] = append(votesByGameId[gameId], &vote)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through vote rows")
+	}
+	return votesByGameId, nil
 }
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// Benchmark_GetGame compares loading a game with separate queries against the earlier loader, which joined every
+// message onto every bot row. Run with: go test -run XXX -bench GetGame ./internal/storage
+func Benchmark_GetGame(b *testing.B) {
+	requireTestDb(b)
+	for _, messageCount := range []int{10, 100, 1000} {
+		seedGameForBenchmark(b, messageCount)
+
+		b.Run(fmt.Sprintf("separate queries with %d messages", messageCount), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := getGameUsingCustomDbHandler(context.Background(), testDb, "benchmark_game_id1", false)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("joined rows with %d messages", messageCount), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := getGameUsingJoinedRows(context.Background(), testDb, "benchmark_game_id1")
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		cleanupGameForBenchmark(b)
+	}
+}
+
+func seedGameForBenchmark(b *testing.B, messageCount int) {
+	b.Helper()
+	_, err := testDb.Exec(
+		`INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order")
+		VALUES ('benchmark_game_id1', 'STARTED', 0, Array['benchmark_bot_id1','benchmark_bot_id2','benchmark_bot_id3','benchmark_bot_id4','benchmark_bot_id5'])`,
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 1; i <= 5; i++ {
+		_, err := testDb.Exec(
+			`INSERT INTO public."bots" ("id", "name", "type", "game_id") VALUES ($1, $2, 'AI', 'benchmark_game_id1')`,
+			fmt.Sprintf("benchmark_bot_id%d", i), fmt.Sprintf("bot%d", i),
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; i < messageCount; i++ {
+		sourceBotId := fmt.Sprintf("benchmark_bot_id%d", i%5+1)
+		targetBotId := fmt.Sprintf("benchmark_bot_id%d", (i+1)%5+1)
+		_, err := testDb.Exec(
+			`INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type")
+			VALUES ($1, 'benchmark_game_id1', $2, $3, 'What is your favourite colour?', 'question')`,
+			fmt.Sprintf("benchmark_message_id%d", i), sourceBotId, targetBotId,
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func cleanupGameForBenchmark(b *testing.B) {
+	b.Helper()
+	_, err := testDb.Exec(`DELETE FROM public."games" WHERE id = 'benchmark_game_id1'`)
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+// getGameUsingJoinedRows is the earlier game loader, kept only to benchmark against.
+func getGameUsingJoinedRows(ctx context.Context, customDb customDbHandler, gameId string) (*model.Game, error) {
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("cannot getGame for a blank gameId")
+	}
+
+	var (
+		opts           model.GameOptions
+		stateHandledAt sql.NullTime
+	)
+
+	query := `SELECT
+	g.id, g.state, g.current_turn_index, g.turn_order,
+	g.state_handled, g.state_handled_at, g.state_total_time,
+	g.last_question, g.last_question_target_bot_id,
+	g.result, g.winning_bot_id, g.public, g.prompt_version,
+	g.conversation_summary, g.summarized_message_count, g.ai_accusations, g.elimination,
+	g.voting_rounds, g.ai_votes, g.mode, g.decoy_bot_id,
+	g.created_at, g.updated_at,
+	b.id, b.name, b.type, b.player_id, b.help_count, b.last_help_suggestions, b.stated_facts,
+	b.tag_count, b.last_tagged_at, b.eliminated,
+	m.source_bot_id, m.target_bot_id, m.text, m.created_at, m.type,
+	m.response_time_ms, m.help_usage, m.help_style, m.ai_model, m.prompt_version, m.prompt_tokens, m.completion_tokens
+	FROM public."games" AS g
+	LEFT JOIN public."bots" AS b ON b.game_id = g.id
+	LEFT JOIN public."messages" AS m ON m.target_bot_id = b.id
+	WHERE g.id = $1
+	ORDER BY b.created_at ASC, b.id ASC, m.created_at ASC, m.id ASC`
+
+	rows, err := customDb.QueryContext(ctx, query, gameId)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select game")
+	}
+	defer rows.Close()
+
+	botOptsMap := map[string]model.BotOptions{}
+	botOptsOrderedIds := []string{}
+
+	for rows.Next() {
+		var botOpts model.BotOptions
+		var playerId sql.NullString
+		var messageSourceBotId sql.NullString
+		var messageTargetBotId sql.NullString
+		var messageText sql.NullString
+		var messageType sql.NullString
+		var lastQuestion sql.NullString
+		var lastQuestionTargetBotId sql.NullString
+		var result sql.NullString
+		var winningBotId sql.NullString
+		var decoyBotId sql.NullString
+		var promptVersion sql.NullString
+		var conversationSummary sql.NullString
+		var messageCreatedAt sql.NullTime
+		var lastTaggedAt sql.NullTime
+		var messageResponseTimeMs sql.NullInt64
+		var messageHelpUsage sql.NullString
+		var messageHelpStyle sql.NullString
+		var messageAiModel sql.NullString
+		var messagePromptVersion sql.NullString
+		var messagePromptTokens sql.NullInt64
+		var messageCompletionTokens sql.NullInt64
+		err := rows.Scan(
+			&opts.Id,
+			&opts.State,
+			&opts.CurrentTurnIndex,
+			pq.Array(&opts.TurnOrder),
+			&opts.StateHandled,
+			&stateHandledAt,
+			&opts.StateTotalTime,
+			&lastQuestion,
+			&lastQuestionTargetBotId,
+			&result,
+			&winningBotId,
+			&opts.Public,
+			&promptVersion,
+			&conversationSummary,
+			&opts.SummarizedMessageCount,
+			&opts.AiAccusations,
+			&opts.Elimination,
+			&opts.VotingRounds,
+			&opts.AiVotes,
+			&opts.Mode,
+			&decoyBotId,
+			&opts.CreatedAt,
+			&opts.UpdatedAt,
+			&botOpts.Id,
+			&botOpts.Name,
+			&botOpts.TypeOfBot,
+			&playerId,
+			&botOpts.HelpCount,
+			pq.Array(&botOpts.LastHelpSuggestions),
+			pq.Array(&botOpts.StatedFacts),
+			&botOpts.TagCount,
+			&lastTaggedAt,
+			&botOpts.Eliminated,
+			&messageSourceBotId,
+			&messageTargetBotId,
+			&messageText,
+			&messageCreatedAt,
+			&messageType,
+			&messageResponseTimeMs,
+			&messageHelpUsage,
+			&messageHelpStyle,
+			&messageAiModel,
+			&messagePromptVersion,
+			&messagePromptTokens,
+			&messageCompletionTokens,
+		)
+
+		if lastQuestion.Valid {
+			opts.LastQuestion = lastQuestion.String
+		}
+		if lastQuestionTargetBotId.Valid {
+			opts.LastQuestionTargetBotId = lastQuestionTargetBotId.String
+		}
+		if result.Valid {
+			opts.Result = result.String
+		}
+		if winningBotId.Valid {
+			opts.WinningBotId = winningBotId.String
+		}
+		if decoyBotId.Valid {
+			opts.DecoyBotId = decoyBotId.String
+		}
+		if promptVersion.Valid {
+			opts.PromptVersion = promptVersion.String
+		}
+		if conversationSummary.Valid {
+			opts.ConversationSummary = conversationSummary.String
+		}
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+
+		if !utilities.IsBlank(botOpts.Id) {
+			if playerId.Valid {
+				player, err := model.NewPlayer(model.PlayerOptions{Id: playerId.String})
+				if err != nil {
+					return nil, utilities.WrapBadError(err, "failed to create player")
+				}
+				botOpts.ConnectedPlayer = player
+			}
+			if lastTaggedAt.Valid {
+				botOpts.LastTaggedAt = &lastTaggedAt.Time
+			}
+			_, ok := botOptsMap[botOpts.Id]
+			if !ok {
+				botOptsOrderedIds = append(botOptsOrderedIds, botOpts.Id)
+				botOptsMap[botOpts.Id] = botOpts
+			}
+			if messageText.Valid {
+				message := model.Message{
+					SourceBotId:      messageSourceBotId.String,
+					TargetBotId:      messageTargetBotId.String,
+					Text:             messageText.String,
+					CreatedAt:        messageCreatedAt.Time,
+					MessageType:      messageType.String,
+					ResponseTime:     time.Duration(messageResponseTimeMs.Int64) * time.Millisecond,
+					HelpUsage:        messageHelpUsage.String,
+					HelpStyle:        messageHelpStyle.String,
+					AiModel:          messageAiModel.String,
+					PromptVersion:    messagePromptVersion.String,
+					PromptTokens:     messagePromptTokens.Int64,
+					CompletionTokens: messageCompletionTokens.Int64,
+				}
+				opts.Messages = append(opts.Messages, &message)
+			}
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through bot rows")
+	}
+
+	for _, botOptsId := range botOptsOrderedIds {
+		bot, err := model.NewBot(botOptsMap[botOptsId])
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed to create bot")
+		}
+		opts.Bots = append(opts.Bots, bot)
+	}
+
+	if stateHandledAt.Valid {
+		opts.StateHandledAt = &stateHandledAt.Time
+	}
+
+	if utilities.IsBlank(opts.Id) {
+		return nil, errors.Errorf("game not found: %s", gameId)
+	}
+
+	votesByGameId, err := getVotesForGames(ctx, customDb, []string{gameId})
+	if err != nil {
+		return nil, err
+	}
+	opts.Votes = votesByGameId[gameId]
+
+	game, err := model.NewGame(opts)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to create game")
+	}
+	return game, nil
+}
@@ -1,19 +1,27 @@
 package storage
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/vipulvpatil/airetreat-go/internal/model"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
-func (s *Storage) GetUnhandledGameIdsForState(gameStateString string) ([]string, error) {
+// GetUnhandledGameIdsForState reads from the read replica, so a game that was handled moments ago may still be returned.
+// The jobs started for these games check the state of the game again before doing anything.
+func (s *Storage) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	gameState := model.GameState(gameStateString)
 	if !gameState.Valid() {
 		return nil, errors.New("invalid game state")
 	}
 
-	rows, err := s.db.Query(
+	rows, err := s.readDb().QueryContext(
+		ctx,
 		`SELECT id
 		FROM public."games"
 		WHERE state = $1
@@ -46,3 +54,139 @@ func (s *Storage) GetUnhandledGameIdsForState(gameStateString string) ([]string,
 	}
 	return gameIds, nil
 }
+
+// GetGameIdsForAiAccusation returns the games with AI accusations that are in play, and where a human has answered
+// a question since the AI bots last looked for someone to accuse.
+func (s *Storage) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT g.id
+		FROM public."games" AS g
+		WHERE g.ai_accusations = true
+		AND g.state IN ('WAITING_FOR_AI_QUESTION', 'WAITING_FOR_HUMAN_QUESTION', 'WAITING_FOR_AI_ANSWER', 'WAITING_FOR_HUMAN_ANSWER')
+		AND EXISTS (
+			SELECT 1
+			FROM public."messages" AS m
+			JOIN public."bots" AS b ON m.source_bot_id = b.id
+			WHERE m.game_id = g.id
+			AND b.type = 'HUMAN'
+			AND m.type = 'answer'
+			AND (g.ai_accusation_checked_at IS NULL OR m.created_at > g.ai_accusation_checked_at)
+		)
+		ORDER BY g.created_at DESC, g.id DESC
+		`,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "error getting games for ai accusation")
+	}
+	defer rows.Close()
+
+	gameIds := []string{}
+
+	for rows.Next() {
+		var gameId string
+		err := rows.Scan(
+			&gameId,
+		)
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		gameIds = append(gameIds, gameId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through game rows")
+	}
+	return gameIds, nil
+}
+
+// GetGameIdsForVotingToClose returns the games that are voting, and where everyone has had their time to vote.
+func (s *Storage) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT g.id
+		FROM public."games" AS g
+		WHERE g.state = 'VOTING'
+		AND g.state_handled = false
+		AND g.state_handled_at + g.state_total_time * interval '1 second' <= $1
+		ORDER BY g.created_at DESC, g.id DESC
+		`, time.Now(),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "error getting games for voting to close")
+	}
+	defer rows.Close()
+
+	gameIds := []string{}
+
+	for rows.Next() {
+		var gameId string
+		err := rows.Scan(
+			&gameId,
+		)
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		gameIds = append(gameIds, gameId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through game rows")
+	}
+	return gameIds, nil
+}
+
+// GetGameIdsForAnalysis returns the finished games that have not been analyzed yet.
+func (s *Storage) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT g.id
+		FROM public."games" AS g
+		WHERE g.state = 'FINISHED'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM public."bot_analyses" AS ba
+			JOIN public."bots" AS b ON ba.bot_id = b.id
+			WHERE b.game_id = g.id
+		)
+		ORDER BY g.created_at DESC, g.id DESC
+		`,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "error getting games for analysis")
+	}
+	defer rows.Close()
+
+	gameIds := []string{}
+
+	for rows.Next() {
+		var gameId string
+		err := rows.Scan(
+			&gameId,
+		)
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		gameIds = append(gameIds, gameId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through game rows")
+	}
+	return gameIds, nil
+}
@@ -1,165 +1,163 @@
 package storage
 
 import (
-	"math/rand"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 )
 
-func Test_Game_GetUnhandledGameIdsForState(t *testing.T) {
-	tests := []struct {
-		name            string
-		input           string
-		output          []string
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:            "returns nil when incorrect game state is passed in",
-			input:           "",
-			output:          nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "invalid game state",
-		},
-		{
-			name:   "returns a list of unhandled game Ids matching state",
-			input:  "PLAYERS_JOINED",
-			output: []string{"game_id3", "game_id1"},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-				VALUES ('game_id1', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-				VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-				VALUES ('game_id2', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-				VALUES ('bot_id2', 'bot2', 'AI', 'game_id2')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id3', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id3', 'bot3', 'AI', 'game_id3')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id4', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id4', 'bot4', 'AI', 'game_id4')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id5', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], true)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id5', 'bot5', 'AI', 'game_id5')`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id2'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id3'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id4'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id5'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-		{
-			name:   "returns an empty list if no games match",
-			input:  "PLAYERS_JOINED",
-			output: []string{},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id1', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], true)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id2', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id2', 'bot2', 'AI', 'game_id2')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id3', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], true)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id3', 'bot3', 'AI', 'game_id3')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id4', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id4', 'bot4', 'AI', 'game_id4')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id5', 'PLAYERS_JOINED', 0, Array['b','p1','b','p2'], true)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id5', 'bot5', 'AI', 'game_id5')`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id2'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id3'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id4'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id5'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
+// createGamesForSuite creates the games oldest first, a minute apart, so that the order they come back in is known.
+func createGamesForSuite(t *testing.T, s suiteStorage, opts []GameCreateOptions) []string {
+	gameIds := []string{}
+	for i, opt := range opts {
+		gameId, err := s.CreateGame(context.Background(), opt)
+		assert.NoError(t, err)
+		err = s.BackdateGame(gameId, time.Now().Add(time.Duration(i-len(opts))*time.Minute))
+		assert.NoError(t, err)
+		gameIds = append(gameIds, gameId)
 	}
+	return gameIds
+}
+
+func updateGameForSuite(t *testing.T, s suiteStorage, gameId string, opts GameUpdateOptions) {
+	err := s.UpdateGameState(context.Background(), gameId, opts)
+	assert.NoError(t, err)
+}
+
+func Test_Game_GetUnhandledGameIdsForState(t *testing.T) {
+	t.Run("errors when an invalid game state is passed in", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds, err := s.GetUnhandledGameIdsForState(context.Background(), "")
+			assert.EqualError(t, err, "invalid game state")
+			assert.Nil(t, gameIds)
+		})
+	})
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
+	t.Run("returns the unhandled games in the state, newest first", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 4))
+			playersJoined := "PLAYERS_JOINED"
+			handled := true
+			updateGameForSuite(t, s, gameIds[0], GameUpdateOptions{State: &playersJoined})
+			updateGameForSuite(t, s, gameIds[2], GameUpdateOptions{State: &playersJoined})
+			updateGameForSuite(t, s, gameIds[3], GameUpdateOptions{State: &playersJoined, StateHandled: &handled})
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			unhandledGameIds, err := s.GetUnhandledGameIdsForState(context.Background(), playersJoined)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[2], gameIds[0]}, unhandledGameIds)
+		})
+	})
+
+	t.Run("returns an empty list if no games match", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 2))
+			playersJoined := "PLAYERS_JOINED"
+			handled := true
+			updateGameForSuite(t, s, gameIds[0], GameUpdateOptions{State: &playersJoined, StateHandled: &handled})
+
+			unhandledGameIds, err := s.GetUnhandledGameIdsForState(context.Background(), playersJoined)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{}, unhandledGameIds)
+		})
+	})
+}
+
+func Test_Game_GetGameIdsForAiAccusation(t *testing.T) {
+	t.Run("returns games in play with ai accusations and a human answer since the last check", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			withAccusations := GameCreateOptions{AiAccusations: true}
+			gameIds := createGamesForSuite(t, s, []GameCreateOptions{withAccusations, {}, withAccusations, withAccusations, withAccusations, withAccusations})
+			checkedBefore := time.Now().Add(-1 * time.Hour)
+			checkedAfter := time.Now().Add(1 * time.Hour)
+			waitingForAiQuestion := "WAITING_FOR_AI_QUESTION"
+			finished := "FINISHED"
+			gameUpdates := []GameUpdateOptions{
+				{State: &waitingForAiQuestion},
+				{State: &waitingForAiQuestion},
+				{State: &waitingForAiQuestion, AiAccusationCheckedAt: &checkedBefore},
+				{State: &waitingForAiQuestion, AiAccusationCheckedAt: &checkedAfter},
+				{State: &finished},
+				{State: &waitingForAiQuestion},
+			}
 
-			rand.Seed(0)
-			gameIds, err := s.GetUnhandledGameIdsForState(tt.input)
-			if !tt.errorExpected {
+			for i, gameId := range gameIds {
+				game, err := s.GetGame(context.Background(), gameId)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.output, gameIds)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
+				answeringBotId := game.Bots()[0].Id()
+				// The human in the last game has not answered, only the ai has.
+				if i != len(gameIds)-1 {
+					joinGameForSuite(t, s, answeringBotId)
+				} else {
+					answeringBotId = game.Bots()[1].Id()
+				}
+				err = s.CreateMessage(context.Background(), answeringBotId, answeringBotId, "an answer", "answer", MessageMetadata{})
+				assert.NoError(t, err)
+				updateGameForSuite(t, s, gameId, gameUpdates[i])
 			}
+
+			accusationGameIds, err := s.GetGameIdsForAiAccusation(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[2], gameIds[0]}, accusationGameIds)
+
+			checkedBeforeGame, err := s.GetGame(context.Background(), gameIds[2])
+			assert.NoError(t, err)
+			assert.True(t, checkedBeforeGame.HasHumanAnswerSinceAiAccusationCheck())
+			checkedAfterGame, err := s.GetGame(context.Background(), gameIds[3])
+			assert.NoError(t, err)
+			assert.False(t, checkedAfterGame.HasHumanAnswerSinceAiAccusationCheck())
 		})
-	}
+	})
+}
+
+func Test_Game_GetGameIdsForVotingToClose(t *testing.T) {
+	t.Run("returns unhandled voting games once the voting time is over", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 4))
+			voting := "VOTING"
+			finished := "FINISHED"
+			handled := true
+			twoMinutesAgo := time.Now().Add(-2 * time.Minute)
+			now := time.Now()
+			votingTime := int64(60)
+			updateGameForSuite(t, s, gameIds[0], GameUpdateOptions{State: &voting, StateHandledAt: &twoMinutesAgo, StateTotalTime: &votingTime})
+			updateGameForSuite(t, s, gameIds[1], GameUpdateOptions{State: &voting, StateHandledAt: &now, StateTotalTime: &votingTime})
+			updateGameForSuite(t, s, gameIds[2], GameUpdateOptions{State: &voting, StateHandled: &handled, StateHandledAt: &twoMinutesAgo, StateTotalTime: &votingTime})
+			updateGameForSuite(t, s, gameIds[3], GameUpdateOptions{State: &finished, StateHandledAt: &twoMinutesAgo, StateTotalTime: &votingTime})
+
+			votingGameIds, err := s.GetGameIdsForVotingToClose(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[0]}, votingGameIds)
+		})
+	})
+}
+
+func Test_Game_GetGameIdsForAnalysis(t *testing.T) {
+	t.Run("returns finished games that have not been analyzed yet", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 4))
+			finished := "FINISHED"
+			voting := "VOTING"
+			updateGameForSuite(t, s, gameIds[0], GameUpdateOptions{State: &finished})
+			updateGameForSuite(t, s, gameIds[1], GameUpdateOptions{State: &finished})
+			updateGameForSuite(t, s, gameIds[2], GameUpdateOptions{State: &finished})
+			updateGameForSuite(t, s, gameIds[3], GameUpdateOptions{State: &voting})
+
+			analyzedGame, err := s.GetGame(context.Background(), gameIds[1])
+			assert.NoError(t, err)
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.CreateGameAnalysisUsingTransaction(context.Background(), model.GameAnalysis{
+					GameId:      gameIds[1],
+					BotAnalyses: []model.BotAnalysis{{BotId: analyzedGame.Bots()[0].Id(), HumannessScore: 50}},
+				}, tx)
+			})
+			assert.NoError(t, err)
+
+			analysisGameIds, err := s.GetGameIdsForAnalysis(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[2], gameIds[0]}, analysisGameIds)
+		})
+	})
 }
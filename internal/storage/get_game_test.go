@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -13,6 +14,7 @@ import (
 // GetGameUsingTransaction
 
 func Test_GetGame(t *testing.T) {
+	requireTestDb(t)
 	tests := []struct {
 		name            string
 		input           string
@@ -45,7 +47,7 @@ func Test_GetGame(t *testing.T) {
 			errorString:     "game not found: game_id1",
 		},
 		{
-			name:  "bad error when found game with bad rows",
+			name:  "bad error when found game without bots",
 			input: "game_id1",
 			outputFunc: func() *model.Game {
 				return nil
@@ -66,7 +68,7 @@ func Test_GetGame(t *testing.T) {
 				},
 			},
 			errorExpected: true,
-			errorString:   "THIS IS BAD: failed while scanning rows: sql: Scan error on column index 14, name \"id\": converting NULL to string is unsupported",
+			errorString:   "THIS IS BAD: game has no bots: game_id1",
 		},
 		{
 			name:  "error when found bot with bad data",
@@ -157,6 +159,18 @@ func Test_GetGame(t *testing.T) {
 						TypeOfBot: "AI",
 						HelpCount: 3,
 					}
+					if i == 1 {
+						botOpts.StatedFacts = []string{"My name is Bot 2 Dot 2"}
+					}
+					if i == 2 {
+						botOpts.Eliminated = true
+					}
+					if i == 4 {
+						botOpts.LastHelpSuggestions = []string{"Where is the gold?", "Gold?", "Is there gold?"}
+						botOpts.TagCount = 1
+						lastTaggedAt := time.Now()
+						botOpts.LastTaggedAt = &lastTaggedAt
+					}
 					bot, _ := model.NewBot(botOpts)
 					bots = append(bots, bot)
 				}
@@ -174,14 +188,24 @@ func Test_GetGame(t *testing.T) {
 						CreatedAt:               time.Now(),
 						UpdatedAt:               time.Now(),
 						Bots:                    bots,
+						PromptVersion:           "v1",
+						ConversationSummary:     "bot2 asked bot1 its name.",
+						SummarizedMessageCount:  2,
+						AiAccusations:           true,
+						Elimination:             true,
+						VotingRounds:            2,
+						AiVotes:                 true,
+						Votes: []*model.Vote{
+							{VoterBotId: "bot_id5", SuspectBotId: "bot_id2"},
+						},
 						Messages: []*model.Message{
 							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q1: what is your name?", CreatedAt: time.Now(), MessageType: "question"},
 							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A1: My name is Antony Gonsalvez", CreatedAt: time.Now(), MessageType: "answer"},
-							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
-							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
 							{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q1: What is your name?", CreatedAt: time.Now(), MessageType: "question"},
 							{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "A1: Bot 2 Dot 2", CreatedAt: time.Now(), MessageType: "answer"},
-							{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q2: Second question?", CreatedAt: time.Now(), MessageType: "question"},
+							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
+							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
+							{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q2: Second question?", CreatedAt: time.Now(), MessageType: "question", ResponseTime: 9 * time.Second, AiModel: "text-davinci-003", PromptVersion: "v1", PromptTokens: 150, CompletionTokens: 10},
 						},
 					},
 				)
@@ -190,10 +214,10 @@ func Test_GetGame(t *testing.T) {
 			setupSqlStmts: []TestSqlStmts{
 				{
 					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "state_handled_at"
+						"id", "state", "current_turn_index", "turn_order", "state_handled", "state_handled_at", "prompt_version", "conversation_summary", "summarized_message_count", "ai_accusations", "elimination", "voting_rounds", "ai_votes"
 					)
 					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1', 'bot_id2', 'bot_id3', 'bot_id4', 'bot_id5'], false, current_timestamp
+						'game_id1', 'STARTED', 0, Array['bot_id1', 'bot_id2', 'bot_id3', 'bot_id4', 'bot_id5'], false, current_timestamp, 'v1', 'bot2 asked bot1 its name.', 2, true, true, 2, true
 					)`,
 				},
 				{
@@ -206,18 +230,18 @@ func Test_GetGame(t *testing.T) {
 				},
 				{
 					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id", "help_count"
+						"id", "name", "type", "game_id", "help_count", "stated_facts"
 					)
 					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1', 3
+						'bot_id2', 'bot2', 'AI', 'game_id1', 3, Array['My name is Bot 2 Dot 2']
 					)`,
 				},
 				{
 					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id", "help_count"
+						"id", "name", "type", "game_id", "help_count", "eliminated"
 					)
 					VALUES (
-						'bot_id3', 'bot3', 'AI', 'game_id1', 3
+						'bot_id3', 'bot3', 'AI', 'game_id1', 3, true
 					)`,
 				},
 				{
@@ -240,16 +264,20 @@ func Test_GetGame(t *testing.T) {
 				{
 					Query: `UPDATE public."bots" SET
 					"player_id" = 'player_id1',
-					"type" = 'HUMAN'
+					"type" = 'HUMAN',
+					"last_help_suggestions" = Array['Where is the gold?', 'Gold?', 'Is there gold?'],
+					"tag_count" = 1,
+					"last_tagged_at" = current_timestamp
 					WHERE id = 'bot_id5'`,
 				},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id1', 'bot_id2', 'bot_id1', 'Q1: what is your name?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id2', 'bot_id1', 'bot_id1', 'A1: My name is Antony Gonsalvez', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id3', 'bot_id1', 'bot_id2', 'Q1: What is your name?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id4', 'bot_id2', 'bot_id2', 'A1: Bot 2 Dot 2', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id5', 'bot_id2', 'bot_id1', 'Q2: Where is the gold?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id6', 'bot_id1', 'bot_id1', 'A2: what gold!', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id7', 'bot_id1', 'bot_id2', 'Q2: Second question?', 'question')`},
+				{Query: `INSERT INTO public."votes" ("id", "game_id", "voter_bot_id", "suspect_bot_id") VALUES ('vote_id1', 'game_id1', 'bot_id5', 'bot_id2')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id1', 'game_id1', 'bot_id2', 'bot_id1', 'Q1: what is your name?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id2', 'game_id1', 'bot_id1', 'bot_id1', 'A1: My name is Antony Gonsalvez', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id3', 'game_id1', 'bot_id1', 'bot_id2', 'Q1: What is your name?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id4', 'game_id1', 'bot_id2', 'bot_id2', 'A1: Bot 2 Dot 2', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id5', 'game_id1', 'bot_id2', 'bot_id1', 'Q2: Where is the gold?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id6', 'game_id1', 'bot_id1', 'bot_id1', 'A2: what gold!', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "response_time_ms", "ai_model", "prompt_version", "prompt_tokens", "completion_tokens") VALUES ('message_id7', 'game_id1', 'bot_id1', 'bot_id2', 'Q2: Second question?', 'question', 9000, 'text-davinci-003', 'v1', 150, 10)`},
 				{
 					Query: `UPDATE public."games" SET
 					"last_question" = 'Q2: Second question?',
@@ -274,11 +302,11 @@ func Test_GetGame(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
 			rand.Seed(0)
-			result, err := s.GetGame(tt.input)
+			result, err := s.GetGame(context.Background(), tt.input)
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				output := tt.outputFunc()
@@ -292,6 +320,7 @@ func Test_GetGame(t *testing.T) {
 }
 
 func Test_GetGameUsingTransaction(t *testing.T) {
+	requireTestDb(t)
 	tests := []struct {
 		name            string
 		input           string
@@ -337,10 +366,10 @@ func Test_GetGameUsingTransaction(t *testing.T) {
 						Messages: []*model.Message{
 							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q1: what is your name?", CreatedAt: time.Now(), MessageType: "question"},
 							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A1: My name is Antony Gonsalvez", CreatedAt: time.Now(), MessageType: "answer"},
-							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
-							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
 							{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q1: What is your name?", CreatedAt: time.Now(), MessageType: "question"},
 							{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "A1: Bot 2 Dot 2", CreatedAt: time.Now(), MessageType: "answer"},
+							{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
+							{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
 							{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q2: Second question?", CreatedAt: time.Now(), MessageType: "question"},
 						},
 					},
@@ -403,13 +432,13 @@ func Test_GetGameUsingTransaction(t *testing.T) {
 					"type" = 'HUMAN'
 					WHERE id = 'bot_id5'`,
 				},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id1', 'bot_id2', 'bot_id1', 'Q1: what is your name?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id2', 'bot_id1', 'bot_id1', 'A1: My name is Antony Gonsalvez', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id3', 'bot_id1', 'bot_id2', 'Q1: What is your name?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id4', 'bot_id2', 'bot_id2', 'A1: Bot 2 Dot 2', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id5', 'bot_id2', 'bot_id1', 'Q2: Where is the gold?', 'question')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id6', 'bot_id1', 'bot_id1', 'A2: what gold!', 'answer')`},
-				{Query: `INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id7', 'bot_id1', 'bot_id2', 'Q2: Second question?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id1', 'game_id1', 'bot_id2', 'bot_id1', 'Q1: what is your name?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id2', 'game_id1', 'bot_id1', 'bot_id1', 'A1: My name is Antony Gonsalvez', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id3', 'game_id1', 'bot_id1', 'bot_id2', 'Q1: What is your name?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id4', 'game_id1', 'bot_id2', 'bot_id2', 'A1: Bot 2 Dot 2', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id5', 'game_id1', 'bot_id2', 'bot_id1', 'Q2: Where is the gold?', 'question')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id6', 'game_id1', 'bot_id1', 'bot_id1', 'A2: what gold!', 'answer')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type") VALUES ('message_id7', 'game_id1', 'bot_id1', 'bot_id2', 'Q2: Second question?', 'question')`},
 				{
 					Query: `UPDATE public."games" SET
 					"last_question" = 'Q2: Second question?',
@@ -434,12 +463,12 @@ func Test_GetGameUsingTransaction(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			tx, err := s.BeginTransaction()
+			tx, err := s.BeginTransaction(context.Background())
 			assert.NoError(t, err)
-			result, err := s.GetGameUsingTransaction(tt.input, tx)
+			result, err := s.GetGameUsingTransaction(context.Background(), tt.input, tx)
 			tx.Commit()
 			if !tt.errorExpected {
 				assert.NoError(t, err)
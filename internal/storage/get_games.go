@@ -1,18 +1,24 @@
 package storage
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
-func (s *Storage) GetGames(playerId string) ([]string, error) {
+func (s *Storage) GetGames(ctx context.Context, playerId string) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(playerId) {
 		return nil, errors.New("cannot GetGames for a blank playerId")
 	}
 
-	rows, err := s.db.Query(
+	rows, err := s.readDb().QueryContext(
+		ctx,
 		`SELECT game_id
 		FROM public."bots"
 		WHERE player_id = $1
@@ -46,12 +52,16 @@ func (s *Storage) GetGames(playerId string) ([]string, error) {
 	return gameIds, nil
 }
 
-func (s *Storage) GetOldGames(gameExpiryDuration time.Duration) ([]string, error) {
+func (s *Storage) GetOldGames(ctx context.Context, gameExpiryDuration time.Duration) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if gameExpiryDuration > -5*time.Minute {
 		return nil, errors.New("invalid game expiry duration. Max acceptable time is -5 minutes.")
 	}
 
-	rows, err := s.db.Query(
+	rows, err := s.db.QueryContext(
+		ctx,
 		`SELECT id
 		FROM public."games"
 		WHERE created_at < $1
@@ -85,18 +95,22 @@ func (s *Storage) GetOldGames(gameExpiryDuration time.Duration) ([]string, error
 	return gameIds, nil
 }
 
-func (s *Storage) GetPublicJoinableGames() ([]string, error) {
+func (s *Storage) GetPublicJoinableGames(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	recent := time.Now().Add(-30 * time.Minute)
 
-	rows, err := s.db.Query(
-		`SELECT g.id, count(b.id)
+	rows, err := s.readDb().QueryContext(
+		ctx,
+		`SELECT g.id, g.mode, count(b.id)
 		FROM public."games" AS g
 		INNER JOIN public."bots" AS b ON b.game_id = g.id
 		WHERE g.created_at > $1
 		AND g.public = true
 		AND g.state = 'STARTED'
 		AND b.type = 'HUMAN'
-		GROUP BY g.id
+		GROUP BY g.id, g.mode
 		ORDER BY g.created_at DESC, g.id DESC`,
 		recent,
 	)
@@ -109,9 +123,11 @@ func (s *Storage) GetPublicJoinableGames() ([]string, error) {
 
 	for rows.Next() {
 		var gameId string
-		var humanBotCount int
+		var mode string
+		var humanBotCount int64
 		err := rows.Scan(
 			&gameId,
+			&mode,
 			&humanBotCount,
 		)
 
@@ -119,7 +135,7 @@ func (s *Storage) GetPublicJoinableGames() ([]string, error) {
 			return nil, utilities.WrapBadError(err, "failed while scanning rows")
 		}
 
-		if humanBotCount == 1 {
+		if humanBotCount < model.HumanPlayerCountForMode(mode) {
 			gameIds = append(gameIds, gameId)
 		}
 	}
@@ -131,17 +147,21 @@ func (s *Storage) GetPublicJoinableGames() ([]string, error) {
 	return gameIds, nil
 }
 
-func (s *Storage) GetAutoJoinableGames() ([]string, error) {
+func (s *Storage) GetAutoJoinableGames(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	fiveMinutesAgo := time.Now().Add(-5 * time.Minute)
 
-	rows, err := s.db.Query(
-		`SELECT g.id, count(b.id)
+	rows, err := s.readDb().QueryContext(
+		ctx,
+		`SELECT g.id, g.mode, count(b.id)
 		FROM public."games" AS g
 		INNER JOIN public."bots" AS b ON b.game_id = g.id
 		WHERE g.created_at > $1
 		AND g.state = 'STARTED'
 		AND b.type = 'HUMAN'
-		GROUP BY g.id
+		GROUP BY g.id, g.mode
 		ORDER BY g.created_at DESC, g.id DESC`,
 		fiveMinutesAgo,
 	)
@@ -154,9 +174,11 @@ func (s *Storage) GetAutoJoinableGames() ([]string, error) {
 
 	for rows.Next() {
 		var gameId string
-		var humanBotCount int
+		var mode string
+		var humanBotCount int64
 		err := rows.Scan(
 			&gameId,
+			&mode,
 			&humanBotCount,
 		)
 
@@ -164,7 +186,7 @@ func (s *Storage) GetAutoJoinableGames() ([]string, error) {
 			return nil, utilities.WrapBadError(err, "failed while scanning rows")
 		}
 
-		if humanBotCount == 1 {
+		if humanBotCount < model.HumanPlayerCountForMode(mode) {
 			gameIds = append(gameIds, gameId)
 		}
 	}
@@ -1,227 +1,68 @@
 package storage
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 )
 
 func Test_Game_GetGames(t *testing.T) {
-	tests := []struct {
-		name            string
-		input           string
-		output          []string
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:            "errors when playerId is blank",
-			input:           "",
-			output:          []string{},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "cannot GetGames for a blank playerId",
-		},
-		{
-			name:   "returns a list of gameIds for the player",
-			input:  "player_id1",
-			output: []string{"game_id5", "game_id2", "game_id1"},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-				VALUES ('game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-				VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-				VALUES ('game_id2', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-				VALUES ('bot_id2', 'bot2', 'AI', 'game_id2')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id3', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id3', 'bot3', 'AI', 'game_id3')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id4', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id4', 'bot4', 'AI', 'game_id4')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id5', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id5', 'bot5', 'AI', 'game_id5')`,
-				},
-				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id1')`},
-				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id2')`},
-				{Query: `UPDATE public."bots" SET "player_id" = 'player_id1', "type" = 'HUMAN' WHERE id = 'bot_id1'`},
-				{Query: `UPDATE public."bots" SET "player_id" = 'player_id1', "type" = 'HUMAN' WHERE id = 'bot_id2'`},
-				{Query: `UPDATE public."bots" SET "player_id" = 'player_id2', "type" = 'HUMAN' WHERE id = 'bot_id3'`},
-				{Query: `UPDATE public."bots" SET "player_id" = 'player_id2', "type" = 'HUMAN' WHERE id = 'bot_id4'`},
-				{Query: `UPDATE public."bots" SET "player_id" = 'player_id1', "type" = 'HUMAN' WHERE id = 'bot_id5'`},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id2'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id3'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id4'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id5'`},
-				{Query: `DELETE FROM public."players" WHERE id = 'player_id1'`},
-				{Query: `DELETE FROM public."players" WHERE id = 'player_id2'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
-
-			rand.Seed(0)
-			games, err := s.GetGames(tt.input)
-			if !tt.errorExpected {
+	t.Run("errors when playerId is blank", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			_, err := s.GetGames(context.Background(), "")
+			assert.EqualError(t, err, "cannot GetGames for a blank playerId")
+		})
+	})
+
+	t.Run("returns the games of the player, newest first", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 5))
+			player1, err := s.CreatePlayer(context.Background())
+			assert.NoError(t, err)
+			player2, err := s.CreatePlayer(context.Background())
+			assert.NoError(t, err)
+			for i, player := range []*model.Player{player1, player1, player2, player2, player1} {
+				game, err := s.GetGame(context.Background(), gameIds[i])
 				assert.NoError(t, err)
-				assert.Equal(t, tt.output, games)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
+				connectPlayerForSuite(t, s, game.Bots()[0].Id(), player.Id())
 			}
+
+			playerGameIds, err := s.GetGames(context.Background(), player1.Id())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[4], gameIds[1], gameIds[0]}, playerGameIds)
 		})
-	}
+	})
 }
 
 func Test_Game_GetOldGames(t *testing.T) {
-	tests := []struct {
-		name            string
-		input           time.Duration
-		output          []string
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:            "errors when timeDuration is invalid",
-			input:           1 * time.Second,
-			output:          []string{},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "invalid game expiry duration. Max acceptable time is -5 minutes.",
-		},
-		{
-			name:   "returns a list of gameIds that are old",
-			input:  -1 * time.Hour,
-			output: []string{"game_id5", "game_id2", "game_id1"},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at")
-					VALUES ('game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false, $1)`,
-					Args: []any{time.Now().Add(-2 * time.Hour)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id1', 'bot1', 'AI', 'game_id1')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at")
-					VALUES ('game_id2', 'STARTED', 0, Array['b','p1','b','p2'], false, $1)`,
-					Args: []any{time.Now().Add(-2 * time.Hour)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id2', 'bot2', 'AI', 'game_id2')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id3', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id3', 'bot3', 'AI', 'game_id3')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
-					VALUES ('game_id4', 'STARTED', 0, Array['b','p1','b','p2'], false)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id4', 'bot4', 'AI', 'game_id4')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at")
-					VALUES ('game_id5', 'STARTED', 0, Array['b','p1','b','p2'], false, $1)`,
-					Args: []any{time.Now().Add(-2 * time.Hour)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id5', 'bot5', 'AI', 'game_id5')`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id2'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id3'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id4'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id5'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+	t.Run("errors when timeDuration is invalid", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			_, err := s.GetOldGames(context.Background(), 1*time.Second)
+			assert.EqualError(t, err, "invalid game expiry duration. Max acceptable time is -5 minutes.")
+		})
+	})
 
-			rand.Seed(0)
-			games, err := s.GetOldGames(tt.input)
-			if !tt.errorExpected {
+	t.Run("returns the games that are older than the duration, newest first", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameIds := createGamesForSuite(t, s, make([]GameCreateOptions, 5))
+			for i, gameId := range []string{gameIds[0], gameIds[1], gameIds[4]} {
+				err := s.BackdateGame(gameId, time.Now().Add(-2*time.Hour).Add(time.Duration(i)*time.Minute))
 				assert.NoError(t, err)
-				assert.Equal(t, tt.output, games)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
 			}
+
+			oldGameIds, err := s.GetOldGames(context.Background(), -1*time.Hour)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[4], gameIds[1], gameIds[0]}, oldGameIds)
 		})
-	}
+	})
 }
+
 func Test_Game_GetPublicJoinableGames(t *testing.T) {
+	requireTestDb(t)
 	tests := []struct {
 		name            string
 		output          []string
@@ -300,11 +141,11 @@ func Test_Game_GetPublicJoinableGames(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
 			rand.Seed(0)
-			games, err := s.GetPublicJoinableGames()
+			games, err := s.GetPublicJoinableGames(context.Background())
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.output, games)
@@ -317,86 +158,25 @@ func Test_Game_GetPublicJoinableGames(t *testing.T) {
 }
 
 func Test_Game_GetAutoJoinableGames(t *testing.T) {
-	tests := []struct {
-		name            string
-		output          []string
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:   "returns a list of gameIds that are auto joinable",
-			output: []string{"game_id5", "game_id1"},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "public")
-					VALUES ('game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false, $1, true)`,
-					Args: []any{time.Now().Add(-4 * time.Minute)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id1', 'bot1', 'HUMAN', 'game_id1')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "public")
-					VALUES ('game_id2', 'STARTED', 0, Array['b','p1','b','p2'], false, $1, true)`,
-					Args: []any{time.Now().Add(-35 * time.Minute)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id2', 'bot2', 'HUMAN', 'game_id2')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "public")
-					VALUES ('game_id4', 'STARTED', 0, Array['b','p1','b','p2'], false, $1, true)`,
-					Args: []any{time.Now().Add(-6 * time.Minute)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id4', 'bot4', 'AI', 'game_id4')`,
-				},
-				{
-					Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "public")
-					VALUES ('game_id5', 'STARTED', 0, Array['b','p1','b','p2'], false, $1, false)`,
-					Args: []any{time.Now().Add(-3 * time.Minute)},
-				},
-				{
-					Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
-					VALUES ('bot_id5', 'bot5', 'HUMAN', 'game_id5')`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id2'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id4'`},
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id5'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
-
-			rand.Seed(0)
-			games, err := s.GetAutoJoinableGames()
-			if !tt.errorExpected {
+	t.Run("returns recent games that humans have started joining but that still need more humans", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			public := GameCreateOptions{Public: true}
+			gameIds := createGamesForSuite(t, s, []GameCreateOptions{public, public, {Public: true, Mode: "TEAM"}, public, {}, public})
+			gameAges := []time.Duration{4 * time.Minute, 35 * time.Minute, 2 * time.Minute, 6 * time.Minute, 3 * time.Minute, time.Minute}
+			humanCounts := []int{1, 1, 2, 0, 1, 2}
+			for i, gameId := range gameIds {
+				err := s.BackdateGame(gameId, time.Now().Add(-gameAges[i]))
 				assert.NoError(t, err)
-				assert.Equal(t, tt.output, games)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
+				game, err := s.GetGame(context.Background(), gameId)
+				assert.NoError(t, err)
+				for _, bot := range game.Bots()[:humanCounts[i]] {
+					joinGameForSuite(t, s, bot.Id())
+				}
 			}
+
+			joinableGameIds, err := s.GetAutoJoinableGames(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{gameIds[2], gameIds[4], gameIds[0]}, joinableGameIds)
 		})
-	}
+	})
 }
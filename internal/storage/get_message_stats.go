@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type MessageStatsRetriever interface {
+	GetMessageStats(ctx context.Context, since time.Time) ([]model.MessageStats, error)
+}
+
+func (s *Storage) GetMessageStats(ctx context.Context, since time.Time) ([]model.MessageStats, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT b.type, m.type,
+		COALESCE(m.help_usage, ''), COALESCE(m.ai_model, ''), COALESCE(m.prompt_version, ''),
+		count(m.id), COALESCE(avg(m.response_time_ms), 0)::BIGINT,
+		COALESCE(sum(m.prompt_tokens), 0), COALESCE(sum(m.completion_tokens), 0)
+		FROM public."messages" AS m
+		INNER JOIN public."bots" AS b ON b.id = m.source_bot_id
+		WHERE m.created_at > $1
+		GROUP BY b.type, m.type, m.help_usage, m.ai_model, m.prompt_version
+		ORDER BY b.type ASC, m.type ASC, 3 ASC, 4 ASC, 5 ASC`,
+		since,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select message stats")
+	}
+	defer rows.Close()
+
+	messageStatsList := []model.MessageStats{}
+
+	for rows.Next() {
+		var messageStats model.MessageStats
+		var averageResponseTimeMs int64
+		err := rows.Scan(
+			&messageStats.SourceBotType,
+			&messageStats.MessageType,
+			&messageStats.HelpUsage,
+			&messageStats.AiModel,
+			&messageStats.PromptVersion,
+			&messageStats.MessageCount,
+			&averageResponseTimeMs,
+			&messageStats.PromptTokens,
+			&messageStats.CompletionTokens,
+		)
+
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+
+		messageStats.AverageResponseTime = time.Duration(averageResponseTimeMs) * time.Millisecond
+		messageStatsList = append(messageStatsList, messageStats)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through message stats rows")
+	}
+	return messageStatsList, nil
+}
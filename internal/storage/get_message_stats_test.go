@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+func Test_GetMessageStats(t *testing.T) {
+	requireTestDb(t)
+	tests := []struct {
+		name            string
+		input           time.Time
+		output          []model.MessageStats
+		setupSqlStmts   []TestSqlStmts
+		cleanupSqlStmts []TestSqlStmts
+		errorExpected   bool
+		errorString     string
+	}{
+		{
+			name:            "returns empty list when there are no messages",
+			input:           time.Now().Add(-1 * time.Hour),
+			output:          []model.MessageStats{},
+			setupSqlStmts:   nil,
+			cleanupSqlStmts: nil,
+			errorExpected:   false,
+			errorString:     "",
+		},
+		{
+			name:  "aggregates messages by source bot type, message type, help usage and prompt",
+			input: time.Now().Add(-1 * time.Hour),
+			output: []model.MessageStats{
+				{
+					SourceBotType:       "AI",
+					MessageType:         "answer",
+					AiModel:             "text-davinci-003",
+					PromptVersion:       "v1",
+					MessageCount:        2,
+					AverageResponseTime: 10 * time.Second,
+					PromptTokens:        300,
+					CompletionTokens:    20,
+				},
+				{
+					SourceBotType:       "HUMAN",
+					MessageType:         "question",
+					HelpUsage:           "NONE",
+					MessageCount:        1,
+					AverageResponseTime: 20 * time.Second,
+				},
+				{
+					SourceBotType:       "HUMAN",
+					MessageType:         "question",
+					HelpUsage:           "VERBATIM",
+					MessageCount:        1,
+					AverageResponseTime: 4 * time.Second,
+				},
+			},
+			setupSqlStmts: []TestSqlStmts{
+				{
+					Query: `INSERT INTO public."games" (
+						"id", "state", "current_turn_index", "turn_order", "state_handled"
+					)
+					VALUES (
+						'game_id1', 'STARTED', 0, Array['bot_id1', 'bot_id2', 'bot_id3'], false
+					)`,
+				},
+				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id1')`},
+				{
+					Query: `INSERT INTO public."bots" (
+						"id", "name", "type", "game_id", "player_id"
+					)
+					VALUES (
+						'bot_id1', 'bot1', 'HUMAN', 'game_id1', 'player_id1'
+					)`,
+				},
+				{
+					Query: `INSERT INTO public."bots" (
+						"id", "name", "type", "game_id"
+					)
+					VALUES (
+						'bot_id2', 'bot2', 'AI', 'game_id1'
+					)`,
+				},
+				{
+					Query: `INSERT INTO public."bots" (
+						"id", "name", "type", "game_id"
+					)
+					VALUES (
+						'bot_id3', 'bot3', 'AI', 'game_id1'
+					)`,
+				},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "response_time_ms", "help_usage") VALUES ('message_id1', 'game_id1', 'bot_id1', 'bot_id2', 'Q1', 'question', 20000, 'NONE')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "response_time_ms", "ai_model", "prompt_version", "prompt_tokens", "completion_tokens") VALUES ('message_id2', 'game_id1', 'bot_id2', 'bot_id2', 'A1', 'answer', 8000, 'text-davinci-003', 'v1', 140, 12)`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "response_time_ms", "help_usage") VALUES ('message_id3', 'game_id1', 'bot_id1', 'bot_id3', 'Q2', 'question', 4000, 'VERBATIM')`},
+				{Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "response_time_ms", "ai_model", "prompt_version", "prompt_tokens", "completion_tokens") VALUES ('message_id4', 'game_id1', 'bot_id3', 'bot_id3', 'A2', 'answer', 12000, 'text-davinci-003', 'v1', 160, 8)`},
+			},
+			cleanupSqlStmts: []TestSqlStmts{
+				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+				{Query: `DELETE FROM public."players" WHERE id = 'player_id1'`},
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewDbStorage(
+				StorageOptions{
+					Db: testDb,
+				},
+			)
+
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
+
+			result, err := s.GetMessageStats(context.Background(), tt.input)
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, result)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+		})
+	}
+}
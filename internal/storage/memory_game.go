@@ -0,0 +1,832 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// memoryGame holds a game row in Options, leaving out the bots, messages and votes, which are kept alongside it.
+type memoryGame struct {
+	options        model.GameOptions
+	stateClaimedAt *time.Time
+	bots           []*memoryBot
+	messages       []memoryMessage
+	votes          []memoryVote
+	botAnalyses    []memoryBotAnalysis
+}
+
+type memoryBot struct {
+	id                  string
+	name                string
+	typeOfBot           string
+	playerId            string
+	helpCount           int64
+	lastHelpSuggestions []string
+	statedFacts         []string
+	tagCount            int64
+	lastTaggedAt        *time.Time
+	eliminated          bool
+	createdAt           time.Time
+}
+
+type memoryMessage struct {
+	id      string
+	message model.Message
+}
+
+type memoryVote struct {
+	id           string
+	voterBotId   string
+	suspectBotId string
+}
+
+type memoryBotAnalysis struct {
+	id          string
+	botAnalysis model.BotAnalysis
+}
+
+// copy is deep enough that a transaction can change its copy without changing the committed game.
+func (g *memoryGame) copy() *memoryGame {
+	gameCopy := *g
+	gameCopy.options.TurnOrder = copyStrings(g.options.TurnOrder)
+	gameCopy.bots = make([]*memoryBot, 0, len(g.bots))
+	for _, bot := range g.bots {
+		botCopy := *bot
+		botCopy.lastHelpSuggestions = copyStrings(bot.lastHelpSuggestions)
+		botCopy.statedFacts = copyStrings(bot.statedFacts)
+		gameCopy.bots = append(gameCopy.bots, &botCopy)
+	}
+	gameCopy.messages = append([]memoryMessage{}, g.messages...)
+	gameCopy.votes = append([]memoryVote{}, g.votes...)
+	gameCopy.botAnalyses = append([]memoryBotAnalysis{}, g.botAnalyses...)
+	return &gameCopy
+}
+
+func copyStrings(strs []string) []string {
+	if strs == nil {
+		return nil
+	}
+	return append([]string{}, strs...)
+}
+
+func (g *memoryGame) botWithId(botId string) *memoryBot {
+	for _, bot := range g.bots {
+		if bot.id == botId {
+			return bot
+		}
+	}
+	return nil
+}
+
+func (g *memoryGame) humanBotCount() int64 {
+	count := int64(0)
+	for _, bot := range g.bots {
+		if bot.typeOfBot == "HUMAN" {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *memoryGame) turn() model.GameTurn {
+	return model.GameTurn{
+		State:                   g.options.State,
+		CurrentTurnIndex:        g.options.CurrentTurnIndex,
+		LastQuestionTargetBotId: g.options.LastQuestionTargetBotId,
+	}
+}
+
+func (g *memoryGame) bumpVersion() {
+	g.options.Version++
+}
+
+func (g *memoryGame) toModel() (*model.Game, error) {
+	opts := g.options
+	opts.TurnOrder = copyStrings(g.options.TurnOrder)
+
+	if len(g.bots) == 0 {
+		return nil, utilities.NewBadError(fmt.Sprintf("game has no bots: %s", opts.Id))
+	}
+	bots := append([]*memoryBot{}, g.bots...)
+	sort.SliceStable(bots, func(i, j int) bool {
+		if bots[i].createdAt.Equal(bots[j].createdAt) {
+			return bots[i].id < bots[j].id
+		}
+		return bots[i].createdAt.Before(bots[j].createdAt)
+	})
+	for _, memoryBot := range bots {
+		botOpts := model.BotOptions{
+			Id:                  memoryBot.id,
+			Name:                memoryBot.name,
+			TypeOfBot:           memoryBot.typeOfBot,
+			HelpCount:           memoryBot.helpCount,
+			LastHelpSuggestions: copyStrings(memoryBot.lastHelpSuggestions),
+			StatedFacts:         copyStrings(memoryBot.statedFacts),
+			TagCount:            memoryBot.tagCount,
+			LastTaggedAt:        memoryBot.lastTaggedAt,
+			Eliminated:          memoryBot.eliminated,
+		}
+		if !utilities.IsBlank(memoryBot.playerId) {
+			player, err := model.NewPlayer(model.PlayerOptions{Id: memoryBot.playerId})
+			if err != nil {
+				return nil, utilities.WrapBadError(err, "failed to create player")
+			}
+			botOpts.ConnectedPlayer = player
+		}
+		bot, err := model.NewBot(botOpts)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed to create bot")
+		}
+		opts.Bots = append(opts.Bots, bot)
+	}
+
+	for _, memoryMessage := range g.messages {
+		message := memoryMessage.message
+		opts.Messages = append(opts.Messages, &message)
+	}
+
+	for _, memoryVote := range g.votes {
+		opts.Votes = append(opts.Votes, &model.Vote{VoterBotId: memoryVote.voterBotId, SuspectBotId: memoryVote.suspectBotId})
+	}
+
+	game, err := model.NewGame(opts)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to create game")
+	}
+	return game, nil
+}
+
+func (m *MemoryStorage) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
+	id := m.IdGenerator.Generate()
+	now := time.Now()
+
+	game := &memoryGame{
+		options: model.GameOptions{
+			Id:               id,
+			State:            "STARTED",
+			CurrentTurnIndex: 0,
+			StateHandled:     false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+			Public:           createOpts.Public,
+			PromptVersion:    createOpts.PromptVersion,
+			AiAccusations:    createOpts.AiAccusations,
+			Elimination:      createOpts.Elimination,
+			VotingRounds:     createOpts.VotingRounds,
+			AiVotes:          createOpts.AiVotes,
+			Mode:             createOpts.Mode,
+		},
+	}
+
+	for _, name := range model.RandomBotNames() {
+		bot := &memoryBot{
+			id:        m.IdGenerator.Generate(),
+			name:      name,
+			typeOfBot: "AI",
+			createdAt: now,
+		}
+		game.bots = append(game.bots, bot)
+		game.options.TurnOrder = append(game.options.TurnOrder, bot.id)
+	}
+
+	// Checks the game the same way Storage does, and picks up the default mode.
+	modelGame, err := game.toModel()
+	if err != nil {
+		return "", err
+	}
+	game.options.Mode = modelGame.Mode()
+
+	err = m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		existingGame, err := tx.gameForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if existingGame != nil {
+			return utilities.NewBadError(fmt.Sprintf("game already exists: %s", id))
+		}
+		tx.games[id] = game
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (m *MemoryStorage) GetGame(ctx context.Context, gameId string) (*model.Game, error) {
+	return m.getGame(m.newTransaction(ctx), gameId, false)
+}
+
+func (m *MemoryStorage) GetGameUsingTransaction(ctx context.Context, gameId string, transaction DatabaseTransaction) (*model.Game, error) {
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return m.getGame(tx, gameId, true)
+}
+
+func (m *MemoryStorage) getGame(tx *memoryTransaction, gameId string, exclusiveLock bool) (*model.Game, error) {
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("cannot getGame for a blank gameId")
+	}
+
+	var game *memoryGame
+	if exclusiveLock {
+		var err error
+		game, err = tx.gameForUpdate(gameId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		game = tx.game(gameId)
+	}
+	if game == nil {
+		return nil, errors.Errorf("game not found: %s", gameId)
+	}
+	return game.toModel()
+}
+
+func (m *MemoryStorage) GetGames(ctx context.Context, playerId string) ([]string, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("cannot GetGames for a blank playerId")
+	}
+
+	bots := []*memoryBot{}
+	gameIdsByBot := map[*memoryBot]string{}
+	m.mu.Lock()
+	for _, game := range m.games {
+		for _, bot := range game.bots {
+			if bot.playerId == playerId {
+				bots = append(bots, bot)
+				gameIdsByBot[bot] = game.options.Id
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(bots, func(i, j int) bool {
+		if bots[i].createdAt.Equal(bots[j].createdAt) {
+			return bots[i].id > bots[j].id
+		}
+		return bots[i].createdAt.After(bots[j].createdAt)
+	})
+	gameIds := []string{}
+	for _, bot := range bots {
+		gameIds = append(gameIds, gameIdsByBot[bot])
+	}
+	return gameIds, nil
+}
+
+func (m *MemoryStorage) UpdateGameState(ctx context.Context, gameId string, updateOpts GameUpdateOptions) error {
+	return m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		return updateMemoryGameState(tx, gameId, updateOpts)
+	})
+}
+
+func (m *MemoryStorage) UpdateGameStateUsingTransaction(ctx context.Context, gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+	return updateMemoryGameState(tx, gameId, updateOpts)
+}
+
+func updateMemoryGameState(tx *memoryTransaction, gameId string, updateOpts GameUpdateOptions) error {
+	_, args := sqlAndArgsForUpdate(updateOpts)
+	if len(args) == 0 {
+		return errors.New("no update options provided")
+	}
+
+	game, err := tx.gameForUpdate(gameId)
+	if err != nil {
+		return err
+	}
+	if updateOpts.ExpectedVersion != nil && (game == nil || game.options.Version != *updateOpts.ExpectedVersion) {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected version: %d", gameId, *updateOpts.ExpectedVersion)
+	}
+	if updateOpts.ExpectedTurn != nil && (game == nil || game.turn() != *updateOpts.ExpectedTurn) {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected turn: %+v", gameId, *updateOpts.ExpectedTurn)
+	}
+	if game == nil {
+		return utilities.NewBadError("Very few or too many rows were affected when updating game in db. This is highly unexpected. rowsAffected: 0")
+	}
+
+	opts := &game.options
+	if updateOpts.State != nil {
+		opts.State = *updateOpts.State
+	}
+	if updateOpts.CurrentTurnIndex != nil {
+		opts.CurrentTurnIndex = *updateOpts.CurrentTurnIndex
+	}
+	if updateOpts.TurnOrder != nil {
+		opts.TurnOrder = copyStrings(updateOpts.TurnOrder)
+	}
+	if updateOpts.StateHandled != nil {
+		opts.StateHandled = *updateOpts.StateHandled
+		game.stateClaimedAt = nil
+	}
+	if updateOpts.StateHandledAt != nil {
+		stateHandledAt := *updateOpts.StateHandledAt
+		opts.StateHandledAt = &stateHandledAt
+	}
+	if updateOpts.LastQuestion != nil {
+		opts.LastQuestion = *updateOpts.LastQuestion
+	}
+	if updateOpts.LastQuestionTargetBotId != nil {
+		opts.LastQuestionTargetBotId = *updateOpts.LastQuestionTargetBotId
+	}
+	if updateOpts.StateTotalTime != nil {
+		opts.StateTotalTime = *updateOpts.StateTotalTime
+	}
+	if updateOpts.Result != nil {
+		opts.Result = *updateOpts.Result
+	}
+	if updateOpts.WinningBotId != nil {
+		opts.WinningBotId = *updateOpts.WinningBotId
+	}
+	if updateOpts.ConversationSummary != nil {
+		opts.ConversationSummary = updateOpts.ConversationSummary.Text
+		opts.SummarizedMessageCount = updateOpts.ConversationSummary.MessageCount
+	}
+	if updateOpts.AiAccusationCheckedAt != nil {
+		aiAccusationCheckedAt := *updateOpts.AiAccusationCheckedAt
+		opts.AiAccusationCheckedAt = &aiAccusationCheckedAt
+	}
+	if updateOpts.DecoyBotId != nil {
+		opts.DecoyBotId = *updateOpts.DecoyBotId
+	}
+	opts.UpdatedAt = time.Now()
+	game.bumpVersion()
+	return nil
+}
+
+func (m *MemoryStorage) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx context.Context, gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+
+	game, err := tx.gameForUpdate(gameId)
+	if err != nil {
+		return err
+	}
+	if game == nil || game.humanBotCount() != humanPlayerCount {
+		return nil
+	}
+
+	game.options.State = "PLAYERS_JOINED"
+	game.options.UpdatedAt = time.Now()
+	game.bumpVersion()
+	return nil
+}
+
+func (m *MemoryStorage) DeleteGame(ctx context.Context, gameId string) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	return m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		game, err := tx.gameForUpdate(gameId)
+		if err != nil {
+			return err
+		}
+		if game == nil {
+			return utilities.NewBadError("Very few or too many rows were affected when deleting game in db. This is highly unexpected. rowsAffected: 0")
+		}
+		tx.games[gameId] = nil
+		return nil
+	})
+}
+
+// sortedGameIds orders games the way the game id queries do, newest first.
+func sortedGameIds(games []*memoryGame) []string {
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].options.CreatedAt.Equal(games[j].options.CreatedAt) {
+			return games[i].options.Id > games[j].options.Id
+		}
+		return games[i].options.CreatedAt.After(games[j].options.CreatedAt)
+	})
+	gameIds := []string{}
+	for _, game := range games {
+		gameIds = append(gameIds, game.options.Id)
+	}
+	return gameIds
+}
+
+func (m *MemoryStorage) gameIdsWhere(matches func(game *memoryGame) bool) []string {
+	games := []*memoryGame{}
+	for _, game := range m.committedGames() {
+		if matches(game) {
+			games = append(games, game)
+		}
+	}
+	return sortedGameIds(games)
+}
+
+func (m *MemoryStorage) GetOldGames(ctx context.Context, gameExpiryDuration time.Duration) ([]string, error) {
+	if gameExpiryDuration > -5*time.Minute {
+		return nil, errors.New("invalid game expiry duration. Max acceptable time is -5 minutes.")
+	}
+
+	expiredAt := time.Now().Add(gameExpiryDuration)
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		return game.options.CreatedAt.Before(expiredAt)
+	}), nil
+}
+
+// Like the query in Storage, games that no human has joined yet are not auto joinable.
+func (m *MemoryStorage) GetAutoJoinableGames(ctx context.Context) ([]string, error) {
+	fiveMinutesAgo := time.Now().Add(-5 * time.Minute)
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		humanBotCount := game.humanBotCount()
+		return game.options.CreatedAt.After(fiveMinutesAgo) &&
+			game.options.State == "STARTED" &&
+			humanBotCount > 0 &&
+			humanBotCount < model.HumanPlayerCountForMode(game.options.Mode)
+	}), nil
+}
+
+func (m *MemoryStorage) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
+	gameState := model.GameState(gameStateString)
+	if !gameState.Valid() {
+		return nil, errors.New("invalid game state")
+	}
+
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		return game.options.State == gameState.String() && !game.options.StateHandled
+	}), nil
+}
+
+// Like SKIP LOCKED in Storage, games locked by another transaction are left for the next claim.
+func (m *MemoryStorage) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	err := validateClaim(gameStates, limit, claimTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	claimStates := map[string]bool{}
+	for _, gameState := range gameStates {
+		claimStates[gameState] = true
+	}
+	now := time.Now()
+	claimedBefore := now.Add(-claimTimeout)
+
+	claimedGames := []ClaimedGame{}
+	err = m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		for _, game := range tx.claimGames(limit, func(game *memoryGame) bool {
+			if !claimStates[game.options.State] {
+				return false
+			}
+			return !game.options.StateHandled || (game.stateClaimedAt != nil && !game.stateClaimedAt.After(claimedBefore))
+		}) {
+			game.options.StateHandled = true
+			claimedAt := now
+			game.stateClaimedAt = &claimedAt
+			game.options.UpdatedAt = now
+			game.bumpVersion()
+
+			claimedGames = append(claimedGames, ClaimedGame{
+				Id:      game.options.Id,
+				State:   game.options.State,
+				Version: game.options.Version,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimedGames, nil
+}
+
+func (m *MemoryStorage) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	statesInPlay := map[string]bool{
+		"WAITING_FOR_AI_QUESTION":    true,
+		"WAITING_FOR_HUMAN_QUESTION": true,
+		"WAITING_FOR_AI_ANSWER":      true,
+		"WAITING_FOR_HUMAN_ANSWER":   true,
+	}
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		if !game.options.AiAccusations || !statesInPlay[game.options.State] {
+			return false
+		}
+		for _, memoryMessage := range game.messages {
+			message := memoryMessage.message
+			sourceBot := game.botWithId(message.SourceBotId)
+			if sourceBot == nil || sourceBot.typeOfBot != "HUMAN" || message.MessageType != "answer" {
+				continue
+			}
+			if game.options.AiAccusationCheckedAt == nil || message.CreatedAt.After(*game.options.AiAccusationCheckedAt) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (m *MemoryStorage) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	now := time.Now()
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		opts := game.options
+		if opts.State != "VOTING" || opts.StateHandled || opts.StateHandledAt == nil {
+			return false
+		}
+		return !opts.StateHandledAt.Add(time.Duration(opts.StateTotalTime) * time.Second).After(now)
+	}), nil
+}
+
+func (m *MemoryStorage) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
+	return m.gameIdsWhere(func(game *memoryGame) bool {
+		return game.options.State == "FINISHED" && len(game.botAnalyses) == 0
+	}), nil
+}
+
+// helpCount is the number of times the player can ask for help, which depends on the game mode.
+func (m *MemoryStorage) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	if utilities.IsBlank(playerId) {
+		return errors.New("playerId cannot be blank")
+	}
+
+	if helpCount < 0 {
+		return errors.New("helpCount cannot be negative")
+	}
+
+	return m.updateBot(transaction, botId, utilities.NewBadError("No rows were affected when player was connected to Bot. This is highly unexpected."), func(tx *memoryTransaction, bot *memoryBot) error {
+		if tx.player(playerId) == nil {
+			return utilities.NewBadError(fmt.Sprintf("dbError while connecting player to bot: %s %s: no such player", playerId, botId))
+		}
+		bot.playerId = playerId
+		bot.typeOfBot = "HUMAN"
+		bot.helpCount = helpCount
+		return nil
+	})
+}
+
+func (m *MemoryStorage) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return m.updateBot(transaction, botId, errors.Errorf("getting help_count for %s: no such bot", botId), func(tx *memoryTransaction, bot *memoryBot) error {
+		if bot.helpCount <= 0 {
+			return errors.Errorf("help_count should not be updated below 0 for %s", botId)
+		}
+		bot.helpCount--
+		return nil
+	})
+}
+
+// No suggestions clears the last help suggestions.
+func (m *MemoryStorage) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return m.updateBot(transaction, botId, utilities.NewBadError("No rows were affected while updating bot last help suggestions. This is highly unexpected."), func(tx *memoryTransaction, bot *memoryBot) error {
+		bot.lastHelpSuggestions = nil
+		if len(suggestions) > 0 {
+			bot.lastHelpSuggestions = copyStrings(suggestions)
+		}
+		return nil
+	})
+}
+
+func (m *MemoryStorage) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return m.updateBot(transaction, botId, utilities.NewBadError("No rows were affected while updating bot stated facts. This is highly unexpected."), func(tx *memoryTransaction, bot *memoryBot) error {
+		bot.statedFacts = copyStrings(statedFacts)
+		return nil
+	})
+}
+
+// Records a tag used by the bot, which counts towards its tag limit and starts its tag cooldown.
+func (m *MemoryStorage) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return m.updateBot(transaction, botId, utilities.NewBadError("No rows were affected while recording bot tag. This is highly unexpected."), func(tx *memoryTransaction, bot *memoryBot) error {
+		bot.tagCount++
+		bot.lastTaggedAt = &taggedAt
+		return nil
+	})
+}
+
+func (m *MemoryStorage) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return m.updateBot(transaction, botId, utilities.NewBadError("No rows were affected while eliminating bot. This is highly unexpected."), func(tx *memoryTransaction, bot *memoryBot) error {
+		bot.eliminated = true
+		return nil
+	})
+}
+
+// updateBot locks the bot's game, applies update to the bot and bumps the game's version.
+func (m *MemoryStorage) updateBot(transaction DatabaseTransaction, botId string, noSuchBotError error, update func(tx *memoryTransaction, bot *memoryBot) error) error {
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+
+	game, bot, err := tx.gameForUpdateByBot(botId)
+	if err != nil {
+		return err
+	}
+	if bot == nil {
+		return noSuchBotError
+	}
+
+	err = update(tx, bot)
+	if err != nil {
+		return err
+	}
+	game.bumpVersion()
+	return nil
+}
+
+func (m *MemoryStorage) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	id := m.IdGenerator.Generate()
+	return m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		return createMemoryMessage(tx, id, sourceBotId, targetBotId, text, messageType, metadata)
+	})
+}
+
+func (m *MemoryStorage) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error {
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+	id := m.IdGenerator.Generate()
+	return createMemoryMessage(tx, id, sourceBotId, targetBotId, text, messageType, metadata)
+}
+
+func createMemoryMessage(tx *memoryTransaction, id, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	err := validateMessage(sourceBotId, targetBotId, text, messageType, metadata)
+	if err != nil {
+		return err
+	}
+
+	game, sourceBot, err := tx.gameForUpdateByBot(sourceBotId)
+	if err != nil {
+		return err
+	}
+	if sourceBot == nil || game.botWithId(targetBotId) == nil {
+		return utilities.NewBadError(fmt.Sprintf("dbError while inserting message: %s %s %s: no such bot", sourceBotId, targetBotId, text))
+	}
+
+	game.messages = append(game.messages, memoryMessage{
+		id: id,
+		message: model.Message{
+			Text:             text,
+			CreatedAt:        time.Now(),
+			SourceBotId:      sourceBotId,
+			TargetBotId:      targetBotId,
+			MessageType:      messageType,
+			ResponseTime:     metadata.ResponseTime.Truncate(time.Millisecond),
+			HelpUsage:        metadata.HelpUsage,
+			HelpStyle:        metadata.HelpStyle,
+			AiModel:          metadata.AiModel,
+			PromptVersion:    metadata.PromptVersion,
+			PromptTokens:     metadata.PromptTokens,
+			CompletionTokens: metadata.CompletionTokens,
+		},
+	})
+	game.bumpVersion()
+	return nil
+}
+
+// Each bot has a single vote in a game. Voting again replaces the earlier vote.
+func (m *MemoryStorage) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(voterBotId) {
+		return errors.New("voterBotId cannot be blank")
+	}
+
+	if utilities.IsBlank(suspectBotId) {
+		return errors.New("suspectBotId cannot be blank")
+	}
+
+	if voterBotId == suspectBotId {
+		return errors.Errorf("voter and suspect bot cannot be same. %s %s", voterBotId, suspectBotId)
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+
+	game, err := tx.gameForUpdate(gameId)
+	if err != nil {
+		return err
+	}
+	if game == nil || game.botWithId(voterBotId) == nil || game.botWithId(suspectBotId) == nil {
+		return utilities.NewBadError(fmt.Sprintf("dbError while casting vote: %s %s: no such game or bot", voterBotId, suspectBotId))
+	}
+
+	for i, vote := range game.votes {
+		if vote.voterBotId == voterBotId {
+			game.votes[i].suspectBotId = suspectBotId
+			game.bumpVersion()
+			return nil
+		}
+	}
+	game.votes = append(game.votes, memoryVote{id: m.IdGenerator.Generate(), voterBotId: voterBotId, suspectBotId: suspectBotId})
+	game.bumpVersion()
+	return nil
+}
+
+// A game analysis is stored along with the game, so that it goes away along with the game's bots.
+func (m *MemoryStorage) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(analysis.GameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if len(analysis.BotAnalyses) == 0 {
+		return errors.New("botAnalyses cannot be empty")
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+
+	for _, botAnalysis := range analysis.BotAnalyses {
+		if utilities.IsBlank(botAnalysis.BotId) {
+			return errors.New("botId cannot be blank")
+		}
+
+		if botAnalysis.HumannessScore < 0 || botAnalysis.HumannessScore > 100 {
+			return errors.New("humannessScore should be between 0 and 100")
+		}
+
+		game, bot, err := tx.gameForUpdateByBot(botAnalysis.BotId)
+		if err != nil {
+			return err
+		}
+		if bot == nil {
+			return utilities.NewBadError(fmt.Sprintf("dbError while creating bot analysis: %s: no such bot", botAnalysis.BotId))
+		}
+		for _, existingAnalysis := range game.botAnalyses {
+			if existingAnalysis.botAnalysis.BotId == botAnalysis.BotId {
+				return utilities.NewBadError(fmt.Sprintf("dbError while creating bot analysis: %s: bot has already been analyzed", botAnalysis.BotId))
+			}
+		}
+
+		botAnalysis.Tells = copyStrings(botAnalysis.Tells)
+		game.botAnalyses = append(game.botAnalyses, memoryBotAnalysis{id: m.IdGenerator.Generate(), botAnalysis: botAnalysis})
+	}
+	return nil
+}
+
+func (m *MemoryStorage) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("gameId cannot be blank")
+	}
+
+	botAnalyses := []model.BotAnalysis{}
+	game := m.newTransaction(ctx).game(gameId)
+	if game != nil {
+		for _, memoryBotAnalysis := range game.botAnalyses {
+			botAnalysis := memoryBotAnalysis.botAnalysis
+			botAnalysis.Tells = copyStrings(botAnalysis.Tells)
+			if botAnalysis.Tells == nil {
+				botAnalysis.Tells = []string{}
+			}
+			botAnalyses = append(botAnalyses, botAnalysis)
+		}
+	}
+	sort.Slice(botAnalyses, func(i, j int) bool {
+		return botAnalyses[i].BotId < botAnalyses[j].BotId
+	})
+
+	if len(botAnalyses) == 0 {
+		return nil, errors.Errorf("game has not been analyzed yet: %s", gameId)
+	}
+
+	return &model.GameAnalysis{
+		GameId:      gameId,
+		BotAnalyses: botAnalyses,
+	}, nil
+}
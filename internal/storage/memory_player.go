@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// player returns the transaction's own copy of the player if it has one, and the last committed copy otherwise.
+func (tx *memoryTransaction) player(playerId string) *memoryPlayer {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	player, ok := tx.players[playerId]
+	if ok {
+		return player
+	}
+	return m.players[playerId]
+}
+
+// playerForUpdate locks the player and returns the transaction's own copy of it, or nil if there is no such player.
+func (tx *memoryTransaction) playerForUpdate(playerId string) (*memoryPlayer, error) {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := tx.lock("players/" + playerId)
+	if err != nil {
+		return nil, err
+	}
+
+	player, ok := tx.players[playerId]
+	if ok {
+		return player, nil
+	}
+	committedPlayer := m.players[playerId]
+	if committedPlayer == nil {
+		return nil, nil
+	}
+	playerCopy := *committedPlayer
+	tx.players[playerId] = &playerCopy
+	return &playerCopy, nil
+}
+
+func (p *memoryPlayer) toModel() (*model.Player, error) {
+	return model.NewPlayer(model.PlayerOptions{Id: p.id, UserId: p.userId})
+}
+
+func (m *MemoryStorage) UserByEmail(ctx context.Context, email string) (*model.User, error) {
+	if utilities.IsBlank(email) {
+		return nil, errors.New("cannot search by blank email")
+	}
+
+	m.mu.Lock()
+	userOptions, ok := m.users[email]
+	m.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("UserByEmail %s: no such user", email)
+	}
+	return model.NewUser(userOptions)
+}
+
+func (m *MemoryStorage) CreatePlayer(ctx context.Context) (*model.Player, error) {
+	return m.createPlayer(ctx, nil)
+}
+
+func (m *MemoryStorage) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+	return m.createPlayer(ctx, &userId)
+}
+
+func (m *MemoryStorage) createPlayer(ctx context.Context, userId *string) (*model.Player, error) {
+	player := &memoryPlayer{
+		id:        m.IdGenerator.Generate(),
+		userId:    userId,
+		createdAt: time.Now(),
+	}
+
+	modelPlayer, err := player.toModel()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to create player")
+	}
+
+	err = m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		if userId != nil && !m.hasUser(*userId) {
+			return utilities.NewBadError(fmt.Sprintf("dbError while inserting player: no such user: %s", *userId))
+		}
+		existingPlayer, err := tx.playerForUpdate(player.id)
+		if err != nil {
+			return err
+		}
+		if existingPlayer != nil {
+			return utilities.NewBadError(fmt.Sprintf("player already exists: %s", player.id))
+		}
+		tx.players[player.id] = player
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return modelPlayer, nil
+}
+
+func (m *MemoryStorage) hasUser(userId string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, user := range m.users {
+		if user.Id == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryStorage) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	player := m.newTransaction(ctx).player(playerId)
+	if player == nil || player.deletedAt != nil {
+		return nil, errors.Errorf("getting player for %s: no such player", playerId)
+	}
+	return player.toModel()
+}
+
+func (m *MemoryStorage) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := tx.playerForUpdate(playerId)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil || player.deletedAt != nil {
+		return nil, errors.Errorf("getting player for %s: no such player", playerId)
+	}
+	return player.toModel()
+}
+
+func (m *MemoryStorage) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.hasUser(userId) {
+		return nil, utilities.NewBadError(fmt.Sprintf("dbError while attempting player update: no such user: %s", userId))
+	}
+
+	player, err := tx.playerForUpdate(playerId)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil || player.deletedAt != nil {
+		return nil, utilities.NewBadError("Very few or too many rows were affected when updating player in db. This is highly unexpected. rowsAffected: 0")
+	}
+	player.userId = &userId
+	return player.toModel()
+}
+
+func (m *MemoryStorage) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	players := []*memoryPlayer{}
+	m.mu.Lock()
+	for _, player := range m.players {
+		if player.deletedAt == nil && player.userId != nil && *player.userId == userId {
+			players = append(players, player)
+		}
+	}
+	m.mu.Unlock()
+
+	if len(players) == 0 {
+		return nil, nil
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].createdAt.Before(players[j].createdAt)
+	})
+	return players[0].toModel()
+}
+
+func (g *memoryGame) hasPlayer(playerId string) bool {
+	for _, bot := range g.bots {
+		if bot.playerId == playerId {
+			return true
+		}
+	}
+	return false
+}
+
+// Practice games are recorded separately, so that they never count towards ranked results.
+func (m *MemoryStorage) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(playerId) {
+		return errors.New("playerId cannot be blank")
+	}
+
+	tx, err := memoryTransactionFor(transaction)
+	if err != nil {
+		return err
+	}
+
+	if tx.player(playerId) == nil {
+		return utilities.NewBadError(fmt.Sprintf("dbError while recording practice result: %s %s: no such player", gameId, playerId))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, practiceResult := range append(m.practiceResults, tx.practiceResults...) {
+		if practiceResult.gameId == gameId {
+			return utilities.NewBadError(fmt.Sprintf("dbError while recording practice result: %s %s: game already has a result", gameId, playerId))
+		}
+	}
+	tx.practiceResults = append(tx.practiceResults, memoryPracticeResult{
+		id:       m.IdGenerator.Generate(),
+		gameId:   gameId,
+		playerId: playerId,
+		won:      won,
+	})
+	return nil
+}
+
+func (m *MemoryStorage) GetMessageStats(ctx context.Context, since time.Time) ([]model.MessageStats, error) {
+	type messageStatsKey struct {
+		sourceBotType string
+		messageType   string
+		helpUsage     string
+		aiModel       string
+		promptVersion string
+	}
+
+	statsByKey := map[messageStatsKey]*model.MessageStats{}
+	totalResponseTimes := map[messageStatsKey]time.Duration{}
+	for _, game := range m.committedGames() {
+		for _, memoryMessage := range game.messages {
+			message := memoryMessage.message
+			sourceBot := game.botWithId(message.SourceBotId)
+			if sourceBot == nil || !message.CreatedAt.After(since) {
+				continue
+			}
+
+			key := messageStatsKey{
+				sourceBotType: sourceBot.typeOfBot,
+				messageType:   message.MessageType,
+				helpUsage:     message.HelpUsage,
+				aiModel:       message.AiModel,
+				promptVersion: message.PromptVersion,
+			}
+			stats, ok := statsByKey[key]
+			if !ok {
+				stats = &model.MessageStats{
+					SourceBotType: key.sourceBotType,
+					MessageType:   key.messageType,
+					HelpUsage:     key.helpUsage,
+					AiModel:       key.aiModel,
+					PromptVersion: key.promptVersion,
+				}
+				statsByKey[key] = stats
+			}
+			stats.MessageCount++
+			stats.PromptTokens += message.PromptTokens
+			stats.CompletionTokens += message.CompletionTokens
+			totalResponseTimes[key] += message.ResponseTime
+		}
+	}
+
+	messageStatsList := []model.MessageStats{}
+	for key, stats := range statsByKey {
+		averageResponseTimeMs := (totalResponseTimes[key].Milliseconds() + stats.MessageCount/2) / stats.MessageCount
+		stats.AverageResponseTime = time.Duration(averageResponseTimeMs) * time.Millisecond
+		messageStatsList = append(messageStatsList, *stats)
+	}
+
+	sort.Slice(messageStatsList, func(i, j int) bool {
+		a, b := messageStatsList[i], messageStatsList[j]
+		if a.SourceBotType != b.SourceBotType {
+			return a.SourceBotType < b.SourceBotType
+		}
+		if a.MessageType != b.MessageType {
+			return a.MessageType < b.MessageType
+		}
+		if a.HelpUsage != b.HelpUsage {
+			return a.HelpUsage < b.HelpUsage
+		}
+		if a.AiModel != b.AiModel {
+			return a.AiModel < b.AiModel
+		}
+		return a.PromptVersion < b.PromptVersion
+	})
+	return messageStatsList, nil
+}
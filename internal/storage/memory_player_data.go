@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func (m *MemoryStorage) ErasePlayer(ctx context.Context, playerId string) (*model.PlayerErasure, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	var erasure *model.PlayerErasure
+	err := m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		player, err := tx.playerForUpdate(playerId)
+		if err != nil {
+			return err
+		}
+		if player == nil || player.deletedAt != nil {
+			return errors.Errorf("erasing player %s: no such player", playerId)
+		}
+		erasure, err = tx.erasePlayer(player)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+func (m *MemoryStorage) EraseUserPlayers(ctx context.Context, userId string) ([]*model.PlayerErasure, error) {
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	playerIds := []string{}
+	for _, player := range m.committedPlayersOfUser(userId) {
+		playerIds = append(playerIds, player.id)
+	}
+	sort.Strings(playerIds)
+
+	erasures := []*model.PlayerErasure{}
+	err := m.inTransaction(ctx, func(tx *memoryTransaction) error {
+		for _, playerId := range playerIds {
+			player, err := tx.playerForUpdate(playerId)
+			if err != nil {
+				return err
+			}
+			// The player may have changed while this transaction waited for the lock.
+			if player == nil || player.deletedAt != nil || player.userId == nil || *player.userId != userId {
+				continue
+			}
+			erasure, err := tx.erasePlayer(player)
+			if err != nil {
+				return err
+			}
+			erasures = append(erasures, erasure)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasures, nil
+}
+
+// erasePlayer expects the transaction to hold the lock on the player. It locks the games of the player in order, like
+// the database does.
+func (tx *memoryTransaction) erasePlayer(player *memoryPlayer) (*model.PlayerErasure, error) {
+	m := tx.storage
+	erasure := model.PlayerErasure{
+		Id:       m.IdGenerator.Generate(),
+		PlayerId: player.id,
+		UserId:   player.userId,
+	}
+
+	gameIds := []string{}
+	for _, committedGame := range m.committedGames() {
+		if committedGame.hasPlayer(player.id) {
+			gameIds = append(gameIds, committedGame.options.Id)
+		}
+	}
+	sort.Strings(gameIds)
+
+	games := []*memoryGame{}
+	for _, gameId := range gameIds {
+		game, err := tx.gameForUpdate(gameId)
+		if err != nil {
+			return nil, err
+		}
+		if game == nil || !game.hasPlayer(player.id) {
+			continue
+		}
+		if game.options.State != "FINISHED" {
+			return nil, errors.Wrapf(ErrPlayerInUnfinishedGame, "erasing player %s in game %s", player.id, gameId)
+		}
+		games = append(games, game)
+	}
+
+	for _, game := range games {
+		botCount, messageCount := game.erasePlayer(player.id)
+		erasure.BotCount += botCount
+		erasure.MessageCount += messageCount
+		game.bumpVersion()
+	}
+
+	now := time.Now()
+	player.userId = nil
+	player.deletedAt = &now
+	erasure.CreatedAt = now
+	tx.playerErasures = append(tx.playerErasures, erasure)
+	return &erasure, nil
+}
+
+// erasePlayer returns how many bots and messages of the player it erased.
+func (g *memoryGame) erasePlayer(playerId string) (int64, int64) {
+	var botCount, messageCount int64
+	erasedBotIds := map[string]bool{}
+	for _, bot := range g.bots {
+		if bot.playerId == playerId {
+			erasedBotIds[bot.id] = true
+			bot.lastHelpSuggestions = nil
+			botCount++
+		}
+	}
+
+	erasedLastQuestion := false
+	for i := range g.messages {
+		message := &g.messages[i].message
+		if !erasedBotIds[message.SourceBotId] {
+			continue
+		}
+		if !utilities.IsBlank(g.options.LastQuestion) && message.Text == g.options.LastQuestion {
+			erasedLastQuestion = true
+		}
+		message.Text = model.ERASED_MESSAGE_TEXT
+		messageCount++
+	}
+	if erasedLastQuestion {
+		g.options.LastQuestion = model.ERASED_MESSAGE_TEXT
+	}
+
+	for i := range g.botAnalyses {
+		if erasedBotIds[g.botAnalyses[i].botAnalysis.BotId] {
+			g.botAnalyses[i].botAnalysis.Tells = []string{}
+		}
+	}
+
+	g.options.ConversationSummary = ""
+	g.options.SummarizedMessageCount = 0
+	return botCount, messageCount
+}
+
+func (m *MemoryStorage) ExportPlayerData(ctx context.Context, playerId string) (*model.DataExport, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	player := m.newTransaction(ctx).player(playerId)
+	if player == nil || player.deletedAt != nil {
+		return nil, errors.Errorf("exporting player %s: no such player", playerId)
+	}
+
+	return &model.DataExport{
+		Players:  m.playersData([]*memoryPlayer{player}),
+		Erasures: []model.PlayerErasure{},
+	}, nil
+}
+
+func (m *MemoryStorage) ExportUserData(ctx context.Context, userId string) (*model.DataExport, error) {
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	var user *model.ExportedUser
+	m.mu.Lock()
+	for _, userOptions := range m.users {
+		if userOptions.Id == userId {
+			email := userOptions.Email
+			user = &model.ExportedUser{Id: userId, Email: &email}
+		}
+	}
+	erasures := []model.PlayerErasure{}
+	for _, erasure := range m.playerErasures {
+		if erasure.UserId != nil && *erasure.UserId == userId {
+			erasures = append(erasures, erasure)
+		}
+	}
+	m.mu.Unlock()
+	if user == nil {
+		return nil, errors.Errorf("exporting user %s: no such user", userId)
+	}
+
+	players := m.committedPlayersOfUser(userId)
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].createdAt.Equal(players[j].createdAt) {
+			return players[i].id < players[j].id
+		}
+		return players[i].createdAt.Before(players[j].createdAt)
+	})
+
+	return &model.DataExport{
+		User:     user,
+		Players:  m.playersData(players),
+		Erasures: erasures,
+	}, nil
+}
+
+// committedPlayersOfUser skips erased players.
+func (m *MemoryStorage) committedPlayersOfUser(userId string) []*memoryPlayer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	players := []*memoryPlayer{}
+	for _, player := range m.players {
+		if player.deletedAt == nil && player.userId != nil && *player.userId == userId {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+func (m *MemoryStorage) playersData(players []*memoryPlayer) []model.PlayerData {
+	games := m.committedGames()
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].options.CreatedAt.Equal(games[j].options.CreatedAt) {
+			return games[i].options.Id < games[j].options.Id
+		}
+		return games[i].options.CreatedAt.Before(games[j].options.CreatedAt)
+	})
+
+	m.mu.Lock()
+	practiceResults := append([]memoryPracticeResult{}, m.practiceResults...)
+	m.mu.Unlock()
+
+	playersData := []model.PlayerData{}
+	for _, player := range players {
+		playerData := model.PlayerData{
+			PlayerId:        player.id,
+			UserId:          player.userId,
+			CreatedAt:       player.createdAt,
+			Bots:            []model.ExportedBot{},
+			Messages:        []model.ExportedMessage{},
+			Votes:           []model.ExportedVote{},
+			PracticeResults: []model.ExportedPracticeResult{},
+		}
+		for _, game := range games {
+			addExportedGameData(&playerData, game)
+		}
+		sort.SliceStable(playerData.Messages, func(i, j int) bool {
+			return playerData.Messages[i].CreatedAt.Before(playerData.Messages[j].CreatedAt)
+		})
+		for _, practiceResult := range practiceResults {
+			if practiceResult.playerId == player.id {
+				playerData.PracticeResults = append(playerData.PracticeResults, model.ExportedPracticeResult{
+					GameId: practiceResult.gameId,
+					Won:    practiceResult.won,
+				})
+			}
+		}
+		playersData = append(playersData, playerData)
+	}
+	return playersData
+}
+
+func addExportedGameData(playerData *model.PlayerData, game *memoryGame) {
+	botIds := map[string]bool{}
+	for _, bot := range game.bots {
+		if bot.playerId != playerData.PlayerId {
+			continue
+		}
+		botIds[bot.id] = true
+		exportedBot := model.ExportedBot{
+			Id:                  bot.id,
+			GameId:              game.options.Id,
+			Name:                bot.name,
+			HelpCount:           bot.helpCount,
+			LastHelpSuggestions: copyStrings(bot.lastHelpSuggestions),
+			TagCount:            bot.tagCount,
+			Eliminated:          bot.eliminated,
+		}
+		for _, botAnalysis := range game.botAnalyses {
+			if botAnalysis.botAnalysis.BotId == bot.id {
+				analysis := botAnalysis.botAnalysis
+				analysis.Tells = copyStrings(analysis.Tells)
+				exportedBot.Analysis = &analysis
+			}
+		}
+		playerData.Bots = append(playerData.Bots, exportedBot)
+	}
+
+	for _, memoryMessage := range game.messages {
+		message := memoryMessage.message
+		if !botIds[message.SourceBotId] {
+			continue
+		}
+		playerData.Messages = append(playerData.Messages, model.ExportedMessage{
+			Id:          memoryMessage.id,
+			GameId:      game.options.Id,
+			SourceBotId: message.SourceBotId,
+			TargetBotId: message.TargetBotId,
+			MessageType: message.MessageType,
+			Text:        message.Text,
+			CreatedAt:   message.CreatedAt,
+		})
+	}
+
+	for _, vote := range game.votes {
+		if botIds[vote.voterBotId] {
+			playerData.Votes = append(playerData.Votes, model.ExportedVote{
+				GameId:       game.options.Id,
+				VoterBotId:   vote.voterBotId,
+				SuspectBotId: vote.suspectBotId,
+			})
+		}
+	}
+}
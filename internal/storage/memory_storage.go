@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// MemoryStorage is an in-memory StorageAccessor for tests that need storage to behave like the database, without a
+// database. Each game, along with its bots, messages, votes and analyses, is locked as a whole. A transaction locks a
+// game the first time it reads it for update or writes to it, and works on its own copy of the game until it commits.
+// Other transactions wait for the lock, and reads without a lock see the last committed copy.
+type MemoryStorage struct {
+	IdGenerator utilities.CuidGenerator
+
+	mu              sync.Mutex
+	lockReleased    *sync.Cond
+	locks           map[string]*memoryTransaction
+	games           map[string]*memoryGame
+	botGameIds      map[string]string
+	players         map[string]*memoryPlayer
+	users           map[string]model.UserOptions
+	practiceResults []memoryPracticeResult
+	playerErasures  []model.PlayerErasure
+}
+
+type MemoryStorageOptions struct {
+	IdGenerator utilities.CuidGenerator
+}
+
+func NewMemoryStorage(opts MemoryStorageOptions) *MemoryStorage {
+	if opts.IdGenerator == nil {
+		opts.IdGenerator = &utilities.RandomIdGenerator{}
+	}
+
+	m := &MemoryStorage{
+		IdGenerator: opts.IdGenerator,
+		locks:       map[string]*memoryTransaction{},
+		games:       map[string]*memoryGame{},
+		botGameIds:  map[string]string{},
+		players:     map[string]*memoryPlayer{},
+		users:       map[string]model.UserOptions{},
+	}
+	m.lockReleased = sync.NewCond(&m.mu)
+	return m
+}
+
+// AddUser stands in for the users that are created outside this service.
+func (m *MemoryStorage) AddUser(opts model.UserOptions) error {
+	_, err := model.NewUser(opts)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, user := range m.users {
+		if user.Id == opts.Id {
+			return errors.Errorf("user already exists: %s", opts.Id)
+		}
+	}
+	if _, ok := m.users[opts.Email]; ok {
+		return errors.Errorf("user already exists with email: %s", opts.Email)
+	}
+	m.users[opts.Email] = opts
+	return nil
+}
+
+// memoryTransaction does not run SQL, so like DatabaseTransactionMock, it leaves customDbHandler unimplemented.
+type memoryTransaction struct {
+	customDbHandler
+	ctx     context.Context
+	storage *MemoryStorage
+	// A nil game marks one that is deleted when the transaction commits.
+	games           map[string]*memoryGame
+	players         map[string]*memoryPlayer
+	practiceResults []memoryPracticeResult
+	playerErasures  []model.PlayerErasure
+	done            bool
+	finished        chan struct{}
+}
+
+func (m *MemoryStorage) BeginTransaction(ctx context.Context) (DatabaseTransaction, error) {
+	tx := m.newTransaction(ctx)
+	tx.rollbackWhenDone()
+	return tx, nil
+}
+
+func (m *MemoryStorage) newTransaction(ctx context.Context) *memoryTransaction {
+	return &memoryTransaction{
+		ctx:      ctx,
+		storage:  m,
+		games:    map[string]*memoryGame{},
+		players:  map[string]*memoryPlayer{},
+		finished: make(chan struct{}),
+	}
+}
+
+// rollbackWhenDone rolls the transaction back once its context is done, like database/sql does. That also stops it
+// from waiting on a lock.
+func (tx *memoryTransaction) rollbackWhenDone() {
+	if tx.ctx.Done() == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-tx.ctx.Done():
+			tx.Rollback()
+		case <-tx.finished:
+		}
+	}()
+}
+
+func (tx *memoryTransaction) Commit() error {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if tx.done {
+		return tx.doneErr()
+	}
+	if err := tx.ctx.Err(); err != nil {
+		tx.release()
+		return err
+	}
+
+	for gameId, game := range tx.games {
+		if game == nil {
+			m.removeGame(gameId)
+			continue
+		}
+		m.games[gameId] = game
+		for _, bot := range game.bots {
+			m.botGameIds[bot.id] = gameId
+		}
+	}
+	m.practiceResults = append(m.practiceResults, tx.practiceResults...)
+	for playerId, player := range tx.players {
+		m.players[playerId] = player
+		if player.deletedAt != nil {
+			m.removePracticeResultsForPlayer(playerId)
+		}
+	}
+	m.playerErasures = append(m.playerErasures, tx.playerErasures...)
+
+	tx.release()
+	return nil
+}
+
+func (tx *memoryTransaction) Rollback() error {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if tx.done {
+		return sql.ErrTxDone
+	}
+
+	tx.release()
+	return nil
+}
+
+// release expects the storage mutex to be held.
+func (tx *memoryTransaction) release() {
+	m := tx.storage
+	for key, owner := range m.locks {
+		if owner == tx {
+			delete(m.locks, key)
+		}
+	}
+	tx.done = true
+	close(tx.finished)
+	m.lockReleased.Broadcast()
+}
+
+// doneErr is the error for using a transaction after it ended, which is the context's error if that is what ended it.
+func (tx *memoryTransaction) doneErr() error {
+	if err := tx.ctx.Err(); err != nil {
+		return err
+	}
+	return sql.ErrTxDone
+}
+
+// memoryTransactionFor fails for transactions that did not come from a MemoryStorage, since there is nothing it can do
+// with them.
+func memoryTransactionFor(transaction DatabaseTransaction) (*memoryTransaction, error) {
+	tx, ok := transaction.(*memoryTransaction)
+	if !ok {
+		return nil, errors.New("MemoryStorage needs a transaction that it began")
+	}
+	return tx, nil
+}
+
+// inTransaction runs f in a transaction of its own, which is how a statement runs outside a transaction.
+func (m *MemoryStorage) inTransaction(ctx context.Context, f func(tx *memoryTransaction) error) error {
+	tx := m.newTransaction(ctx)
+	tx.rollbackWhenDone()
+	defer tx.Rollback()
+
+	err := f(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lock waits until no other transaction holds the lock, or until the transaction is rolled back because its context is
+// done. It expects the storage mutex to be held.
+func (tx *memoryTransaction) lock(key string) error {
+	m := tx.storage
+	for {
+		if tx.done {
+			return tx.doneErr()
+		}
+		owner := m.locks[key]
+		if owner == nil || owner == tx {
+			m.locks[key] = tx
+			return nil
+		}
+		m.lockReleased.Wait()
+	}
+}
+
+// gameForUpdate locks the game and returns the transaction's own copy of it, or nil if there is no such game.
+func (tx *memoryTransaction) gameForUpdate(gameId string) (*memoryGame, error) {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := tx.lock("games/" + gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	game, ok := tx.games[gameId]
+	if ok {
+		return game, nil
+	}
+	committedGame := m.games[gameId]
+	if committedGame == nil {
+		return nil, nil
+	}
+	game = committedGame.copy()
+	tx.games[gameId] = game
+	return game, nil
+}
+
+// claimGames locks up to limit of the committed games that match, oldest first, and returns the transaction's own
+// copies of them. Games that another transaction holds the lock on are skipped.
+func (tx *memoryTransaction) claimGames(limit int, matches func(game *memoryGame) bool) []*memoryGame {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	committedGames := []*memoryGame{}
+	for gameId, game := range m.games {
+		owner := m.locks["games/"+gameId]
+		if (owner == nil || owner == tx) && matches(game) {
+			committedGames = append(committedGames, game)
+		}
+	}
+	sort.Slice(committedGames, func(i, j int) bool {
+		if committedGames[i].options.CreatedAt.Equal(committedGames[j].options.CreatedAt) {
+			return committedGames[i].options.Id < committedGames[j].options.Id
+		}
+		return committedGames[i].options.CreatedAt.Before(committedGames[j].options.CreatedAt)
+	})
+	if len(committedGames) > limit {
+		committedGames = committedGames[:limit]
+	}
+
+	games := []*memoryGame{}
+	for _, committedGame := range committedGames {
+		gameId := committedGame.options.Id
+		m.locks["games/"+gameId] = tx
+		game := committedGame.copy()
+		tx.games[gameId] = game
+		games = append(games, game)
+	}
+	return games
+}
+
+// gameForUpdateByBot is gameForUpdate for the game that the bot is in.
+func (tx *memoryTransaction) gameForUpdateByBot(botId string) (*memoryGame, *memoryBot, error) {
+	m := tx.storage
+	m.mu.Lock()
+	gameId, ok := m.botGameIds[botId]
+	if !ok {
+		for txGameId, game := range tx.games {
+			if game != nil && game.botWithId(botId) != nil {
+				gameId = txGameId
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	game, err := tx.gameForUpdate(gameId)
+	if err != nil || game == nil {
+		return nil, nil, err
+	}
+	return game, game.botWithId(botId), nil
+}
+
+// game returns the transaction's own copy of the game if it has one, and the last committed copy otherwise.
+func (tx *memoryTransaction) game(gameId string) *memoryGame {
+	m := tx.storage
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	game, ok := tx.games[gameId]
+	if ok {
+		return game
+	}
+	return m.games[gameId]
+}
+
+// committedGames returns the last committed copy of every game.
+func (m *MemoryStorage) committedGames() []*memoryGame {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	games := make([]*memoryGame, 0, len(m.games))
+	for _, game := range m.games {
+		games = append(games, game)
+	}
+	return games
+}
+
+// removeGame expects the storage mutex to be held.
+func (m *MemoryStorage) removeGame(gameId string) {
+	game := m.games[gameId]
+	if game == nil {
+		return
+	}
+	for _, bot := range game.bots {
+		delete(m.botGameIds, bot.id)
+	}
+	delete(m.games, gameId)
+}
+
+// An erased player is kept, like it is in the database, with deletedAt set.
+type memoryPlayer struct {
+	id        string
+	userId    *string
+	createdAt time.Time
+	deletedAt *time.Time
+}
+
+type memoryPracticeResult struct {
+	id       string
+	gameId   string
+	playerId string
+	won      bool
+}
+
+// removePracticeResultsForPlayer expects the storage mutex to be held.
+func (m *MemoryStorage) removePracticeResultsForPlayer(playerId string) {
+	practiceResults := []memoryPracticeResult{}
+	for _, practiceResult := range m.practiceResults {
+		if practiceResult.playerId != playerId {
+			practiceResults = append(practiceResults, practiceResult)
+		}
+	}
+	m.practiceResults = practiceResults
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMemoryStorageWithGame(t *testing.T) (*MemoryStorage, string) {
+	m := NewMemoryStorage(MemoryStorageOptions{})
+	gameId, err := m.CreateGame(context.Background(), GameCreateOptions{})
+	assert.NoError(t, err)
+	return m, gameId
+}
+
+func Test_MemoryStorage_Transactions(t *testing.T) {
+	t.Run("errors for transactions that it did not begin", func(t *testing.T) {
+		m, gameId := newMemoryStorageWithGame(t)
+		_, err := m.GetGameUsingTransaction(context.Background(), gameId, &DatabaseTransactionMock{})
+		assert.EqualError(t, err, "MemoryStorage needs a transaction that it began")
+	})
+}
+
+func Test_MemoryStorage_ClaimUnhandledGames(t *testing.T) {
+	m := NewMemoryStorage(MemoryStorageOptions{})
+	state := "WAITING_FOR_AI_QUESTION"
+	gameIds := []string{}
+	for i := 0; i < 2; i++ {
+		gameId, err := m.CreateGame(context.Background(), GameCreateOptions{})
+		assert.NoError(t, err)
+		err = m.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state})
+		assert.NoError(t, err)
+		gameIds = append(gameIds, gameId)
+	}
+
+	tx, err := m.BeginTransaction(context.Background())
+	assert.NoError(t, err)
+	_, err = m.GetGameUsingTransaction(context.Background(), gameIds[0], tx)
+	assert.NoError(t, err)
+
+	games, err := m.ClaimUnhandledGames(context.Background(), []string{state}, 1, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, games, 1)
+	assert.Equal(t, gameIds[1], games[0].Id, "the locked game should be skipped")
+
+	assert.NoError(t, tx.Rollback())
+	games, err = m.ClaimUnhandledGames(context.Background(), []string{state}, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, games, 1)
+	assert.Equal(t, gameIds[0], games[0].Id)
+}
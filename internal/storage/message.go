@@ -1,28 +1,86 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 type MessageCreator interface {
-	CreateMessage(sourceBotId, targetBotId, text, messageType string) error
-	CreateMessageUsingTransaction(sourceBotId, targetBotId, text, messageType string, transaction DatabaseTransaction) error
+	CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error
+	CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error
 }
 
-func (s *Storage) CreateMessage(sourceBotId, targetBotId, text, messageType string) error {
+// MessageMetadata is recorded along with a message for replays and analytics.
+// HelpUsage and HelpStyle are only expected for human messages, while the AI fields are only expected for AI messages.
+type MessageMetadata struct {
+	ResponseTime     time.Duration
+	HelpUsage        string
+	HelpStyle        string
+	AiModel          string
+	PromptVersion    string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+func (s *Storage) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	id := s.IdGenerator.Generate()
-	return createMessageUsingCustomDbHandler(s.db, id, sourceBotId, targetBotId, text, messageType)
+	return createMessageUsingCustomDbHandler(ctx, s.db, id, sourceBotId, targetBotId, text, messageType, metadata)
 }
 
-func (s *Storage) CreateMessageUsingTransaction(sourceBotId, targetBotId, text, messageType string, transaction DatabaseTransaction) error {
+func (s *Storage) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	id := s.IdGenerator.Generate()
-	return createMessageUsingCustomDbHandler(transaction, id, sourceBotId, targetBotId, text, messageType)
+	return createMessageUsingCustomDbHandler(ctx, transaction, id, sourceBotId, targetBotId, text, messageType, metadata)
+}
+
+func createMessageUsingCustomDbHandler(ctx context.Context, customDb customDbHandler, id, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	err := validateMessage(sourceBotId, targetBotId, text, messageType, metadata)
+	if err != nil {
+		return err
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`INSERT INTO public."messages" (
+			"id", "game_id", "source_bot_id", "target_bot_id", "text", "type",
+			"response_time_ms", "help_usage", "help_style", "ai_model", "prompt_version", "prompt_tokens", "completion_tokens"
+		)
+		VALUES (
+			$1, (SELECT "game_id" FROM public."bots" WHERE "id" = $2), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)`,
+		id, sourceBotId, targetBotId, text, messageType,
+		metadata.ResponseTime.Milliseconds(), nullStringIfBlank(metadata.HelpUsage), nullStringIfBlank(metadata.HelpStyle),
+		nullStringIfBlank(metadata.AiModel), nullStringIfBlank(metadata.PromptVersion),
+		metadata.PromptTokens, metadata.CompletionTokens,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while inserting message: %s %s %s", sourceBotId, targetBotId, text))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while inserting message and changing db: %s %s %s", sourceBotId, targetBotId, text))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when inserting message in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+
+	return bumpGameVersionForBot(ctx, customDb, sourceBotId)
 }
 
-func createMessageUsingCustomDbHandler(customDb customDbHandler, id, sourceBotId, targetBotId, text, messageType string) error {
+func validateMessage(sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
 	if utilities.IsBlank(sourceBotId) {
 		return errors.New("sourceBotId cannot be blank")
 	}
@@ -44,25 +102,41 @@ func createMessageUsingCustomDbHandler(customDb customDbHandler, id, sourceBotId
 		if sourceBotId != targetBotId {
 			return errors.Errorf("answer source and target bot should be same. %s %s", sourceBotId, targetBotId)
 		}
+	case "accusation":
+		if sourceBotId == targetBotId {
+			return errors.Errorf("accusation source and target bot cannot be same. %s %s", sourceBotId, targetBotId)
+		}
+	case "chat":
+		if sourceBotId != targetBotId {
+			return errors.Errorf("chat source and target bot should be same. %s %s", sourceBotId, targetBotId)
+		}
+	case "follow_up":
+		if sourceBotId == targetBotId {
+			return errors.Errorf("follow_up source and target bot cannot be same. %s %s", sourceBotId, targetBotId)
+		}
+	case "pass":
+		if sourceBotId != targetBotId {
+			return errors.Errorf("pass source and target bot should be same. %s %s", sourceBotId, targetBotId)
+		}
+	case "reaction":
+		if sourceBotId == targetBotId {
+			return errors.Errorf("reaction source and target bot cannot be same. %s %s", sourceBotId, targetBotId)
+		}
 	default:
 		return errors.New("invalid messageType")
 	}
 
-	result, err := customDb.Exec(
-		`INSERT INTO public."messages" ("id", "source_bot_id", "target_bot_id", "text", "type") VALUES ($1, $2, $3, $4, $5)`, id, sourceBotId, targetBotId, text, messageType,
-	)
-	if err != nil {
-		return utilities.WrapBadError(err, fmt.Sprintf("dbError while inserting message: %s %s %s", sourceBotId, targetBotId, text))
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return utilities.WrapBadError(err, fmt.Sprintf("dbError while inserting message and changing db: %s %s %s", sourceBotId, targetBotId, text))
+	if !utilities.IsBlank(metadata.HelpUsage) && !model.HelpUsage(metadata.HelpUsage).Valid() {
+		return errors.New("invalid helpUsage")
 	}
 
-	if rowsAffected != 1 {
-		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when inserting message in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	if !utilities.IsBlank(metadata.HelpStyle) && !model.IsValidHelpStyle(metadata.HelpStyle) {
+		return errors.New("invalid helpStyle")
 	}
 
 	return nil
 }
+
+func nullStringIfBlank(str string) sql.NullString {
+	return sql.NullString{String: str, Valid: !utilities.IsBlank(str)}
+}
@@ -1,26 +1,42 @@
 package storage
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 type MessageCreatorMockSuccess struct {
 	PlayerId string
 }
 
-func (m *MessageCreatorMockSuccess) CreateMessage(sourceBotId, targetBotId, text, messageType string) error {
+func (m *MessageCreatorMockSuccess) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
 	return nil
 }
 
-func (m *MessageCreatorMockSuccess) CreateMessageUsingTransaction(sourceBotId, targetBotId, text, messageType string, transation DatabaseTransaction) error {
+func (m *MessageCreatorMockSuccess) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transation DatabaseTransaction) error {
 	return nil
 }
 
 type MessageCreatorMockFailure struct {
 }
 
-func (m *MessageCreatorMockFailure) CreateMessage(sourceBotId, targetBotId, text, messageType string) error {
+func (m *MessageCreatorMockFailure) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
 	return errors.New("unable to create message")
 }
 
-func (m *MessageCreatorMockFailure) CreateMessageUsingTransaction(sourceBotId, targetBotId, text, messageType string, transation DatabaseTransaction) error {
+func (m *MessageCreatorMockFailure) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transation DatabaseTransaction) error {
 	return errors.New("unable to create message")
 }
+
+type MessageCreatorConfigurableMock struct {
+	CreateMessageInternal                 func(sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error
+	CreateMessageUsingTransactionInternal func(sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error
+}
+
+func (m *MessageCreatorConfigurableMock) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	return m.CreateMessageInternal(sourceBotId, targetBotId, text, messageType, metadata)
+}
+
+func (m *MessageCreatorConfigurableMock) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error {
+	return m.CreateMessageUsingTransactionInternal(sourceBotId, targetBotId, text, messageType, metadata, transaction)
+}
@@ -1,13 +1,12 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
-	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
 func Test_CreateMessage(t *testing.T) {
@@ -19,12 +18,8 @@ func Test_CreateMessage(t *testing.T) {
 			text        string
 			messageType string
 		}
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		idGenerator     utilities.CuidGenerator
-		dbUpdateCheck   func(*sql.DB) bool
-		errorExpected   bool
-		errorString     string
+		metadata    MessageMetadata
+		errorString string
 	}{
 		{
 			name: "errors when sourceBotId is blank",
@@ -33,32 +28,8 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"",
-				"",
-				"this is a message",
-				"question",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "sourceBotId cannot be blank",
+			}{"", "", "this is a message", "question"},
+			errorString: "sourceBotId cannot be blank",
 		},
 		{
 			name: "errors when targetBotId is blank",
@@ -67,32 +38,8 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"",
-				"this is a message",
-				"question",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "targetBotId cannot be blank",
+			}{"bot_id1", "", "this is a message", "question"},
+			errorString: "targetBotId cannot be blank",
 		},
 		{
 			name: "errors when text is blank",
@@ -101,32 +48,8 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id2",
-				"",
-				"question",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "text cannot be blank",
+			}{"bot_id1", "bot_id2", "", "question"},
+			errorString: "text cannot be blank",
 		},
 		{
 			name: "errors when type is invalid",
@@ -135,32 +58,8 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id2",
-				"message",
-				"some_message",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "invalid messageType",
+			}{"bot_id1", "bot_id2", "message", "some_message"},
+			errorString: "invalid messageType",
 		},
 		{
 			name: "errors when source bot is same as target bot for question",
@@ -169,32 +68,8 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id1",
-				"some question",
-				"question",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "question source and target bot cannot be same. bot_id1 bot_id1",
+			}{"bot_id1", "bot_id1", "some question", "question"},
+			errorString: "question source and target bot cannot be same. bot_id1 bot_id1",
 		},
 		{
 			name: "errors when source bot is different than target bot for answer",
@@ -203,236 +78,167 @@ func Test_CreateMessage(t *testing.T) {
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id2",
-				"some answer",
-				"answer",
-			},
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			idGenerator:     &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.EqualError(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: true,
-			errorString:   "answer source and target bot should be same. bot_id1 bot_id2",
+			}{"bot_id1", "bot_id2", "some answer", "answer"},
+			errorString: "answer source and target bot should be same. bot_id1 bot_id2",
 		},
 		{
-			name: "creates message successfully",
+			name: "errors when source bot is same as target bot for accusation",
 			input: struct {
 				sourceBotId string
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id2",
-				"this is a message",
-				"question",
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'game_id1'
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			idGenerator: &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt)
-				assert.NoError(t, err)
-				assert.Equal(t, "message_id1", id)
-				assert.Equal(t, "bot_id1", sourceBotId)
-				assert.Equal(t, "bot_id2", targetBotId)
-				assert.Equal(t, "this is a message", text)
-				model.AssertTimeAlmostEqual(t, createdAt, time.Now(), 5*time.Second, "createdAt is not within expected range")
-				return true
-			},
-			errorExpected: false,
-			errorString:   "",
+			}{"bot_id1", "bot_id1", "I think bot1 is human.", "accusation"},
+			errorString: "accusation source and target bot cannot be same. bot_id1 bot_id1",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db:          testDb,
-					IdGenerator: tt.idGenerator,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
-			err := s.CreateMessage(tt.input.sourceBotId, tt.input.targetBotId, tt.input.text, tt.input.messageType)
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
-		})
-	}
-}
-func Test_CreateMessageUsingTransaction(t *testing.T) {
-	tests := []struct {
-		name  string
-		input struct {
-			sourceBotId string
-			targetBotId string
-			text        string
-			messageType string
-		}
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		idGenerator     utilities.CuidGenerator
-		dbUpdateCheck   func(*sql.DB) bool
-		errorExpected   bool
-		errorString     string
-	}{
 		{
-			name: "creates message successfully",
+			name: "errors when source bot is different from target bot for chat",
 			input: struct {
 				sourceBotId string
 				targetBotId string
 				text        string
 				messageType string
-			}{
-				"bot_id1",
-				"bot_id2",
-				"this is a message",
-				"question",
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['b','p1','b','p2'], false
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'AI', 'game_id1'
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			idGenerator: &utilities.IdGeneratorMockConstant{Id: "message_id1"},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					id          string
-					sourceBotId string
-					targetBotId string
-					text        string
-					createdAt   time.Time
-					messageType string
-				)
-				err := db.QueryRow(
-					`SELECT "id", "source_bot_id", "target_bot_id", "text", "created_at", "type"
-						FROM public."messages" WHERE "id" = 'message_id1'`,
-				).Scan(&id, &sourceBotId, &targetBotId, &text, &createdAt, &messageType)
-				assert.NoError(t, err)
-				assert.Equal(t, "message_id1", id)
-				assert.Equal(t, "bot_id1", sourceBotId)
-				assert.Equal(t, "bot_id2", targetBotId)
-				assert.Equal(t, "this is a message", text)
-				assert.Equal(t, "question", messageType)
-				model.AssertTimeAlmostEqual(t, createdAt, time.Now(), 5*time.Second, "createdAt is not within expected range")
-				return true
-			},
-			errorExpected: false,
-			errorString:   "",
+			}{"bot_id1", "bot_id2", "hello everyone", "chat"},
+			errorString: "chat source and target bot should be same. bot_id1 bot_id2",
+		},
+		{
+			name: "errors when source bot is same as target bot for follow_up",
+			input: struct {
+				sourceBotId string
+				targetBotId string
+				text        string
+				messageType string
+			}{"bot_id1", "bot_id1", "why though?", "follow_up"},
+			errorString: "follow_up source and target bot cannot be same. bot_id1 bot_id1",
+		},
+		{
+			name: "errors when source bot is different from target bot for pass",
+			input: struct {
+				sourceBotId string
+				targetBotId string
+				text        string
+				messageType string
+			}{"bot_id1", "bot_id2", "Pass", "pass"},
+			errorString: "pass source and target bot should be same. bot_id1 bot_id2",
+		},
+		{
+			name: "errors when source bot is same as target bot for reaction",
+			input: struct {
+				sourceBotId string
+				targetBotId string
+				text        string
+				messageType string
+			}{"bot_id1", "bot_id1", "👍", "reaction"},
+			errorString: "reaction source and target bot cannot be same. bot_id1 bot_id1",
+		},
+		{
+			name: "errors when help usage is invalid",
+			input: struct {
+				sourceBotId string
+				targetBotId string
+				text        string
+				messageType string
+			}{"bot_id1", "bot_id2", "some question", "question"},
+			metadata:    MessageMetadata{HelpUsage: "SOMETIMES"},
+			errorString: "invalid helpUsage",
+		},
+		{
+			name: "errors when help style is invalid",
+			input: struct {
+				sourceBotId string
+				targetBotId string
+				text        string
+				messageType string
+			}{"bot_id1", "bot_id2", "some question", "question"},
+			metadata:    MessageMetadata{HelpUsage: "EDITED", HelpStyle: "rude"},
+			errorString: "invalid helpStyle",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db:          testDb,
-					IdGenerator: tt.idGenerator,
-				},
-			)
+			forEachStorage(t, func(t *testing.T, s suiteStorage) {
+				err := s.CreateMessage(context.Background(), tt.input.sourceBotId, tt.input.targetBotId, tt.input.text, tt.input.messageType, tt.metadata)
+				assert.EqualError(t, err, tt.errorString)
+			})
+		})
+	}
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+	t.Run("errors when the bots do not exist", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := s.CreateMessage(context.Background(), "bot_id1", "bot_id2", "some question", "question", MessageMetadata{})
+			assert.ErrorContains(t, err, "dbError while inserting message: bot_id1 bot_id2 some question")
+		})
+	})
 
-			tx, err := s.BeginTransaction()
+	t.Run("creates message successfully", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
 			assert.NoError(t, err)
-			err = s.CreateMessageUsingTransaction(tt.input.sourceBotId, tt.input.targetBotId, tt.input.text, tt.input.messageType, tx)
-			tx.Commit()
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
+			sourceBotId := game.Bots()[0].Id()
+			targetBotId := game.Bots()[1].Id()
+
+			err = s.CreateMessage(context.Background(), sourceBotId, targetBotId, "this is a message", "question", MessageMetadata{
+				ResponseTime: 12345 * time.Millisecond,
+				HelpUsage:    "EDITED",
+				HelpStyle:    "terse",
+			})
+			assert.NoError(t, err)
+
+			game, err = s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), game.Version())
+			messages := game.GetDetailedMessages()
+			assert.Len(t, messages, 1)
+			assert.Equal(t, sourceBotId, messages[0].SourceBotId)
+			assert.Equal(t, targetBotId, messages[0].TargetBotId)
+			assert.Equal(t, "this is a message", messages[0].Text)
+			assert.Equal(t, "question", messages[0].MessageType)
+			assert.Equal(t, 12345*time.Millisecond, messages[0].ResponseTime)
+			assert.Equal(t, "EDITED", messages[0].HelpUsage)
+			assert.Equal(t, "terse", messages[0].HelpStyle)
+			assert.Empty(t, messages[0].AiModel)
+			model.AssertTimeAlmostEqual(t, messages[0].CreatedAt, time.Now(), 5*time.Second, "createdAt is not within expected range")
 		})
-	}
+	})
+}
+
+func Test_CreateMessageUsingTransaction(t *testing.T) {
+	t.Run("creates message successfully", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			sourceBotId := game.Bots()[0].Id()
+			targetBotId := game.Bots()[1].Id()
+
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.CreateMessageUsingTransaction(context.Background(), sourceBotId, targetBotId, "this is a message", "question", MessageMetadata{
+					ResponseTime:     9 * time.Second,
+					AiModel:          "text-davinci-003",
+					PromptVersion:    "v1",
+					PromptTokens:     150,
+					CompletionTokens: 10,
+				}, tx)
+			})
+			assert.NoError(t, err)
+
+			game, err = s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			messages := game.GetDetailedMessages()
+			assert.Len(t, messages, 1)
+			assert.Equal(t, sourceBotId, messages[0].SourceBotId)
+			assert.Equal(t, targetBotId, messages[0].TargetBotId)
+			assert.Equal(t, "this is a message", messages[0].Text)
+			assert.Equal(t, "question", messages[0].MessageType)
+			assert.Equal(t, 9*time.Second, messages[0].ResponseTime)
+			assert.Empty(t, messages[0].HelpUsage)
+			assert.Equal(t, "text-davinci-003", messages[0].AiModel)
+			assert.Equal(t, "v1", messages[0].PromptVersion)
+			assert.Equal(t, int64(150), messages[0].PromptTokens)
+			assert.Equal(t, int64(10), messages[0].CompletionTokens)
+			model.AssertTimeAlmostEqual(t, messages[0].CreatedAt, time.Now(), 5*time.Second, "createdAt is not within expected range")
+		})
+	})
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -10,16 +11,18 @@ import (
 )
 
 type PlayerAccessor interface {
-	CreatePlayer() (*model.Player, error)
-	GetPlayer(playerId string) (*model.Player, error)
-	GetPlayerUsingTransaction(playerId string, transaction DatabaseTransaction) (*model.Player, error)
-	UpdatePlayerWithUserIdUsingTransaction(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error)
-	GetPlayerForUserOrNil(userId string) (*model.Player, error)
-	CreatePlayerForUser(userId string) (*model.Player, error)
-	DeletePlayer(playerId string) error
+	CreatePlayer(ctx context.Context) (*model.Player, error)
+	GetPlayer(ctx context.Context, playerId string) (*model.Player, error)
+	GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error)
+	UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error)
+	GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error)
+	CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error)
 }
 
-func (s *Storage) CreatePlayer() (*model.Player, error) {
+func (s *Storage) CreatePlayer(ctx context.Context) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	id := s.IdGenerator.Generate()
 
 	playerOpts := model.PlayerOptions{
@@ -31,7 +34,8 @@ func (s *Storage) CreatePlayer() (*model.Player, error) {
 		return nil, utilities.WrapBadError(err, "failed to create player")
 	}
 
-	result, err := s.db.Exec(
+	result, err := s.db.ExecContext(
+		ctx,
 		`INSERT INTO public."players" ("id") VALUES ($1)`,
 		playerOpts.Id,
 	)
@@ -51,23 +55,29 @@ func (s *Storage) CreatePlayer() (*model.Player, error) {
 	return player, nil
 }
 
-func (s *Storage) GetPlayer(playerId string) (*model.Player, error) {
-	return getPlayerUsingCustomDbHandler(s.db, playerId, false)
+func (s *Storage) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getPlayerUsingCustomDbHandler(ctx, s.db, playerId, false)
 }
 
-func (s *Storage) GetPlayerUsingTransaction(playerId string, transaction DatabaseTransaction) (*model.Player, error) {
-	return getPlayerUsingCustomDbHandler(transaction, playerId, true)
+func (s *Storage) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getPlayerUsingCustomDbHandler(ctx, transaction, playerId, true)
 }
 
-func getPlayerUsingCustomDbHandler(customDb customDbHandler, playerId string, exclusiveLock bool) (*model.Player, error) {
+func getPlayerUsingCustomDbHandler(ctx context.Context, customDb customDbHandler, playerId string, exclusiveLock bool) (*model.Player, error) {
 	if utilities.IsBlank(playerId) {
 		return nil, errors.New("playerId cannot be blank")
 	}
 
 	var nullableUserId sql.NullString
 
-	queryWithoutLock := `SELECT user_id FROM public."players" WHERE id = $1`
-	queryWithLock := `SELECT user_id FROM public."players" WHERE id = $1 FOR UPDATE`
+	queryWithoutLock := `SELECT user_id FROM public."players" WHERE id = $1 AND deleted_at IS NULL`
+	queryWithLock := `SELECT user_id FROM public."players" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
 
 	var query string
 
@@ -77,7 +87,7 @@ func getPlayerUsingCustomDbHandler(customDb customDbHandler, playerId string, ex
 		query = queryWithoutLock
 	}
 
-	row := customDb.QueryRow(query, playerId)
+	row := customDb.QueryRowContext(ctx, query, playerId)
 	err := row.Scan(&nullableUserId)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -97,7 +107,10 @@ func getPlayerUsingCustomDbHandler(customDb customDbHandler, playerId string, ex
 	})
 }
 
-func (s *Storage) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (s *Storage) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(playerId) {
 		return nil, errors.New("playerId cannot be blank")
 	}
@@ -106,8 +119,9 @@ func (s *Storage) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string
 		return nil, errors.New("userId cannot be blank")
 	}
 
-	result, err := transaction.Exec(
-		`UPDATE public."players" SET "user_id" = $1 WHERE id = $2`,
+	result, err := transaction.ExecContext(
+		ctx,
+		`UPDATE public."players" SET "user_id" = $1 WHERE id = $2 AND deleted_at IS NULL`,
 		userId,
 		playerId,
 	)
@@ -127,14 +141,17 @@ func (s *Storage) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string
 	return model.NewPlayer(model.PlayerOptions{Id: playerId, UserId: &userId})
 }
 
-func (s *Storage) GetPlayerForUserOrNil(userId string) (*model.Player, error) {
+func (s *Storage) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(userId) {
 		return nil, errors.New("userId cannot be blank")
 	}
 
 	var playerId string
 
-	row := s.db.QueryRow(`SELECT id FROM public."players" WHERE user_id = $1 ORDER BY created_at ASC LIMIT 1`, userId)
+	row := s.db.QueryRowContext(ctx, `SELECT id FROM public."players" WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC LIMIT 1`, userId)
 	err := row.Scan(&playerId)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -149,7 +166,10 @@ func (s *Storage) GetPlayerForUserOrNil(userId string) (*model.Player, error) {
 	})
 }
 
-func (s *Storage) CreatePlayerForUser(userId string) (*model.Player, error) {
+func (s *Storage) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(userId) {
 		return nil, errors.New("userId cannot be blank")
 	}
@@ -166,7 +186,8 @@ func (s *Storage) CreatePlayerForUser(userId string) (*model.Player, error) {
 		return nil, utilities.WrapBadError(err, "failed to create player")
 	}
 
-	result, err := s.db.Exec(
+	result, err := s.db.ExecContext(
+		ctx,
 		`INSERT INTO public."players" ("id", "user_id") VALUES ($1, $2)`,
 		playerOpts.Id,
 		playerOpts.UserId,
@@ -186,27 +207,3 @@ func (s *Storage) CreatePlayerForUser(userId string) (*model.Player, error) {
 
 	return player, nil
 }
-
-func (s *Storage) DeletePlayer(playerId string) error {
-	if utilities.IsBlank(playerId) {
-		return errors.New("playerId cannot be blank")
-	}
-
-	result, err := s.db.Exec(
-		`DELETE FROM public."players" WHERE id = $1`, playerId,
-	)
-	if err != nil {
-		return utilities.WrapBadError(err, "dbError while attempting player deletion")
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return utilities.WrapBadError(err, "dbError while deleting player and changing db")
-	}
-
-	if rowsAffected != 1 {
-		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when deleting player in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
-	}
-
-	return nil
-}
@@ -0,0 +1,632 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type PlayerDataAccessor interface {
+	ErasePlayer(ctx context.Context, playerId string) (*model.PlayerErasure, error)
+	EraseUserPlayers(ctx context.Context, userId string) ([]*model.PlayerErasure, error)
+	ExportPlayerData(ctx context.Context, playerId string) (*model.DataExport, error)
+	ExportUserData(ctx context.Context, userId string) (*model.DataExport, error)
+}
+
+// ErrPlayerInUnfinishedGame is returned when erasing a player that is in a game that has not finished, since the other
+// players of the game would be left waiting on a player that is gone. Every game is deleted a couple of hours after it
+// is created, so the player can be erased once its games have finished or been deleted.
+var ErrPlayerInUnfinishedGame = errors.New("player is in a game that has not finished")
+
+// ErasePlayer erases the data of a player, without taking anything away from the games it played in. The text of
+// every message it sent is replaced with model.ERASED_MESSAGE_TEXT, and its help suggestions, practice results and the
+// tells picked out for its bots are dropped. The summaries of its games are dropped too, since they may quote it, and
+// are written again from the erased messages. Its bots stay in their games, connected to what is left of the player,
+// which is a row with no user that can no longer be looked up. A PlayerErasure records what was erased.
+// Nothing is erased while the player is in a game that has not finished, which fails with ErrPlayerInUnfinishedGame.
+func (s *Storage) ErasePlayer(ctx context.Context, playerId string) (*model.PlayerErasure, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	erasures, err := s.erasePlayers(
+		ctx,
+		`SELECT id, user_id FROM public."players" WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE`,
+		playerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(erasures) == 0 {
+		return nil, errors.Errorf("erasing player %s: no such player", playerId)
+	}
+	return erasures[0], nil
+}
+
+// EraseUserPlayers erases every player of the user, like ErasePlayer does. The user itself is managed outside this
+// service.
+func (s *Storage) EraseUserPlayers(ctx context.Context, userId string) ([]*model.PlayerErasure, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	return s.erasePlayers(
+		ctx,
+		`SELECT id, user_id FROM public."players" WHERE user_id = $1 AND deleted_at IS NULL ORDER BY id ASC FOR NO KEY UPDATE`,
+		userId,
+	)
+}
+
+// erasePlayers erases the players that selectPlayersSql locks, in a single transaction. The players are locked with
+// FOR NO KEY UPDATE, which does not wait on transactions that are only adding a bot for one of them.
+func (s *Storage) erasePlayers(ctx context.Context, selectPlayersSql string, arg string) ([]*model.PlayerErasure, error) {
+	tx, err := s.BeginTransaction(ctx)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, selectPlayersSql, arg)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while getting players to erase")
+	}
+	defer rows.Close()
+
+	players := []*model.Player{}
+	for rows.Next() {
+		var playerId string
+		var userId sql.NullString
+		err := rows.Scan(&playerId, &userId)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		playerOpts := model.PlayerOptions{Id: playerId}
+		if userId.Valid {
+			playerOpts.UserId = &userId.String
+		}
+		player, err := model.NewPlayer(playerOpts)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed to create player")
+		}
+		players = append(players, player)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through player rows")
+	}
+
+	erasures := []*model.PlayerErasure{}
+	for _, player := range players {
+		erasure, err := erasePlayer(ctx, tx, s.IdGenerator.Generate(), player)
+		if err != nil {
+			return nil, err
+		}
+		erasures = append(erasures, erasure)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to commit db transaction")
+	}
+	return erasures, nil
+}
+
+// erasePlayer expects the player to be locked already.
+func erasePlayer(ctx context.Context, customDb customDbHandler, erasureId string, player *model.Player) (*model.PlayerErasure, error) {
+	playerId := player.Id()
+
+	// The games are locked in order, so that erasing cannot deadlock with another transaction that locks more than one.
+	gameIds, err := getIds(
+		ctx, customDb,
+		`SELECT g.id FROM public."games" AS g
+		WHERE g.id IN (SELECT b.game_id FROM public."bots" AS b WHERE b.player_id = $1)
+		ORDER BY g.id ASC
+		FOR UPDATE`,
+		playerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unfinishedGameIds, err := getIds(
+		ctx, customDb,
+		`SELECT id FROM public."games" WHERE id = ANY($1) AND state <> 'FINISHED' ORDER BY id ASC`,
+		pq.Array(gameIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(unfinishedGameIds) > 0 {
+		return nil, errors.Wrapf(ErrPlayerInUnfinishedGame, "erasing player %s in game %s", playerId, unfinishedGameIds[0])
+	}
+
+	// The last question is a copy of a message, so it is erased along with the message it came from.
+	_, err = customDb.ExecContext(
+		ctx,
+		`UPDATE public."games" AS g
+		SET
+			last_question = CASE WHEN EXISTS (
+				SELECT 1 FROM public."messages" AS m
+				JOIN public."bots" AS b ON m.source_bot_id = b.id
+				WHERE m.game_id = g.id AND b.player_id = $2 AND m.text = g.last_question
+			) THEN $3 ELSE g.last_question END,
+			conversation_summary = NULL,
+			summarized_message_count = 0,
+			updated_at = now(),
+			version = g.version + 1
+		WHERE g.id = ANY($1)`,
+		pq.Array(gameIds), playerId, model.ERASED_MESSAGE_TEXT,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing games of player %s", playerId))
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE public."messages" SET text = $2
+		WHERE source_bot_id IN (SELECT id FROM public."bots" WHERE player_id = $1)`,
+		playerId, model.ERASED_MESSAGE_TEXT,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing messages of player %s", playerId))
+	}
+	messageCount, err := result.RowsAffected()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while erasing messages and changing db")
+	}
+
+	_, err = customDb.ExecContext(
+		ctx,
+		`UPDATE public."bot_analyses" SET tells = '{}'
+		WHERE bot_id IN (SELECT id FROM public."bots" WHERE player_id = $1)`,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing bot analyses of player %s", playerId))
+	}
+
+	result, err = customDb.ExecContext(
+		ctx,
+		`UPDATE public."bots" SET last_help_suggestions = NULL WHERE player_id = $1`,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing bots of player %s", playerId))
+	}
+	botCount, err := result.RowsAffected()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while erasing bots and changing db")
+	}
+
+	_, err = customDb.ExecContext(
+		ctx,
+		`DELETE FROM public."practice_results" WHERE player_id = $1`,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing practice results of player %s", playerId))
+	}
+
+	result, err = customDb.ExecContext(
+		ctx,
+		`UPDATE public."players" SET user_id = NULL, deleted_at = now() WHERE id = $1`,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing player %s", playerId))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while erasing player and changing db")
+	}
+	if rowsAffected != 1 {
+		return nil, utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when erasing player in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+
+	erasure := model.PlayerErasure{
+		Id:           erasureId,
+		PlayerId:     playerId,
+		UserId:       player.UserId(),
+		BotCount:     botCount,
+		MessageCount: messageCount,
+	}
+	err = customDb.QueryRowContext(
+		ctx,
+		`INSERT INTO public."player_erasures" ("id", "player_id", "user_id", "bot_count", "message_count")
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`,
+		erasure.Id, erasure.PlayerId, erasure.UserId, erasure.BotCount, erasure.MessageCount,
+	).Scan(&erasure.CreatedAt)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while recording erasure of player %s", playerId))
+	}
+
+	return &erasure, nil
+}
+
+func (s *Storage) ExportPlayerData(ctx context.Context, playerId string) (*model.DataExport, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	players, err := getPlayersData(ctx, s.db, []string{playerId})
+	if err != nil {
+		return nil, err
+	}
+	if len(players) == 0 {
+		return nil, errors.Errorf("exporting player %s: no such player", playerId)
+	}
+
+	return &model.DataExport{
+		Players:  players,
+		Erasures: []model.PlayerErasure{},
+	}, nil
+}
+
+// ExportUserData exports the user along with every player of the user, and the erasures of the players it used to
+// have.
+func (s *Storage) ExportUserData(ctx context.Context, userId string) (*model.DataExport, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	user := model.ExportedUser{Id: userId}
+	var name, email, image sql.NullString
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT name, email, image FROM public."users" WHERE id = $1`,
+		userId,
+	).Scan(&name, &email, &image)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Errorf("exporting user %s: no such user", userId)
+		}
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while exporting user %s", userId))
+	}
+	user.Name = nullStringToPointer(name)
+	user.Email = nullStringToPointer(email)
+	user.Image = nullStringToPointer(image)
+
+	playerIds, err := getIds(
+		ctx, s.db,
+		`SELECT id FROM public."players" WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC, id ASC`,
+		userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := getPlayersData(ctx, s.db, playerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	erasures, err := getPlayerErasuresForUser(ctx, s.db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DataExport{
+		User:     &user,
+		Players:  players,
+		Erasures: erasures,
+	}, nil
+}
+
+func nullStringToPointer(str sql.NullString) *string {
+	if !str.Valid {
+		return nil
+	}
+	return &str.String
+}
+
+func getIds(ctx context.Context, customDb customDbHandler, query string, args ...any) ([]string, error) {
+	rows, err := customDb.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while getting ids")
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		ids = append(ids, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through id rows")
+	}
+	return ids, nil
+}
+
+// getPlayersData skips players that do not exist or have been erased. Each of the players' bots, messages, votes and
+// practice results is loaded for all of them with a single query.
+func getPlayersData(ctx context.Context, customDb customDbHandler, playerIds []string) ([]model.PlayerData, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT id, user_id, created_at FROM public."players"
+		WHERE id = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC`,
+		pq.Array(playerIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while exporting players")
+	}
+	defer rows.Close()
+
+	players := []*model.PlayerData{}
+	playersById := map[string]*model.PlayerData{}
+	for rows.Next() {
+		player := model.PlayerData{
+			Bots:            []model.ExportedBot{},
+			Messages:        []model.ExportedMessage{},
+			Votes:           []model.ExportedVote{},
+			PracticeResults: []model.ExportedPracticeResult{},
+		}
+		var userId sql.NullString
+		err := rows.Scan(&player.PlayerId, &userId, &player.CreatedAt)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning rows")
+		}
+		player.UserId = nullStringToPointer(userId)
+		players = append(players, &player)
+		playersById[player.PlayerId] = &player
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through player rows")
+	}
+
+	if len(players) == 0 {
+		return []model.PlayerData{}, nil
+	}
+	exportedPlayerIds := []string{}
+	for _, player := range players {
+		exportedPlayerIds = append(exportedPlayerIds, player.PlayerId)
+	}
+
+	err = addExportedBots(ctx, customDb, exportedPlayerIds, playersById)
+	if err != nil {
+		return nil, err
+	}
+	err = addExportedMessages(ctx, customDb, exportedPlayerIds, playersById)
+	if err != nil {
+		return nil, err
+	}
+	err = addExportedVotes(ctx, customDb, exportedPlayerIds, playersById)
+	if err != nil {
+		return nil, err
+	}
+	err = addExportedPracticeResults(ctx, customDb, exportedPlayerIds, playersById)
+	if err != nil {
+		return nil, err
+	}
+
+	playersData := []model.PlayerData{}
+	for _, player := range players {
+		playersData = append(playersData, *player)
+	}
+	return playersData, nil
+}
+
+func addExportedBots(ctx context.Context, customDb customDbHandler, playerIds []string, playersById map[string]*model.PlayerData) error {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT b.player_id, b.id, b.game_id, b.name, b.help_count, b.last_help_suggestions, b.tag_count, b.eliminated,
+		ba.humanness_score, ba.tells
+		FROM public."bots" AS b
+		LEFT JOIN public."bot_analyses" AS ba ON ba.bot_id = b.id
+		WHERE b.player_id = ANY($1)
+		ORDER BY b.created_at ASC, b.id ASC`,
+		pq.Array(playerIds),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, "dbError while exporting bots")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerId string
+		var bot model.ExportedBot
+		var humannessScore sql.NullInt64
+		var tells []string
+		err := rows.Scan(
+			&playerId,
+			&bot.Id,
+			&bot.GameId,
+			&bot.Name,
+			&bot.HelpCount,
+			pq.Array(&bot.LastHelpSuggestions),
+			&bot.TagCount,
+			&bot.Eliminated,
+			&humannessScore,
+			pq.Array(&tells),
+		)
+		if err != nil {
+			return utilities.WrapBadError(err, "failed while scanning bot rows")
+		}
+		if humannessScore.Valid {
+			if tells == nil {
+				tells = []string{}
+			}
+			bot.Analysis = &model.BotAnalysis{
+				BotId:          bot.Id,
+				HumannessScore: humannessScore.Int64,
+				Tells:          tells,
+			}
+		}
+		playersById[playerId].Bots = append(playersById[playerId].Bots, bot)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to correctly go through bot rows")
+	}
+	return nil
+}
+
+func addExportedMessages(ctx context.Context, customDb customDbHandler, playerIds []string, playersById map[string]*model.PlayerData) error {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT b.player_id, m.id, m.game_id, m.source_bot_id, m.target_bot_id, m.type, m.text, m.created_at
+		FROM public."messages" AS m
+		JOIN public."bots" AS b ON m.source_bot_id = b.id
+		WHERE b.player_id = ANY($1)
+		ORDER BY m.created_at ASC, m.id ASC`,
+		pq.Array(playerIds),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, "dbError while exporting messages")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerId string
+		var message model.ExportedMessage
+		err := rows.Scan(
+			&playerId,
+			&message.Id,
+			&message.GameId,
+			&message.SourceBotId,
+			&message.TargetBotId,
+			&message.MessageType,
+			&message.Text,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return utilities.WrapBadError(err, "failed while scanning message rows")
+		}
+		playersById[playerId].Messages = append(playersById[playerId].Messages, message)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to correctly go through message rows")
+	}
+	return nil
+}
+
+func addExportedVotes(ctx context.Context, customDb customDbHandler, playerIds []string, playersById map[string]*model.PlayerData) error {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT b.player_id, v.game_id, v.voter_bot_id, v.suspect_bot_id
+		FROM public."votes" AS v
+		JOIN public."bots" AS b ON v.voter_bot_id = b.id
+		WHERE b.player_id = ANY($1)
+		ORDER BY v.created_at ASC, v.id ASC`,
+		pq.Array(playerIds),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, "dbError while exporting votes")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerId string
+		var vote model.ExportedVote
+		err := rows.Scan(&playerId, &vote.GameId, &vote.VoterBotId, &vote.SuspectBotId)
+		if err != nil {
+			return utilities.WrapBadError(err, "failed while scanning vote rows")
+		}
+		playersById[playerId].Votes = append(playersById[playerId].Votes, vote)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to correctly go through vote rows")
+	}
+	return nil
+}
+
+func addExportedPracticeResults(ctx context.Context, customDb customDbHandler, playerIds []string, playersById map[string]*model.PlayerData) error {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT player_id, game_id, won FROM public."practice_results"
+		WHERE player_id = ANY($1)
+		ORDER BY created_at ASC, id ASC`,
+		pq.Array(playerIds),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, "dbError while exporting practice results")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerId string
+		var practiceResult model.ExportedPracticeResult
+		err := rows.Scan(&playerId, &practiceResult.GameId, &practiceResult.Won)
+		if err != nil {
+			return utilities.WrapBadError(err, "failed while scanning practice result rows")
+		}
+		playersById[playerId].PracticeResults = append(playersById[playerId].PracticeResults, practiceResult)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to correctly go through practice result rows")
+	}
+	return nil
+}
+
+func getPlayerErasuresForUser(ctx context.Context, customDb customDbHandler, userId string) ([]model.PlayerErasure, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		`SELECT id, player_id, user_id, bot_count, message_count, created_at FROM public."player_erasures"
+		WHERE user_id = $1
+		ORDER BY created_at ASC, id ASC`,
+		userId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while exporting player erasures")
+	}
+	defer rows.Close()
+
+	erasures := []model.PlayerErasure{}
+	for rows.Next() {
+		var erasure model.PlayerErasure
+		var erasureUserId sql.NullString
+		err := rows.Scan(
+			&erasure.Id,
+			&erasure.PlayerId,
+			&erasureUserId,
+			&erasure.BotCount,
+			&erasure.MessageCount,
+			&erasure.CreatedAt,
+		)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning player erasure rows")
+		}
+		erasure.UserId = nullStringToPointer(erasureUserId)
+		erasures = append(erasures, erasure)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through player erasure rows")
+	}
+	return erasures, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+type PlayerDataAccessorMockConfigurable struct {
+	ErasePlayerInternal      func(playerId string) (*model.PlayerErasure, error)
+	EraseUserPlayersInternal func(userId string) ([]*model.PlayerErasure, error)
+	ExportPlayerDataInternal func(playerId string) (*model.DataExport, error)
+	ExportUserDataInternal   func(userId string) (*model.DataExport, error)
+}
+
+func (p *PlayerDataAccessorMockConfigurable) ErasePlayer(ctx context.Context, playerId string) (*model.PlayerErasure, error) {
+	return p.ErasePlayerInternal(playerId)
+}
+
+func (p *PlayerDataAccessorMockConfigurable) EraseUserPlayers(ctx context.Context, userId string) ([]*model.PlayerErasure, error) {
+	return p.EraseUserPlayersInternal(userId)
+}
+
+func (p *PlayerDataAccessorMockConfigurable) ExportPlayerData(ctx context.Context, playerId string) (*model.DataExport, error) {
+	return p.ExportPlayerDataInternal(playerId)
+}
+
+func (p *PlayerDataAccessorMockConfigurable) ExportUserData(ctx context.Context, userId string) (*model.DataExport, error) {
+	return p.ExportUserDataInternal(userId)
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func Test_PlayerData(t *testing.T) {
+	requireTestDb(t)
+	insertPlayerData := []TestSqlStmts{
+		{Query: `INSERT INTO public."users" ("id", "name", "email") VALUES ('user_id1', 'User One', 'user1@example.com')`},
+		{Query: `INSERT INTO public."players" ("id", "user_id") VALUES ('player_id1', 'user_id1'), ('player_id2', NULL)`},
+		{
+			Query: `INSERT INTO public."games" (
+				"id", "state", "current_turn_index", "turn_order", "state_handled", "last_question",
+				"conversation_summary", "summarized_message_count"
+			)
+			VALUES (
+				'game_id1', 'FINISHED', 0, Array['bot_id1', 'bot_id2', 'bot_id3'], false, 'do you like fish?',
+				'bot1 lives by the sea', 2
+			)`,
+		},
+		{
+			Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id", "player_id", "last_help_suggestions")
+			VALUES
+			('bot_id1', 'bot1', 'HUMAN', 'game_id1', 'player_id1', Array['ask about the sea']),
+			('bot_id2', 'bot2', 'HUMAN', 'game_id1', 'player_id2', NULL),
+			('bot_id3', 'bot3', 'AI', 'game_id1', NULL, NULL)`,
+		},
+		{
+			Query: `INSERT INTO public."messages" ("id", "game_id", "source_bot_id", "target_bot_id", "text", "type", "created_at")
+			VALUES
+			('message_id1', 'game_id1', 'bot_id3', 'bot_id1', 'where do you live?', 'question', now() - interval '3 minutes'),
+			('message_id2', 'game_id1', 'bot_id1', 'bot_id1', 'by the sea', 'answer', now() - interval '2 minutes'),
+			('message_id3', 'game_id1', 'bot_id1', 'bot_id2', 'do you like fish?', 'question', now() - interval '1 minute')`,
+		},
+		{Query: `INSERT INTO public."votes" ("id", "game_id", "voter_bot_id", "suspect_bot_id") VALUES ('vote_id1', 'game_id1', 'bot_id1', 'bot_id2')`},
+		{Query: `INSERT INTO public."bot_analyses" ("id", "bot_id", "humanness_score", "tells") VALUES ('bot_analysis_id1', 'bot_id1', 80, Array['by the sea'])`},
+		{Query: `INSERT INTO public."practice_results" ("id", "game_id", "player_id", "won") VALUES ('practice_result_id1', 'game_id1', 'player_id1', true)`},
+	}
+	deletePlayerData := []TestSqlStmts{
+		{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+		{Query: `DELETE FROM public."player_erasures" WHERE player_id IN ('player_id1', 'player_id2')`},
+		{Query: `DELETE FROM public."players" WHERE id IN ('player_id1', 'player_id2')`},
+		{Query: `DELETE FROM public."users" WHERE id = 'user_id1'`},
+	}
+	newStorage := func() *Storage {
+		s, _ := NewDbStorage(StorageOptions{
+			Db:          testDb,
+			IdGenerator: &utilities.IdGeneratorMockConstant{Id: "erasure_id1"},
+		})
+		return s
+	}
+
+	t.Run("exports everything stored about a user", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		s := newStorage()
+
+		export, err := s.ExportUserData(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, "user_id1", export.User.Id)
+		assert.Equal(t, "User One", *export.User.Name)
+		assert.Equal(t, "user1@example.com", *export.User.Email)
+		assert.Nil(t, export.User.Image)
+		assert.Len(t, export.Players, 1)
+
+		player := export.Players[0]
+		assert.Equal(t, "player_id1", player.PlayerId)
+		assert.Equal(t, []model.ExportedBot{
+			{
+				Id:                  "bot_id1",
+				GameId:              "game_id1",
+				Name:                "bot1",
+				LastHelpSuggestions: []string{"ask about the sea"},
+				Analysis:            &model.BotAnalysis{BotId: "bot_id1", HumannessScore: 80, Tells: []string{"by the sea"}},
+			},
+		}, player.Bots)
+		assert.Len(t, player.Messages, 2, "only the messages the player sent are exported")
+		assert.Equal(t, "by the sea", player.Messages[0].Text)
+		assert.Equal(t, "do you like fish?", player.Messages[1].Text)
+		assert.Equal(t, []model.ExportedVote{{GameId: "game_id1", VoterBotId: "bot_id1", SuspectBotId: "bot_id2"}}, player.Votes)
+		assert.Equal(t, []model.ExportedPracticeResult{{GameId: "game_id1", Won: true}}, player.PracticeResults)
+		assert.Empty(t, export.Erasures)
+	})
+
+	t.Run("exports a player without a user", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		s := newStorage()
+
+		export, err := s.ExportPlayerData(context.Background(), "player_id2")
+		assert.NoError(t, err)
+		assert.Nil(t, export.User)
+		assert.Len(t, export.Players, 1)
+		assert.Len(t, export.Players[0].Bots, 1)
+		assert.Nil(t, export.Players[0].Bots[0].Analysis)
+		assert.Empty(t, export.Players[0].Messages)
+	})
+
+	t.Run("erases a player without taking anything away from its games", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		s := newStorage()
+
+		erasure, err := s.ErasePlayer(context.Background(), "player_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, "erasure_id1", erasure.Id)
+		assert.Equal(t, "player_id1", erasure.PlayerId)
+		assert.Equal(t, "user_id1", *erasure.UserId)
+		assert.Equal(t, int64(1), erasure.BotCount)
+		assert.Equal(t, int64(2), erasure.MessageCount)
+
+		game, err := s.GetGame(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Len(t, game.Bots(), 3)
+		assert.True(t, game.BotWithId("bot_id1").IsHuman())
+		assert.Equal(t, int64(1), game.Version())
+		assert.Equal(t, model.ConversationSummary{}, game.ConversationSummary())
+		messageTexts := []string{}
+		for _, message := range game.GetDetailedMessages() {
+			messageTexts = append(messageTexts, message.Text)
+		}
+		assert.Equal(t, []string{"where do you live?", model.ERASED_MESSAGE_TEXT, model.ERASED_MESSAGE_TEXT}, messageTexts)
+
+		var lastQuestion string
+		var practiceResultCount int
+		err = testDb.QueryRow(`SELECT last_question FROM public."games" WHERE id = 'game_id1'`).Scan(&lastQuestion)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ERASED_MESSAGE_TEXT, lastQuestion)
+		err = testDb.QueryRow(`SELECT count(*) FROM public."practice_results" WHERE player_id = 'player_id1'`).Scan(&practiceResultCount)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, practiceResultCount)
+
+		analysis, err := s.GetGameAnalysis(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, []model.BotAnalysis{{BotId: "bot_id1", HumannessScore: 80, Tells: []string{}}}, analysis.BotAnalyses)
+
+		_, err = s.GetPlayer(context.Background(), "player_id1")
+		assert.EqualError(t, err, "getting player for player_id1: no such player")
+		player, err := s.GetPlayerForUserOrNil(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Nil(t, player)
+
+		export, err := s.ExportUserData(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Empty(t, export.Players)
+		assert.Len(t, export.Erasures, 1)
+		assert.Equal(t, "erasure_id1", export.Erasures[0].Id)
+	})
+
+	t.Run("erases every player of a user", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		s := newStorage()
+
+		erasures, err := s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Len(t, erasures, 1)
+		assert.Equal(t, "player_id1", erasures[0].PlayerId)
+
+		erasures, err = s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Empty(t, erasures)
+	})
+
+	t.Run("does not erase a player that is in a game that has not finished", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		runSqlOnDb(t, testDb, []TestSqlStmts{{Query: `UPDATE public."games" SET state = 'WAITING_FOR_AI_ANSWER' WHERE id = 'game_id1'`}})
+		s := newStorage()
+
+		_, err := s.ErasePlayer(context.Background(), "player_id1")
+		assert.ErrorIs(t, err, ErrPlayerInUnfinishedGame)
+		assert.EqualError(t, err, "erasing player player_id1 in game game_id1: player is in a game that has not finished")
+		_, err = s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.ErrorIs(t, err, ErrPlayerInUnfinishedGame)
+
+		game, err := s.GetGame(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), game.Version())
+		assert.Equal(t, "by the sea", game.GetDetailedMessages()[1].Text)
+		_, err = s.GetPlayer(context.Background(), "player_id1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("errors for players that do not exist or have been erased", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+		s := newStorage()
+
+		_, err := s.ErasePlayer(context.Background(), "")
+		assert.EqualError(t, err, "playerId cannot be blank")
+		_, err = s.ErasePlayer(context.Background(), "player_id3")
+		assert.EqualError(t, err, "erasing player player_id3: no such player")
+		_, err = s.ExportUserData(context.Background(), "user_id2")
+		assert.EqualError(t, err, "exporting user user_id2: no such user")
+
+		_, err = s.ErasePlayer(context.Background(), "player_id2")
+		assert.NoError(t, err)
+		_, err = s.ErasePlayer(context.Background(), "player_id2")
+		assert.EqualError(t, err, "erasing player player_id2: no such player")
+		_, err = s.ExportPlayerData(context.Background(), "player_id2")
+		assert.EqualError(t, err, "exporting player player_id2: no such player")
+	})
+
+	t.Run("does not let a player be deleted along with its bots", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertPlayerData)
+		defer runSqlOnDb(t, testDb, deletePlayerData)
+
+		_, err := testDb.Exec(`DELETE FROM public."players" WHERE id = 'player_id2'`)
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 
 	"github.com/vipulvpatil/airetreat-go/internal/model"
@@ -11,83 +12,75 @@ type PlayerAccessorMockSuccess struct {
 	UserId   *string
 }
 
-func (p *PlayerAccessorMockSuccess) GetPlayer(playerId string) (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) GetPlayerUsingTransaction(playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) CreatePlayer() (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) CreatePlayer(ctx context.Context) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) GetPlayerForUserOrNil(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) CreatePlayerForUser(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockSuccess) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
 	return model.NewPlayer(model.PlayerOptions{
 		Id:     p.PlayerId,
 		UserId: p.UserId,
 	})
 }
 
-func (p *PlayerAccessorMockSuccess) DeletePlayer(playerId string) error {
-	return nil
-}
-
 type PlayerAccessorMockFailure struct {
 }
 
-func (p *PlayerAccessorMockFailure) GetPlayer(playerId string) (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
 	return nil, errors.New("unable to get player")
 }
 
-func (p *PlayerAccessorMockFailure) GetPlayerUsingTransaction(playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return nil, errors.New("unable to get player")
 }
 
-func (p *PlayerAccessorMockFailure) CreatePlayer() (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) CreatePlayer(ctx context.Context) (*model.Player, error) {
 	return nil, errors.New("unable to create player")
 }
 
-func (p *PlayerAccessorMockFailure) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return nil, errors.New("unable to update player")
 }
 
-func (p *PlayerAccessorMockFailure) GetPlayerForUserOrNil(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
 	return nil, errors.New("unable to get player")
 }
 
-func (p *PlayerAccessorMockFailure) CreatePlayerForUser(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockFailure) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
 	return nil, errors.New("unable to create player")
 }
 
-func (p *PlayerAccessorMockFailure) DeletePlayer(playerId string) error {
-	return errors.New("unable to delete player")
-}
-
 type PlayerAccessorMockConfigurable struct {
 	GetPlayerInternal                              func(playerId string) (*model.Player, error)
 	GetPlayerUsingTransactionInternal              func(playerId string, transaction DatabaseTransaction) (*model.Player, error)
@@ -95,33 +88,28 @@ type PlayerAccessorMockConfigurable struct {
 	UpdatePlayerWithUserIdUsingTransactionInternal func(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error)
 	GetPlayerForUserOrNilInternal                  func(userId string) (*model.Player, error)
 	CreatePlayerForUserInternal                    func(userId string) (*model.Player, error)
-	DeletePlayerInternal                           func(playerId string) error
 }
 
-func (p *PlayerAccessorMockConfigurable) GetPlayer(playerId string) (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
 	return p.GetPlayerInternal(playerId)
 }
 
-func (p *PlayerAccessorMockConfigurable) GetPlayerUsingTransaction(playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return p.GetPlayerUsingTransactionInternal(playerId, transaction)
 }
 
-func (p *PlayerAccessorMockConfigurable) CreatePlayer() (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) CreatePlayer(ctx context.Context) (*model.Player, error) {
 	return p.CreatePlayerInternal()
 }
 
-func (p *PlayerAccessorMockConfigurable) UpdatePlayerWithUserIdUsingTransaction(playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
 	return p.UpdatePlayerWithUserIdUsingTransactionInternal(playerId, userId, transaction)
 }
 
-func (p *PlayerAccessorMockConfigurable) GetPlayerForUserOrNil(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
 	return p.GetPlayerForUserOrNilInternal(userId)
 }
 
-func (p *PlayerAccessorMockConfigurable) CreatePlayerForUser(userId string) (*model.Player, error) {
+func (p *PlayerAccessorMockConfigurable) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
 	return p.CreatePlayerForUserInternal(userId)
 }
-
-func (p *PlayerAccessorMockConfigurable) DeletePlayer(playerId string) error {
-	return p.DeletePlayerInternal(playerId)
-}
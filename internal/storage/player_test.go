@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 )
 
 func Test_GetPlayerUsingTransaction(t *testing.T) {
+	requireTestDb(t)
 	userId := "user_id1"
 	playerWithoutUser, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
 	playerWithUser, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1", UserId: &userId})
@@ -77,12 +79,12 @@ func Test_GetPlayerUsingTransaction(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			tx, err := s.BeginTransaction()
+			tx, err := s.BeginTransaction(context.Background())
 			assert.NoError(t, err)
-			playerId, err := s.GetPlayerUsingTransaction(tt.input, tx)
+			playerId, err := s.GetPlayerUsingTransaction(context.Background(), tt.input, tx)
 			tx.Commit()
 
 			if !tt.errorExpected {
@@ -97,6 +99,7 @@ func Test_GetPlayerUsingTransaction(t *testing.T) {
 }
 
 func Test_GetPlayer(t *testing.T) {
+	requireTestDb(t)
 	userId := "user_id1"
 	playerWithoutUser, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
 	playerWithUser, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1", UserId: &userId})
@@ -164,10 +167,10 @@ func Test_GetPlayer(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			playerId, err := s.GetPlayer(tt.input)
+			playerId, err := s.GetPlayer(context.Background(), tt.input)
 
 			if !tt.errorExpected {
 				assert.NoError(t, err)
@@ -181,6 +184,7 @@ func Test_GetPlayer(t *testing.T) {
 }
 
 func Test_CreatePlayer(t *testing.T) {
+	requireTestDb(t)
 	player, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
 	tests := []struct {
 		name            string
@@ -225,10 +229,10 @@ func Test_CreatePlayer(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			player, err := s.CreatePlayer()
+			player, err := s.CreatePlayer(context.Background())
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.output, player)
@@ -237,13 +241,14 @@ func Test_CreatePlayer(t *testing.T) {
 				assert.EqualError(t, err, tt.errorString)
 			}
 			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
 			}
 		})
 	}
 }
 
 func Test_UpdatePlayerWithUserIdUsingTransaction(t *testing.T) {
+	requireTestDb(t)
 	userId := "user_id1"
 	updatedPlayer, _ := model.NewPlayer(model.PlayerOptions{
 		Id:     "player_id1",
@@ -355,12 +360,12 @@ func Test_UpdatePlayerWithUserIdUsingTransaction(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			tx, err := s.BeginTransaction()
+			tx, err := s.BeginTransaction(context.Background())
 			assert.NoError(t, err)
-			player, err := s.UpdatePlayerWithUserIdUsingTransaction(tt.input.playerId, tt.input.userId, tx)
+			player, err := s.UpdatePlayerWithUserIdUsingTransaction(context.Background(), tt.input.playerId, tt.input.userId, tx)
 			tx.Commit()
 			if !tt.errorExpected {
 				assert.NoError(t, err)
@@ -370,13 +375,14 @@ func Test_UpdatePlayerWithUserIdUsingTransaction(t *testing.T) {
 				assert.EqualError(t, err, tt.errorString)
 			}
 			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
 			}
 		})
 	}
 }
 
 func Test_GetPlayerForUserOrNil(t *testing.T) {
+	requireTestDb(t)
 	userId := "user_id1"
 	player, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1", UserId: &userId})
 	tests := []struct {
@@ -430,10 +436,10 @@ func Test_GetPlayerForUserOrNil(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			playerId, err := s.GetPlayerForUserOrNil(tt.input)
+			playerId, err := s.GetPlayerForUserOrNil(context.Background(), tt.input)
 
 			if !tt.errorExpected {
 				assert.NoError(t, err)
@@ -447,6 +453,7 @@ func Test_GetPlayerForUserOrNil(t *testing.T) {
 }
 
 func Test_CreatePlayerForUser(t *testing.T) {
+	requireTestDb(t)
 	connectedUserId := "user_id1"
 	player, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1", UserId: &connectedUserId})
 	tests := []struct {
@@ -508,10 +515,10 @@ func Test_CreatePlayerForUser(t *testing.T) {
 				},
 			)
 
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
 
-			player, err := s.CreatePlayerForUser(tt.input)
+			player, err := s.CreatePlayerForUser(context.Background(), tt.input)
 			if !tt.errorExpected {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.output, player)
@@ -520,88 +527,7 @@ func Test_CreatePlayerForUser(t *testing.T) {
 				assert.EqualError(t, err, tt.errorString)
 			}
 			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
-		})
-	}
-}
-
-func Test_DeletePlayer(t *testing.T) {
-	tests := []struct {
-		name            string
-		input           string
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		dbUpdateCheck   func(*sql.DB) bool
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name:            "errors if playerId is blank",
-			input:           "",
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			dbUpdateCheck:   nil,
-			errorExpected:   true,
-			errorString:     "playerId cannot be blank",
-		},
-		{
-			name:            "errors if db delete errors",
-			input:           "player_id1",
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			dbUpdateCheck:   nil,
-			errorExpected:   true,
-			errorString:     "THIS IS BAD: Very few or too many rows were affected when deleting player in db. This is highly unexpected. rowsAffected: 0",
-		},
-		{
-			name:  "deletes player successfully",
-			input: "player_id1",
-			setupSqlStmts: []TestSqlStmts{
-				{Query: `INSERT INTO public."users" ("id") VALUES ('user_id1')`},
-				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id1')`},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."users" WHERE id = 'user_id1'`},
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					playerId string
-				)
-				err := db.QueryRow(
-					`SELECT "id" FROM public."players" WHERE "id" = 'player_id1'`,
-				).Scan(&playerId)
-				assert.Error(t, err, "sql: no rows in result set")
-				return true
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
-
-			tx, err := s.BeginTransaction()
-			assert.NoError(t, err)
-			err = s.DeletePlayer(tt.input)
-			tx.Commit()
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
 			}
 		})
 	}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type PracticeResultRecorder interface {
+	RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error
+}
+
+// Practice games are recorded in their own table, so that they never count towards ranked results.
+func (s *Storage) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	id := s.IdGenerator.Generate()
+	return recordPracticeResult(ctx, transaction, id, gameId, playerId, won)
+}
+
+func recordPracticeResult(ctx context.Context, customDb customDbHandler, id, gameId, playerId string, won bool) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(playerId) {
+		return errors.New("playerId cannot be blank")
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`INSERT INTO public."practice_results" (
+			"id", "game_id", "player_id", "won"
+		)
+		VALUES (
+			$1, $2, $3, $4
+		)`,
+		id, gameId, playerId, won,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while recording practice result: %s %s", gameId, playerId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row after recording practice result: %s %s", gameId, playerId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when recording practice result in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+
+	return nil
+}
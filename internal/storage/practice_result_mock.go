@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+type PracticeResultRecorderMockSuccess struct {
+}
+
+func (p *PracticeResultRecorderMockSuccess) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	return nil
+}
+
+type PracticeResultRecorderMockFailure struct {
+}
+
+func (p *PracticeResultRecorderMockFailure) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	return errors.New("unable to record practice result")
+}
+
+type PracticeResultRecorderConfigurableMock struct {
+	RecordPracticeResultUsingTransactionInternal func(gameId, playerId string, won bool, transaction DatabaseTransaction) error
+}
+
+func (p *PracticeResultRecorderConfigurableMock) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	return p.RecordPracticeResultUsingTransactionInternal(gameId, playerId, won, transaction)
+}
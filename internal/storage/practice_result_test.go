@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func Test_RecordPracticeResultUsingTransaction(t *testing.T) {
+	requireTestDb(t)
+	tests := []struct {
+		name  string
+		input struct {
+			gameId   string
+			playerId string
+			won      bool
+		}
+		setupSqlStmts   []TestSqlStmts
+		cleanupSqlStmts []TestSqlStmts
+		idGenerator     utilities.CuidGenerator
+		dbUpdateCheck   func(*sql.DB) bool
+		errorExpected   bool
+		errorString     string
+	}{
+		{
+			name: "errors if gameId is blank",
+			input: struct {
+				gameId   string
+				playerId string
+				won      bool
+			}{"", "player_id1", true},
+			idGenerator:   &utilities.IdGeneratorMockConstant{Id: "practice_result_id1"},
+			errorExpected: true,
+			errorString:   "gameId cannot be blank",
+		},
+		{
+			name: "errors if playerId is blank",
+			input: struct {
+				gameId   string
+				playerId string
+				won      bool
+			}{"game_id1", "", true},
+			idGenerator:   &utilities.IdGeneratorMockConstant{Id: "practice_result_id1"},
+			errorExpected: true,
+			errorString:   "playerId cannot be blank",
+		},
+		{
+			name: "records practice result successfully",
+			input: struct {
+				gameId   string
+				playerId string
+				won      bool
+			}{"game_id1", "player_id1", true},
+			setupSqlStmts: []TestSqlStmts{
+				{Query: `INSERT INTO public."players" ("id") VALUES ('player_id1')`},
+			},
+			cleanupSqlStmts: []TestSqlStmts{
+				{Query: `DELETE FROM public."players" WHERE id = 'player_id1'`},
+			},
+			idGenerator: &utilities.IdGeneratorMockConstant{Id: "practice_result_id1"},
+			dbUpdateCheck: func(db *sql.DB) bool {
+				var gameId, playerId string
+				var won bool
+				err := db.QueryRow(
+					`SELECT "game_id", "player_id", "won"
+						FROM public."practice_results" WHERE "id" = 'practice_result_id1'`,
+				).Scan(&gameId, &playerId, &won)
+				assert.NoError(t, err)
+				assert.Equal(t, "game_id1", gameId)
+				assert.Equal(t, "player_id1", playerId)
+				assert.True(t, won)
+				return true
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewDbStorage(
+				StorageOptions{
+					Db:          testDb,
+					IdGenerator: tt.idGenerator,
+				},
+			)
+
+			runSqlOnDb(t, s.db.DB, tt.setupSqlStmts)
+			defer runSqlOnDb(t, s.db.DB, tt.cleanupSqlStmts)
+
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			err = s.RecordPracticeResultUsingTransaction(context.Background(), tt.input.gameId, tt.input.playerId, tt.input.won, tx)
+			tx.Commit()
+			if !tt.errorExpected {
+				assert.NoError(t, err)
+			} else {
+				assert.NotEmpty(t, tt.errorString)
+				assert.EqualError(t, err, tt.errorString)
+			}
+			if tt.dbUpdateCheck != nil {
+				assert.True(t, tt.dbUpdateCheck(s.db.DB))
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// beginLaggingReplica stands in for a read replica that has fallen behind. A repeatable read transaction keeps seeing
+// the database as it was when its first query ran.
+func beginLaggingReplica(t *testing.T) *sql.Tx {
+	tx, err := testDb.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	assert.NoError(t, err)
+	_, err = tx.Exec(`SELECT 1`)
+	assert.NoError(t, err)
+	return tx
+}
+
+// laggingReplica shares the snapshot of the lagging replica with every read, instead of starting a new one.
+type laggingReplica struct {
+	*sql.Tx
+}
+
+func (r laggingReplica) beginSnapshot(ctx context.Context) (DatabaseTransaction, error) {
+	return laggingReplicaSnapshot{r.Tx}, nil
+}
+
+// Ending a read leaves the snapshot of the lagging replica open for the rest of the test.
+type laggingReplicaSnapshot struct {
+	*sql.Tx
+}
+
+func (laggingReplicaSnapshot) Commit() error   { return nil }
+func (laggingReplicaSnapshot) Rollback() error { return nil }
+
+func Test_ReadReplica(t *testing.T) {
+	requireTestDb(t)
+	insertGame := []TestSqlStmts{
+		{
+			Query: `INSERT INTO public."games" ("id", "state", "current_turn_index", "turn_order", "state_handled")
+			VALUES ('game_id1', 'STARTED', 0, Array['bot_id1','bot_id2'], false)`,
+		},
+		{
+			Query: `INSERT INTO public."bots" ("id", "name", "type", "game_id")
+			VALUES ('bot_id1', 'bot1', 'AI', 'game_id1'), ('bot_id2', 'bot2', 'AI', 'game_id1')`,
+		},
+	}
+	deleteGame := []TestSqlStmts{
+		{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
+	}
+
+	t.Run("GetGame reads from the replica once it has caught up", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertGame)
+		defer runSqlOnDb(t, testDb, deleteGame)
+
+		replica := beginLaggingReplica(t)
+		defer replica.Rollback()
+		s, _ := NewDbStorage(StorageOptions{Db: testDb})
+		s.replica = laggingReplica{replica}
+
+		// The version is left alone, so the replica is as recent as the primary as far as GetGame can tell.
+		runSqlOnDb(t, testDb, []TestSqlStmts{
+			{Query: `UPDATE public."bots" SET "name" = 'renamed' WHERE id = 'bot_id1'`},
+		})
+
+		game, err := s.GetGame(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, "bot1", game.BotWithId("bot_id1").Name())
+	})
+
+	t.Run("GetGame reads from the primary while the replica is behind", func(t *testing.T) {
+		runSqlOnDb(t, testDb, insertGame)
+		defer runSqlOnDb(t, testDb, deleteGame)
+
+		replica := beginLaggingReplica(t)
+		defer replica.Rollback()
+		s, _ := NewDbStorage(StorageOptions{Db: testDb, GameCacheSize: 10})
+		s.replica = laggingReplica{replica}
+
+		err := s.CreateMessage(context.Background(), "bot_id1", "bot_id2", "what is your name?", "question", MessageMetadata{})
+		assert.NoError(t, err)
+
+		replicaGame, err := getGameUsingCustomDbHandler(context.Background(), replica, "game_id1", false)
+		assert.NoError(t, err)
+		assert.Empty(t, replicaGame.GetDetailedMessages())
+
+		game, err := s.GetGame(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Len(t, game.GetDetailedMessages(), 1)
+	})
+
+	t.Run("GetGame reads from the primary when the game is not on the replica yet", func(t *testing.T) {
+		replica := beginLaggingReplica(t)
+		defer replica.Rollback()
+		s, _ := NewDbStorage(StorageOptions{Db: testDb})
+		s.replica = laggingReplica{replica}
+
+		runSqlOnDb(t, testDb, insertGame)
+		defer runSqlOnDb(t, testDb, deleteGame)
+
+		game, err := s.GetGame(context.Background(), "game_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, "game_id1", game.Id())
+	})
+
+	t.Run("transactions read from the primary", func(t *testing.T) {
+		replica := beginLaggingReplica(t)
+		defer replica.Rollback()
+		s, _ := NewDbStorage(StorageOptions{Db: testDb})
+		s.replica = laggingReplica{replica}
+
+		runSqlOnDb(t, testDb, insertGame)
+		defer runSqlOnDb(t, testDb, deleteGame)
+
+		tx, err := s.BeginTransaction(context.Background())
+		assert.NoError(t, err)
+		defer tx.Rollback()
+		game, err := s.GetGameUsingTransaction(context.Background(), "game_id1", tx)
+		assert.NoError(t, err)
+		assert.Equal(t, "game_id1", game.Id())
+	})
+
+	t.Run("GetUnhandledGameIdsForState reads from the replica", func(t *testing.T) {
+		replica := beginLaggingReplica(t)
+		defer replica.Rollback()
+		s, _ := NewDbStorage(StorageOptions{Db: testDb})
+		s.replica = laggingReplica{replica}
+
+		runSqlOnDb(t, testDb, insertGame)
+		defer runSqlOnDb(t, testDb, deleteGame)
+
+		gameIds, err := s.GetUnhandledGameIdsForState(context.Background(), "STARTED")
+		assert.NoError(t, err)
+		assert.NotContains(t, gameIds, "game_id1")
+
+		s.replica = nil
+		gameIds, err = s.GetUnhandledGameIdsForState(context.Background(), "STARTED")
+		assert.NoError(t, err)
+		assert.Contains(t, gameIds, "game_id1")
+	})
+}
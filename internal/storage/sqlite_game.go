@@ -0,0 +1,964 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// sqliteSelectGamesSql selects the same columns as selectGamesSql, so that scanGameOptions scans its rows.
+const sqliteSelectGamesSql = `SELECT
+	g.id, g.state, g.current_turn_index, g.turn_order,
+	g.state_handled, g.state_handled_at, g.state_total_time,
+	g.last_question, g.last_question_target_bot_id,
+	g.result, g.winning_bot_id, g.public, g.prompt_version,
+	g.conversation_summary, g.summarized_message_count, g.ai_accusations, g.elimination,
+	g.voting_rounds, g.ai_votes, g.mode, g.decoy_bot_id, g.ai_accusation_checked_at,
+	g.created_at, g.updated_at, g.version
+	FROM "games" AS g`
+
+func (s *SqliteStorage) CreateGame(ctx context.Context, createOpts GameCreateOptions) (string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	id := s.IdGenerator.Generate()
+
+	botOptionsList := []model.BotOptions{}
+	bots := []*model.Bot{}
+	nonRandomTurnOrder := []string{}
+	for _, name := range model.RandomBotNames() {
+		botOpts := model.BotOptions{
+			Id:        s.IdGenerator.Generate(),
+			Name:      name,
+			TypeOfBot: "AI",
+		}
+		botOptionsList = append(botOptionsList, botOpts)
+		bot, err := model.NewBot(botOpts)
+		if err != nil {
+			return "", utilities.WrapBadError(err, "failed to create bot")
+		}
+		bots = append(bots, bot)
+		nonRandomTurnOrder = append(nonRandomTurnOrder, botOpts.Id)
+	}
+
+	gameOption := model.GameOptions{
+		Id:               id,
+		State:            "STARTED",
+		CurrentTurnIndex: 0,
+		TurnOrder:        nonRandomTurnOrder,
+		StateHandled:     false,
+		Bots:             bots,
+		Public:           createOpts.Public,
+		PromptVersion:    createOpts.PromptVersion,
+		AiAccusations:    createOpts.AiAccusations,
+		Elimination:      createOpts.Elimination,
+		VotingRounds:     createOpts.VotingRounds,
+		AiVotes:          createOpts.AiVotes,
+		Mode:             createOpts.Mode,
+	}
+
+	game, err := model.NewGame(gameOption)
+	if err != nil {
+		return "", utilities.WrapBadError(err, "failed to create game")
+	}
+
+	createdAt := sqliteTimestamp(time.Now())
+	err = s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO "games" (
+				"id", "state", "current_turn_index", "turn_order", "state_handled", "public", "prompt_version", "ai_accusations", "elimination", "voting_rounds", "ai_votes", "mode", "created_at", "updated_at"
+			)
+			VALUES (
+				?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?13
+			)`,
+			gameOption.Id, gameOption.State,
+			gameOption.CurrentTurnIndex, pq.Array(gameOption.TurnOrder),
+			gameOption.StateHandled, gameOption.Public, nullStringIfBlank(gameOption.PromptVersion),
+			gameOption.AiAccusations, gameOption.Elimination, gameOption.VotingRounds, gameOption.AiVotes,
+			game.Mode(), createdAt,
+		)
+		if err != nil {
+			return err
+		}
+		err = expectOneRowAffected(result, "inserting game")
+		if err != nil {
+			return err
+		}
+
+		for _, botOpts := range botOptionsList {
+			result, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO "bots" ("id", "name", "type", "game_id", "created_at") VALUES (?, ?, ?, ?, ?)`,
+				botOpts.Id, botOpts.Name, botOpts.TypeOfBot, gameOption.Id, createdAt,
+			)
+			if err != nil {
+				return err
+			}
+			err = expectOneRowAffected(result, "inserting bot")
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return gameOption.Id, nil
+}
+
+// expectOneRowAffected fails the way the Storage methods do when a write that should change a single row does not.
+func expectOneRowAffected(result sql.Result, action string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while %s and changing db", action))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when %s in db. This is highly unexpected. rowsAffected: %d", action, rowsAffected))
+	}
+	return nil
+}
+
+// GetGame loads the game from a snapshot, like Storage does, so that a message sent in between its queries cannot come
+// back along with the state and turn from before it.
+func (s *SqliteStorage) GetGame(ctx context.Context, gameId string) (*model.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var game *model.Game
+	err := s.inSnapshot(ctx, func(tx *sql.Tx) error {
+		var err error
+		game, err = getSqliteGame(ctx, tx, gameId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// The transaction already holds the write lock, so there is nothing more to lock.
+func (s *SqliteStorage) GetGameUsingTransaction(ctx context.Context, gameId string, transaction DatabaseTransaction) (*model.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getSqliteGame(ctx, transaction, gameId)
+}
+
+func getSqliteGame(ctx context.Context, customDb customDbHandler, gameId string) (*model.Game, error) {
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("cannot getGame for a blank gameId")
+	}
+
+	opts, err := scanGameOptions(customDb.QueryRowContext(ctx, sqliteSelectGamesSql+`
+	WHERE g.id = ?`, gameId))
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("game not found: %s", gameId)
+	}
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select game")
+	}
+
+	games, err := hydrateSqliteGames(ctx, customDb, []*model.GameOptions{opts})
+	if err != nil {
+		return nil, err
+	}
+	return games[0], nil
+}
+
+// getSqliteGamesOptions leaves out the games that do not exist.
+func getSqliteGamesOptions(ctx context.Context, customDb customDbHandler, gameIds []string) ([]*model.GameOptions, error) {
+	rows, err := customDb.QueryContext(
+		ctx,
+		sqliteSelectGamesSql+`
+	WHERE g.id IN (SELECT value FROM json_each(?))
+	ORDER BY g.created_at ASC, g.id ASC`,
+		sqliteIds(gameIds),
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to select games")
+	}
+	defer rows.Close()
+
+	gameOptsList := []*model.GameOptions{}
+	for rows.Next() {
+		opts, err := scanGameOptions(rows)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed while scanning game rows")
+		}
+		gameOptsList = append(gameOptsList, opts)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to correctly go through game rows")
+	}
+	return gameOptsList, nil
+}
+
+// hydrateSqliteGames is hydrateGames for SQLite.
+func hydrateSqliteGames(ctx context.Context, customDb customDbHandler, gameOptsList []*model.GameOptions) ([]*model.Game, error) {
+	gameIds := []string{}
+	for _, opts := range gameOptsList {
+		gameIds = append(gameIds, opts.Id)
+	}
+
+	botsByGameId := map[string][]*model.Bot{}
+	err := forEachSqliteRow(
+		ctx, customDb,
+		`SELECT b.game_id, b.id, b.name, b.type, b.player_id, b.help_count, b.last_help_suggestions, b.stated_facts,
+		b.tag_count, b.last_tagged_at, b.eliminated
+		FROM "bots" AS b
+		WHERE b.game_id IN (SELECT value FROM json_each(?))
+		ORDER BY b.created_at ASC, b.id ASC`,
+		[]any{sqliteIds(gameIds)},
+		func(rows *sql.Rows) error {
+			gameId, bot, err := scanBot(rows)
+			if err != nil {
+				return err
+			}
+			botsByGameId[gameId] = append(botsByGameId[gameId], bot)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	messagesByGameId := map[string][]*model.Message{}
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT m.game_id, m.source_bot_id, m.target_bot_id, m.text, m.created_at, m.type,
+		m.response_time_ms, m.help_usage, m.help_style, m.ai_model, m.prompt_version, m.prompt_tokens, m.completion_tokens
+		FROM "messages" AS m
+		WHERE m.game_id IN (SELECT value FROM json_each(?))
+		ORDER BY m.created_at ASC, m.id ASC`,
+		[]any{sqliteIds(gameIds)},
+		func(rows *sql.Rows) error {
+			gameId, message, err := scanMessage(rows)
+			if err != nil {
+				return err
+			}
+			messagesByGameId[gameId] = append(messagesByGameId[gameId], message)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	votesByGameId := map[string][]*model.Vote{}
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT v.game_id, v.voter_bot_id, v.suspect_bot_id
+		FROM "votes" AS v
+		WHERE v.game_id IN (SELECT value FROM json_each(?))
+		ORDER BY v.created_at ASC, v.id ASC`,
+		[]any{sqliteIds(gameIds)},
+		func(rows *sql.Rows) error {
+			var gameId string
+			var vote model.Vote
+			err := rows.Scan(&gameId, &vote.VoterBotId, &vote.SuspectBotId)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning vote rows")
+			}
+			votesByGameId[gameId] = append(votesByGameId[gameId], &vote)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	games := []*model.Game{}
+	for _, opts := range gameOptsList {
+		opts.Bots = botsByGameId[opts.Id]
+		if len(opts.Bots) == 0 {
+			return nil, utilities.NewBadError(fmt.Sprintf("game has no bots: %s", opts.Id))
+		}
+		opts.Messages = messagesByGameId[opts.Id]
+		opts.Votes = votesByGameId[opts.Id]
+
+		game, err := model.NewGame(*opts)
+		if err != nil {
+			return nil, utilities.WrapBadError(err, "failed to create game")
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// forEachSqliteRow calls scan for each row that query selects.
+func forEachSqliteRow(ctx context.Context, customDb customDbHandler, query string, args []any, scan func(rows *sql.Rows) error) error {
+	rows, err := customDb.QueryContext(ctx, query, args...)
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to select rows")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to correctly go through rows")
+	}
+	return nil
+}
+
+func (s *SqliteStorage) GetGames(ctx context.Context, playerId string) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("cannot GetGames for a blank playerId")
+	}
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT game_id FROM "bots" WHERE player_id = ? ORDER BY created_at DESC, id DESC`,
+		playerId,
+	)
+}
+
+func (s *SqliteStorage) UpdateGameState(ctx context.Context, gameId string, updateOpts GameUpdateOptions) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		return updateSqliteGameState(ctx, tx, gameId, updateOpts)
+	})
+}
+
+func (s *SqliteStorage) UpdateGameStateUsingTransaction(ctx context.Context, gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateSqliteGameState(ctx, transaction, gameId, updateOpts)
+}
+
+// updateSqliteGameState builds the update from sqlAndArgsForUpdate, like updateGameState does, so that the columns that
+// each of the update options sets are only listed once.
+func updateSqliteGameState(ctx context.Context, customDb customDbHandler, gameId string, updateOpts GameUpdateOptions) error {
+	updateSqlsPart, args := sqlAndArgsForUpdate(updateOpts)
+	if len(args) == 0 {
+		return errors.New("no update options provided")
+	}
+	updateSqlsPart, args = autoAddUpdateTimeStamp(updateSqlsPart, args)
+	updateSqlsPart = append(updateSqlsPart, "\"version\" = \"version\" + 1")
+	args = append(args, gameId)
+	updateSql := fmt.Sprintf("UPDATE \"games\" SET %s WHERE \"id\" = $%d", strings.Join(updateSqlsPart, ", "), len(args))
+	if updateOpts.ExpectedVersion != nil {
+		args = append(args, *updateOpts.ExpectedVersion)
+		updateSql = fmt.Sprintf("%s AND \"version\" = $%d", updateSql, len(args))
+	}
+	if updateOpts.ExpectedTurn != nil {
+		args = append(args, updateOpts.ExpectedTurn.State, updateOpts.ExpectedTurn.CurrentTurnIndex, updateOpts.ExpectedTurn.LastQuestionTargetBotId)
+		index := len(args) - 2
+		updateSql = fmt.Sprintf(
+			"%s AND \"state\" = $%d AND \"current_turn_index\" = $%d AND COALESCE(\"last_question_target_bot_id\", '') = $%d",
+			updateSql, index, index+1, index+2,
+		)
+	}
+
+	result, err := customDb.ExecContext(ctx, sqlitePlaceholders(updateSql), sqliteArgs(args)...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, "dbError while updating game and changing db")
+	}
+
+	if rowsAffected == 0 && updateOpts.ExpectedVersion != nil {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected version: %d", gameId, *updateOpts.ExpectedVersion)
+	}
+	if rowsAffected == 0 && updateOpts.ExpectedTurn != nil {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected turn: %+v", gameId, *updateOpts.ExpectedTurn)
+	}
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when updating game in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+	return nil
+}
+
+// The game moves on once exactly humanPlayerCount humans have joined, which depends on the mode of the game.
+func (s *SqliteStorage) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx context.Context, gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	_, err := transaction.ExecContext(
+		ctx,
+		`UPDATE "games" SET state = 'PLAYERS_JOINED', updated_at = ?, version = version + 1
+		WHERE id = ?
+		AND (SELECT count(b.id) FROM "bots" AS b WHERE b.game_id = "games".id AND b.type = 'HUMAN') = ?`,
+		sqliteTimestamp(time.Now()), gameId, humanPlayerCount,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while updating game state: %s", gameId))
+	}
+	return nil
+}
+
+func (s *SqliteStorage) DeleteGame(ctx context.Context, gameId string) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	return s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM "games" WHERE id = ?`, gameId)
+		if err != nil {
+			return err
+		}
+		return expectOneRowAffected(result, "deleting game")
+	})
+}
+
+func (s *SqliteStorage) GetOldGames(ctx context.Context, gameExpiryDuration time.Duration) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if gameExpiryDuration > -5*time.Minute {
+		return nil, errors.New("invalid game expiry duration. Max acceptable time is -5 minutes.")
+	}
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT id FROM "games" WHERE created_at < ? ORDER BY created_at DESC, id DESC`,
+		sqliteTimestamp(time.Now().Add(gameExpiryDuration)),
+	)
+}
+
+func (s *SqliteStorage) GetAutoJoinableGames(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	gameIds := []string{}
+	err := forEachSqliteRow(
+		ctx, s.readDb,
+		`SELECT g.id, g.mode, count(b.id)
+		FROM "games" AS g
+		INNER JOIN "bots" AS b ON b.game_id = g.id
+		WHERE g.created_at > ?
+		AND g.state = 'STARTED'
+		AND b.type = 'HUMAN'
+		GROUP BY g.id, g.mode
+		ORDER BY g.created_at DESC, g.id DESC`,
+		[]any{sqliteTimestamp(time.Now().Add(-5 * time.Minute))},
+		func(rows *sql.Rows) error {
+			var gameId string
+			var mode string
+			var humanBotCount int64
+			err := rows.Scan(&gameId, &mode, &humanBotCount)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning rows")
+			}
+			if humanBotCount < model.HumanPlayerCountForMode(mode) {
+				gameIds = append(gameIds, gameId)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return gameIds, nil
+}
+
+func (s *SqliteStorage) GetUnhandledGameIdsForState(ctx context.Context, gameStateString string) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	gameState := model.GameState(gameStateString)
+	if !gameState.Valid() {
+		return nil, errors.New("invalid game state")
+	}
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT id FROM "games" WHERE state = ? AND state_handled = false ORDER BY created_at DESC, id DESC`,
+		gameState.String(),
+	)
+}
+
+// ClaimUnhandledGames claims games like Storage does. There is nothing to skip, since claiming holds the write lock,
+// and no other transaction can have a game locked in the meantime.
+func (s *SqliteStorage) ClaimUnhandledGames(ctx context.Context, gameStates []string, limit int, claimTimeout time.Duration) ([]ClaimedGame, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	err := validateClaim(gameStates, limit, claimTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	claimedGames := []ClaimedGame{}
+	err = s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		now := time.Now()
+		gameIds, err := getIds(
+			ctx, tx,
+			`UPDATE "games"
+			SET state_handled = true, state_claimed_at = ?1, updated_at = ?1, version = version + 1
+			WHERE id IN (
+				SELECT g.id
+				FROM "games" AS g
+				WHERE g.state IN (SELECT value FROM json_each(?2))
+				AND (
+					g.state_handled = false
+					OR (g.state_claimed_at IS NOT NULL AND g.state_claimed_at <= ?3)
+				)
+				ORDER BY g.created_at ASC, g.id ASC
+				LIMIT ?4
+			)
+			RETURNING id`,
+			sqliteTimestamp(now), sqliteIds(gameStates), sqliteTimestamp(now.Add(-claimTimeout)), limit,
+		)
+		if err != nil {
+			return utilities.WrapBadError(err, "failed to claim games")
+		}
+
+		return forEachSqliteRow(
+			ctx, tx,
+			`SELECT id, state, version FROM "games"
+			WHERE id IN (SELECT value FROM json_each(?))
+			ORDER BY created_at ASC, id ASC`,
+			[]any{sqliteIds(gameIds)},
+			func(rows *sql.Rows) error {
+				var claimedGame ClaimedGame
+				err := rows.Scan(&claimedGame.Id, &claimedGame.State, &claimedGame.Version)
+				if err != nil {
+					return utilities.WrapBadError(err, "failed while scanning rows")
+				}
+				claimedGames = append(claimedGames, claimedGame)
+				return nil
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimedGames, nil
+}
+
+// GetGameIdsForAiAccusation returns the games with AI accusations that are in play, and where a human has answered
+// a question since the AI bots last looked for someone to accuse.
+func (s *SqliteStorage) GetGameIdsForAiAccusation(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT g.id
+		FROM "games" AS g
+		WHERE g.ai_accusations = true
+		AND g.state IN ('WAITING_FOR_AI_QUESTION', 'WAITING_FOR_HUMAN_QUESTION', 'WAITING_FOR_AI_ANSWER', 'WAITING_FOR_HUMAN_ANSWER')
+		AND EXISTS (
+			SELECT 1
+			FROM "messages" AS m
+			JOIN "bots" AS b ON m.source_bot_id = b.id
+			WHERE m.game_id = g.id
+			AND b.type = 'HUMAN'
+			AND m.type = 'answer'
+			AND (g.ai_accusation_checked_at IS NULL OR m.created_at > g.ai_accusation_checked_at)
+		)
+		ORDER BY g.created_at DESC, g.id DESC`,
+	)
+}
+
+// GetGameIdsForVotingToClose returns the games that are voting, and where everyone has had their time to vote.
+func (s *SqliteStorage) GetGameIdsForVotingToClose(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT g.id
+		FROM "games" AS g
+		WHERE g.state = 'VOTING'
+		AND g.state_handled = false
+		AND julianday(g.state_handled_at) + g.state_total_time / 86400.0 <= julianday(?)
+		ORDER BY g.created_at DESC, g.id DESC`,
+		sqliteTimestamp(time.Now()),
+	)
+}
+
+// GetGameIdsForAnalysis returns the finished games that have not been analyzed yet.
+func (s *SqliteStorage) GetGameIdsForAnalysis(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getIds(
+		ctx, s.readDb,
+		`SELECT g.id
+		FROM "games" AS g
+		WHERE g.state = 'FINISHED'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM "bot_analyses" AS ba
+			JOIN "bots" AS b ON ba.bot_id = b.id
+			WHERE b.game_id = g.id
+		)
+		ORDER BY g.created_at DESC, g.id DESC`,
+	)
+}
+
+// bumpSqliteGameVersion is bumpGameVersion for SQLite.
+func bumpSqliteGameVersion(ctx context.Context, customDb customDbHandler, gameId string) error {
+	_, err := customDb.ExecContext(ctx, `UPDATE "games" SET "version" = "version" + 1 WHERE id = ?`, gameId)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while bumping game version: %s", gameId))
+	}
+	return nil
+}
+
+func bumpSqliteGameVersionForBot(ctx context.Context, customDb customDbHandler, botId string) error {
+	_, err := customDb.ExecContext(
+		ctx,
+		`UPDATE "games" SET "version" = "version" + 1
+		WHERE id = (SELECT b.game_id FROM "bots" AS b WHERE b.id = ?)`, botId,
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while bumping game version for bot: %s", botId))
+	}
+	return nil
+}
+
+// helpCount is the number of times the player can ask for help, which depends on the game mode.
+func (s *SqliteStorage) UpdateBotWithPlayerIdUsingTransaction(ctx context.Context, botId, playerId string, helpCount int64, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	if utilities.IsBlank(playerId) {
+		return errors.New("playerId cannot be blank")
+	}
+
+	if helpCount < 0 {
+		return errors.New("helpCount cannot be negative")
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "connecting player to bot", playerId+" "+botId,
+		`UPDATE "bots" SET "player_id" = ?, "type" = 'HUMAN', "help_count" = ? WHERE id = ?`,
+		playerId, helpCount, botId,
+	)
+}
+
+func (s *SqliteStorage) UpdateBotDecrementHelpCountUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	var helpCount int
+	err := transaction.QueryRowContext(ctx, `SELECT b.help_count FROM "bots" AS b WHERE b.id = ?`, botId).Scan(&helpCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("getting help_count for %s: no such bot", botId)
+		}
+		return errors.Errorf("getting help_count for %s: %v", botId, err)
+	}
+
+	if helpCount <= 0 {
+		return errors.Errorf("help_count should not be updated below 0 for %s", botId)
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "decrementing bot help count", botId,
+		`UPDATE "bots" SET "help_count" = ? WHERE id = ?`,
+		helpCount-1, botId,
+	)
+}
+
+// No suggestions clears the last help suggestions.
+func (s *SqliteStorage) UpdateBotLastHelpSuggestionsUsingTransaction(ctx context.Context, botId string, suggestions []string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	var lastHelpSuggestions interface{}
+	if len(suggestions) > 0 {
+		lastHelpSuggestions = pq.Array(suggestions)
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "updating bot last help suggestions", botId,
+		`UPDATE "bots" SET "last_help_suggestions" = ? WHERE id = ?`,
+		lastHelpSuggestions, botId,
+	)
+}
+
+func (s *SqliteStorage) UpdateBotStatedFactsUsingTransaction(ctx context.Context, botId string, statedFacts []string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "updating bot stated facts", botId,
+		`UPDATE "bots" SET "stated_facts" = ? WHERE id = ?`,
+		pq.Array(statedFacts), botId,
+	)
+}
+
+// Records a tag used by the bot, which counts towards its tag limit and starts its tag cooldown.
+func (s *SqliteStorage) UpdateBotAfterTagUsingTransaction(ctx context.Context, botId string, taggedAt time.Time, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "recording bot tag", botId,
+		`UPDATE "bots" SET "tag_count" = "tag_count" + 1, "last_tagged_at" = ? WHERE id = ?`,
+		sqliteTimestamp(taggedAt), botId,
+	)
+}
+
+func (s *SqliteStorage) UpdateBotEliminatedUsingTransaction(ctx context.Context, botId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(botId) {
+		return errors.New("botId cannot be blank")
+	}
+
+	return updateSqliteBot(
+		ctx, transaction, botId, "eliminating bot", botId,
+		`UPDATE "bots" SET "eliminated" = true WHERE id = ?`,
+		botId,
+	)
+}
+
+// updateSqliteBot runs an update of a single bot, and bumps the version of its game. ids are the ids that db errors
+// mention, like they do for Storage.
+func updateSqliteBot(ctx context.Context, customDb customDbHandler, botId string, action string, ids string, query string, args ...any) error {
+	result, err := customDb.ExecContext(ctx, query, args...)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while %s: %s", action, ids))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row while %s: %s", action, ids))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("No rows were affected while %s. This is highly unexpected.", action))
+	}
+
+	return bumpSqliteGameVersionForBot(ctx, customDb, botId)
+}
+
+func (s *SqliteStorage) CreateMessage(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	id := s.IdGenerator.Generate()
+	return s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		return createSqliteMessage(ctx, tx, id, sourceBotId, targetBotId, text, messageType, metadata)
+	})
+}
+
+func (s *SqliteStorage) CreateMessageUsingTransaction(ctx context.Context, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	id := s.IdGenerator.Generate()
+	return createSqliteMessage(ctx, transaction, id, sourceBotId, targetBotId, text, messageType, metadata)
+}
+
+func createSqliteMessage(ctx context.Context, customDb customDbHandler, id, sourceBotId, targetBotId, text, messageType string, metadata MessageMetadata) error {
+	err := validateMessage(sourceBotId, targetBotId, text, messageType, metadata)
+	if err != nil {
+		return err
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`INSERT INTO "messages" (
+			"id", "game_id", "source_bot_id", "target_bot_id", "text", "type",
+			"response_time_ms", "help_usage", "help_style", "ai_model", "prompt_version", "prompt_tokens", "completion_tokens",
+			"created_at"
+		)
+		VALUES (
+			?1, (SELECT "game_id" FROM "bots" WHERE "id" = ?2), ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13
+		)`,
+		id, sourceBotId, targetBotId, text, messageType,
+		metadata.ResponseTime.Milliseconds(), nullStringIfBlank(metadata.HelpUsage), nullStringIfBlank(metadata.HelpStyle),
+		nullStringIfBlank(metadata.AiModel), nullStringIfBlank(metadata.PromptVersion),
+		metadata.PromptTokens, metadata.CompletionTokens, sqliteTimestamp(time.Now()),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while inserting message: %s %s %s", sourceBotId, targetBotId, text))
+	}
+
+	err = expectOneRowAffected(result, "inserting message")
+	if err != nil {
+		return err
+	}
+
+	return bumpSqliteGameVersionForBot(ctx, customDb, sourceBotId)
+}
+
+// Each bot has a single vote in a game. Voting again replaces the earlier vote.
+func (s *SqliteStorage) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(voterBotId) {
+		return errors.New("voterBotId cannot be blank")
+	}
+
+	if utilities.IsBlank(suspectBotId) {
+		return errors.New("suspectBotId cannot be blank")
+	}
+
+	if voterBotId == suspectBotId {
+		return errors.Errorf("voter and suspect bot cannot be same. %s %s", voterBotId, suspectBotId)
+	}
+
+	result, err := transaction.ExecContext(
+		ctx,
+		`INSERT INTO "votes" (
+			"id", "game_id", "voter_bot_id", "suspect_bot_id", "created_at", "updated_at"
+		)
+		VALUES (
+			?1, ?2, ?3, ?4, ?5, ?5
+		)
+		ON CONFLICT ("voter_bot_id") DO UPDATE SET "suspect_bot_id" = ?4, "updated_at" = ?5`,
+		s.IdGenerator.Generate(), gameId, voterBotId, suspectBotId, sqliteTimestamp(time.Now()),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while casting vote: %s %s", voterBotId, suspectBotId))
+	}
+
+	err = expectOneRowAffected(result, "casting vote")
+	if err != nil {
+		return err
+	}
+
+	return bumpSqliteGameVersion(ctx, transaction, gameId)
+}
+
+// A game analysis is stored as one row per bot, so that it goes away along with the game's bots.
+func (s *SqliteStorage) CreateGameAnalysisUsingTransaction(ctx context.Context, analysis model.GameAnalysis, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(analysis.GameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if len(analysis.BotAnalyses) == 0 {
+		return errors.New("botAnalyses cannot be empty")
+	}
+
+	createdAt := sqliteTimestamp(time.Now())
+	for _, botAnalysis := range analysis.BotAnalyses {
+		if utilities.IsBlank(botAnalysis.BotId) {
+			return errors.New("botId cannot be blank")
+		}
+
+		if botAnalysis.HumannessScore < 0 || botAnalysis.HumannessScore > 100 {
+			return errors.New("humannessScore should be between 0 and 100")
+		}
+
+		result, err := transaction.ExecContext(
+			ctx,
+			`INSERT INTO "bot_analyses" ("id", "bot_id", "humanness_score", "tells", "created_at") VALUES (?, ?, ?, ?, ?)`,
+			s.IdGenerator.Generate(), botAnalysis.BotId, botAnalysis.HumannessScore, pq.Array(botAnalysis.Tells), createdAt,
+		)
+		if err != nil {
+			return utilities.WrapBadError(err, fmt.Sprintf("dbError while creating bot analysis: %s", botAnalysis.BotId))
+		}
+
+		err = expectOneRowAffected(result, "creating bot analysis")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteStorage) GetGameAnalysis(ctx context.Context, gameId string) (*model.GameAnalysis, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return nil, errors.New("gameId cannot be blank")
+	}
+
+	botAnalyses := []model.BotAnalysis{}
+	err := forEachSqliteRow(
+		ctx, s.readDb,
+		`SELECT ba.bot_id, ba.humanness_score, ba.tells
+		FROM "bot_analyses" AS ba
+		JOIN "bots" AS b ON ba.bot_id = b.id
+		WHERE b.game_id = ?
+		ORDER BY b.id ASC`,
+		[]any{gameId},
+		func(rows *sql.Rows) error {
+			var botAnalysis model.BotAnalysis
+			err := rows.Scan(&botAnalysis.BotId, &botAnalysis.HumannessScore, pq.Array(&botAnalysis.Tells))
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning rows")
+			}
+			if botAnalysis.Tells == nil {
+				botAnalysis.Tells = []string{}
+			}
+			botAnalyses = append(botAnalyses, botAnalysis)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(botAnalyses) == 0 {
+		return nil, errors.Errorf("game has not been analyzed yet: %s", gameId)
+	}
+
+	return &model.GameAnalysis{
+		GameId:      gameId,
+		BotAnalyses: botAnalyses,
+	}, nil
+}
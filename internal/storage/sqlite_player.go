@@ -0,0 +1,239 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+func (s *SqliteStorage) UserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(email) {
+		return nil, errors.New("cannot search by blank email")
+	}
+
+	userOptions := model.UserOptions{}
+	row := s.readDb.QueryRowContext(ctx, `SELECT id, email FROM "users" WHERE email = ?`, email)
+	err := row.Scan(&userOptions.Id, &userOptions.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Errorf("UserByEmail %s: no such user", email)
+		}
+		return nil, errors.Errorf("UserByEmail %s: %v", email, err)
+	}
+	return model.NewUser(userOptions)
+}
+
+func (s *SqliteStorage) CreatePlayer(ctx context.Context) (*model.Player, error) {
+	return s.createPlayer(ctx, nil)
+}
+
+func (s *SqliteStorage) CreatePlayerForUser(ctx context.Context, userId string) (*model.Player, error) {
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+	return s.createPlayer(ctx, &userId)
+}
+
+func (s *SqliteStorage) createPlayer(ctx context.Context, userId *string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	playerOpts := model.PlayerOptions{
+		Id:     s.IdGenerator.Generate(),
+		UserId: userId,
+	}
+
+	player, err := model.NewPlayer(playerOpts)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "failed to create player")
+	}
+
+	err = s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO "players" ("id", "user_id", "created_at") VALUES (?, ?, ?)`,
+			playerOpts.Id, playerOpts.UserId, sqliteTimestamp(time.Now()),
+		)
+		if err != nil {
+			return err
+		}
+		return expectOneRowAffected(result, "inserting player")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+func (s *SqliteStorage) GetPlayer(ctx context.Context, playerId string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getSqlitePlayer(ctx, s.readDb, playerId)
+}
+
+func (s *SqliteStorage) GetPlayerUsingTransaction(ctx context.Context, playerId string, transaction DatabaseTransaction) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return getSqlitePlayer(ctx, transaction, playerId)
+}
+
+func getSqlitePlayer(ctx context.Context, customDb customDbHandler, playerId string) (*model.Player, error) {
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	var nullableUserId sql.NullString
+	row := customDb.QueryRowContext(ctx, `SELECT user_id FROM "players" WHERE id = ? AND deleted_at IS NULL`, playerId)
+	err := row.Scan(&nullableUserId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.Errorf("getting player for %s: no such player", playerId)
+		}
+		return nil, errors.Errorf("getting player for %s: %v", playerId, err)
+	}
+
+	return model.NewPlayer(model.PlayerOptions{
+		Id:     playerId,
+		UserId: nullStringToPointer(nullableUserId),
+	})
+}
+
+func (s *SqliteStorage) UpdatePlayerWithUserIdUsingTransaction(ctx context.Context, playerId, userId string, transaction DatabaseTransaction) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	result, err := transaction.ExecContext(
+		ctx,
+		`UPDATE "players" SET "user_id" = ? WHERE id = ? AND deleted_at IS NULL`,
+		userId,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while attempting player update")
+	}
+
+	err = expectOneRowAffected(result, "updating player")
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPlayer(model.PlayerOptions{Id: playerId, UserId: &userId})
+}
+
+func (s *SqliteStorage) GetPlayerForUserOrNil(ctx context.Context, userId string) (*model.Player, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	var playerId string
+	row := s.readDb.QueryRowContext(
+		ctx,
+		`SELECT id FROM "players" WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at ASC LIMIT 1`,
+		userId,
+	)
+	err := row.Scan(&playerId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Errorf("getting player for user %s: %v", userId, err)
+	}
+
+	return model.NewPlayer(model.PlayerOptions{
+		Id:     playerId,
+		UserId: &userId,
+	})
+}
+
+// Practice games are recorded in their own table, so that they never count towards ranked results.
+func (s *SqliteStorage) RecordPracticeResultUsingTransaction(ctx context.Context, gameId, playerId string, won bool, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(playerId) {
+		return errors.New("playerId cannot be blank")
+	}
+
+	result, err := transaction.ExecContext(
+		ctx,
+		`INSERT INTO "practice_results" ("id", "game_id", "player_id", "won", "created_at") VALUES (?, ?, ?, ?, ?)`,
+		s.IdGenerator.Generate(), gameId, playerId, won, sqliteTimestamp(time.Now()),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while recording practice result: %s %s", gameId, playerId))
+	}
+	return expectOneRowAffected(result, "recording practice result")
+}
+
+func (s *SqliteStorage) GetMessageStats(ctx context.Context, since time.Time) ([]model.MessageStats, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	messageStatsList := []model.MessageStats{}
+	err := forEachSqliteRow(
+		ctx, s.readDb,
+		`SELECT b.type, m.type,
+		COALESCE(m.help_usage, ''), COALESCE(m.ai_model, ''), COALESCE(m.prompt_version, ''),
+		count(m.id), CAST(ROUND(COALESCE(avg(m.response_time_ms), 0)) AS INTEGER),
+		COALESCE(sum(m.prompt_tokens), 0), COALESCE(sum(m.completion_tokens), 0)
+		FROM "messages" AS m
+		INNER JOIN "bots" AS b ON b.id = m.source_bot_id
+		WHERE m.created_at > ?
+		GROUP BY b.type, m.type, m.help_usage, m.ai_model, m.prompt_version
+		ORDER BY b.type ASC, m.type ASC, 3 ASC, 4 ASC, 5 ASC`,
+		[]any{sqliteTimestamp(since)},
+		func(rows *sql.Rows) error {
+			var messageStats model.MessageStats
+			var averageResponseTimeMs int64
+			err := rows.Scan(
+				&messageStats.SourceBotType,
+				&messageStats.MessageType,
+				&messageStats.HelpUsage,
+				&messageStats.AiModel,
+				&messageStats.PromptVersion,
+				&messageStats.MessageCount,
+				&averageResponseTimeMs,
+				&messageStats.PromptTokens,
+				&messageStats.CompletionTokens,
+			)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning rows")
+			}
+
+			messageStats.AverageResponseTime = time.Duration(averageResponseTimeMs) * time.Millisecond
+			messageStatsList = append(messageStatsList, messageStats)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return messageStatsList, nil
+}
@@ -0,0 +1,491 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+// ErasePlayer erases the data of a player, like it does for Storage.
+func (s *SqliteStorage) ErasePlayer(ctx context.Context, playerId string) (*model.PlayerErasure, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	erasures, err := s.erasePlayers(
+		ctx,
+		`SELECT id, user_id FROM "players" WHERE id = ? AND deleted_at IS NULL`,
+		playerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(erasures) == 0 {
+		return nil, errors.Errorf("erasing player %s: no such player", playerId)
+	}
+	return erasures[0], nil
+}
+
+// EraseUserPlayers erases every player of the user, like ErasePlayer does. The user itself is managed outside this
+// service.
+func (s *SqliteStorage) EraseUserPlayers(ctx context.Context, userId string) ([]*model.PlayerErasure, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	return s.erasePlayers(
+		ctx,
+		`SELECT id, user_id FROM "players" WHERE user_id = ? AND deleted_at IS NULL ORDER BY id ASC`,
+		userId,
+	)
+}
+
+// erasePlayers erases the players that selectPlayersSql selects, in a single transaction.
+func (s *SqliteStorage) erasePlayers(ctx context.Context, selectPlayersSql string, arg string) ([]*model.PlayerErasure, error) {
+	erasures := []*model.PlayerErasure{}
+	err := s.inTransaction(ctx, func(tx DatabaseTransaction) error {
+		players := []*model.Player{}
+		err := forEachSqliteRow(ctx, tx, selectPlayersSql, []any{arg}, func(rows *sql.Rows) error {
+			var playerId string
+			var userId sql.NullString
+			err := rows.Scan(&playerId, &userId)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning rows")
+			}
+			player, err := model.NewPlayer(model.PlayerOptions{Id: playerId, UserId: nullStringToPointer(userId)})
+			if err != nil {
+				return utilities.WrapBadError(err, "failed to create player")
+			}
+			players = append(players, player)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, player := range players {
+			erasure, err := eraseSqlitePlayer(ctx, tx, s.IdGenerator.Generate(), player)
+			if err != nil {
+				return err
+			}
+			erasures = append(erasures, erasure)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasures, nil
+}
+
+// eraseSqlitePlayer is erasePlayer for SQLite.
+func eraseSqlitePlayer(ctx context.Context, customDb customDbHandler, erasureId string, player *model.Player) (*model.PlayerErasure, error) {
+	playerId := player.Id()
+	now := sqliteTimestamp(time.Now())
+
+	unfinishedGameIds, err := getIds(
+		ctx, customDb,
+		`SELECT id FROM "games"
+		WHERE id IN (SELECT game_id FROM "bots" WHERE player_id = ?) AND state <> 'FINISHED'
+		ORDER BY id ASC`,
+		playerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(unfinishedGameIds) > 0 {
+		return nil, errors.Wrapf(ErrPlayerInUnfinishedGame, "erasing player %s in game %s", playerId, unfinishedGameIds[0])
+	}
+
+	// The last question is a copy of a message, so it is erased along with the message it came from.
+	_, err = customDb.ExecContext(
+		ctx,
+		`UPDATE "games" AS g
+		SET
+			last_question = CASE WHEN EXISTS (
+				SELECT 1 FROM "messages" AS m
+				JOIN "bots" AS b ON m.source_bot_id = b.id
+				WHERE m.game_id = g.id AND b.player_id = ?1 AND m.text = g.last_question
+			) THEN ?2 ELSE g.last_question END,
+			conversation_summary = NULL,
+			summarized_message_count = 0,
+			updated_at = ?3,
+			version = g.version + 1
+		WHERE g.id IN (SELECT b.game_id FROM "bots" AS b WHERE b.player_id = ?1)`,
+		playerId, model.ERASED_MESSAGE_TEXT, now,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing games of player %s", playerId))
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`UPDATE "messages" SET text = ?
+		WHERE source_bot_id IN (SELECT id FROM "bots" WHERE player_id = ?)`,
+		model.ERASED_MESSAGE_TEXT, playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing messages of player %s", playerId))
+	}
+	messageCount, err := result.RowsAffected()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while erasing messages and changing db")
+	}
+
+	_, err = customDb.ExecContext(
+		ctx,
+		`UPDATE "bot_analyses" SET tells = ?
+		WHERE bot_id IN (SELECT id FROM "bots" WHERE player_id = ?)`,
+		pq.Array([]string{}), playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing bot analyses of player %s", playerId))
+	}
+
+	result, err = customDb.ExecContext(
+		ctx,
+		`UPDATE "bots" SET last_help_suggestions = NULL WHERE player_id = ?`,
+		playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing bots of player %s", playerId))
+	}
+	botCount, err := result.RowsAffected()
+	if err != nil {
+		return nil, utilities.WrapBadError(err, "dbError while erasing bots and changing db")
+	}
+
+	_, err = customDb.ExecContext(ctx, `DELETE FROM "practice_results" WHERE player_id = ?`, playerId)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing practice results of player %s", playerId))
+	}
+
+	result, err = customDb.ExecContext(
+		ctx,
+		`UPDATE "players" SET user_id = NULL, deleted_at = ? WHERE id = ?`,
+		now, playerId,
+	)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while erasing player %s", playerId))
+	}
+	err = expectOneRowAffected(result, "erasing player")
+	if err != nil {
+		return nil, err
+	}
+
+	erasure := model.PlayerErasure{
+		Id:           erasureId,
+		PlayerId:     playerId,
+		UserId:       player.UserId(),
+		BotCount:     botCount,
+		MessageCount: messageCount,
+	}
+	err = customDb.QueryRowContext(
+		ctx,
+		`INSERT INTO "player_erasures" ("id", "player_id", "user_id", "bot_count", "message_count", "created_at")
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING created_at`,
+		erasure.Id, erasure.PlayerId, erasure.UserId, erasure.BotCount, erasure.MessageCount, now,
+	).Scan(&erasure.CreatedAt)
+	if err != nil {
+		return nil, utilities.WrapBadError(err, fmt.Sprintf("dbError while recording erasure of player %s", playerId))
+	}
+
+	return &erasure, nil
+}
+
+func (s *SqliteStorage) ExportPlayerData(ctx context.Context, playerId string) (*model.DataExport, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(playerId) {
+		return nil, errors.New("playerId cannot be blank")
+	}
+
+	var players []model.PlayerData
+	err := s.inSnapshot(ctx, func(tx *sql.Tx) error {
+		var err error
+		players, err = getSqlitePlayersData(ctx, tx, []string{playerId})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(players) == 0 {
+		return nil, errors.Errorf("exporting player %s: no such player", playerId)
+	}
+
+	return &model.DataExport{
+		Players:  players,
+		Erasures: []model.PlayerErasure{},
+	}, nil
+}
+
+// ExportUserData exports the user along with every player of the user, and the erasures of the players it used to
+// have.
+func (s *SqliteStorage) ExportUserData(ctx context.Context, userId string) (*model.DataExport, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if utilities.IsBlank(userId) {
+		return nil, errors.New("userId cannot be blank")
+	}
+
+	var export *model.DataExport
+	err := s.inSnapshot(ctx, func(tx *sql.Tx) error {
+		user := model.ExportedUser{Id: userId}
+		var name, email, image sql.NullString
+		err := tx.QueryRowContext(ctx, `SELECT name, email, image FROM "users" WHERE id = ?`, userId).Scan(&name, &email, &image)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.Errorf("exporting user %s: no such user", userId)
+			}
+			return utilities.WrapBadError(err, fmt.Sprintf("dbError while exporting user %s", userId))
+		}
+		user.Name = nullStringToPointer(name)
+		user.Email = nullStringToPointer(email)
+		user.Image = nullStringToPointer(image)
+
+		playerIds, err := getIds(
+			ctx, tx,
+			`SELECT id FROM "players" WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at ASC, id ASC`,
+			userId,
+		)
+		if err != nil {
+			return err
+		}
+
+		players, err := getSqlitePlayersData(ctx, tx, playerIds)
+		if err != nil {
+			return err
+		}
+
+		erasures := []model.PlayerErasure{}
+		err = forEachSqliteRow(
+			ctx, tx,
+			`SELECT id, player_id, user_id, bot_count, message_count, created_at FROM "player_erasures"
+			WHERE user_id = ?
+			ORDER BY created_at ASC, id ASC`,
+			[]any{userId},
+			func(rows *sql.Rows) error {
+				var erasure model.PlayerErasure
+				var erasureUserId sql.NullString
+				err := rows.Scan(
+					&erasure.Id,
+					&erasure.PlayerId,
+					&erasureUserId,
+					&erasure.BotCount,
+					&erasure.MessageCount,
+					&erasure.CreatedAt,
+				)
+				if err != nil {
+					return utilities.WrapBadError(err, "failed while scanning player erasure rows")
+				}
+				erasure.UserId = nullStringToPointer(erasureUserId)
+				erasures = append(erasures, erasure)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		export = &model.DataExport{
+			User:     &user,
+			Players:  players,
+			Erasures: erasures,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// getSqlitePlayersData is getPlayersData for SQLite.
+func getSqlitePlayersData(ctx context.Context, customDb customDbHandler, playerIds []string) ([]model.PlayerData, error) {
+	players := []*model.PlayerData{}
+	playersById := map[string]*model.PlayerData{}
+	err := forEachSqliteRow(
+		ctx, customDb,
+		`SELECT id, user_id, created_at FROM "players"
+		WHERE id IN (SELECT value FROM json_each(?)) AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC`,
+		[]any{sqliteIds(playerIds)},
+		func(rows *sql.Rows) error {
+			player := model.PlayerData{
+				Bots:            []model.ExportedBot{},
+				Messages:        []model.ExportedMessage{},
+				Votes:           []model.ExportedVote{},
+				PracticeResults: []model.ExportedPracticeResult{},
+			}
+			var userId sql.NullString
+			err := rows.Scan(&player.PlayerId, &userId, &player.CreatedAt)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning rows")
+			}
+			player.UserId = nullStringToPointer(userId)
+			players = append(players, &player)
+			playersById[player.PlayerId] = &player
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(players) == 0 {
+		return []model.PlayerData{}, nil
+	}
+	exportedPlayerIds := sqliteIds(func() []string {
+		ids := []string{}
+		for _, player := range players {
+			ids = append(ids, player.PlayerId)
+		}
+		return ids
+	}())
+
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT b.player_id, b.id, b.game_id, b.name, b.help_count, b.last_help_suggestions, b.tag_count, b.eliminated,
+		ba.humanness_score, ba.tells
+		FROM "bots" AS b
+		LEFT JOIN "bot_analyses" AS ba ON ba.bot_id = b.id
+		WHERE b.player_id IN (SELECT value FROM json_each(?))
+		ORDER BY b.created_at ASC, b.id ASC`,
+		[]any{exportedPlayerIds},
+		func(rows *sql.Rows) error {
+			var playerId string
+			var bot model.ExportedBot
+			var humannessScore sql.NullInt64
+			var tells []string
+			err := rows.Scan(
+				&playerId,
+				&bot.Id,
+				&bot.GameId,
+				&bot.Name,
+				&bot.HelpCount,
+				pq.Array(&bot.LastHelpSuggestions),
+				&bot.TagCount,
+				&bot.Eliminated,
+				&humannessScore,
+				pq.Array(&tells),
+			)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning bot rows")
+			}
+			if humannessScore.Valid {
+				if tells == nil {
+					tells = []string{}
+				}
+				bot.Analysis = &model.BotAnalysis{
+					BotId:          bot.Id,
+					HumannessScore: humannessScore.Int64,
+					Tells:          tells,
+				}
+			}
+			playersById[playerId].Bots = append(playersById[playerId].Bots, bot)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT b.player_id, m.id, m.game_id, m.source_bot_id, m.target_bot_id, m.type, m.text, m.created_at
+		FROM "messages" AS m
+		JOIN "bots" AS b ON m.source_bot_id = b.id
+		WHERE b.player_id IN (SELECT value FROM json_each(?))
+		ORDER BY m.created_at ASC, m.id ASC`,
+		[]any{exportedPlayerIds},
+		func(rows *sql.Rows) error {
+			var playerId string
+			var message model.ExportedMessage
+			err := rows.Scan(
+				&playerId,
+				&message.Id,
+				&message.GameId,
+				&message.SourceBotId,
+				&message.TargetBotId,
+				&message.MessageType,
+				&message.Text,
+				&message.CreatedAt,
+			)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning message rows")
+			}
+			playersById[playerId].Messages = append(playersById[playerId].Messages, message)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT b.player_id, v.game_id, v.voter_bot_id, v.suspect_bot_id
+		FROM "votes" AS v
+		JOIN "bots" AS b ON v.voter_bot_id = b.id
+		WHERE b.player_id IN (SELECT value FROM json_each(?))
+		ORDER BY v.created_at ASC, v.id ASC`,
+		[]any{exportedPlayerIds},
+		func(rows *sql.Rows) error {
+			var playerId string
+			var vote model.ExportedVote
+			err := rows.Scan(&playerId, &vote.GameId, &vote.VoterBotId, &vote.SuspectBotId)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning vote rows")
+			}
+			playersById[playerId].Votes = append(playersById[playerId].Votes, vote)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachSqliteRow(
+		ctx, customDb,
+		`SELECT player_id, game_id, won FROM "practice_results"
+		WHERE player_id IN (SELECT value FROM json_each(?))
+		ORDER BY created_at ASC, id ASC`,
+		[]any{exportedPlayerIds},
+		func(rows *sql.Rows) error {
+			var playerId string
+			var practiceResult model.ExportedPracticeResult
+			err := rows.Scan(&playerId, &practiceResult.GameId, &practiceResult.Won)
+			if err != nil {
+				return utilities.WrapBadError(err, "failed while scanning practice result rows")
+			}
+			playersById[playerId].PracticeResults = append(playersById[playerId].PracticeResults, practiceResult)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	playersData := []model.PlayerData{}
+	for _, player := range players {
+		playersData = append(playersData, *player)
+	}
+	return playersData, nil
+}
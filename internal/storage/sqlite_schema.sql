@@ -0,0 +1,126 @@
+-- The SQLite schema mirrors database_schema_test.sql, for the tables that this service uses.
+-- Arrays are stored as text in the Postgres array format, so that pq.Array reads and writes them for both databases.
+-- Timestamps are stored as UTC text of a fixed width, so that they sort and compare as text. They are always set by
+-- SqliteStorage rather than defaulted here, since SQLite only has the current time to the millisecond.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "name" TEXT,
+    "email" TEXT UNIQUE,
+    "email_verified" TIMESTAMP,
+    "image" TEXT
+);
+
+CREATE TABLE IF NOT EXISTS "players" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "created_at" TIMESTAMP NOT NULL,
+    "user_id" TEXT REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "deleted_at" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "games" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "state" TEXT NOT NULL,
+    "current_turn_index" INTEGER NOT NULL,
+    "turn_order" TEXT,
+    "state_handled" BOOLEAN NOT NULL,
+    "state_handled_at" TIMESTAMP,
+    "state_claimed_at" TIMESTAMP,
+    "last_question" TEXT,
+    "last_question_target_bot_id" TEXT UNIQUE REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "created_at" TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP NOT NULL,
+    "state_total_time" INTEGER NOT NULL DEFAULT 0,
+    "result" TEXT,
+    "winning_bot_id" TEXT UNIQUE REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "public" BOOLEAN NOT NULL DEFAULT false,
+    "prompt_version" TEXT,
+    "conversation_summary" TEXT,
+    "summarized_message_count" INTEGER NOT NULL DEFAULT 0,
+    "ai_accusations" BOOLEAN NOT NULL DEFAULT false,
+    "ai_accusation_checked_at" TIMESTAMP,
+    "elimination" BOOLEAN NOT NULL DEFAULT false,
+    "voting_rounds" INTEGER NOT NULL DEFAULT 0,
+    "ai_votes" BOOLEAN NOT NULL DEFAULT false,
+    "mode" TEXT NOT NULL DEFAULT 'CLASSIC',
+    "decoy_bot_id" TEXT REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "version" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "bots" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "type" TEXT NOT NULL,
+    "player_id" TEXT REFERENCES "players"("id") ON DELETE RESTRICT ON UPDATE CASCADE,
+    "game_id" TEXT NOT NULL REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "created_at" TIMESTAMP NOT NULL,
+    "help_count" INTEGER NOT NULL DEFAULT 0,
+    "last_help_suggestions" TEXT,
+    "stated_facts" TEXT,
+    "tag_count" INTEGER NOT NULL DEFAULT 0,
+    "last_tagged_at" TIMESTAMP,
+    "eliminated" BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS "bots_game_id_idx" ON "bots"("game_id");
+
+CREATE INDEX IF NOT EXISTS "bots_player_id_idx" ON "bots"("player_id");
+
+CREATE TABLE IF NOT EXISTS "messages" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "game_id" TEXT NOT NULL REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "created_at" TIMESTAMP NOT NULL,
+    "text" TEXT NOT NULL,
+    "source_bot_id" TEXT NOT NULL REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "type" TEXT NOT NULL,
+    "target_bot_id" TEXT NOT NULL REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "response_time_ms" INTEGER,
+    "help_usage" TEXT,
+    "help_style" TEXT,
+    "ai_model" TEXT,
+    "prompt_version" TEXT,
+    "prompt_tokens" INTEGER,
+    "completion_tokens" INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS "messages_game_id_created_at_idx" ON "messages"("game_id", "created_at", "id");
+
+CREATE INDEX IF NOT EXISTS "messages_source_bot_id_idx" ON "messages"("source_bot_id");
+
+CREATE TABLE IF NOT EXISTS "votes" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "game_id" TEXT NOT NULL REFERENCES "games"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "voter_bot_id" TEXT NOT NULL UNIQUE REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "suspect_bot_id" TEXT NOT NULL REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "created_at" TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "votes_game_id_idx" ON "votes"("game_id");
+
+CREATE TABLE IF NOT EXISTS "practice_results" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "game_id" TEXT NOT NULL UNIQUE,
+    "player_id" TEXT NOT NULL REFERENCES "players"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "won" BOOLEAN NOT NULL,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "bot_analyses" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "bot_id" TEXT NOT NULL UNIQUE REFERENCES "bots"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    "humanness_score" INTEGER NOT NULL,
+    "tells" TEXT,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "player_erasures" (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "player_id" TEXT NOT NULL,
+    "user_id" TEXT,
+    "bot_count" INTEGER NOT NULL,
+    "message_count" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "player_erasures_user_id_idx" ON "player_erasures"("user_id");
@@ -0,0 +1,255 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+//go:embed sqlite_schema.sql
+var sqliteSchemaSql string
+
+const (
+	sqliteTimestampLayout = "2006-01-02 15:04:05.000000000"
+	// sqliteLockRetryInterval is how often a transaction that is waiting for the write lock tries to take it again.
+	sqliteLockRetryInterval = 2 * time.Millisecond
+)
+
+// SqliteStorage is a StorageAccessor backed by a SQLite database file, for running the service on a single machine
+// without Postgres. SQLite has a single write lock for the whole database. Every transaction takes it when it begins,
+// and holds it until it commits or rolls back, which serializes transactions the way locking a game does in Postgres,
+// only more coarsely. A statement outside a transaction runs in a transaction of its own.
+// Reads outside a transaction go through a separate read-only connection pool, which sees the last committed data
+// without waiting for the write lock.
+// The SQLite driver needs cgo, so SqliteStorage is only built with the sqlite build tag, and the service itself never
+// links it.
+type SqliteStorage struct {
+	db           *sql.DB
+	readDb       *sql.DB
+	IdGenerator  utilities.CuidGenerator
+	queryTimeout time.Duration
+}
+
+// Path is the database file, which is created along with its tables if it does not exist yet.
+// QueryTimeout is the deadline for the queries of each SqliteStorage method call. There is no deadline when it is 0.
+type SqliteStorageOptions struct {
+	Path         string
+	IdGenerator  utilities.CuidGenerator
+	QueryTimeout time.Duration
+}
+
+func NewSqliteStorage(opts SqliteStorageOptions) (*SqliteStorage, error) {
+	if utilities.IsBlank(opts.Path) {
+		return nil, errors.New("Needs a path for the database file")
+	}
+
+	if opts.IdGenerator == nil {
+		opts.IdGenerator = &utilities.RandomIdGenerator{}
+	}
+
+	if opts.QueryTimeout < 0 {
+		return nil, errors.New("QueryTimeout cannot be negative")
+	}
+
+	// SQLite's own busy timeout is turned off for writes, since it would keep waiting for the lock after the context is
+	// done. beginImmediate waits for it instead.
+	db, err := sql.Open("sqlite3", sqliteDsn(opts.Path, url.Values{
+		"_busy_timeout": {"0"},
+		"_foreign_keys": {"1"},
+		"_journal_mode": {"WAL"},
+		"_txlock":       {"immediate"},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(sqliteSchemaSql)
+	if err != nil {
+		db.Close()
+		return nil, utilities.WrapBadError(err, "failed to create sqlite schema")
+	}
+
+	readDb, err := sql.Open("sqlite3", sqliteDsn(opts.Path, url.Values{
+		"mode":          {"ro"},
+		"_busy_timeout": {"5000"},
+		"_txlock":       {"deferred"},
+	}))
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SqliteStorage{
+		db:           db,
+		readDb:       readDb,
+		IdGenerator:  opts.IdGenerator,
+		queryTimeout: opts.QueryTimeout,
+	}, nil
+}
+
+func sqliteDsn(path string, params url.Values) string {
+	return fmt.Sprintf("file:%s?%s", path, params.Encode())
+}
+
+func (s *SqliteStorage) Close() error {
+	readErr := s.readDb.Close()
+	err := s.db.Close()
+	if err != nil {
+		return err
+	}
+	return readErr
+}
+
+// queryContext is the context for the queries of a single SqliteStorage method call, like it is for Storage.
+func (s *SqliteStorage) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+// AddUser stands in for the users that are created outside this service.
+func (s *SqliteStorage) AddUser(opts model.UserOptions) error {
+	_, err := model.NewUser(opts)
+	if err != nil {
+		return err
+	}
+
+	return s.inTransaction(context.Background(), func(tx DatabaseTransaction) error {
+		_, err := tx.ExecContext(
+			context.Background(),
+			`INSERT INTO "users" ("id", "email") VALUES (?, ?)`,
+			opts.Id, opts.Email,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "adding user %s", opts.Id)
+		}
+		return nil
+	})
+}
+
+// The transaction is rolled back if ctx is done before it is committed. It is not bound to QueryTimeout, since it spans
+// several SqliteStorage method calls.
+func (s *SqliteStorage) BeginTransaction(ctx context.Context) (DatabaseTransaction, error) {
+	tx, err := s.beginImmediate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &databaseTransaction{
+		Tx: tx,
+	}, nil
+}
+
+// beginImmediate begins a transaction that holds the write lock, and waits for the lock until ctx is done.
+func (s *SqliteStorage) beginImmediate(ctx context.Context) (*sql.Tx, error) {
+	for {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if !isSqliteBusy(err) {
+			return tx, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sqliteLockRetryInterval):
+		}
+	}
+}
+
+func isSqliteBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrBusy
+}
+
+// inTransaction runs f in a transaction of its own, which is how a statement runs outside a transaction.
+func (s *SqliteStorage) inTransaction(ctx context.Context, f func(tx DatabaseTransaction) error) error {
+	tx, err := s.BeginTransaction(ctx)
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	err = f(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to commit db transaction")
+	}
+	return nil
+}
+
+// inSnapshot runs f in a read-only transaction, so that all of its queries see the same snapshot, like
+// getGameSnapshot does for Storage. It never waits for the write lock.
+func (s *SqliteStorage) inSnapshot(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := s.readDb.BeginTx(ctx, nil)
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	err = f(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return utilities.WrapBadError(err, "failed to commit db transaction")
+	}
+	return nil
+}
+
+// sqliteTimestamp is how every time is written to SQLite. Times are written in UTC and to the nanosecond, with a fixed
+// width, so that comparing and ordering them as text is the same as doing so as times.
+func sqliteTimestamp(t time.Time) string {
+	return t.UTC().Format(sqliteTimestampLayout)
+}
+
+func sqliteNullTimestamp(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: sqliteTimestamp(*t), Valid: true}
+}
+
+// sqliteIds is an argument that is matched against with `IN (SELECT value FROM json_each(?))`, which is how SQLite
+// takes the place of `= ANY($1)`.
+func sqliteIds(ids []string) string {
+	idsJson, _ := json.Marshal(ids)
+	return string(idsJson)
+}
+
+var sqlitePlaceholderRegexp = regexp.MustCompile(`\$(\d+)`)
+
+// sqlitePlaceholders turns the numbered placeholders of Postgres, $1, into those of SQLite, ?1. Other dollar signs are
+// left as they are.
+func sqlitePlaceholders(query string) string {
+	return sqlitePlaceholderRegexp.ReplaceAllString(query, "?${1}")
+}
+
+// sqliteArgs writes the times among args the way sqliteTimestamp does.
+func sqliteArgs(args []any) []any {
+	sqliteArgs := make([]any, 0, len(args))
+	for _, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			arg = sqliteTimestamp(t)
+		}
+		sqliteArgs = append(sqliteArgs, arg)
+	}
+	return sqliteArgs
+}
@@ -0,0 +1,34 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sqlitePlaceholders(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{
+			name:   "turns numbered placeholders into those of SQLite",
+			input:  `UPDATE "games" SET state = $2, version = version + 1 WHERE id = $1 AND version = $10`,
+			output: `UPDATE "games" SET state = ?2, version = version + 1 WHERE id = ?1 AND version = ?10`,
+		},
+		{
+			name:   "leaves other dollar signs as they are",
+			input:  `UPDATE "games" SET last_question = 'costs $ a lot', result = '$x' WHERE id = $1`,
+			output: `UPDATE "games" SET last_question = 'costs $ a lot', result = '$x' WHERE id = ?1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.output, sqlitePlaceholders(tt.input))
+		})
+	}
+}
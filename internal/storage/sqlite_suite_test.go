@@ -0,0 +1,33 @@
+//go:build sqlite
+
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	suiteStorages = append(suiteStorages, suiteStorageBackend{
+		name: "SqliteStorage",
+		newStorage: func(t *testing.T) suiteStorage {
+			s, err := NewSqliteStorage(SqliteStorageOptions{Path: filepath.Join(t.TempDir(), "storage.db")})
+			assert.NoError(t, err)
+			t.Cleanup(func() { s.Close() })
+			return testSqliteStorage{s}
+		},
+	})
+}
+
+type testSqliteStorage struct {
+	*SqliteStorage
+}
+
+func (s testSqliteStorage) BackdateGame(gameId string, createdAt time.Time) error {
+	_, err := s.db.ExecContext(context.Background(), `UPDATE "games" SET created_at = ? WHERE id = ?`, sqliteTimestamp(createdAt), gameId)
+	return err
+}
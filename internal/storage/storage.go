@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
@@ -11,19 +13,37 @@ type StorageAccessor interface {
 	UserRetriever
 	GameAccessor
 	PlayerAccessor
+	PlayerDataAccessor
 	MessageCreator
+	MessageStatsRetriever
 	BotAccessor
+	VoteCreator
+	PracticeResultRecorder
+	GameAnalysisAccessor
 	DatabaseTransactionProvider
 }
 
 type Storage struct {
-	db          *sql.DB
-	IdGenerator utilities.CuidGenerator
+	db *retryingDb
+	// replica is nil when there is no read replica.
+	replica      snapshotDb
+	IdGenerator  utilities.CuidGenerator
+	gameCache    *gameCache
+	queryTimeout time.Duration
 }
 
+// GameCacheSize is the number of games GetGame keeps in memory. Games are not cached when it is 0.
+// QueryTimeout is the deadline for the queries of each Storage method call. There is no deadline when it is 0.
+// RetryPolicy is how statements outside a transaction are retried when the connection to the database fails. They are
+// not retried when it is left empty.
+// ReadReplicaDb is an optional read-only replica of Db, which takes the reads that can afford to lag behind a little.
 type StorageOptions struct {
-	Db          *sql.DB
-	IdGenerator utilities.CuidGenerator
+	Db            *sql.DB
+	ReadReplicaDb *sql.DB
+	IdGenerator   utilities.CuidGenerator
+	GameCacheSize int
+	QueryTimeout  time.Duration
+	RetryPolicy   RetryPolicy
 }
 
 func NewDbStorage(opts StorageOptions) (*Storage, error) {
@@ -35,16 +55,73 @@ func NewDbStorage(opts StorageOptions) (*Storage, error) {
 		opts.IdGenerator = &utilities.RandomIdGenerator{}
 	}
 
-	return &Storage{
-		db:          opts.Db,
-		IdGenerator: opts.IdGenerator,
-	}, nil
+	if opts.GameCacheSize < 0 {
+		return nil, errors.New("GameCacheSize cannot be negative")
+	}
+
+	if opts.QueryTimeout < 0 {
+		return nil, errors.New("QueryTimeout cannot be negative")
+	}
+
+	if opts.RetryPolicy.MaxRetries < 0 {
+		return nil, errors.New("RetryPolicy.MaxRetries cannot be negative")
+	}
+
+	var cache *gameCache
+	if opts.GameCacheSize > 0 {
+		cache = newGameCache(opts.GameCacheSize)
+	}
+
+	s := &Storage{
+		db:           &retryingDb{DB: opts.Db, retryPolicy: opts.RetryPolicy},
+		IdGenerator:  opts.IdGenerator,
+		gameCache:    cache,
+		queryTimeout: opts.QueryTimeout,
+	}
+	if opts.ReadReplicaDb != nil {
+		s.replica = &retryingDb{DB: opts.ReadReplicaDb, retryPolicy: opts.RetryPolicy}
+	}
+	return s, nil
+}
+
+// readDb is where reads go when they can afford to lag behind the primary, which is the read replica if there is one.
+func (s *Storage) readDb() customDbHandler {
+	if s.replica == nil {
+		return s.db
+	}
+	return s.replica
+}
+
+// queryContext is the context for the queries of a single Storage method call. It is done when the caller's context is
+// done, or once QueryTimeout has passed.
+func (s *Storage) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+func (s *Storage) GameCacheStats() GameCacheStats {
+	if s.gameCache == nil {
+		return GameCacheStats{}
+	}
+	return s.gameCache.currentStats()
+}
+
+func (s *Storage) DbStats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// snapshotDb can start a read-only transaction, for reads that need every query to see the same snapshot.
+type snapshotDb interface {
+	customDbHandler
+	beginSnapshot(ctx context.Context) (DatabaseTransaction, error)
 }
 
 // A lot of queries/updates need to be part of a transaction but not all.
 // So we have the below interface that will allow the caller to either pass in *sql.DB or *sql.Tx depending on it's needs and our code will handle it without any issues.
 type customDbHandler interface {
-	Exec(query string, args ...any) (sql.Result, error)
-	Query(query string, args ...any) (*sql.Rows, error)
-	QueryRow(query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
@@ -4,8 +4,13 @@ type StorageAccessorMock struct {
 	UserRetriever
 	GameAccessor
 	PlayerAccessor
+	PlayerDataAccessor
 	MessageCreator
+	MessageStatsRetriever
 	BotAccessor
+	VoteCreator
+	PracticeResultRecorder
+	GameAnalysisAccessor
 	DatabaseTransactionProvider
 }
 
@@ -44,8 +49,32 @@ func WithBotAccessorMock(mock BotAccessor) StorageAccessorMockOption {
 	}
 }
 
+func WithVoteCreatorMock(mock VoteCreator) StorageAccessorMockOption {
+	return func(s *StorageAccessorMock) {
+		s.VoteCreator = mock
+	}
+}
+
+func WithPracticeResultRecorderMock(mock PracticeResultRecorder) StorageAccessorMockOption {
+	return func(s *StorageAccessorMock) {
+		s.PracticeResultRecorder = mock
+	}
+}
+
+func WithGameAnalysisAccessorMock(mock GameAnalysisAccessor) StorageAccessorMockOption {
+	return func(s *StorageAccessorMock) {
+		s.GameAnalysisAccessor = mock
+	}
+}
+
 func WithDatabaseTransactionProviderMock(mock DatabaseTransactionProvider) StorageAccessorMockOption {
 	return func(s *StorageAccessorMock) {
 		s.DatabaseTransactionProvider = mock
 	}
 }
+
+func WithPlayerDataAccessorMock(mock PlayerDataAccessor) StorageAccessorMockOption {
+	return func(s *StorageAccessorMock) {
+		s.PlayerDataAccessor = mock
+	}
+}
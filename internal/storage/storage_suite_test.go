@@ -0,0 +1,581 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+// The tests that use forEachStorage run against every StorageAccessor, so that the backends behave the same. Tests for
+// what only one backend does stay with that backend.
+
+type suiteStorage interface {
+	StorageAccessor
+	// AddUser stands in for the users that are created outside this service.
+	AddUser(opts model.UserOptions) error
+	// BackdateGame stands in for games that were created a while ago.
+	BackdateGame(gameId string, createdAt time.Time) error
+}
+
+type testDbStorage struct {
+	*Storage
+}
+
+func (s testDbStorage) AddUser(opts model.UserOptions) error {
+	_, err := testDb.Exec(`INSERT INTO public."users" ("id", "email") VALUES ($1, $2)`, opts.Id, opts.Email)
+	return err
+}
+
+func (s testDbStorage) BackdateGame(gameId string, createdAt time.Time) error {
+	_, err := testDb.Exec(`UPDATE public."games" SET created_at = $2 WHERE id = $1`, gameId, createdAt)
+	return err
+}
+
+type testMemoryStorage struct {
+	*MemoryStorage
+}
+
+func (m testMemoryStorage) BackdateGame(gameId string, createdAt time.Time) error {
+	return m.inTransaction(context.Background(), func(tx *memoryTransaction) error {
+		game, err := tx.gameForUpdate(gameId)
+		if err != nil {
+			return err
+		}
+		if game == nil {
+			return errors.Errorf("game not found: %s", gameId)
+		}
+		game.options.CreatedAt = createdAt
+		return nil
+	})
+}
+
+type suiteStorageBackend struct {
+	name       string
+	newStorage func(t *testing.T) suiteStorage
+}
+
+// suiteStorages are the backends that the suite runs against. SqliteStorage is added when building with the sqlite tag.
+var suiteStorages = []suiteStorageBackend{
+	{
+		name: "Storage",
+		newStorage: func(t *testing.T) suiteStorage {
+			requireTestDb(t)
+			s, err := NewDbStorage(StorageOptions{Db: testDb})
+			assert.NoError(t, err)
+			t.Cleanup(func() {
+				runSqlOnDb(t, testDb, []TestSqlStmts{
+					{Query: `TRUNCATE public."games", public."bots", public."messages", public."votes", public."practice_results",
+					public."bot_analyses", public."players", public."player_erasures", public."users" CASCADE`},
+				})
+			})
+			return testDbStorage{s}
+		},
+	},
+	{
+		name: "MemoryStorage",
+		newStorage: func(t *testing.T) suiteStorage {
+			return testMemoryStorage{NewMemoryStorage(MemoryStorageOptions{})}
+		},
+	},
+}
+
+func forEachStorage(t *testing.T, test func(t *testing.T, s suiteStorage)) {
+	for _, suiteStorage := range suiteStorages {
+		t.Run(suiteStorage.name, func(t *testing.T) {
+			test(t, suiteStorage.newStorage(t))
+		})
+	}
+}
+
+func createGameForSuite(t *testing.T, s suiteStorage) string {
+	gameId, err := s.CreateGame(context.Background(), GameCreateOptions{})
+	assert.NoError(t, err)
+	return gameId
+}
+
+// createBotForSuite creates a game, and returns it along with the first of its bots.
+func createBotForSuite(t *testing.T, s suiteStorage) (string, string) {
+	gameId := createGameForSuite(t, s)
+	game, err := s.GetGame(context.Background(), gameId)
+	assert.NoError(t, err)
+	return gameId, game.Bots()[0].Id()
+}
+
+// joinGameForSuite connects a new player to the bot, which makes the bot human.
+func joinGameForSuite(t *testing.T, s suiteStorage, botId string) string {
+	player, err := s.CreatePlayer(context.Background())
+	assert.NoError(t, err)
+	connectPlayerForSuite(t, s, botId, player.Id())
+	return player.Id()
+}
+
+func connectPlayerForSuite(t *testing.T, s suiteStorage, botId, playerId string) {
+	err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+		return s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, playerId, 0, tx)
+	})
+	assert.NoError(t, err)
+}
+
+func getBotForSuite(t *testing.T, s suiteStorage, gameId, botId string) *model.Bot {
+	game, err := s.GetGame(context.Background(), gameId)
+	assert.NoError(t, err)
+	return game.BotWithId(botId)
+}
+
+// inSuiteTransaction runs f in a transaction, which is committed unless f errors.
+func inSuiteTransaction(t *testing.T, s suiteStorage, f func(tx DatabaseTransaction) error) error {
+	tx, err := s.BeginTransaction(context.Background())
+	assert.NoError(t, err)
+	err = f(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	assert.NoError(t, tx.Commit())
+	return nil
+}
+
+func Test_Storage_Transactions(t *testing.T) {
+	t.Run("changes are only seen by others once committed", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			botId := game.Bots()[0].Id()
+			targetBotId := game.Bots()[1].Id()
+
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			err = s.CreateMessageUsingTransaction(context.Background(), botId, targetBotId, "what is your name?", "question", MessageMetadata{}, tx)
+			assert.NoError(t, err)
+
+			gameInTx, err := s.GetGameUsingTransaction(context.Background(), gameId, tx)
+			assert.NoError(t, err)
+			assert.Len(t, gameInTx.GetDetailedMessages(), 1)
+			gameOutsideTx, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Empty(t, gameOutsideTx.GetDetailedMessages())
+			assert.Equal(t, int64(0), gameOutsideTx.Version())
+
+			assert.NoError(t, tx.Commit())
+			committedGame, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Len(t, committedGame.GetDetailedMessages(), 1)
+			assert.Equal(t, int64(1), committedGame.Version())
+		})
+	})
+
+	t.Run("rolled back changes are discarded", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			state := "PLAYERS_JOINED"
+
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			err = s.UpdateGameStateUsingTransaction(context.Background(), gameId, GameUpdateOptions{State: &state}, tx)
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Rollback())
+			assert.Error(t, tx.Commit())
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.True(t, game.HasJustStarted())
+			assert.Equal(t, int64(0), game.Version())
+		})
+	})
+}
+
+func Test_Storage_RowLocking(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		gameId := createGameForSuite(t, s)
+		state := "PLAYERS_JOINED"
+
+		tx1, err := s.BeginTransaction(context.Background())
+		assert.NoError(t, err)
+		_, err = s.GetGameUsingTransaction(context.Background(), gameId, tx1)
+		assert.NoError(t, err)
+
+		gameSeenByTx2 := make(chan *model.Game)
+		go func() {
+			tx2, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			defer tx2.Rollback()
+			game, err := s.GetGameUsingTransaction(context.Background(), gameId, tx2)
+			assert.NoError(t, err)
+			gameSeenByTx2 <- game
+		}()
+
+		select {
+		case <-gameSeenByTx2:
+			assert.Fail(t, "the game should stay locked until the first transaction commits")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		err = s.UpdateGameStateUsingTransaction(context.Background(), gameId, GameUpdateOptions{State: &state}, tx1)
+		assert.NoError(t, err)
+		assert.NoError(t, tx1.Commit())
+
+		game := <-gameSeenByTx2
+		assert.True(t, game.IsInStatePlayersJoined())
+	})
+}
+
+func Test_Storage_ContextCancellation(t *testing.T) {
+	t.Run("stops waiting for a lock once the context is done", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+
+			tx1, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			defer tx1.Rollback()
+			_, err = s.GetGameUsingTransaction(context.Background(), gameId, tx1)
+			assert.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			startedAt := time.Now()
+			// SqliteStorage waits for the lock when the transaction begins, rather than when the game is read.
+			tx2, err := s.BeginTransaction(ctx)
+			if err == nil {
+				defer tx2.Rollback()
+				_, err = s.GetGameUsingTransaction(ctx, gameId, tx2)
+			}
+			assert.Error(t, err)
+			assert.Less(t, time.Since(startedAt), time.Second)
+
+			assert.NoError(t, tx1.Commit())
+		})
+	})
+
+	t.Run("rolls back the transaction once the context is done, which releases its locks", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			state := "PLAYERS_JOINED"
+
+			ctx, cancel := context.WithCancel(context.Background())
+			tx1, err := s.BeginTransaction(ctx)
+			assert.NoError(t, err)
+			err = s.UpdateGameStateUsingTransaction(ctx, gameId, GameUpdateOptions{State: &state}, tx1)
+			assert.NoError(t, err)
+			cancel()
+
+			tx2, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			defer tx2.Rollback()
+			game, err := s.GetGameUsingTransaction(context.Background(), gameId, tx2)
+			assert.NoError(t, err)
+			assert.False(t, game.IsInStatePlayersJoined())
+			assert.Error(t, tx1.Commit())
+		})
+	})
+}
+
+func Test_Storage_ConcurrentUpdatesWithRetries(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		gameId := createGameForSuite(t, s)
+
+		updaters := 10
+		var wg sync.WaitGroup
+		errs := make([]error, updaters)
+		for i := 0; i < updaters; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = RetryOnConcurrentModification(updaters, func() error {
+					game, err := s.GetGame(context.Background(), gameId)
+					if err != nil {
+						return err
+					}
+					summary := game.ConversationSummary()
+					summary.MessageCount++
+					version := game.Version()
+
+					tx, err := s.BeginTransaction(context.Background())
+					if err != nil {
+						return err
+					}
+					defer tx.Rollback()
+					err = s.UpdateGameStateUsingTransaction(context.Background(), gameId, GameUpdateOptions{ConversationSummary: &summary, ExpectedVersion: &version}, tx)
+					if err != nil {
+						return err
+					}
+					return tx.Commit()
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+		game, err := s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(updaters), game.ConversationSummary().MessageCount)
+		assert.Equal(t, int64(updaters), game.Version())
+	})
+}
+
+func Test_Storage_Games(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		player1, err := s.CreatePlayer(context.Background())
+		assert.NoError(t, err)
+		player2, err := s.CreatePlayer(context.Background())
+		assert.NoError(t, err)
+
+		gameId, err := s.CreateGame(context.Background(), GameCreateOptions{AiAccusations: true})
+		assert.NoError(t, err)
+		game, err := s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		assert.Equal(t, "CLASSIC", game.Mode())
+		assert.Len(t, game.Bots(), 5)
+
+		joinableGameIds, err := s.GetAutoJoinableGames(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, joinableGameIds, "games that no human has joined are not auto joinable")
+
+		join := func(playerId string, botId string) {
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			defer tx.Rollback()
+			game, err := s.GetGameUsingTransaction(context.Background(), gameId, tx)
+			assert.NoError(t, err)
+			err = s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, playerId, game.HelpBudget(), tx)
+			assert.NoError(t, err)
+			err = s.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(context.Background(), gameId, game.HumanPlayerCount(), tx)
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Commit())
+		}
+
+		join(player1.Id(), game.Bots()[0].Id())
+		joinableGameIds, err = s.GetAutoJoinableGames(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{gameId}, joinableGameIds)
+
+		join(player2.Id(), game.Bots()[1].Id())
+		unhandledGameIds, err := s.GetUnhandledGameIdsForState(context.Background(), "PLAYERS_JOINED")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{gameId}, unhandledGameIds)
+		playerGameIds, err := s.GetGames(context.Background(), player1.Id())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{gameId}, playerGameIds)
+
+		state := "WAITING_FOR_AI_QUESTION"
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state})
+		assert.NoError(t, err)
+		err = s.CreateMessage(context.Background(), game.Bots()[2].Id(), game.Bots()[0].Id(), "what is your name?", "question", MessageMetadata{AiModel: "gpt", ResponseTime: 1500 * time.Millisecond})
+		assert.NoError(t, err)
+		err = s.CreateMessage(context.Background(), game.Bots()[0].Id(), game.Bots()[0].Id(), "my name is bot1", "answer", MessageMetadata{ResponseTime: 3 * time.Second})
+		assert.NoError(t, err)
+		accusationGameIds, err := s.GetGameIdsForAiAccusation(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{gameId}, accusationGameIds)
+
+		messageStats, err := s.GetMessageStats(context.Background(), time.Now().Add(-1*time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, []model.MessageStats{
+			{SourceBotType: "AI", MessageType: "question", AiModel: "gpt", MessageCount: 1, AverageResponseTime: 1500 * time.Millisecond},
+			{SourceBotType: "HUMAN", MessageType: "answer", MessageCount: 1, AverageResponseTime: 3 * time.Second},
+		}, messageStats)
+
+		staleVersion := int64(0)
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedVersion: &staleVersion})
+		assert.True(t, IsConcurrentModification(err))
+		movedOnTurn := model.GameTurn{State: "PLAYERS_JOINED"}
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedTurn: &movedOnTurn})
+		assert.True(t, IsConcurrentModification(err))
+		currentTurn := model.GameTurn{State: state}
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedTurn: &currentTurn})
+		assert.NoError(t, err, "messages do not change the turn")
+
+		_, err = s.ErasePlayer(context.Background(), player1.Id())
+		assert.ErrorIs(t, err, ErrPlayerInUnfinishedGame, "player2 would be left waiting on player1")
+		game, err = s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		assert.Equal(t, "my name is bot1", game.GetDetailedMessages()[1].Text, "nothing is erased")
+		_, err = s.GetPlayer(context.Background(), player1.Id())
+		assert.NoError(t, err)
+
+		finished := "FINISHED"
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &finished})
+		assert.NoError(t, err)
+		erasure, err := s.ErasePlayer(context.Background(), player1.Id())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.BotCount)
+		assert.Equal(t, int64(1), erasure.MessageCount)
+		_, err = s.GetPlayer(context.Background(), player1.Id())
+		assert.EqualError(t, err, "getting player for "+player1.Id()+": no such player")
+		game, err = s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		assert.Len(t, game.Bots(), 5, "the bots of an erased player stay in the game")
+		assert.Equal(t, model.ERASED_MESSAGE_TEXT, game.GetDetailedMessages()[1].Text)
+		assert.Equal(t, "what is your name?", game.GetDetailedMessages()[0].Text)
+
+		err = s.DeleteGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		_, err = s.GetGame(context.Background(), gameId)
+		assert.EqualError(t, err, "game not found: "+gameId)
+		err = s.CreateMessage(context.Background(), game.Bots()[0].Id(), game.Bots()[0].Id(), "anyone there?", "answer", MessageMetadata{})
+		assert.Error(t, err)
+	})
+}
+
+func Test_Storage_Players(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		assert.NoError(t, s.AddUser(model.UserOptions{Id: "user_id1", Email: "user1@example.com"}))
+
+		user, err := s.UserByEmail(context.Background(), "user1@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "user_id1", user.GetId())
+
+		_, err = s.CreatePlayerForUser(context.Background(), "user_id2")
+		assert.Error(t, err, "players can only belong to users that exist")
+
+		player, err := s.CreatePlayer(context.Background())
+		assert.NoError(t, err)
+		noPlayer, err := s.GetPlayerForUserOrNil(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Nil(t, noPlayer)
+
+		tx, err := s.BeginTransaction(context.Background())
+		assert.NoError(t, err)
+		_, err = s.UpdatePlayerWithUserIdUsingTransaction(context.Background(), player.Id(), "user_id1", tx)
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Commit())
+
+		userPlayer, err := s.GetPlayerForUserOrNil(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, player.Id(), userPlayer.Id())
+	})
+}
+
+func Test_Storage_PlayerData(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		gameId := createGameForSuite(t, s)
+		assert.NoError(t, s.AddUser(model.UserOptions{Id: "user_id1", Email: "user1@example.com"}))
+		player, err := s.CreatePlayerForUser(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		game, err := s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		botId := game.Bots()[0].Id()
+		aiBotId := game.Bots()[1].Id()
+
+		tx, err := s.BeginTransaction(context.Background())
+		assert.NoError(t, err)
+		err = s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, player.Id(), 3, tx)
+		assert.NoError(t, err)
+		err = s.RecordPracticeResultUsingTransaction(context.Background(), gameId, player.Id(), true, tx)
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Commit())
+		err = s.CreateMessage(context.Background(), aiBotId, botId, "where are you from?", "question", MessageMetadata{})
+		assert.NoError(t, err)
+		err = s.CreateMessage(context.Background(), botId, botId, "a small town by the sea", "answer", MessageMetadata{})
+		assert.NoError(t, err)
+		question := "a small town by the sea"
+		summary := model.ConversationSummary{Text: "bot1 lives by the sea", MessageCount: 2}
+		state := "FINISHED"
+		err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, LastQuestion: &question, ConversationSummary: &summary})
+		assert.NoError(t, err)
+
+		export, err := s.ExportUserData(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Equal(t, "user1@example.com", *export.User.Email)
+		assert.Len(t, export.Players, 1)
+		assert.Equal(t, player.Id(), export.Players[0].PlayerId)
+		assert.Len(t, export.Players[0].Bots, 1)
+		assert.Equal(t, botId, export.Players[0].Bots[0].Id)
+		assert.Len(t, export.Players[0].Messages, 1, "only the messages the player sent are exported")
+		assert.Equal(t, "a small town by the sea", export.Players[0].Messages[0].Text)
+		assert.Equal(t, []model.ExportedPracticeResult{{GameId: gameId, Won: true}}, export.Players[0].PracticeResults)
+		assert.Empty(t, export.Erasures)
+
+		erasures, err := s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Len(t, erasures, 1)
+		assert.Equal(t, player.Id(), erasures[0].PlayerId)
+		assert.Equal(t, "user_id1", *erasures[0].UserId)
+		assert.Equal(t, int64(1), erasures[0].BotCount)
+		assert.Equal(t, int64(1), erasures[0].MessageCount)
+
+		game, err = s.GetGame(context.Background(), gameId)
+		assert.NoError(t, err)
+		assert.True(t, game.BotWithId(botId).IsHuman(), "the bot stays in the game as it was")
+		assert.Equal(t, "where are you from?", game.GetDetailedMessages()[0].Text)
+		assert.Equal(t, model.ERASED_MESSAGE_TEXT, game.GetDetailedMessages()[1].Text)
+		assert.Equal(t, model.ConversationSummary{}, game.ConversationSummary())
+		assert.Equal(t, model.ERASED_MESSAGE_TEXT, game.GameViewForPlayer(player.Id()).LastQuestion)
+
+		userPlayer, err := s.GetPlayerForUserOrNil(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Nil(t, userPlayer)
+		_, err = s.ExportPlayerData(context.Background(), player.Id())
+		assert.EqualError(t, err, "exporting player "+player.Id()+": no such player")
+		_, err = s.ErasePlayer(context.Background(), player.Id())
+		assert.EqualError(t, err, "erasing player "+player.Id()+": no such player")
+
+		export, err = s.ExportUserData(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Empty(t, export.Players)
+		assert.Len(t, export.Erasures, 1, "the erasure is kept on record")
+
+		otherPlayer, err := s.CreatePlayer(context.Background())
+		assert.NoError(t, err)
+		tx, err = s.BeginTransaction(context.Background())
+		assert.NoError(t, err)
+		defer tx.Rollback()
+		err = s.RecordPracticeResultUsingTransaction(context.Background(), gameId, otherPlayer.Id(), false, tx)
+		assert.NoError(t, err, "the practice result of the erased player is gone, so the game can have another")
+	})
+}
+
+func Test_Storage_ErasingMidGame(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s suiteStorage) {
+		assert.NoError(t, s.AddUser(model.UserOptions{Id: "user_id1", Email: "user1@example.com"}))
+		finishedGameId := createGameForSuite(t, s)
+		unfinishedGameId := createGameForSuite(t, s)
+		players := []*model.Player{}
+		for _, gameId := range []string{finishedGameId, unfinishedGameId} {
+			player, err := s.CreatePlayerForUser(context.Background(), "user_id1")
+			assert.NoError(t, err)
+			players = append(players, player)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			botId := game.Bots()[0].Id()
+			tx, err := s.BeginTransaction(context.Background())
+			assert.NoError(t, err)
+			err = s.UpdateBotWithPlayerIdUsingTransaction(context.Background(), botId, player.Id(), 3, tx)
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Commit())
+			err = s.CreateMessage(context.Background(), botId, botId, "a small town by the sea", "answer", MessageMetadata{})
+			assert.NoError(t, err)
+		}
+		finished := "FINISHED"
+		err := s.UpdateGameState(context.Background(), finishedGameId, GameUpdateOptions{State: &finished})
+		assert.NoError(t, err)
+
+		_, err = s.ErasePlayer(context.Background(), players[1].Id())
+		assert.ErrorIs(t, err, ErrPlayerInUnfinishedGame)
+		_, err = s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.ErrorIs(t, err, ErrPlayerInUnfinishedGame)
+
+		export, err := s.ExportUserData(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Len(t, export.Players, 2, "no player of the user is erased")
+		assert.Empty(t, export.Erasures)
+		for _, gameId := range []string{finishedGameId, unfinishedGameId} {
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, "a small town by the sea", game.GetDetailedMessages()[0].Text)
+		}
+
+		err = s.UpdateGameState(context.Background(), unfinishedGameId, GameUpdateOptions{State: &finished})
+		assert.NoError(t, err)
+		erasures, err := s.EraseUserPlayers(context.Background(), "user_id1")
+		assert.NoError(t, err)
+		assert.Len(t, erasures, 2, "the players can be erased once their games have finished")
+	})
+}
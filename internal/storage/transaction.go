@@ -1,9 +1,12 @@
 package storage
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 type DatabaseTransactionProvider interface {
-	BeginTransaction() (DatabaseTransaction, error)
+	BeginTransaction(ctx context.Context) (DatabaseTransaction, error)
 }
 
 type databaseTransaction struct {
@@ -16,8 +19,10 @@ type DatabaseTransaction interface {
 	Rollback() error
 }
 
-func (s *Storage) BeginTransaction() (DatabaseTransaction, error) {
-	tx, err := s.db.Begin()
+// The transaction is rolled back if ctx is done before it is committed. It is not bound to QueryTimeout, since it spans
+// several Storage method calls.
+func (s *Storage) BeginTransaction(ctx context.Context) (DatabaseTransaction, error) {
+	tx, err := s.db.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 )
 
@@ -24,7 +25,7 @@ type DatabaseTransactionProviderMock struct {
 	Transaction *DatabaseTransactionMock
 }
 
-func (s *DatabaseTransactionProviderMock) BeginTransaction() (DatabaseTransaction, error) {
+func (s *DatabaseTransactionProviderMock) BeginTransaction(ctx context.Context) (DatabaseTransaction, error) {
 	if s.Transaction == nil {
 		return nil, errors.New("unable to begin a db transaction")
 	}
@@ -1,12 +1,14 @@
 package storage
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
 
@@ -21,26 +23,54 @@ type GameUpdateOptions struct {
 	StateTotalTime          *int64
 	Result                  *string
 	WinningBotId            *string
+	ConversationSummary     *model.ConversationSummary
+	AiAccusationCheckedAt   *time.Time
+	DecoyBotId              *string
+	// When set, the update only goes through if the game is still at this version. Otherwise it fails with
+	// ErrConcurrentModification.
+	ExpectedVersion *int64
+	// When set, the update only goes through if it is still the same turn in the game, however else the game has changed
+	// in the meantime. Otherwise it fails with ErrConcurrentModification.
+	ExpectedTurn *model.GameTurn
 }
 
-func (s *Storage) UpdateGameState(gameId string, updateOpts GameUpdateOptions) error {
-	return updateGameState(s.db, gameId, updateOpts)
+func (s *Storage) UpdateGameState(ctx context.Context, gameId string, updateOpts GameUpdateOptions) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateGameState(ctx, s.db, gameId, updateOpts)
 }
 
-func (s *Storage) UpdateGameStateUsingTransaction(gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
-	return updateGameState(transaction, gameId, updateOpts)
+func (s *Storage) UpdateGameStateUsingTransaction(ctx context.Context, gameId string, updateOpts GameUpdateOptions, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateGameState(ctx, transaction, gameId, updateOpts)
 }
 
-func updateGameState(customDb customDbHandler, gameId string, updateOpts GameUpdateOptions) error {
+func updateGameState(ctx context.Context, customDb customDbHandler, gameId string, updateOpts GameUpdateOptions) error {
 	updateSqlsPart, args := sqlAndArgsForUpdate(updateOpts)
 	if len(args) == 0 {
 		return errors.New("no update options provided")
 	}
 	updateSqlsPart, args = autoAddUpdateTimeStamp(updateSqlsPart, args)
+	updateSqlsPart = append(updateSqlsPart, "\"version\" = \"version\" + 1")
 	updateSqlSetPart := strings.Join(updateSqlsPart, ", ")
 	argsWithGameId := append(args, gameId)
 	updateSql := fmt.Sprintf("UPDATE public.\"games\" SET %s WHERE \"id\" = $%d", updateSqlSetPart, len(args)+1)
-	result, err := customDb.Exec(updateSql, argsWithGameId...)
+	if updateOpts.ExpectedVersion != nil {
+		argsWithGameId = append(argsWithGameId, *updateOpts.ExpectedVersion)
+		updateSql = fmt.Sprintf("%s AND \"version\" = $%d", updateSql, len(argsWithGameId))
+	}
+	if updateOpts.ExpectedTurn != nil {
+		argsWithGameId = append(argsWithGameId, updateOpts.ExpectedTurn.State, updateOpts.ExpectedTurn.CurrentTurnIndex, updateOpts.ExpectedTurn.LastQuestionTargetBotId)
+		index := len(argsWithGameId) - 2
+		updateSql = fmt.Sprintf(
+			"%s AND \"state\" = $%d AND \"current_turn_index\" = $%d AND COALESCE(\"last_question_target_bot_id\", '') = $%d",
+			updateSql, index, index+1, index+2,
+		)
+	}
+	result, err := customDb.ExecContext(ctx, updateSql, argsWithGameId...)
 
 	if err != nil {
 		return err
@@ -50,14 +80,23 @@ func updateGameState(customDb customDbHandler, gameId string, updateOpts GameUpd
 		return utilities.WrapBadError(err, "dbError while updating game and changing db")
 	}
 
+	if rowsAffected == 0 && updateOpts.ExpectedVersion != nil {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected version: %d", gameId, *updateOpts.ExpectedVersion)
+	}
+	if rowsAffected == 0 && updateOpts.ExpectedTurn != nil {
+		return errors.Wrapf(ErrConcurrentModification, "gameId: %s, expected turn: %+v", gameId, *updateOpts.ExpectedTurn)
+	}
 	if rowsAffected != 1 {
 		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when updating game in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
 	}
 	return nil
 }
 
-func (s *Storage) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(gameId string, transaction DatabaseTransaction) error {
-	return updateGameStateIfEnoughPlayersHaveJoined(transaction, gameId)
+func (s *Storage) UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(ctx context.Context, gameId string, humanPlayerCount int64, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	return updateGameStateIfEnoughPlayersHaveJoined(ctx, transaction, gameId, humanPlayerCount)
 }
 
 func sqlAndArgsForUpdate(updateOpts GameUpdateOptions) ([]string, []interface{}) {
@@ -82,7 +121,9 @@ func sqlAndArgsForUpdate(updateOpts GameUpdateOptions) ([]string, []interface{})
 		index++
 	}
 	if updateOpts.StateHandled != nil {
+		// Setting StateHandled ends any claim on the game.
 		setSqls = append(setSqls, fmt.Sprintf("\"state_handled\" = $%d", index))
+		setSqls = append(setSqls, "\"state_claimed_at\" = NULL")
 		args = append(args, *updateOpts.StateHandled)
 		index++
 	}
@@ -116,6 +157,24 @@ func sqlAndArgsForUpdate(updateOpts GameUpdateOptions) ([]string, []interface{})
 		args = append(args, *updateOpts.WinningBotId)
 		index++
 	}
+	if updateOpts.ConversationSummary != nil {
+		setSqls = append(setSqls, fmt.Sprintf("\"conversation_summary\" = $%d", index))
+		args = append(args, updateOpts.ConversationSummary.Text)
+		index++
+		setSqls = append(setSqls, fmt.Sprintf("\"summarized_message_count\" = $%d", index))
+		args = append(args, updateOpts.ConversationSummary.MessageCount)
+		index++
+	}
+	if updateOpts.AiAccusationCheckedAt != nil {
+		setSqls = append(setSqls, fmt.Sprintf("\"ai_accusation_checked_at\" = $%d", index))
+		args = append(args, *updateOpts.AiAccusationCheckedAt)
+		index++
+	}
+	if updateOpts.DecoyBotId != nil {
+		setSqls = append(setSqls, fmt.Sprintf("\"decoy_bot_id\" = $%d", index))
+		args = append(args, *updateOpts.DecoyBotId)
+		index++
+	}
 
 	return setSqls, args
 }
@@ -128,12 +187,14 @@ func autoAddUpdateTimeStamp(setSqls []string, args []interface{}) ([]string, []i
 	return setSqls, args
 }
 
-func updateGameStateIfEnoughPlayersHaveJoined(customDb customDbHandler, gameId string) error {
+// The game moves on once exactly humanPlayerCount humans have joined, which depends on the mode of the game.
+func updateGameStateIfEnoughPlayersHaveJoined(ctx context.Context, customDb customDbHandler, gameId string, humanPlayerCount int64) error {
 	if utilities.IsBlank(gameId) {
 		return errors.New("gameId cannot be blank")
 	}
 
-	result, err := customDb.Exec(
+	result, err := customDb.ExecContext(
+		ctx,
 		`WITH selected_games AS (
 			SELECT g.id, count(b.id) AS human_bot_count
 			FROM public."games" AS g
@@ -143,11 +204,11 @@ func updateGameStateIfEnoughPlayersHaveJoined(customDb customDbHandler, gameId s
 			GROUP BY g.id
 		)
 		UPDATE public."games" AS games
-		SET state = 'PLAYERS_JOINED', updated_at = $2
+		SET state = 'PLAYERS_JOINED', updated_at = $2, version = version + 1
 		FROM selected_games
 		WHERE games.id = selected_games.id
-		AND human_bot_count = 2`,
-		gameId, time.Now(),
+		AND human_bot_count = $3`,
+		gameId, time.Now(), humanPlayerCount,
 	)
 	if err != nil {
 		return utilities.WrapBadError(err, fmt.Sprintf("dbError while updating game state: %s", gameId))
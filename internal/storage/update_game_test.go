@@ -1,617 +1,319 @@
 package storage
 
 import (
-	"database/sql"
-	"math/rand"
+	"context"
 	"testing"
 	"time"
 
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
 )
 
+func botIdsForSuite(game *model.Game) []string {
+	botIds := []string{}
+	for _, bot := range game.Bots() {
+		botIds = append(botIds, bot.Id())
+	}
+	return botIds
+}
+
 func Test_Game_UpdateGameState(t *testing.T) {
 	state := "PLAYERS_JOINED"
-	currentTurnIndex := int64(4)
-	turnOrder := []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"}
 	stateHandled := true
 	stateHandledAt := time.Now()
 	lastQuestion := "what is the question?"
-	lastQuestionTargetBotId := "bot_id2"
 	stateTotalTime := int64(60)
-	result := "game has this result"
-	winningBotId := "bot_id2"
-	tests := []struct {
-		name  string
-		input struct {
-			gameId     string
-			updateOpts GameUpdateOptions
-		}
-		dbUpdateCheck   func(*sql.DB) bool
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name: "updates game with all given details",
-			input: struct {
-				gameId     string
-				updateOpts GameUpdateOptions
-			}{
-				gameId: "game_id1",
-				updateOpts: GameUpdateOptions{
-					State:                   &state,
-					CurrentTurnIndex:        &currentTurnIndex,
-					TurnOrder:               turnOrder,
-					StateHandled:            &stateHandled,
-					StateHandledAt:          &stateHandledAt,
-					LastQuestion:            &lastQuestion,
-					LastQuestionTargetBotId: &lastQuestionTargetBotId,
-					StateTotalTime:          &stateTotalTime,
-					Result:                  &result,
-					WinningBotId:            &winningBotId,
-				},
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState                   string
-					scanCurrentTurnIndex        int64
-					scanTurnOrder               []string
-					scanStateHandled            bool
-					scanStateHandledAt          sql.NullTime
-					scanLastQuestion            sql.NullString
-					scanLastQuestionTargetBotId sql.NullString
-					scanStateTotalTime          int64
-					updatedAt                   time.Time
-					scanResult                  sql.NullString
-					scanWinningBotId            sql.NullString
-				)
-				row := db.QueryRow(
-					`SELECT g.state, g.current_turn_index, g.turn_order, g.state_handled, g.state_handled_at,
-					g.last_question, g.last_question_target_bot_id, g.state_total_time, g.updated_at,
-					g.result, g.winning_bot_id
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &scanCurrentTurnIndex, pq.Array(&scanTurnOrder), &scanStateHandled, &scanStateHandledAt, &scanLastQuestion, &scanLastQuestionTargetBotId, &scanStateTotalTime, &updatedAt, &scanResult, &scanWinningBotId)
-				assert.NoError(t, err)
-				assert.Equal(t, state, scanState)
-				assert.Equal(t, currentTurnIndex, scanCurrentTurnIndex)
-				assert.Equal(t, turnOrder, scanTurnOrder)
-				assert.Equal(t, stateHandled, scanStateHandled)
-				assert.True(t, scanStateHandledAt.Valid)
-				model.AssertTimeAlmostEqual(t, scanStateHandledAt.Time, stateHandledAt, 1*time.Second)
-				assert.True(t, scanLastQuestion.Valid)
-				assert.Equal(t, lastQuestion, scanLastQuestion.String)
-				assert.True(t, scanLastQuestionTargetBotId.Valid)
-				assert.Equal(t, lastQuestionTargetBotId, scanLastQuestionTargetBotId.String)
-				assert.Equal(t, stateTotalTime, scanStateTotalTime)
-				assert.True(t, scanResult.Valid)
-				assert.Equal(t, scanResult.String, result)
-				assert.True(t, scanWinningBotId.Valid)
-				assert.Equal(t, scanWinningBotId.String, winningBotId)
-				model.AssertTimeAlmostEqual(t, time.Now(), updatedAt, 1*time.Second)
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1, $2
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-		{
-			name: "updates game partially with given details",
-			input: struct {
-				gameId     string
-				updateOpts GameUpdateOptions
-			}{
-				gameId: "game_id1",
-				updateOpts: GameUpdateOptions{
-					State:                   &state,
-					StateHandled:            &stateHandled,
-					StateHandledAt:          &stateHandledAt,
-					LastQuestion:            &lastQuestion,
-					LastQuestionTargetBotId: &lastQuestionTargetBotId,
-					StateTotalTime:          &stateTotalTime,
-				},
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState                   string
-					scanCurrentTurnIndex        int64
-					scanTurnOrder               []string
-					scanStateHandled            bool
-					scanStateHandledAt          sql.NullTime
-					scanLastQuestion            sql.NullString
-					scanLastQuestionTargetBotId sql.NullString
-					scanStateTotalTime          int64
-					updatedAt                   time.Time
-				)
-				unchangedCurrentTurnIndex := int64(0)
-				unchangedTurnOrder := []string{"bot_id1"}
-				row := db.QueryRow(
-					`SELECT g.state, g.current_turn_index, g.turn_order, g.state_handled, g.state_handled_at,
-					g.last_question, g.last_question_target_bot_id, g.state_total_time, g.updated_at
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &scanCurrentTurnIndex, pq.Array(&scanTurnOrder), &scanStateHandled, &scanStateHandledAt, &scanLastQuestion, &scanLastQuestionTargetBotId, &scanStateTotalTime, &updatedAt)
-				assert.NoError(t, err)
-				assert.Equal(t, state, scanState)
-				assert.Equal(t, unchangedCurrentTurnIndex, scanCurrentTurnIndex)
-				assert.Equal(t, unchangedTurnOrder, scanTurnOrder)
-				assert.Equal(t, stateHandled, scanStateHandled)
-				assert.True(t, scanStateHandledAt.Valid)
-				model.AssertTimeAlmostEqual(t, scanStateHandledAt.Time, stateHandledAt, 1*time.Second)
-				assert.True(t, scanLastQuestion.Valid)
-				assert.Equal(t, lastQuestion, scanLastQuestion.String)
-				assert.True(t, scanLastQuestionTargetBotId.Valid)
-				assert.Equal(t, lastQuestionTargetBotId, scanLastQuestionTargetBotId.String)
-				assert.Equal(t, stateTotalTime, scanStateTotalTime)
-				model.AssertTimeAlmostEqual(t, time.Now(), updatedAt, 1*time.Second)
-
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1, $2
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-		{
-			name: "errors if no update options provided",
-			input: struct {
-				gameId     string
-				updateOpts GameUpdateOptions
-			}{
-				gameId:     "game_id1",
-				updateOpts: GameUpdateOptions{},
-			},
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "no update options provided",
-		},
-	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
-
-			rand.Seed(0)
-			err := s.UpdateGameState(tt.input.gameId, tt.input.updateOpts)
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
+	t.Run("updates game with all given details", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId, err := s.CreateGame(context.Background(), GameCreateOptions{Mode: "PRACTICE"})
+			assert.NoError(t, err)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			botIds := botIdsForSuite(game)
+			turnOrder := []string{botIds[4], botIds[3], botIds[2], botIds[1], botIds[0]}
+			currentTurnIndex := int64(4)
+			result := "game has this result"
+			conversationSummary := model.ConversationSummary{Text: "bot1 asked about food.", MessageCount: 4}
+			aiAccusationCheckedAt := time.Now().Add(-5 * time.Second)
+
+			err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{
+				State:                   &state,
+				CurrentTurnIndex:        &currentTurnIndex,
+				TurnOrder:               turnOrder,
+				StateHandled:            &stateHandled,
+				StateHandledAt:          &stateHandledAt,
+				LastQuestion:            &lastQuestion,
+				LastQuestionTargetBotId: &botIds[1],
+				StateTotalTime:          &stateTotalTime,
+				Result:                  &result,
+				WinningBotId:            &botIds[1],
+				ConversationSummary:     &conversationSummary,
+				AiAccusationCheckedAt:   &aiAccusationCheckedAt,
+				DecoyBotId:              &botIds[2],
+			})
+			assert.NoError(t, err)
+
+			expected, err := model.NewGame(model.GameOptions{
+				Id:                      gameId,
+				State:                   state,
+				CurrentTurnIndex:        currentTurnIndex,
+				TurnOrder:               turnOrder,
+				StateHandled:            stateHandled,
+				StateHandledAt:          &stateHandledAt,
+				StateTotalTime:          stateTotalTime,
+				LastQuestion:            lastQuestion,
+				LastQuestionTargetBotId: botIds[1],
+				CreatedAt:               time.Now(),
+				UpdatedAt:               time.Now(),
+				Bots:                    game.Bots(),
+				Result:                  result,
+				WinningBotId:            botIds[1],
+				ConversationSummary:     conversationSummary.Text,
+				SummarizedMessageCount:  conversationSummary.MessageCount,
+				Mode:                    "PRACTICE",
+				DecoyBotId:              botIds[2],
+				Version:                 1,
+			})
+			assert.NoError(t, err)
+			game, err = s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			model.AssertEqualGame(t, expected, game)
+			assert.True(t, game.IsDecoy(botIds[2]))
 		})
-	}
+	})
+
+	t.Run("updates game partially with given details", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			botIds := botIdsForSuite(game)
+			turnOrder := []string{botIds[1], botIds[0]}
+			currentTurnIndex := int64(1)
+			updateGameForSuite(t, s, gameId, GameUpdateOptions{CurrentTurnIndex: &currentTurnIndex, TurnOrder: turnOrder})
+
+			err = s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{
+				State:                   &state,
+				StateHandled:            &stateHandled,
+				StateHandledAt:          &stateHandledAt,
+				LastQuestion:            &lastQuestion,
+				LastQuestionTargetBotId: &botIds[1],
+				StateTotalTime:          &stateTotalTime,
+			})
+			assert.NoError(t, err)
+
+			expected, err := model.NewGame(model.GameOptions{
+				Id:                      gameId,
+				State:                   state,
+				CurrentTurnIndex:        currentTurnIndex,
+				TurnOrder:               turnOrder,
+				StateHandled:            stateHandled,
+				StateHandledAt:          &stateHandledAt,
+				StateTotalTime:          stateTotalTime,
+				LastQuestion:            lastQuestion,
+				LastQuestionTargetBotId: botIds[1],
+				CreatedAt:               time.Now(),
+				UpdatedAt:               time.Now(),
+				Bots:                    game.Bots(),
+				Version:                 2,
+			})
+			assert.NoError(t, err)
+			game, err = s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			model.AssertEqualGame(t, expected, game)
+		})
+	})
+
+	t.Run("updates game if it is still at the expected version", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			currentVersion := int64(0)
+
+			err := s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedVersion: &currentVersion})
+			assert.NoError(t, err)
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, state, game.State())
+			assert.Equal(t, currentVersion+1, game.Version())
+		})
+	})
+
+	t.Run("errors if game has been modified since the expected version", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			summary := model.ConversationSummary{Text: "bot1 said hello.", MessageCount: 1}
+			updateGameForSuite(t, s, gameId, GameUpdateOptions{ConversationSummary: &summary})
+			staleVersion := int64(0)
+
+			err := s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedVersion: &staleVersion})
+			assert.EqualError(t, err, "gameId: "+gameId+", expected version: 0: game was modified concurrently")
+			assert.True(t, IsConcurrentModification(err))
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, "STARTED", game.State())
+			assert.Equal(t, int64(1), game.Version())
+		})
+	})
+
+	t.Run("updates game if it is still the expected turn, even though its version has changed", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			summary := model.ConversationSummary{Text: "bot1 said hello.", MessageCount: 1}
+			updateGameForSuite(t, s, gameId, GameUpdateOptions{ConversationSummary: &summary})
+			currentTurn := model.GameTurn{State: "STARTED", CurrentTurnIndex: 0}
+
+			err := s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedTurn: &currentTurn})
+			assert.NoError(t, err)
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, state, game.State())
+		})
+	})
+
+	t.Run("errors if the turn of the game has moved on", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			movedOnTurn := model.GameTurn{State: "STARTED", CurrentTurnIndex: 1}
+
+			err := s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{State: &state, ExpectedTurn: &movedOnTurn})
+			assert.EqualError(t, err, "gameId: "+gameId+", expected turn: {State:STARTED CurrentTurnIndex:1 LastQuestionTargetBotId:}: game was modified concurrently")
+			assert.True(t, IsConcurrentModification(err))
+
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			assert.Equal(t, "STARTED", game.State())
+		})
+	})
+
+	t.Run("errors if no update options provided", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			err := s.UpdateGameState(context.Background(), gameId, GameUpdateOptions{})
+			assert.EqualError(t, err, "no update options provided")
+		})
+	})
 }
 
 func Test_Game_UpdateGameStateUsingTrasaction(t *testing.T) {
-	state := "PLAYERS_JOINED"
-	currentTurnIndex := int64(4)
-	turnOrder := []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"}
-	stateHandled := true
-	stateHandledAt := time.Now()
-	lastQuestion := "what is the question?"
-	lastQuestionTargetBotId := "bot_id2"
-	stateTotalTime := int64(60)
-	tests := []struct {
-		name  string
-		input struct {
-			gameId     string
-			updateOpts GameUpdateOptions
-		}
-		dbUpdateCheck   func(*sql.DB) bool
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
-	}{
-		{
-			name: "updates game with all given details",
-			input: struct {
-				gameId     string
-				updateOpts GameUpdateOptions
-			}{
-				gameId: "game_id1",
-				updateOpts: GameUpdateOptions{
+	t.Run("updates game with the given details once committed", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			botIds := botIdsForSuite(game)
+			state := "PLAYERS_JOINED"
+			currentTurnIndex := int64(4)
+			turnOrder := []string{botIds[4], botIds[3], botIds[2], botIds[1], botIds[0]}
+			stateHandled := true
+			stateHandledAt := time.Now()
+			lastQuestion := "what is the question?"
+			stateTotalTime := int64(60)
+
+			err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateGameStateUsingTransaction(context.Background(), gameId, GameUpdateOptions{
 					State:                   &state,
 					CurrentTurnIndex:        &currentTurnIndex,
 					TurnOrder:               turnOrder,
 					StateHandled:            &stateHandled,
 					StateHandledAt:          &stateHandledAt,
 					LastQuestion:            &lastQuestion,
-					LastQuestionTargetBotId: &lastQuestionTargetBotId,
-					StateTotalTime:          &stateTotalTime,
-				},
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState                   string
-					scanCurrentTurnIndex        int64
-					scanTurnOrder               []string
-					scanStateHandled            bool
-					scanStateHandledAt          sql.NullTime
-					scanLastQuestion            sql.NullString
-					scanLastQuestionTargetBotId sql.NullString
-					scanStateTotalTime          int64
-					updatedAt                   time.Time
-				)
-				row := db.QueryRow(
-					`SELECT g.state, g.current_turn_index, g.turn_order, g.state_handled, g.state_handled_at,
-					g.last_question, g.last_question_target_bot_id, g.state_total_time, g.updated_at
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &scanCurrentTurnIndex, pq.Array(&scanTurnOrder), &scanStateHandled, &scanStateHandledAt, &scanLastQuestion, &scanLastQuestionTargetBotId, &scanStateTotalTime, &updatedAt)
-				assert.NoError(t, err)
-				assert.Equal(t, state, scanState)
-				assert.Equal(t, currentTurnIndex, scanCurrentTurnIndex)
-				assert.Equal(t, turnOrder, scanTurnOrder)
-				assert.Equal(t, stateHandled, scanStateHandled)
-				assert.True(t, scanStateHandledAt.Valid)
-				model.AssertTimeAlmostEqual(t, scanStateHandledAt.Time, stateHandledAt, 1*time.Second)
-				assert.True(t, scanLastQuestion.Valid)
-				assert.Equal(t, lastQuestion, scanLastQuestion.String)
-				assert.True(t, scanLastQuestionTargetBotId.Valid)
-				assert.Equal(t, lastQuestionTargetBotId, scanLastQuestionTargetBotId.String)
-				assert.Equal(t, stateTotalTime, scanStateTotalTime)
-				model.AssertTimeAlmostEqual(t, time.Now(), updatedAt, 1*time.Second)
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1, $2
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-		{
-			name: "updates game partially with given details",
-			input: struct {
-				gameId     string
-				updateOpts GameUpdateOptions
-			}{
-				gameId: "game_id1",
-				updateOpts: GameUpdateOptions{
-					State:                   &state,
-					StateHandled:            &stateHandled,
-					StateHandledAt:          &stateHandledAt,
-					LastQuestion:            &lastQuestion,
-					LastQuestionTargetBotId: &lastQuestionTargetBotId,
+					LastQuestionTargetBotId: &botIds[1],
 					StateTotalTime:          &stateTotalTime,
-				},
-			},
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState                   string
-					scanCurrentTurnIndex        int64
-					scanTurnOrder               []string
-					scanStateHandled            bool
-					scanStateHandledAt          sql.NullTime
-					scanLastQuestion            sql.NullString
-					scanLastQuestionTargetBotId sql.NullString
-					scanStateTotalTime          int64
-					updatedAt                   time.Time
-				)
-				unchangedCurrentTurnIndex := int64(0)
-				unchangedTurnOrder := []string{"bot_id1"}
-				row := db.QueryRow(
-					`SELECT g.state, g.current_turn_index, g.turn_order, g.state_handled, g.state_handled_at,
-					g.last_question, g.last_question_target_bot_id, g.state_total_time, g.updated_at
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &scanCurrentTurnIndex, pq.Array(&scanTurnOrder), &scanStateHandled, &scanStateHandledAt, &scanLastQuestion, &scanLastQuestionTargetBotId, &scanStateTotalTime, &updatedAt)
-				assert.NoError(t, err)
-				assert.Equal(t, state, scanState)
-				assert.Equal(t, unchangedCurrentTurnIndex, scanCurrentTurnIndex)
-				assert.Equal(t, unchangedTurnOrder, scanTurnOrder)
-				assert.Equal(t, stateHandled, scanStateHandled)
-				assert.True(t, scanStateHandledAt.Valid)
-				model.AssertTimeAlmostEqual(t, scanStateHandledAt.Time, stateHandledAt, 1*time.Second)
-				assert.True(t, scanLastQuestion.Valid)
-				assert.Equal(t, lastQuestion, scanLastQuestion.String)
-				assert.True(t, scanLastQuestionTargetBotId.Valid)
-				assert.Equal(t, lastQuestionTargetBotId, scanLastQuestionTargetBotId.String)
-				assert.Equal(t, stateTotalTime, scanStateTotalTime)
-				model.AssertTimeAlmostEqual(t, time.Now(), updatedAt, 1*time.Second)
-
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "created_at", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1, $2
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'AI', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+				}, tx)
+			})
+			assert.NoError(t, err)
 
-			rand.Seed(0)
-			tx, err := s.BeginTransaction()
+			expected, err := model.NewGame(model.GameOptions{
+				Id:                      gameId,
+				State:                   state,
+				CurrentTurnIndex:        currentTurnIndex,
+				TurnOrder:               turnOrder,
+				StateHandled:            stateHandled,
+				StateHandledAt:          &stateHandledAt,
+				StateTotalTime:          stateTotalTime,
+				LastQuestion:            lastQuestion,
+				LastQuestionTargetBotId: botIds[1],
+				CreatedAt:               time.Now(),
+				UpdatedAt:               time.Now(),
+				Bots:                    game.Bots(),
+				Version:                 1,
+			})
 			assert.NoError(t, err)
-			err = s.UpdateGameStateUsingTransaction(tt.input.gameId, tt.input.updateOpts, tx)
-			tx.Commit()
-			if !tt.errorExpected {
-				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
+			game, err = s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			model.AssertEqualGame(t, expected, game)
 		})
-	}
+	})
+
+	t.Run("errors if no update options provided", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateGameStateUsingTransaction(context.Background(), gameId, GameUpdateOptions{}, tx)
+			})
+			assert.EqualError(t, err, "no update options provided")
+		})
+	})
 }
 
 func Test_Game_UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(t *testing.T) {
 	tests := []struct {
-		name            string
-		input           string
-		dbUpdateCheck   func(*sql.DB) bool
-		setupSqlStmts   []TestSqlStmts
-		cleanupSqlStmts []TestSqlStmts
-		errorExpected   bool
-		errorString     string
+		name          string
+		mode          string
+		humanCount    int
+		expectedState string
 	}{
 		{
-			name:  "updates game if enough players have joined",
-			input: "game_id1",
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState string
-					updatedAt time.Time
-				)
-				row := db.QueryRow(
-					`SELECT g.state, g.updated_at
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &updatedAt)
-				assert.NoError(t, err)
-				assert.Equal(t, "PLAYERS_JOINED", scanState)
-				model.AssertTimeAlmostEqual(t, time.Now(), updatedAt, 1*time.Second)
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'HUMAN', 'game_id1'
-					)`,
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id2', 'bot2', 'HUMAN', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
+			name:          "updates game if enough players have joined",
+			humanCount:    2,
+			expectedState: "PLAYERS_JOINED",
 		},
 		{
-			name:  "does not update game if enough players have not joined",
-			input: "game_id1",
-			dbUpdateCheck: func(db *sql.DB) bool {
-				var (
-					scanState string
-					updatedAt time.Time
-				)
-				row := db.QueryRow(
-					`SELECT g.state, g.updated_at
-					FROM public."games" AS g
-					WHERE g.id = 'game_id1'`,
-				)
-				assert.NoError(t, row.Err())
-				err := row.Scan(&scanState, &updatedAt)
-				assert.NoError(t, err)
-				assert.Equal(t, "STARTED", scanState)
-				model.AssertTimeAlmostEqual(t, time.Now().Add(-1*time.Hour), updatedAt, 1*time.Second)
-				return true
-			},
-			setupSqlStmts: []TestSqlStmts{
-				{
-					Query: `INSERT INTO public."games" (
-						"id", "state", "current_turn_index", "turn_order", "state_handled", "updated_at"
-					)
-					VALUES (
-						'game_id1', 'STARTED', 0, Array['bot_id1'], false, $1
-					)`,
-					Args: []any{
-						time.Now().Add(-1 * time.Hour),
-					},
-				},
-				{
-					Query: `INSERT INTO public."bots" (
-						"id", "name", "type", "game_id"
-					)
-					VALUES (
-						'bot_id1', 'bot1', 'HUMAN', 'game_id1'
-					)`,
-				},
-			},
-			cleanupSqlStmts: []TestSqlStmts{
-				{Query: `DELETE FROM public."games" WHERE id = 'game_id1'`},
-			},
-			errorExpected: false,
-			errorString:   "",
+			name:          "does not update game if enough players have not joined",
+			humanCount:    1,
+			expectedState: "STARTED",
 		},
 		{
-			name:            "errors if gameId is blank",
-			input:           "",
-			dbUpdateCheck:   nil,
-			setupSqlStmts:   nil,
-			cleanupSqlStmts: nil,
-			errorExpected:   true,
-			errorString:     "gameId cannot be blank",
+			name:          "does not update team game until every human has joined",
+			mode:          "TEAM",
+			humanCount:    2,
+			expectedState: "STARTED",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, _ := NewDbStorage(
-				StorageOptions{
-					Db: testDb,
-				},
-			)
-
-			runSqlOnDb(t, s.db, tt.setupSqlStmts)
-			defer runSqlOnDb(t, s.db, tt.cleanupSqlStmts)
+			forEachStorage(t, func(t *testing.T, s suiteStorage) {
+				gameId, err := s.CreateGame(context.Background(), GameCreateOptions{Mode: tt.mode})
+				assert.NoError(t, err)
+				game, err := s.GetGame(context.Background(), gameId)
+				assert.NoError(t, err)
+				for _, bot := range game.Bots()[:tt.humanCount] {
+					joinGameForSuite(t, s, bot.Id())
+				}
 
-			tx, err := s.BeginTransaction()
-			assert.NoError(t, err)
+				err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+					return s.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(context.Background(), gameId, game.HumanPlayerCount(), tx)
+				})
+				assert.NoError(t, err)
 
-			err = s.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(tt.input, tx)
-			tx.Commit()
-			if !tt.errorExpected {
+				game, err = s.GetGame(context.Background(), gameId)
 				assert.NoError(t, err)
-			} else {
-				assert.NotEmpty(t, tt.errorString)
-				assert.EqualError(t, err, tt.errorString)
-			}
-			if tt.dbUpdateCheck != nil {
-				assert.True(t, tt.dbUpdateCheck(s.db))
-			}
+				assert.Equal(t, tt.expectedState, game.State())
+			})
 		})
 	}
+
+	t.Run("errors if gameId is blank", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+				return s.UpdateGameStateIfEnoughPlayersHaveJoinedUsingTransaction(context.Background(), "", 2, tx)
+			})
+			assert.EqualError(t, err, "gameId cannot be blank")
+		})
+	})
 }
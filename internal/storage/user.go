@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/pkg/errors"
@@ -10,16 +11,19 @@ import (
 )
 
 type UserRetriever interface {
-	UserByEmail(email string) (*model.User, error)
+	UserByEmail(ctx context.Context, email string) (*model.User, error)
 }
 
-func (s *Storage) UserByEmail(email string) (*model.User, error) {
+func (s *Storage) UserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	if utilities.IsBlank(email) {
 		return nil, errors.New("cannot search by blank email")
 	}
 
 	userOptions := model.UserOptions{}
-	row := s.db.QueryRow(`SELECT id, email FROM public."users" WHERE email = $1`, email)
+	row := s.db.QueryRowContext(ctx, `SELECT id, email FROM public."users" WHERE email = $1`, email)
 	err := row.Scan(&userOptions.Id, &userOptions.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/vipulvpatil/airetreat-go/internal/model"
@@ -11,7 +13,7 @@ type UserRetrieverMockSuccess struct {
 	Email string
 }
 
-func (u *UserRetrieverMockSuccess) UserByEmail(email string) (*model.User, error) {
+func (u *UserRetrieverMockSuccess) UserByEmail(ctx context.Context, email string) (*model.User, error) {
 	return model.NewUser(model.UserOptions{
 		Id:    u.Id,
 		Email: u.Email,
@@ -23,6 +25,6 @@ type UserRetrieverMockFailure struct {
 	Email string
 }
 
-func (u *UserRetrieverMockFailure) UserByEmail(email string) (*model.User, error) {
+func (u *UserRetrieverMockFailure) UserByEmail(ctx context.Context, email string) (*model.User, error) {
 	return nil, errors.New("cannot find user by email")
 }
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/utilities"
+)
+
+type VoteCreator interface {
+	CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error
+}
+
+// Each bot has a single vote in a game. Voting again replaces the earlier vote.
+func (s *Storage) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	id := s.IdGenerator.Generate()
+	return createVote(ctx, transaction, id, gameId, voterBotId, suspectBotId)
+}
+
+func createVote(ctx context.Context, customDb customDbHandler, id, gameId, voterBotId, suspectBotId string) error {
+	if utilities.IsBlank(gameId) {
+		return errors.New("gameId cannot be blank")
+	}
+
+	if utilities.IsBlank(voterBotId) {
+		return errors.New("voterBotId cannot be blank")
+	}
+
+	if utilities.IsBlank(suspectBotId) {
+		return errors.New("suspectBotId cannot be blank")
+	}
+
+	if voterBotId == suspectBotId {
+		return errors.Errorf("voter and suspect bot cannot be same. %s %s", voterBotId, suspectBotId)
+	}
+
+	result, err := customDb.ExecContext(
+		ctx,
+		`INSERT INTO public."votes" (
+			"id", "game_id", "voter_bot_id", "suspect_bot_id"
+		)
+		VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT ("voter_bot_id") DO UPDATE SET "suspect_bot_id" = $4, "updated_at" = $5`,
+		id, gameId, voterBotId, suspectBotId, time.Now(),
+	)
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while casting vote: %s %s", voterBotId, suspectBotId))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return utilities.WrapBadError(err, fmt.Sprintf("dbError while checking affected row after casting vote: %s %s", voterBotId, suspectBotId))
+	}
+
+	if rowsAffected != 1 {
+		return utilities.NewBadError(fmt.Sprintf("Very few or too many rows were affected when casting vote in db. This is highly unexpected. rowsAffected: %d", rowsAffected))
+	}
+
+	return bumpGameVersion(ctx, customDb, gameId)
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+type VoteCreatorMockSuccess struct {
+}
+
+func (v *VoteCreatorMockSuccess) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	return nil
+}
+
+type VoteCreatorMockFailure struct {
+}
+
+func (v *VoteCreatorMockFailure) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	return errors.New("unable to cast vote")
+}
+
+type VoteCreatorConfigurableMock struct {
+	CreateVoteUsingTransactionInternal func(gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error
+}
+
+func (v *VoteCreatorConfigurableMock) CreateVoteUsingTransaction(ctx context.Context, gameId, voterBotId, suspectBotId string, transaction DatabaseTransaction) error {
+	return v.CreateVoteUsingTransactionInternal(gameId, voterBotId, suspectBotId, transaction)
+}
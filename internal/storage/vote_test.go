@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+func Test_CreateVoteUsingTransaction(t *testing.T) {
+	tests := []struct {
+		name  string
+		input struct {
+			gameId       string
+			voterBotId   string
+			suspectBotId string
+		}
+		errorString string
+	}{
+		{
+			name: "errors if gameId is blank",
+			input: struct {
+				gameId       string
+				voterBotId   string
+				suspectBotId string
+			}{"", "bot_id1", "bot_id2"},
+			errorString: "gameId cannot be blank",
+		},
+		{
+			name: "errors if voterBotId is blank",
+			input: struct {
+				gameId       string
+				voterBotId   string
+				suspectBotId string
+			}{"game_id1", "", "bot_id2"},
+			errorString: "voterBotId cannot be blank",
+		},
+		{
+			name: "errors if suspectBotId is blank",
+			input: struct {
+				gameId       string
+				voterBotId   string
+				suspectBotId string
+			}{"game_id1", "bot_id1", ""},
+			errorString: "suspectBotId cannot be blank",
+		},
+		{
+			name: "errors if the bot votes for itself",
+			input: struct {
+				gameId       string
+				voterBotId   string
+				suspectBotId string
+			}{"game_id1", "bot_id1", "bot_id1"},
+			errorString: "voter and suspect bot cannot be same. bot_id1 bot_id1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStorage(t, func(t *testing.T, s suiteStorage) {
+				err := inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+					return s.CreateVoteUsingTransaction(context.Background(), tt.input.gameId, tt.input.voterBotId, tt.input.suspectBotId, tx)
+				})
+				assert.EqualError(t, err, tt.errorString)
+			})
+		})
+	}
+
+	t.Run("casts a vote, and replaces an earlier vote by the same bot", func(t *testing.T) {
+		forEachStorage(t, func(t *testing.T, s suiteStorage) {
+			gameId := createGameForSuite(t, s)
+			game, err := s.GetGame(context.Background(), gameId)
+			assert.NoError(t, err)
+			voterBotId := game.Bots()[0].Id()
+
+			for _, suspectBot := range game.Bots()[1:3] {
+				err = inSuiteTransaction(t, s, func(tx DatabaseTransaction) error {
+					return s.CreateVoteUsingTransaction(context.Background(), gameId, voterBotId, suspectBot.Id(), tx)
+				})
+				assert.NoError(t, err)
+
+				game, err = s.GetGame(context.Background(), gameId)
+				assert.NoError(t, err)
+				assert.Equal(t, &model.Vote{VoterBotId: voterBotId, SuspectBotId: suspectBot.Id()}, game.VoteOf(voterBotId))
+			}
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package workers
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+// An AI bot that is mentioned in the chat replies after CHAT_REPLY_DELAY.
+// If nobody mentions an AI bot, one of them chimes in once the chat has been quiet for CHAT_LULL_DURATION.
+const CHAT_REPLY_DELAY = 4 * time.Second
+const CHAT_LULL_DURATION = 15 * time.Second
+
+type scheduledChatMessage struct {
+	botId string
+	at    time.Time
+}
+
+// scheduleAiChatMessage picks the AI bot that should speak next in a group chat and when it should do so.
+// It returns nil if none of the AI bots can chat anymore.
+func scheduleAiChatMessage(game *model.Game) *scheduledChatMessage {
+	aiBotIds := game.AiBotIdsThatCanChat()
+	if len(aiBotIds) == 0 {
+		return nil
+	}
+
+	messages := game.GetDetailedMessages()
+
+	var reply *scheduledChatMessage
+	for _, aiBotId := range aiBotIds {
+		mentionedAt, mentioned := lastUnansweredMentionOf(game.BotWithId(aiBotId), messages)
+		if !mentioned {
+			continue
+		}
+		at := mentionedAt.Add(CHAT_REPLY_DELAY)
+		if reply == nil || at.Before(reply.at) {
+			reply = &scheduledChatMessage{botId: aiBotId, at: at}
+		}
+	}
+	if reply != nil {
+		return reply
+	}
+
+	lastMessageAt := game.ChatStartedAt()
+	lastSpeakerBotId := ""
+	if len(messages) > 0 {
+		lastMessage := messages[len(messages)-1]
+		lastMessageAt = lastMessage.CreatedAt
+		lastSpeakerBotId = lastMessage.SourceBotId
+	}
+
+	// The last speaker only keeps talking if nobody else is around to.
+	candidateBotIds := []string{}
+	for _, aiBotId := range aiBotIds {
+		if aiBotId != lastSpeakerBotId {
+			candidateBotIds = append(candidateBotIds, aiBotId)
+		}
+	}
+	if len(candidateBotIds) == 0 {
+		candidateBotIds = aiBotIds
+	}
+
+	return &scheduledChatMessage{
+		botId: candidateBotIds[rand.Intn(len(candidateBotIds))],
+		at:    lastMessageAt.Add(CHAT_LULL_DURATION),
+	}
+}
+
+// lastUnansweredMentionOf finds the first message that mentions the bot by name since the bot last spoke.
+func lastUnansweredMentionOf(bot *model.Bot, messages []model.DetailedMessage) (time.Time, bool) {
+	name := strings.ToLower(bot.Name())
+	var mentionedAt time.Time
+	mentioned := false
+	for _, message := range messages {
+		if message.SourceBotId == bot.Id() {
+			mentioned = false
+			continue
+		}
+		if !mentioned && strings.Contains(strings.ToLower(message.Text), name) {
+			mentionedAt = message.CreatedAt
+			mentioned = true
+		}
+	}
+	return mentionedAt, mentioned
+}
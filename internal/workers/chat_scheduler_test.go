@@ -0,0 +1,108 @@
+package workers
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
+)
+
+func Test_scheduleAiChatMessage(t *testing.T) {
+	chatStartedAt := time.Now().Add(-time.Minute)
+	groupChatGame := func(messages []*model.Message) *model.Game {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		for i := 0; i < 2; i++ {
+			player, _ := model.NewPlayer(model.PlayerOptions{Id: fmt.Sprintf("player_id%d", i+1)})
+			bots[i].ConnectPlayer(player)
+		}
+		game, _ := model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            "CHATTING",
+				CurrentTurnIndex: 0,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				StateHandledAt:   &chatStartedAt,
+				StateTotalTime:   300,
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				Mode:             "GROUP_CHAT",
+				Messages:         messages,
+			},
+		)
+		return game
+	}
+	chatMessage := func(botId, text string, createdAt time.Time) *model.Message {
+		return &model.Message{SourceBotId: botId, TargetBotId: botId, Text: text, CreatedAt: createdAt, MessageType: "chat"}
+	}
+	maxedOutMessages := func(botId string) []*model.Message {
+		messages := []*model.Message{}
+		for i := 0; i < model.GROUP_CHAT_MESSAGE_CAP; i++ {
+			messages = append(messages, chatMessage(botId, "hi", chatStartedAt))
+		}
+		return messages
+	}
+
+	tests := []struct {
+		name           string
+		game           *model.Game
+		expectedOutput *scheduledChatMessage
+	}{
+		{
+			name: "mentioned ai bot replies soon after the mention",
+			game: groupChatGame([]*model.Message{
+				chatMessage("bot_id1", "hey BOT5, where are you from?", chatStartedAt.Add(10*time.Second)),
+				chatMessage("bot_id2", "bot3 is quiet", chatStartedAt.Add(20*time.Second)),
+			}),
+			expectedOutput: &scheduledChatMessage{botId: "bot_id5", at: chatStartedAt.Add(10*time.Second + CHAT_REPLY_DELAY)},
+		},
+		{
+			name: "ai bot that has spoken since it was mentioned does not reply again",
+			game: groupChatGame([]*model.Message{
+				chatMessage("bot_id1", "bot5, where are you from?", chatStartedAt.Add(10*time.Second)),
+				chatMessage("bot_id5", "Pune", chatStartedAt.Add(15*time.Second)),
+				chatMessage("bot_id2", "bot3 is quiet", chatStartedAt.Add(20*time.Second)),
+			}),
+			expectedOutput: &scheduledChatMessage{botId: "bot_id3", at: chatStartedAt.Add(20*time.Second + CHAT_REPLY_DELAY)},
+		},
+		{
+			name:           "random ai bot chimes in after a lull when the chat has not started",
+			game:           groupChatGame(nil),
+			expectedOutput: &scheduledChatMessage{botId: "bot_id3", at: chatStartedAt.Add(CHAT_LULL_DURATION)},
+		},
+		{
+			name: "ai bot other than the last speaker chimes in after a lull",
+			game: groupChatGame(append(maxedOutMessages("bot_id3"),
+				chatMessage("bot_id4", "so quiet", chatStartedAt.Add(30*time.Second)),
+			)),
+			expectedOutput: &scheduledChatMessage{botId: "bot_id5", at: chatStartedAt.Add(30*time.Second + CHAT_LULL_DURATION)},
+		},
+		{
+			name: "no ai bot chats once all of them are out of messages",
+			game: groupChatGame(append(append(maxedOutMessages("bot_id3"), maxedOutMessages("bot_id4")...),
+				maxedOutMessages("bot_id5")...,
+			)),
+			expectedOutput: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			scheduled := scheduleAiChatMessage(tt.game)
+			assert.Equal(t, tt.expectedOutput, scheduled)
+		})
+	}
+}
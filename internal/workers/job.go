@@ -1,11 +1,13 @@
 package workers
 
 import (
+	"context"
 	"math/rand"
 	"time"
 
 	"github.com/gocraft/work"
 	"github.com/pkg/errors"
+	"github.com/vipulvpatil/airetreat-go/internal/model"
 	aibot "github.com/vipulvpatil/airetreat-go/internal/services/ai-bot"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
@@ -13,22 +15,23 @@ import (
 
 type jobContext struct{}
 
-func (j *jobContext) startGameOncePlayersHaveJoined(job *work.Job) error {
-	gameId := job.ArgString("gameId")
+// CONCURRENT_MODIFICATION_ATTEMPTS is how many times a job starts over when the game changes under it.
+const CONCURRENT_MODIFICATION_ATTEMPTS = 3
 
-	if utilities.IsBlank(gameId) {
-		err := errors.New("gameId is required")
+func (j *jobContext) startGameOncePlayersHaveJoined(job *work.Job) error {
+	gameId, claimedVersion, err := claimedGameArgs(job)
+	if err != nil {
 		logger.LogError(err)
 		return err
 	}
-	game, err := workerStorage.GetGame(gameId)
+	game, err := workerStorage.GetGame(workerCtx, gameId)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	if game.StateHasBeenHandled() {
-		err := errors.Errorf("game has already been handled: %s", gameId)
+	err = checkClaim(game, &claimedVersion)
+	if err != nil {
 		logger.LogError(err)
 		return err
 	}
@@ -39,53 +42,95 @@ func (j *jobContext) startGameOncePlayersHaveJoined(job *work.Job) error {
 		return err
 	}
 
-	randomizedTurnOrder := game.RandomizedTurnOrder()
+	updateOptions, err := updateOptsToStartRound(game)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
 
-	firstTurnBot := game.BotWithId(randomizedTurnOrder[0])
+	return workerStorage.UpdateGameState(workerCtx, gameId, updateOptions)
+}
 
-	var newGameState string
+// In elimination games, each round after an AI bot is eliminated starts afresh with the remaining bots.
+func (j *jobContext) startNextRound(job *work.Job) error {
+	gameId, claimedVersion, err := claimedGameArgs(job)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
 
-	if firstTurnBot.IsAi() {
-		newGameState = "WAITING_FOR_AI_QUESTION"
-	} else if firstTurnBot.IsHuman() {
-		newGameState = "WAITING_FOR_HUMAN_QUESTION"
+	err = checkClaim(game, &claimedVersion)
+	if err != nil {
+		logger.LogError(err)
+		return err
 	}
 
-	startTurnIndex := int64(0)
+	if !game.IsInStateBotEliminated() {
+		err := errors.Errorf("game should be in BotEliminated state: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
 
-	updateOpts := storage.GameUpdateOptions{
-		State:            &newGameState,
-		CurrentTurnIndex: &startTurnIndex,
-		TurnOrder:        randomizedTurnOrder,
+	updateOptions, err := updateOptsToStartRound(game)
+	if err != nil {
+		logger.LogError(err)
+		return err
 	}
 
-	return workerStorage.UpdateGameState(gameId, updateOpts)
+	return workerStorage.UpdateGameState(workerCtx, gameId, updateOptions)
 }
 
-func (j *jobContext) askQuestionOnBehalfOfBot(job *work.Job) error {
-	gameId := job.ArgString("gameId")
-
-	if utilities.IsBlank(gameId) {
-		err := errors.New("gameId is required")
-		logger.LogError(err)
-		return err
+func updateOptsToStartRound(game *model.Game) (storage.GameUpdateOptions, error) {
+	gameUpdate, err := game.GetGameUpdateToStartRound()
+	if err != nil {
+		return storage.GameUpdateOptions{}, err
 	}
 
-	tx, err := workerStorage.BeginTransaction()
+	newGameState := gameUpdate.State.String()
+	turn := game.Turn()
+	return storage.GameUpdateOptions{
+		State:            &newGameState,
+		CurrentTurnIndex: gameUpdate.CurrentTurnIndex,
+		TurnOrder:        gameUpdate.TurnOrder,
+		StateHandled:     gameUpdate.StateHandled,
+		StateHandledAt:   gameUpdate.StateHandledAt,
+		StateTotalTime:   gameUpdate.StateTotalTime,
+		DecoyBotId:       gameUpdate.DecoyBotId,
+		ExpectedTurn:     &turn,
+	}, nil
+}
+
+func (j *jobContext) askQuestionOnBehalfOfBot(job *work.Job) error {
+	gameId, claimedVersion, err := claimedGameArgs(job)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
-	defer tx.Rollback()
 
-	game, err := workerStorage.GetGameUsingTransaction(gameId, tx)
+	// Only the first attempt expects the game to be at the version it was claimed at, since the job starts over
+	// because the game has changed.
+	expectedVersion := &claimedVersion
+	return retryOnConcurrentModification(func() error {
+		err := askQuestionOnBehalfOfBot(gameId, expectedVersion)
+		expectedVersion = nil
+		return err
+	})
+}
+
+func askQuestionOnBehalfOfBot(gameId string, claimedVersion *int64) error {
+	game, err := workerStorage.GetGame(workerCtx, gameId)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	if game.StateHasBeenHandled() {
-		err := errors.Errorf("game has already been handled: %s", gameId)
+	err = checkClaim(game, claimedVersion)
+	if err != nil {
 		logger.LogError(err)
 		return err
 	}
@@ -107,35 +152,52 @@ func (j *jobContext) askQuestionOnBehalfOfBot(job *work.Job) error {
 			BotId:        sourceBot.Id(),
 			Game:         game,
 			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
 		},
 	)
-	question := aiBot.GetNextQuestion()
+	question := aiBot.GetNextQuestion(workerCtx)
 
-	// Wait a random amount of time.
-	time.Sleep(time.Duration(minDelayAfterAIResponse+rand.Intn(maxDelayAfterAIResponse-minDelayAfterAIResponse)) * time.Second)
+	err = waitAfterAiResponse(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
 
-	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), targetBotId, question)
+	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), targetBotId, question.Text, "question")
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
 	newGameState := gameUpdate.State.String()
+	turn := game.Turn()
 	updateOptions := storage.GameUpdateOptions{
 		State:                   &newGameState,
 		CurrentTurnIndex:        gameUpdate.CurrentTurnIndex,
 		StateHandled:            gameUpdate.StateHandled,
 		LastQuestion:            gameUpdate.LastQuestion,
 		LastQuestionTargetBotId: gameUpdate.LastQuestionTargetBotId,
+		StateHandledAt:          gameUpdate.StateHandledAt,
+		StateTotalTime:          gameUpdate.StateTotalTime,
+		ConversationSummary:     question.ConversationSummary,
+		ExpectedTurn:            &turn,
+	}
+
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
 	}
+	defer tx.Rollback()
 
-	err = workerStorage.UpdateGameStateUsingTransaction(gameId, updateOptions, tx)
+	err = workerStorage.UpdateGameStateUsingTransaction(workerCtx, gameId, updateOptions, tx)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	err = workerStorage.CreateMessageUsingTransaction(sourceBot.Id(), targetBotId, question, "question", tx)
+	metadata := messageMetadataForAiMessage(game, question)
+	err = workerStorage.CreateMessageUsingTransaction(workerCtx, sourceBot.Id(), targetBotId, question.Text, "question", metadata, tx)
 	if err != nil {
 		logger.LogError(err)
 		return err
@@ -147,29 +209,31 @@ func (j *jobContext) askQuestionOnBehalfOfBot(job *work.Job) error {
 }
 
 func (j *jobContext) answerQuestionOnBehalfOfBot(job *work.Job) error {
-	gameId := job.ArgString("gameId")
-
-	if utilities.IsBlank(gameId) {
-		err := errors.New("gameId is required")
+	gameId, claimedVersion, err := claimedGameArgs(job)
+	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	tx, err := workerStorage.BeginTransaction()
-	if err != nil {
-		logger.LogError(err)
+	// Only the first attempt expects the game to be at the version it was claimed at, since the job starts over
+	// because the game has changed.
+	expectedVersion := &claimedVersion
+	return retryOnConcurrentModification(func() error {
+		err := answerQuestionOnBehalfOfBot(gameId, expectedVersion)
+		expectedVersion = nil
 		return err
-	}
-	defer tx.Rollback()
+	})
+}
 
-	game, err := workerStorage.GetGameUsingTransaction(gameId, tx)
+func answerQuestionOnBehalfOfBot(gameId string, claimedVersion *int64) error {
+	game, err := workerStorage.GetGame(workerCtx, gameId)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	if game.StateHasBeenHandled() {
-		err := errors.Errorf("game has already been handled: %s", gameId)
+	err = checkClaim(game, claimedVersion)
+	if err != nil {
 		logger.LogError(err)
 		return err
 	}
@@ -187,35 +251,592 @@ func (j *jobContext) answerQuestionOnBehalfOfBot(job *work.Job) error {
 			BotId:        sourceBot.Id(),
 			Game:         game,
 			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
 		},
 	)
-	answer := aiBot.GetNextAnswer()
+	answer := aiBot.GetNextAnswer(workerCtx)
+
+	// Every now and then, the AI bot reacts to the question before answering it, like a human might.
+	var reaction *botReaction
+	if aiReactionPercent > 0 && rand.Intn(100) < aiReactionPercent {
+		reaction = getReactionOnBehalfOfBot(workerCtx, game, sourceBot.Id())
+	}
 
-	// Wait a random amount of time.
-	time.Sleep(time.Duration(minDelayAfterAIResponse+rand.Intn(maxDelayAfterAIResponse-minDelayAfterAIResponse)) * time.Second)
+	err = waitAfterAiResponse(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
 
-	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), sourceBot.Id(), answer)
+	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(sourceBot.Id(), sourceBot.Id(), answer.Text, "answer")
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
 	newGameState := gameUpdate.State.String()
+	turn := game.Turn()
 	updateOptions := storage.GameUpdateOptions{
 		State:                   &newGameState,
 		CurrentTurnIndex:        gameUpdate.CurrentTurnIndex,
 		StateHandled:            gameUpdate.StateHandled,
 		LastQuestion:            gameUpdate.LastQuestion,
 		LastQuestionTargetBotId: gameUpdate.LastQuestionTargetBotId,
+		StateHandledAt:          gameUpdate.StateHandledAt,
+		StateTotalTime:          gameUpdate.StateTotalTime,
+		ConversationSummary:     answer.ConversationSummary,
+		ExpectedTurn:            &turn,
+	}
+
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	err = workerStorage.UpdateGameStateUsingTransaction(workerCtx, gameId, updateOptions, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if reaction != nil {
+		metadata := messageMetadataForAiMessage(game, reaction.AiMessage)
+		err = workerStorage.CreateMessageUsingTransaction(workerCtx, sourceBot.Id(), reaction.targetBotId, reaction.Text, "reaction", metadata, tx)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	metadata := messageMetadataForAiMessage(game, answer)
+	err = workerStorage.CreateMessageUsingTransaction(workerCtx, sourceBot.Id(), sourceBot.Id(), answer.Text, "answer", metadata, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if len(answer.StatedFacts) > 0 {
+		err = workerStorage.UpdateBotStatedFactsUsingTransaction(workerCtx, sourceBot.Id(), sourceBot.StatedFactsWith(answer.StatedFacts), tx)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	logger.LogError(err)
+	return err
+}
+
+// claimedGameArgs returns the game, and the version it was claimed at, that a job for a claimed game is started with.
+func claimedGameArgs(job *work.Job) (string, int64, error) {
+	gameId := job.ArgString("gameId")
+	if utilities.IsBlank(gameId) {
+		return "", 0, errors.New("gameId is required")
+	}
+
+	claimedVersion := job.ArgInt64("claimedVersion")
+	if job.ArgError() != nil {
+		return "", 0, errors.New("claimedVersion is required")
+	}
+	return gameId, claimedVersion, nil
+}
+
+// checkClaim errors unless the game is still claimed, and, if claimedVersion is given, is still at the version it was
+// claimed at. Otherwise the claim may have timed out and the game been claimed again, for another job to handle.
+func checkClaim(game *model.Game, claimedVersion *int64) error {
+	if !game.StateHasBeenHandled() {
+		return errors.Errorf("game has not been claimed: %s", game.Id())
+	}
+	if claimedVersion != nil && game.Version() != *claimedVersion {
+		return errors.Errorf("game has changed since it was claimed: %s", game.Id())
+	}
+	return nil
+}
+
+// releaseClaimOnceFailedForGood releases the claim on the game of a job for a claimed game once the job has failed for
+// the last time. Otherwise nothing would handle the game until its claim timed out.
+func (j *jobContext) releaseClaimOnceFailedForGood(job *work.Job, next work.NextMiddlewareFunc) error {
+	err := next()
+	if err == nil || job.Fails+1 < CLAIMED_GAME_JOB_MAX_FAILS {
+		return err
+	}
+
+	for gameState, jobName := range JobsForClaimedGameStates {
+		if jobName == job.Name {
+			releaseErr := releaseClaim(job.ArgString("gameId"), gameState)
+			if releaseErr != nil {
+				logger.LogError(releaseErr)
+			}
+		}
+	}
+	return err
+}
+
+// releaseClaim leaves the game alone if it has moved on from the state it was claimed in, or its claim has been
+// released already.
+func releaseClaim(gameId string, claimedGameState string) error {
+	if utilities.IsBlank(gameId) {
+		return nil
+	}
+
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		return err
+	}
+
+	if game.State() != claimedGameState || !game.StateHasBeenHandled() {
+		return nil
+	}
+
+	stateHandled := false
+	version := game.Version()
+	return workerStorage.UpdateGameState(workerCtx, gameId, storage.GameUpdateOptions{
+		StateHandled:    &stateHandled,
+		ExpectedVersion: &version,
+	})
+}
+
+// The jobs do not hold a transaction while the AI bots come up with their messages, so something else can change the
+// game first. The job then starts over with the game as it is now, a few times before giving up.
+func retryOnConcurrentModification(f func() error) error {
+	return storage.RetryOnConcurrentModification(CONCURRENT_MODIFICATION_ATTEMPTS, f)
+}
+
+// waitAfterAiResponse waits a random amount of time, so that AI bots do not respond faster than a human could. It gives
+// up early once ctx is done, such as when the worker is shutting down.
+func waitAfterAiResponse(ctx context.Context) error {
+	delay := time.Duration(minDelayAfterAIResponse+rand.Intn(maxDelayAfterAIResponse-minDelayAfterAIResponse)) * time.Second
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type botReaction struct {
+	aibot.AiMessage
+	targetBotId string
+}
+
+// getReactionOnBehalfOfBot returns nil if the AI bot does not come up with a valid reaction, since reactions are optional.
+func getReactionOnBehalfOfBot(ctx context.Context, game *model.Game, botId string) *botReaction {
+	targetBotId := game.BotIdToReactTo()
+	if utilities.IsBlank(targetBotId) {
+		return nil
+	}
+
+	aiReactor := aibot.NewAiReactor(
+		aibot.AiBotOptions{
+			BotId:        botId,
+			Game:         game,
+			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
+		},
+	)
+	reaction := aiReactor.GetReaction(ctx)
+	if reaction == nil {
+		return nil
+	}
+
+	_, err := game.GetGameUpdateAfterIncomingMessage(botId, targetBotId, reaction.Text, "reaction")
+	if err != nil {
+		logger.LogError(err)
+		return nil
+	}
+
+	return &botReaction{AiMessage: *reaction, targetBotId: targetBotId}
+}
+
+// The response time includes the random wait, since that is what the humans in the game experience.
+func messageMetadataForAiMessage(game *model.Game, aiMessage aibot.AiMessage) storage.MessageMetadata {
+	return storage.MessageMetadata{
+		ResponseTime:     game.ResponseTimeForNextMessage(time.Now()),
+		AiModel:          aiMessage.AiModel,
+		PromptVersion:    aiMessage.PromptVersion,
+		PromptTokens:     aiMessage.PromptTokens,
+		CompletionTokens: aiMessage.CompletionTokens,
+	}
+}
+
+// accuseOnBehalfOfAiBot lets a random AI bot look for a human in the conversation so far.
+// It runs alongside the turns, so it does not change whose turn it is unless the AI bot tags a human and ends the game.
+// Since the turn does not tell two accusation jobs apart, the job expects the game to still be at the version it read.
+func (j *jobContext) accuseOnBehalfOfAiBot(job *work.Job) error {
+	gameId := job.ArgString("gameId")
+
+	if utilities.IsBlank(gameId) {
+		err := errors.New("gameId is required")
+		logger.LogError(err)
+		return err
+	}
+
+	return retryOnConcurrentModification(func() error {
+		return accuseOnBehalfOfAiBot(gameId)
+	})
+}
+
+func accuseOnBehalfOfAiBot(gameId string) error {
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if !game.AiAccusations() {
+		err := errors.Errorf("game does not have ai accusations: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
+
+	if !game.IsInPlay() {
+		err := errors.Errorf("game should be in play: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
+
+	// Another job may have checked the game since this one was enqueued, or since this one started over.
+	if !game.HasHumanAnswerSinceAiAccusationCheck() {
+		return nil
+	}
+
+	accusingBot, err := game.GetOneRandomAiBot()
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	aiAccuser := aibot.NewAiAccuser(
+		aibot.AiBotOptions{
+			BotId:        accusingBot.Id(),
+			Game:         game,
+			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
+		},
+	)
+	accusation := aiAccuser.GetAccusation(workerCtx)
+
+	// The check is recorded even when there is no accusation, so that the AI bots wait for the humans to say more.
+	checkedAt := time.Now()
+	version := game.Version()
+	updateOptions := storage.GameUpdateOptions{
+		AiAccusationCheckedAt: &checkedAt,
+		ExpectedVersion:       &version,
+	}
+	if accusation != nil {
+		updateOptions.ConversationSummary = accusation.ConversationSummary
+	}
+
+	if accusation != nil && game.ShouldAiTag(accusation.SuspectBotId, accusation.Confidence) {
+		gameUpdate, err := game.GetGameUpdateAfterTag(accusingBot.Id(), accusation.SuspectBotId)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+		newGameState := gameUpdate.State.String()
+		updateOptions.State = &newGameState
+		updateOptions.Result = gameUpdate.Result
+		updateOptions.WinningBotId = gameUpdate.WinningBotId
 	}
 
-	err = workerStorage.UpdateGameStateUsingTransaction(gameId, updateOptions, tx)
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	err = workerStorage.UpdateGameStateUsingTransaction(workerCtx, gameId, updateOptions, tx)
 	if err != nil {
 		logger.LogError(err)
 		return err
 	}
 
-	err = workerStorage.CreateMessageUsingTransaction(sourceBot.Id(), sourceBot.Id(), answer, "answer", tx)
+	if accusation != nil && game.ShouldAiAccuse(accusation.Confidence) {
+		metadata := messageMetadataForAiMessage(game, accusation.AiMessage)
+		err = workerStorage.CreateMessageUsingTransaction(workerCtx, accusingBot.Id(), accusation.SuspectBotId, accusation.Text, "accusation", metadata, tx)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	logger.LogError(err)
+	return err
+}
+
+// closeVoting tallies the votes once everyone has had their time to vote.
+// In games with AI votes, each AI bot votes for the bot it suspects the most just before the tally.
+func (j *jobContext) closeVoting(job *work.Job) error {
+	gameId := job.ArgString("gameId")
+
+	if utilities.IsBlank(gameId) {
+		err := errors.New("gameId is required")
+		logger.LogError(err)
+		return err
+	}
+
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if !game.VotingHasClosed(time.Now()) {
+		err := errors.Errorf("game voting should have closed: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
+
+	aiVotes := []*model.Vote{}
+	for _, aiBotId := range game.AiVoterBotIds() {
+		aiAccuser := aibot.NewAiAccuser(
+			aibot.AiBotOptions{
+				BotId:        aiBotId,
+				Game:         game,
+				OpenAiClient: openAiClient,
+				Prompts:      promptRegistry,
+			},
+		)
+		// An AI bot that cannot decide does not vote.
+		accusation := aiAccuser.GetAccusation(workerCtx)
+		if accusation == nil {
+			continue
+		}
+		aiVotes = append(aiVotes, &model.Vote{VoterBotId: aiBotId, SuspectBotId: accusation.SuspectBotId})
+	}
+
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// The humans can vote until the tally, so the game is only locked now that the AI bots have decided, and the votes
+	// are counted as they are at this point.
+	game, err = workerStorage.GetGameUsingTransaction(workerCtx, gameId, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	gameUpdate, err := game.GetGameUpdateAfterVoting(aiVotes)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	for _, aiVote := range aiVotes {
+		err = workerStorage.CreateVoteUsingTransaction(workerCtx, gameId, aiVote.VoterBotId, aiVote.SuspectBotId, tx)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	newGameState := gameUpdate.State.String()
+	stateHandled := true
+	updateOptions := storage.GameUpdateOptions{
+		State:        &newGameState,
+		Result:       gameUpdate.Result,
+		WinningBotId: gameUpdate.WinningBotId,
+		StateHandled: &stateHandled,
+	}
+
+	err = workerStorage.UpdateGameStateUsingTransaction(workerCtx, gameId, updateOptions, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	err = tx.Commit()
+	logger.LogError(err)
+	return err
+}
+
+// chatOnBehalfOfAiBots runs on every tick of a group chat. It ends the chat once time is up, and otherwise
+// posts a message for the AI bot that is due to speak next, if any.
+func (j *jobContext) chatOnBehalfOfAiBots(job *work.Job) error {
+	gameId := job.ArgString("gameId")
+
+	if utilities.IsBlank(gameId) {
+		err := errors.New("gameId is required")
+		logger.LogError(err)
+		return err
+	}
+
+	return retryOnConcurrentModification(func() error {
+		return chatOnBehalfOfAiBots(gameId)
+	})
+}
+
+func chatOnBehalfOfAiBots(gameId string) error {
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if !game.IsInStateChatting() {
+		err := errors.Errorf("game should be in Chatting state: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
+
+	// Messages in a group chat do not change the turn, so the job expects the game to still be at the version it read.
+	// If anyone posts while the AI bot writes, the job starts over and the AI bot takes the new message into account.
+	version := game.Version()
+
+	if game.ChatHasEnded(time.Now()) {
+		gameUpdate, err := game.GetGameUpdateAfterTimeUp()
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+
+		newGameState := gameUpdate.State.String()
+		stateHandled := true
+		updateOptions := storage.GameUpdateOptions{
+			State:           &newGameState,
+			Result:          gameUpdate.Result,
+			WinningBotId:    gameUpdate.WinningBotId,
+			StateHandled:    &stateHandled,
+			ExpectedVersion: &version,
+		}
+
+		err = workerStorage.UpdateGameState(workerCtx, gameId, updateOptions)
+		logger.LogError(err)
+		return err
+	}
+
+	scheduled := scheduleAiChatMessage(game)
+	if scheduled == nil || scheduled.at.After(time.Now()) {
+		return nil
+	}
+
+	chattingBot := game.BotWithId(scheduled.botId)
+	aiChatter := aibot.NewAiChatter(
+		aibot.AiBotOptions{
+			BotId:        chattingBot.Id(),
+			Game:         game,
+			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
+		},
+	)
+	chatMessage := aiChatter.GetNextChatMessage(workerCtx)
+
+	gameUpdate, err := game.GetGameUpdateAfterIncomingMessage(chattingBot.Id(), chattingBot.Id(), chatMessage.Text, "chat")
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	newGameState := gameUpdate.State.String()
+	updateOptions := storage.GameUpdateOptions{
+		State:               &newGameState,
+		StateHandled:        gameUpdate.StateHandled,
+		ConversationSummary: chatMessage.ConversationSummary,
+		ExpectedVersion:     &version,
+	}
+
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	err = workerStorage.UpdateGameStateUsingTransaction(workerCtx, gameId, updateOptions, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	metadata := messageMetadataForAiMessage(game, chatMessage)
+	err = workerStorage.CreateMessageUsingTransaction(workerCtx, chattingBot.Id(), chattingBot.Id(), chatMessage.Text, "chat", metadata, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if len(chatMessage.StatedFacts) > 0 {
+		err = workerStorage.UpdateBotStatedFactsUsingTransaction(workerCtx, chattingBot.Id(), chattingBot.StatedFactsWith(chatMessage.StatedFacts), tx)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	logger.LogError(err)
+	return err
+}
+
+// analyzeFinishedGame scores how human every bot in a finished game seemed, and stores it for the post-game report.
+func (j *jobContext) analyzeFinishedGame(job *work.Job) error {
+	gameId := job.ArgString("gameId")
+
+	if utilities.IsBlank(gameId) {
+		err := errors.New("gameId is required")
+		logger.LogError(err)
+		return err
+	}
+
+	return retryOnConcurrentModification(func() error {
+		return analyzeFinishedGame(gameId)
+	})
+}
+
+func analyzeFinishedGame(gameId string) error {
+	game, err := workerStorage.GetGame(workerCtx, gameId)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	if !game.IsInStateFinished() {
+		err := errors.Errorf("game should be finished: %s", gameId)
+		logger.LogError(err)
+		return err
+	}
+
+	gameAnalyzer := aibot.NewGameAnalyzer(
+		aibot.AiBotOptions{
+			Game:         game,
+			OpenAiClient: openAiClient,
+			Prompts:      promptRegistry,
+		},
+	)
+	gameAnalysis := gameAnalyzer.GetGameAnalysis(workerCtx)
+
+	tx, err := workerStorage.BeginTransaction(workerCtx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// A finished game can still change, such as when a player's data is erased, and the analysis must not quote
+	// anything that is gone. Locking the game keeps it as it is until the analysis is stored.
+	lockedGame, err := workerStorage.GetGameUsingTransaction(workerCtx, gameId, tx)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+	if lockedGame.Version() != game.Version() {
+		err := errors.Wrapf(storage.ErrConcurrentModification, "gameId: %s, expected version: %d", gameId, game.Version())
+		logger.LogError(err)
+		return err
+	}
+
+	err = workerStorage.CreateGameAnalysisUsingTransaction(workerCtx, gameAnalysis, tx)
 	if err != nil {
 		logger.LogError(err)
 		return err
@@ -234,7 +855,7 @@ func (j *jobContext) deleteExpiredGames(job *work.Job) error {
 		logger.LogError(err)
 		return err
 	}
-	game, err := workerStorage.GetGame(gameId)
+	game, err := workerStorage.GetGame(workerCtx, gameId)
 	if err != nil {
 		logger.LogError(err)
 		return err
@@ -244,5 +865,5 @@ func (j *jobContext) deleteExpiredGames(job *work.Job) error {
 		return nil
 	}
 
-	return workerStorage.DeleteGame(gameId)
+	return workerStorage.DeleteGame(workerCtx, gameId)
 }
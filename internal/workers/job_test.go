@@ -1,8 +1,10 @@
 package workers
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
 	"github.com/vipulvpatil/airetreat-go/internal/model"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
@@ -26,7 +29,8 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 		{
 			name: "updates game successfully to WAITING_FOR_AI_QUESTION",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(string) (*model.Game, error) {
@@ -46,7 +50,7 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 							State:            "PLAYERS_JOINED",
 							CurrentTurnIndex: 0,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     false,
+							StateHandled:     true,
 							StateTotalTime:   0,
 							CreatedAt:        time.Now(),
 							UpdatedAt:        time.Now(),
@@ -77,7 +81,8 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 		{
 			name: "updates game successfully to WAITING_FOR_HUMAN_QUESTION",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(string) (*model.Game, error) {
@@ -103,7 +108,7 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 							State:            "PLAYERS_JOINED",
 							CurrentTurnIndex: 0,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     false,
+							StateHandled:     true,
 							StateTotalTime:   0,
 							CreatedAt:        time.Now(),
 							UpdatedAt:        time.Now(),
@@ -134,7 +139,8 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 		{
 			name: "errors if game is not in db",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(string) (*model.Game, error) {
@@ -148,7 +154,8 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 		{
 			name: "errors if game is in wrong state",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(string) (*model.Game, error) {
@@ -165,7 +172,7 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 							State:            "STARTED",
 							CurrentTurnIndex: 0,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     false,
+							StateHandled:     true,
 							StateTotalTime:   0,
 							CreatedAt:        time.Now(),
 							UpdatedAt:        time.Now(),
@@ -178,9 +185,10 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 			errorString:   "game should be in PlayersJoined state: game_id1",
 		},
 		{
-			name: "errors if game is has already been handled",
+			name: "errors if game has not been claimed",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
 				GetGameInternal: func(string) (*model.Game, error) {
@@ -197,7 +205,7 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 							State:            "STARTED",
 							CurrentTurnIndex: 0,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     true,
+							StateHandled:     false,
 							StateTotalTime:   0,
 							CreatedAt:        time.Now(),
 							UpdatedAt:        time.Now(),
@@ -207,7 +215,7 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 				},
 			},
 			errorExpected: true,
-			errorString:   "game has already been handled: game_id1",
+			errorString:   "game has not been claimed: game_id1",
 		},
 		{
 			name: "errors if gameId is blank",
@@ -218,6 +226,50 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 			errorExpected:    true,
 			errorString:      "gameId is required",
 		},
+		{
+			name: "errors if the version the game was claimed at is missing",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			gameAccessorMock: nil,
+			errorExpected:    true,
+			errorString:      "claimedVersion is required",
+		},
+		{
+			name: "errors if game has changed since it was claimed",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 2,
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					bot, _ := model.NewBot(
+						model.BotOptions{
+							Id:        "bot_id1",
+							Name:      "bot1",
+							TypeOfBot: "AI",
+						},
+					)
+					// The claim timed out, and the game was claimed again.
+					return model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "PLAYERS_JOINED",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     true,
+							StateTotalTime:   0,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now(),
+							Bots:             []*model.Bot{bot},
+							Version:          3,
+						},
+					)
+				},
+			},
+			errorExpected: true,
+			errorString:   "game has changed since it was claimed: game_id1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -241,6 +293,141 @@ func Test_startGameOncePlayersHaveJoined(t *testing.T) {
 	}
 }
 
+func Test_startNextRound(t *testing.T) {
+	eliminationGame := func(state string, stateHandled bool) (*model.Game, error) {
+		player1, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+		player2, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id2"})
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:         fmt.Sprintf("bot_id%d", i+1),
+				Name:       fmt.Sprintf("bot%d", i+1),
+				TypeOfBot:  "AI",
+				Eliminated: i == 2,
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		bots[0].ConnectPlayer(player1)
+		bots[1].ConnectPlayer(player2)
+		return model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            state,
+				CurrentTurnIndex: 3,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id4", "bot_id5"},
+				StateHandled:     stateHandled,
+				StateTotalTime:   0,
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				Elimination:      true,
+			},
+		)
+	}
+
+	tests := []struct {
+		name             string
+		input            map[string]interface{}
+		gameAccessorMock storage.GameAccessor
+		errorExpected    bool
+		errorString      string
+	}{
+		{
+			name: "starts the next round with the remaining bots",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					return eliminationGame("BOT_ELIMINATED", true)
+				},
+				UpdateGameStateInternal: func(gameId string, opts storage.GameUpdateOptions) error {
+					assert.Equal(t, "game_id1", gameId)
+					assert.Equal(t, "WAITING_FOR_AI_QUESTION", *opts.State)
+					assert.Equal(t, int64(0), *opts.CurrentTurnIndex)
+					assert.Equal(t, []string{"bot_id4", "bot_id2", "bot_id1", "bot_id5"}, opts.TurnOrder)
+					return nil
+				},
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+		{
+			name: "errors if game is not in db",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					return nil, errors.New("game not in db")
+				},
+			},
+			errorExpected: true,
+			errorString:   "game not in db",
+		},
+		{
+			name: "errors if game is in wrong state",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					return eliminationGame("WAITING_FOR_AI_QUESTION", true)
+				},
+			},
+			errorExpected: true,
+			errorString:   "game should be in BotEliminated state: game_id1",
+		},
+		{
+			name: "errors if game has not been claimed",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					return eliminationGame("BOT_ELIMINATED", false)
+				},
+			},
+			errorExpected: true,
+			errorString:   "game has not been claimed: game_id1",
+		},
+		{
+			name: "errors if gameId is blank",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			gameAccessorMock: nil,
+			errorExpected:    true,
+			errorString:      "gameId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithGameAccessorMock(tt.gameAccessorMock),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			jc := jobContext{}
+			err := jc.startNextRound(&work.Job{
+				Args: tt.input,
+			})
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -256,12 +443,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "updates game successfully",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					bots := []*model.Bot{}
 					for i := 0; i < 5; i++ {
 						botOpts := model.BotOptions{
@@ -278,7 +466,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:            "WAITING_FOR_AI_QUESTION",
 							CurrentTurnIndex: 0,
 							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     false,
+							StateHandled:     true,
 							StateTotalTime:   0,
 							CreatedAt:        time.Now(),
 							UpdatedAt:        time.Now(),
@@ -301,11 +489,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 					expectedLastQuestion := "Some question from AI"
 					expectedLastQuestionTargetBotId := "bot_id4"
 
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_QUESTION", CurrentTurnIndex: 0, LastQuestionTargetBotId: ""}
 					assert.Equal(t, storage.GameUpdateOptions{
 						State:                   &expectedState,
 						StateHandled:            &expectedStateHandled,
 						LastQuestion:            &expectedLastQuestion,
 						LastQuestionTargetBotId: &expectedLastQuestionTargetBotId,
+						ExpectedTurn:            &expectedTurn,
 					}, updateOpts, "game state should be updated with correct update options")
 					return nil
 				},
@@ -331,25 +521,52 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors if unable to get transaction",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    nil,
 			messageCreatorMock: nil,
-			gameAccessorMock:   nil,
-			openAiClientMock:   nil,
-			txShouldCommit:     false,
-			errorExpected:      true,
-			errorString:        "unable to begin a db transaction",
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					return model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "WAITING_FOR_AI_QUESTION",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     true,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now(),
+							Bots:             bots,
+						},
+					)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Some question from AI"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to begin a db transaction",
 		},
 		{
 			name: "errors if cannot get game",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					return nil, errors.New("cannot get game")
 				},
 			},
@@ -359,14 +576,15 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 			errorString:      "cannot get game",
 		},
 		{
-			name: "errors if game has already been handled",
+			name: "errors if game has not been claimed",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -389,7 +607,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_HUMAN_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            true,
+							StateHandled:            false,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -404,17 +622,18 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 			openAiClientMock: nil,
 			txShouldCommit:   false,
 			errorExpected:    true,
-			errorString:      "game has already been handled: game_id1",
+			errorString:      "game has not been claimed: game_id1",
 		},
 		{
 			name: "errors if game not in correct state",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -437,7 +656,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_HUMAN_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -457,12 +676,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors if cannot determine bot to ask a question",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					bots := []*model.Bot{}
 					for i := 0; i < 1; i++ {
 						botOpts := model.BotOptions{
@@ -479,7 +699,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_QUESTION",
 							CurrentTurnIndex:        0,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -499,12 +719,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors if unable to update game state",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -527,7 +748,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_QUESTION",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -550,12 +771,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors and rollsback if unable to create message",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockFailure{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -578,7 +800,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_QUESTION",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -595,11 +817,13 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 					expectedLastQuestion := "Some question from AI"
 					expectedLastQuestionTargetBotId := "bot_id4"
 
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_QUESTION", CurrentTurnIndex: 2, LastQuestionTargetBotId: "bot_id1"}
 					assert.Equal(t, storage.GameUpdateOptions{
 						State:                   &expectedState,
 						StateHandled:            &expectedStateHandled,
 						LastQuestion:            &expectedLastQuestion,
 						LastQuestionTargetBotId: &expectedLastQuestionTargetBotId,
+						ExpectedTurn:            &expectedTurn,
 					}, updateOpts, "game state should be updated with correct update options")
 					return nil
 				},
@@ -613,6 +837,7 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 
 	for _, tt := range tests {
 		openAiClient = tt.openAiClientMock
+		promptRegistry, _ = prompts.LoadRegistry("")
 		minDelayAfterAIResponse = 0
 		maxDelayAfterAIResponse = 1
 		logger = &utilities.NullLogger{}
@@ -648,6 +873,144 @@ func Test_askQuestionOnBehalfOfBot(t *testing.T) {
 	}
 }
 
+func Test_askQuestionOnBehalfOfBot_StopsOnceWorkersAreShuttingDown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	workerCtx = ctx
+	defer func() { workerCtx = context.Background() }()
+
+	openAiClientMock := &openai.MockClientBlocking{Called: make(chan struct{}, 1)}
+	transactionMock := &storage.DatabaseTransactionMock{}
+	messageCreated := false
+	openAiClient = openAiClientMock
+	promptRegistry, _ = prompts.LoadRegistry("")
+	minDelayAfterAIResponse = 8
+	maxDelayAfterAIResponse = 15
+	defer func() {
+		minDelayAfterAIResponse = 0
+		maxDelayAfterAIResponse = 1
+	}()
+	logger = &utilities.NullLogger{}
+	workerStorage = storage.NewStorageAccessorMock(
+		storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+			Transaction: transactionMock,
+		}),
+		storage.WithGameAccessorMock(&storage.GameAccessorConfigurableMock{
+			GetGameInternal: func(gameId string) (*model.Game, error) {
+				bots := []*model.Bot{}
+				for i := 0; i < 5; i++ {
+					botOpts := model.BotOptions{
+						Id:        fmt.Sprintf("bot_id%d", i+1),
+						Name:      fmt.Sprintf("bot%d", i+1),
+						TypeOfBot: "AI",
+					}
+					bot, _ := model.NewBot(botOpts)
+					bots = append(bots, bot)
+				}
+				return model.NewGame(
+					model.GameOptions{
+						Id:               "game_id1",
+						State:            "WAITING_FOR_AI_QUESTION",
+						CurrentTurnIndex: 0,
+						TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+						StateHandled:     true,
+						CreatedAt:        time.Now(),
+						UpdatedAt:        time.Now(),
+						Bots:             bots,
+					},
+				)
+			},
+			UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+				return nil
+			},
+		}),
+		storage.WithMessageCreatorMock(&storage.MessageCreatorConfigurableMock{
+			CreateMessageUsingTransactionInternal: func(sourceBotId, targetBotId, text, messageType string, metadata storage.MessageMetadata, transaction storage.DatabaseTransaction) error {
+				messageCreated = true
+				return nil
+			},
+		}),
+	)
+
+	t.Run("abandons the OpenAI request and the wait after it", func(t *testing.T) {
+		go func() {
+			<-openAiClientMock.Called
+			cancel()
+		}()
+
+		start := time.Now()
+		jc := jobContext{}
+		err := jc.askQuestionOnBehalfOfBot(&work.Job{
+			Args: map[string]interface{}{"gameId": "game_id1", "claimedVersion": 0},
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Duration(minDelayAfterAIResponse)*time.Second)
+		assert.False(t, messageCreated, "message should not have been created")
+		assert.False(t, transactionMock.Rolledback, "transaction should not have begun")
+		assert.False(t, transactionMock.Committed, "transaction should not have committed")
+	})
+}
+
+func Test_askQuestionOnBehalfOfBot_StartsOverOnceTheTurnHasMoved(t *testing.T) {
+	transactionMock := &storage.DatabaseTransactionMock{}
+	openAiClient = &openai.MockClientSuccess{Text: "Some question from AI"}
+	promptRegistry, _ = prompts.LoadRegistry("")
+	logger = &utilities.NullLogger{}
+	reads := 0
+	updates := 0
+	workerStorage = storage.NewStorageAccessorMock(
+		storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+			Transaction: transactionMock,
+		}),
+		storage.WithGameAccessorMock(&storage.GameAccessorConfigurableMock{
+			GetGameInternal: func(gameId string) (*model.Game, error) {
+				reads++
+				bots := []*model.Bot{}
+				for i := 0; i < 5; i++ {
+					botOpts := model.BotOptions{
+						Id:        fmt.Sprintf("bot_id%d", i+1),
+						Name:      fmt.Sprintf("bot%d", i+1),
+						TypeOfBot: "AI",
+					}
+					bot, _ := model.NewBot(botOpts)
+					bots = append(bots, bot)
+				}
+				return model.NewGame(
+					model.GameOptions{
+						Id:               "game_id1",
+						State:            "WAITING_FOR_AI_QUESTION",
+						CurrentTurnIndex: 0,
+						TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+						StateHandled:     true,
+						CreatedAt:        time.Now(),
+						UpdatedAt:        time.Now(),
+						Bots:             bots,
+						// The game has moved on from the version it was claimed at by the time it is read again.
+						Version: int64(reads - 1),
+					},
+				)
+			},
+			UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+				updates++
+				if updates == 1 {
+					return errors.Wrap(storage.ErrConcurrentModification, "gameId: game_id1")
+				}
+				return nil
+			},
+		}),
+		storage.WithMessageCreatorMock(&storage.MessageCreatorMockSuccess{}),
+	)
+
+	t.Run("reads the game again and asks the question", func(t *testing.T) {
+		jc := jobContext{}
+		err := jc.askQuestionOnBehalfOfBot(&work.Job{
+			Args: map[string]interface{}{"gameId": "game_id1", "claimedVersion": 0},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reads, "game should have been read again")
+		assert.True(t, transactionMock.Committed, "transaction should have committed")
+	})
+}
+
 func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -655,6 +1018,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 		transactionMock    *storage.DatabaseTransactionMock
 		messageCreatorMock storage.MessageCreator
 		gameAccessorMock   storage.GameAccessor
+		botAccessorMock    storage.BotAccessor
 		openAiClientMock   openai.Client
 		txShouldCommit     bool
 		errorExpected      bool
@@ -663,12 +1027,13 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "updates game successfully",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					bots := []*model.Bot{}
 					for i := 0; i < 5; i++ {
 						botOpts := model.BotOptions{
@@ -685,7 +1050,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_ANSWER",
 							CurrentTurnIndex:        0,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "Here is a question?",
 							LastQuestionTargetBotId: "bot_id4",
@@ -709,54 +1074,224 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 					expectedStateHandled := false
 					expectedCurrentTurnIndex := int64(1)
 
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_ANSWER", CurrentTurnIndex: 0, LastQuestionTargetBotId: "bot_id4"}
 					assert.Equal(t, storage.GameUpdateOptions{
 						State:            &expectedState,
 						CurrentTurnIndex: &expectedCurrentTurnIndex,
 						StateHandled:     &expectedStateHandled,
+						ExpectedTurn:     &expectedTurn,
 					}, updateOpts, "game state should be updated with correct update options")
 					return nil
 				},
 			},
-			openAiClientMock: &openai.MockClientSuccess{Text: "Some answer from AI"},
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotStatedFactsUsingTransactionInternal: func(botId string, statedFacts []string, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "bot_id4", botId)
+					assert.Equal(t, []string{"I love pizza"}, statedFacts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSequence{Texts: []string{"Some answer from AI", "I love pizza"}},
 			txShouldCommit:   true,
 			errorExpected:    false,
 			errorString:      "",
 		},
 		{
-			name: "errors if gameId not provided",
-			input: map[string]interface{}{
-				"gameId": "",
-			},
-			transactionMock:    nil,
-			messageCreatorMock: nil,
-			gameAccessorMock:   nil,
-			openAiClientMock:   nil,
-			txShouldCommit:     false,
-			errorExpected:      true,
-			errorString:        "gameId is required",
-		},
-		{
-			name: "errors if unable to get transaction",
+			name: "updates game successfully without updating bot when answer states no facts",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    nil,
-			messageCreatorMock: nil,
-			gameAccessorMock:   nil,
-			openAiClientMock:   nil,
-			txShouldCommit:     false,
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					return model.NewGame(
+						model.GameOptions{
+							Id:                      "game_id1",
+							State:                   "WAITING_FOR_AI_ANSWER",
+							CurrentTurnIndex:        0,
+							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:            true,
+							StateTotalTime:          0,
+							LastQuestion:            "Here is a question?",
+							LastQuestionTargetBotId: "bot_id4",
+							CreatedAt:               time.Now(),
+							UpdatedAt:               time.Now(),
+							Bots:                    bots,
+							Messages: []*model.Message{
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q1: what is your name?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A1: My name is Antony Gonsalvez", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q1: What is your name?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "A1: Bot 2 Dot 2", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q2: Second question?", CreatedAt: time.Now(), MessageType: "question"},
+							},
+						},
+					)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "WAITING_FOR_AI_QUESTION"
+					expectedStateHandled := false
+					expectedCurrentTurnIndex := int64(1)
+
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_ANSWER", CurrentTurnIndex: 0, LastQuestionTargetBotId: "bot_id4"}
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:            &expectedState,
+						CurrentTurnIndex: &expectedCurrentTurnIndex,
+						StateHandled:     &expectedStateHandled,
+						ExpectedTurn:     &expectedTurn,
+					}, updateOpts, "game state should be updated with correct update options")
+					return nil
+				},
+			},
+			botAccessorMock:  nil,
+			openAiClientMock: &openai.MockClientSequence{Texts: []string{"Some answer from AI", "NONE"}},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "errors and rolls back if unable to update bot stated facts",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					return model.NewGame(
+						model.GameOptions{
+							Id:                      "game_id1",
+							State:                   "WAITING_FOR_AI_ANSWER",
+							CurrentTurnIndex:        0,
+							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:            true,
+							StateTotalTime:          0,
+							LastQuestion:            "Here is a question?",
+							LastQuestionTargetBotId: "bot_id4",
+							CreatedAt:               time.Now(),
+							UpdatedAt:               time.Now(),
+							Bots:                    bots,
+							Messages: []*model.Message{
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q1: what is your name?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A1: My name is Antony Gonsalvez", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id1", Text: "Q2: Where is the gold?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "A2: what gold!", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q1: What is your name?", CreatedAt: time.Now(), MessageType: "question"},
+								{SourceBotId: "bot_id2", TargetBotId: "bot_id2", Text: "A1: Bot 2 Dot 2", CreatedAt: time.Now(), MessageType: "answer"},
+								{SourceBotId: "bot_id1", TargetBotId: "bot_id2", Text: "Q2: Second question?", CreatedAt: time.Now(), MessageType: "question"},
+							},
+						},
+					)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "WAITING_FOR_AI_QUESTION"
+					expectedStateHandled := false
+					expectedCurrentTurnIndex := int64(1)
+
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_ANSWER", CurrentTurnIndex: 0, LastQuestionTargetBotId: "bot_id4"}
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:            &expectedState,
+						CurrentTurnIndex: &expectedCurrentTurnIndex,
+						StateHandled:     &expectedStateHandled,
+						ExpectedTurn:     &expectedTurn,
+					}, updateOpts, "game state should be updated with correct update options")
+					return nil
+				},
+			},
+			botAccessorMock:  &storage.BotAccessorMockFailure{},
+			openAiClientMock: &openai.MockClientSequence{Texts: []string{"Some answer from AI", "I love pizza"}},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to update bot",
+		},
+		{
+			name: "errors if gameId not provided",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			transactionMock:    nil,
+			messageCreatorMock: nil,
+			gameAccessorMock:   nil,
+			openAiClientMock:   nil,
+			txShouldCommit:     false,
 			errorExpected:      true,
-			errorString:        "unable to begin a db transaction",
+			errorString:        "gameId is required",
+		},
+		{
+			name: "errors if unable to get transaction",
+			input: map[string]interface{}{
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
+			},
+			transactionMock:    nil,
+			messageCreatorMock: nil,
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					return model.NewGame(
+						model.GameOptions{
+							Id:                      "game_id1",
+							State:                   "WAITING_FOR_AI_ANSWER",
+							CurrentTurnIndex:        0,
+							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:            true,
+							LastQuestion:            "what is your name?",
+							LastQuestionTargetBotId: "bot_id1",
+							CreatedAt:               time.Now(),
+							UpdatedAt:               time.Now(),
+							Bots:                    bots,
+						},
+					)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Some answer from AI"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to begin a db transaction",
 		},
 		{
 			name: "errors if cannot get game",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					return nil, errors.New("cannot get game")
 				},
 			},
@@ -766,14 +1301,15 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 			errorString:      "cannot get game",
 		},
 		{
-			name: "errors if game has already been handled",
+			name: "errors if game has not been claimed",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -796,7 +1332,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_HUMAN_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            true,
+							StateHandled:            false,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -811,17 +1347,18 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 			openAiClientMock: nil,
 			txShouldCommit:   false,
 			errorExpected:    true,
-			errorString:      "game has already been handled: game_id1",
+			errorString:      "game has not been claimed: game_id1",
 		},
 		{
 			name: "errors if game not in correct state",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
-			transactionMock:    &storage.DatabaseTransactionMock{},
+			transactionMock:    nil,
 			messageCreatorMock: nil,
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -844,7 +1381,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_HUMAN_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id1",
@@ -864,12 +1401,13 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors if unable to update game state",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -892,7 +1430,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id3",
@@ -915,12 +1453,13 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 		{
 			name: "errors and rollsback if unable to create message",
 			input: map[string]interface{}{
-				"gameId": "game_id1",
+				"gameId":         "game_id1",
+				"claimedVersion": 0,
 			},
 			transactionMock:    &storage.DatabaseTransactionMock{},
 			messageCreatorMock: &storage.MessageCreatorMockFailure{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				GetGameInternal: func(gameId string) (*model.Game, error) {
 					player1, _ := model.NewPlayer(
 						model.PlayerOptions{
 							Id: "player_id1",
@@ -943,7 +1482,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 							State:                   "WAITING_FOR_AI_ANSWER",
 							CurrentTurnIndex:        2,
 							TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:            false,
+							StateHandled:            true,
 							StateTotalTime:          0,
 							LastQuestion:            "what is the answer?",
 							LastQuestionTargetBotId: "bot_id3",
@@ -959,10 +1498,12 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 					expectedStateHandled := false
 					expectedCurrentTurnIndex := int64(3)
 
+					expectedTurn := model.GameTurn{State: "WAITING_FOR_AI_ANSWER", CurrentTurnIndex: 2, LastQuestionTargetBotId: "bot_id3"}
 					assert.Equal(t, storage.GameUpdateOptions{
 						State:            &expectedState,
 						CurrentTurnIndex: &expectedCurrentTurnIndex,
 						StateHandled:     &expectedStateHandled,
+						ExpectedTurn:     &expectedTurn,
 					}, updateOpts, "game state should be updated with correct update options")
 					return nil
 				},
@@ -976,6 +1517,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 
 	for _, tt := range tests {
 		openAiClient = tt.openAiClientMock
+		promptRegistry, _ = prompts.LoadRegistry("")
 		minDelayAfterAIResponse = 0
 		maxDelayAfterAIResponse = 1
 		logger = &utilities.NullLogger{}
@@ -985,6 +1527,7 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 			}),
 			storage.WithGameAccessorMock(tt.gameAccessorMock),
 			storage.WithMessageCreatorMock(tt.messageCreatorMock),
+			storage.WithBotAccessorMock(tt.botAccessorMock),
 		)
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -1011,61 +1554,820 @@ func Test_answerQuestionOnBehalfOfBot(t *testing.T) {
 	}
 }
 
-func Test_deleteExpiredGames(t *testing.T) {
+func Test_answerQuestionOnBehalfOfBot_Reaction(t *testing.T) {
+	aiReactionPercent = 100
+	defer func() { aiReactionPercent = 0 }()
+
+	askedAt := time.Now().Add(-time.Minute)
+	createdMessages := []string{}
+	transactionMock := &storage.DatabaseTransactionMock{}
+	openAiClient = &openai.MockClientSequence{Texts: []string{"Some answer from AI", "None", "😂"}}
+	promptRegistry, _ = prompts.LoadRegistry("")
+	logger = &utilities.NullLogger{}
+	workerStorage = storage.NewStorageAccessorMock(
+		storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+			Transaction: transactionMock,
+		}),
+		storage.WithGameAccessorMock(&storage.GameAccessorConfigurableMock{
+			GetGameInternal: func(gameId string) (*model.Game, error) {
+				bots := []*model.Bot{}
+				for i := 0; i < 5; i++ {
+					botOpts := model.BotOptions{
+						Id:        fmt.Sprintf("bot_id%d", i+1),
+						Name:      fmt.Sprintf("bot%d", i+1),
+						TypeOfBot: "AI",
+					}
+					bot, _ := model.NewBot(botOpts)
+					bots = append(bots, bot)
+				}
+				return model.NewGame(
+					model.GameOptions{
+						Id:                      "game_id1",
+						State:                   "WAITING_FOR_AI_ANSWER",
+						CurrentTurnIndex:        0,
+						TurnOrder:               []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+						LastQuestion:            "Pineapple on pizza?",
+						LastQuestionTargetBotId: "bot_id4",
+						StateHandled:            true,
+						CreatedAt:               time.Now(),
+						UpdatedAt:               time.Now(),
+						Bots:                    bots,
+						Messages: []*model.Message{
+							{SourceBotId: "bot_id1", TargetBotId: "bot_id4", Text: "Pineapple on pizza?", CreatedAt: askedAt, MessageType: "question"},
+						},
+					},
+				)
+			},
+			UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+				return nil
+			},
+		}),
+		storage.WithMessageCreatorMock(&storage.MessageCreatorConfigurableMock{
+			CreateMessageUsingTransactionInternal: func(sourceBotId, targetBotId, text, messageType string, metadata storage.MessageMetadata, transaction storage.DatabaseTransaction) error {
+				createdMessages = append(createdMessages, fmt.Sprintf("%s %s %s %s", messageType, sourceBotId, targetBotId, text))
+				return nil
+			},
+		}),
+	)
+
+	t.Run("ai bot reacts to the question before answering it", func(t *testing.T) {
+		rand.Seed(0)
+		jc := jobContext{}
+		err := jc.answerQuestionOnBehalfOfBot(&work.Job{
+			Args: map[string]interface{}{"gameId": "game_id1", "claimedVersion": 0},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"reaction bot_id4 bot_id1 😂",
+			"answer bot_id4 bot_id4 Some answer from AI",
+		}, createdMessages)
+		assert.True(t, transactionMock.Committed, "transaction should have committed")
+	})
+}
+
+func Test_accuseOnBehalfOfAiBot(t *testing.T) {
+	// The games are read at version 0, which the jobs expect them to still be at when they update them.
+	gameVersion := int64(0)
+	gameWithAccusations := func(state string, aiAccusations bool, aiAccusationCheckedAt *time.Time) (*model.Game, error) {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		for i := 0; i < 2; i++ {
+			player, _ := model.NewPlayer(model.PlayerOptions{Id: fmt.Sprintf("player_id%d", i+1)})
+			bots[i].ConnectPlayer(player)
+		}
+		return model.NewGame(
+			model.GameOptions{
+				Id:                    "game_id1",
+				State:                 state,
+				CurrentTurnIndex:      0,
+				TurnOrder:             []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				StateHandled:          false,
+				CreatedAt:             time.Now(),
+				UpdatedAt:             time.Now(),
+				Bots:                  bots,
+				AiAccusations:         aiAccusations,
+				AiAccusationCheckedAt: aiAccusationCheckedAt,
+				Messages: []*model.Message{
+					{SourceBotId: "bot_id3", TargetBotId: "bot_id1", Text: "What is your name?", CreatedAt: time.Now(), MessageType: "question"},
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "lol idk, whats urs", CreatedAt: time.Now(), MessageType: "answer"},
+				},
+			},
+		)
+	}
+	// The time of the check cannot be known in advance, so it is verified separately from the other update options.
+	assertUpdateOptions := func(t *testing.T, expected storage.GameUpdateOptions, actual storage.GameUpdateOptions) {
+		if assert.NotNil(t, actual.AiAccusationCheckedAt, "time of the check should be recorded") {
+			model.AssertTimeAlmostEqual(t, time.Now(), *actual.AiAccusationCheckedAt, 1*time.Second)
+		}
+		actual.AiAccusationCheckedAt = nil
+		assert.Equal(t, expected, actual, "game state should be updated with correct update options")
+	}
+
 	tests := []struct {
-		name             string
-		input            map[string]interface{}
-		gameAccessorMock storage.GameAccessor
-		errorExpected    bool
-		errorString      string
+		name               string
+		input              map[string]interface{}
+		transactionMock    *storage.DatabaseTransactionMock
+		messageCreatorMock storage.MessageCreator
+		gameAccessorMock   storage.GameAccessor
+		openAiClientMock   openai.Client
+		txShouldCommit     bool
+		errorExpected      bool
+		errorString        string
 	}{
 		{
-			name: "deletes game if present and is not recently updated",
+			name: "tags a human and finishes the game when the ai bot is confident enough",
 			input: map[string]interface{}{
 				"gameId": "game_id1",
 			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameInternal: func(string) (*model.Game, error) {
-					bots := []*model.Bot{}
-					for i := 0; i < 5; i++ {
-						botOpts := model.BotOptions{
-							Id:        fmt.Sprintf("bot_id%d", i+1),
-							Name:      fmt.Sprintf("bot%d", i+1),
-							TypeOfBot: "AI",
-						}
-						bot, _ := model.NewBot(botOpts)
-						bots = append(bots, bot)
-					}
-					return model.NewGame(
-						model.GameOptions{
-							Id:               "game_id1",
-							State:            "PLAYERS_JOINED",
-							CurrentTurnIndex: 0,
-							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
-							StateHandled:     false,
-							StateTotalTime:   0,
-							CreatedAt:        time.Now(),
-							UpdatedAt:        time.Now().Add(-10 * time.Hour),
-							Bots:             bots,
-						},
-					)
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", true, nil)
 				},
-				DeleteGameInternal: func(gameId string) error {
-					assert.Equal(t, "game_id1", gameId)
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "FINISHED"
+					expectedResult := "bot1 was tagged by bot4 and lost. bot2 won."
+					expectedWinningBotId := "bot_id2"
+					assertUpdateOptions(t, storage.GameUpdateOptions{
+						State:           &expectedState,
+						Result:          &expectedResult,
+						WinningBotId:    &expectedWinningBotId,
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
 					return nil
 				},
 			},
-			errorExpected: false,
-			errorString:   "",
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
 		},
 		{
-			name: "continues silently if game is present and is recently updated",
+			name: "only accuses when the ai bot is not confident enough to tag",
 			input: map[string]interface{}{
 				"gameId": "game_id1",
 			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
 			gameAccessorMock: &storage.GameAccessorConfigurableMock{
-				GetGameInternal: func(string) (*model.Game, error) {
-					bots := []*model.Bot{}
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", true, nil)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					assertUpdateOptions(t, storage.GameUpdateOptions{
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 75"},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "only records the check when the ai bot is not confident enough to accuse",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_HUMAN_ANSWER", true, nil)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					assertUpdateOptions(t, storage.GameUpdateOptions{
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 20"},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "only records the check when the ai does not name a suspect",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", true, nil)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					assertUpdateOptions(t, storage.GameUpdateOptions{
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "They all seem like bots to me."},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "does nothing if no human has answered since the game was last checked",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					checkedAt := time.Now().Add(time.Second)
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", true, &checkedAt)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					assert.Fail(t, "game should not be updated")
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "errors if game does not have ai accusations",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    nil,
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", false, nil)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "game does not have ai accusations: game_id1",
+		},
+		{
+			name: "errors if game is not in play",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    nil,
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("FINISHED", true, nil)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "game should be in play: game_id1",
+		},
+		{
+			name: "errors if message could not be created",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return gameWithAccusations("WAITING_FOR_AI_QUESTION", true, nil)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 75"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to create message",
+		},
+		{
+			name: "errors if gameId is blank",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			transactionMock:  nil,
+			gameAccessorMock: nil,
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "gameId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		openAiClient = tt.openAiClientMock
+		promptRegistry, _ = prompts.LoadRegistry("")
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+				Transaction: tt.transactionMock,
+			}),
+			storage.WithGameAccessorMock(tt.gameAccessorMock),
+			storage.WithMessageCreatorMock(tt.messageCreatorMock),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			jc := jobContext{}
+			err := jc.accuseOnBehalfOfAiBot(&work.Job{
+				Args: tt.input,
+			})
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.transactionMock != nil {
+				if tt.txShouldCommit {
+					assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				} else {
+					assert.True(t, tt.transactionMock.Rolledback, "transaction should have rolledback")
+					assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				}
+			}
+		})
+	}
+}
+
+func Test_closeVoting(t *testing.T) {
+	votingGame := func(votingStartedAt time.Time, aiVotes bool) (*model.Game, error) {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		for i := 0; i < 2; i++ {
+			player, _ := model.NewPlayer(model.PlayerOptions{Id: fmt.Sprintf("player_id%d", i+1)})
+			bots[i].ConnectPlayer(player)
+		}
+		return model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            "VOTING",
+				CurrentTurnIndex: 10,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				StateHandled:     false,
+				StateHandledAt:   &votingStartedAt,
+				StateTotalTime:   60,
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				VotingRounds:     2,
+				AiVotes:          aiVotes,
+				Votes: []*model.Vote{
+					{VoterBotId: "bot_id2", SuspectBotId: "bot_id1"},
+				},
+				Messages: []*model.Message{
+					{SourceBotId: "bot_id3", TargetBotId: "bot_id1", Text: "What is your name?", CreatedAt: time.Now(), MessageType: "question"},
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "lol idk, whats urs", CreatedAt: time.Now(), MessageType: "answer"},
+				},
+			},
+		)
+	}
+	votingStartedAt := time.Now().Add(-2 * time.Minute)
+
+	tests := []struct {
+		name             string
+		input            map[string]interface{}
+		transactionMock  *storage.DatabaseTransactionMock
+		voteCreatorMock  storage.VoteCreator
+		gameAccessorMock storage.GameAccessor
+		openAiClientMock openai.Client
+		txShouldCommit   bool
+		errorExpected    bool
+		errorString      string
+	}{
+		{
+			name: "tallies the votes of humans and ai bots and finishes the game",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			voteCreatorMock: &storage.VoteCreatorConfigurableMock{
+				CreateVoteUsingTransactionInternal: func(gameId, voterBotId, suspectBotId string, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "game_id1", gameId)
+					assert.Contains(t, []string{"bot_id3", "bot_id4", "bot_id5"}, voterBotId)
+					assert.Equal(t, "bot_id1", suspectBotId)
+					return nil
+				},
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return votingGame(votingStartedAt, true)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame(votingStartedAt, true)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "FINISHED"
+					expectedResult := "bot1 got the most votes and lost. bot2 won."
+					expectedWinningBotId := "bot_id2"
+					expectedStateHandled := true
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:        &expectedState,
+						Result:       &expectedResult,
+						WinningBotId: &expectedWinningBotId,
+						StateHandled: &expectedStateHandled,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "tallies only the human votes when ai bots do not vote",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			voteCreatorMock: &storage.VoteCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return votingGame(votingStartedAt, false)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame(votingStartedAt, false)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "FINISHED"
+					expectedResult := "bot1 got the most votes and lost. bot2 won."
+					expectedWinningBotId := "bot_id2"
+					expectedStateHandled := true
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:        &expectedState,
+						Result:       &expectedResult,
+						WinningBotId: &expectedWinningBotId,
+						StateHandled: &expectedStateHandled,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "errors if vote could not be created",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			voteCreatorMock: &storage.VoteCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return votingGame(votingStartedAt, true)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame(votingStartedAt, true)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to cast vote",
+		},
+		{
+			name: "errors if voting has not closed yet",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: nil,
+			voteCreatorMock: &storage.VoteCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return votingGame(time.Now(), true)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return votingGame(time.Now(), true)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "game voting should have closed: game_id1",
+		},
+		{
+			name: "errors if gameId is blank",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			transactionMock:  nil,
+			gameAccessorMock: nil,
+			openAiClientMock: &openai.MockClientSuccess{Text: "Suspect: bot1\nConfidence: 95"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "gameId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		openAiClient = tt.openAiClientMock
+		promptRegistry, _ = prompts.LoadRegistry("")
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+				Transaction: tt.transactionMock,
+			}),
+			storage.WithGameAccessorMock(tt.gameAccessorMock),
+			storage.WithVoteCreatorMock(tt.voteCreatorMock),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			jc := jobContext{}
+			err := jc.closeVoting(&work.Job{
+				Args: tt.input,
+			})
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.transactionMock != nil {
+				if tt.txShouldCommit {
+					assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				} else {
+					assert.True(t, tt.transactionMock.Rolledback, "transaction should have rolledback")
+					assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				}
+			}
+		})
+	}
+}
+
+func Test_analyzeFinishedGame(t *testing.T) {
+	analyzableGame := func(state string, version int64) (*model.Game, error) {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		player, _ := model.NewPlayer(model.PlayerOptions{Id: "player_id1"})
+		bots[0].ConnectPlayer(player)
+		return model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            state,
+				CurrentTurnIndex: 10,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				Version:          version,
+				Messages: []*model.Message{
+					{SourceBotId: "bot_id3", TargetBotId: "bot_id1", Text: "What is your name?", CreatedAt: time.Now(), MessageType: "question"},
+					{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "lol idk, whats urs", CreatedAt: time.Now(), MessageType: "answer", ResponseTime: 30 * time.Second},
+				},
+			},
+		)
+	}
+	// Every time the game is locked, it has changed since it was last read.
+	changingGame := func(changes int) *storage.GameAccessorConfigurableMock {
+		version := int64(0)
+		return &storage.GameAccessorConfigurableMock{
+			GetGameInternal: func(gameId string) (*model.Game, error) {
+				return analyzableGame("FINISHED", version)
+			},
+			GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+				if changes > 0 {
+					changes--
+					version++
+				}
+				return analyzableGame("FINISHED", version)
+			},
+		}
+	}
+
+	tests := []struct {
+		name                     string
+		input                    map[string]interface{}
+		transactionMock          *storage.DatabaseTransactionMock
+		gameAnalysisAccessorMock storage.GameAnalysisAccessor
+		gameAccessorMock         storage.GameAccessor
+		txShouldCommit           bool
+		errorExpected            bool
+		errorString              string
+	}{
+		{
+			name: "stores the analysis of every bot",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: &storage.DatabaseTransactionMock{},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorConfigurableMock{
+				CreateGameAnalysisUsingTransactionInternal: func(analysis model.GameAnalysis, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "game_id1", analysis.GameId)
+					assert.Len(t, analysis.BotAnalyses, 5)
+					assert.Equal(t, model.BotAnalysis{BotId: "bot_id1", HumannessScore: 92, Tells: []string{"lol idk, whats urs"}}, analysis.BotAnalyses[0])
+					assert.Equal(t, model.BotAnalysis{BotId: "bot_id2", HumannessScore: 50, Tells: []string{}}, analysis.BotAnalyses[1])
+					return nil
+				},
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return analyzableGame("FINISHED", 0)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return analyzableGame("FINISHED", 0)
+				},
+			},
+			txShouldCommit: true,
+			errorExpected:  false,
+			errorString:    "",
+		},
+		{
+			name: "errors if the analysis could not be stored",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:          &storage.DatabaseTransactionMock{},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return analyzableGame("FINISHED", 0)
+				},
+				GetGameUsingTransactionInternal: func(gameId string, transaction storage.DatabaseTransaction) (*model.Game, error) {
+					return analyzableGame("FINISHED", 0)
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "unable to create game analysis",
+		},
+		{
+			name: "starts over if the game changed while it was analyzed",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:          &storage.DatabaseTransactionMock{},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockSuccess{},
+			gameAccessorMock:         changingGame(1),
+			txShouldCommit:           true,
+			errorExpected:            false,
+			errorString:              "",
+		},
+		{
+			name: "errors if the game keeps changing while it is analyzed",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:          &storage.DatabaseTransactionMock{},
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockFailure{},
+			gameAccessorMock:         changingGame(CONCURRENT_MODIFICATION_ATTEMPTS),
+			txShouldCommit:           false,
+			errorExpected:            true,
+			errorString:              "gameId: game_id1, expected version: 2: game was modified concurrently",
+		},
+		{
+			name: "errors if the game has not finished",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:          nil,
+			gameAnalysisAccessorMock: &storage.GameAnalysisAccessorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return analyzableGame("VOTING", 0)
+				},
+			},
+			txShouldCommit: false,
+			errorExpected:  true,
+			errorString:    "game should be finished: game_id1",
+		},
+		{
+			name: "errors if gameId is blank",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			transactionMock:  nil,
+			gameAccessorMock: nil,
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "gameId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		openAiClient = &openai.MockClientSuccess{Text: "Score: 100"}
+		promptRegistry, _ = prompts.LoadRegistry("")
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+				Transaction: tt.transactionMock,
+			}),
+			storage.WithGameAccessorMock(tt.gameAccessorMock),
+			storage.WithGameAnalysisAccessorMock(tt.gameAnalysisAccessorMock),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			jc := jobContext{}
+			err := jc.analyzeFinishedGame(&work.Job{
+				Args: tt.input,
+			})
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.transactionMock != nil {
+				if tt.txShouldCommit {
+					assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				} else {
+					assert.True(t, tt.transactionMock.Rolledback, "transaction should have rolledback")
+					assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				}
+			}
+		})
+	}
+}
+
+func Test_deleteExpiredGames(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            map[string]interface{}
+		gameAccessorMock storage.GameAccessor
+		errorExpected    bool
+		errorString      string
+	}{
+		{
+			name: "deletes game if present and is not recently updated",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					bots := []*model.Bot{}
+					for i := 0; i < 5; i++ {
+						botOpts := model.BotOptions{
+							Id:        fmt.Sprintf("bot_id%d", i+1),
+							Name:      fmt.Sprintf("bot%d", i+1),
+							TypeOfBot: "AI",
+						}
+						bot, _ := model.NewBot(botOpts)
+						bots = append(bots, bot)
+					}
+					return model.NewGame(
+						model.GameOptions{
+							Id:               "game_id1",
+							State:            "PLAYERS_JOINED",
+							CurrentTurnIndex: 0,
+							TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+							StateHandled:     false,
+							StateTotalTime:   0,
+							CreatedAt:        time.Now(),
+							UpdatedAt:        time.Now().Add(-10 * time.Hour),
+							Bots:             bots,
+						},
+					)
+				},
+				DeleteGameInternal: func(gameId string) error {
+					assert.Equal(t, "game_id1", gameId)
+					return nil
+				},
+			},
+			errorExpected: false,
+			errorString:   "",
+		},
+		{
+			name: "continues silently if game is present and is recently updated",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(string) (*model.Game, error) {
+					bots := []*model.Bot{}
 					for i := 0; i < 5; i++ {
 						botOpts := model.BotOptions{
 							Id:        fmt.Sprintf("bot_id%d", i+1),
@@ -1138,3 +2440,430 @@ func Test_deleteExpiredGames(t *testing.T) {
 		})
 	}
 }
+
+func Test_chatOnBehalfOfAiBots(t *testing.T) {
+	// The games are read at version 0, which the jobs expect them to still be at when they update them.
+	gameVersion := int64(0)
+	groupChatGame := func(state string, chatStartedAt time.Time, messages []*model.Message) (*model.Game, error) {
+		bots := []*model.Bot{}
+		for i := 0; i < 5; i++ {
+			botOpts := model.BotOptions{
+				Id:        fmt.Sprintf("bot_id%d", i+1),
+				Name:      fmt.Sprintf("bot%d", i+1),
+				TypeOfBot: "AI",
+			}
+			bot, _ := model.NewBot(botOpts)
+			bots = append(bots, bot)
+		}
+		for i := 0; i < 2; i++ {
+			player, _ := model.NewPlayer(model.PlayerOptions{Id: fmt.Sprintf("player_id%d", i+1)})
+			bots[i].ConnectPlayer(player)
+		}
+		return model.NewGame(
+			model.GameOptions{
+				Id:               "game_id1",
+				State:            state,
+				CurrentTurnIndex: 0,
+				TurnOrder:        []string{"bot_id1", "bot_id2", "bot_id3", "bot_id4", "bot_id5"},
+				StateHandled:     false,
+				StateHandledAt:   &chatStartedAt,
+				StateTotalTime:   300,
+				CreatedAt:        time.Now(),
+				UpdatedAt:        time.Now(),
+				Bots:             bots,
+				Mode:             "GROUP_CHAT",
+				Messages:         messages,
+			},
+		)
+	}
+
+	tests := []struct {
+		name               string
+		input              map[string]interface{}
+		transactionMock    *storage.DatabaseTransactionMock
+		messageCreatorMock storage.MessageCreator
+		gameAccessorMock   storage.GameAccessor
+		botAccessorMock    storage.BotAccessor
+		openAiClientMock   openai.Client
+		txShouldCommit     bool
+		errorExpected      bool
+		errorString        string
+	}{
+		{
+			name: "posts a chat message for the ai bot that was mentioned",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockSuccess{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return groupChatGame("CHATTING", time.Now().Add(-time.Minute), []*model.Message{
+						{SourceBotId: "bot_id1", TargetBotId: "bot_id1", Text: "bot4, what do you like to eat?", CreatedAt: time.Now().Add(-10 * time.Second), MessageType: "chat"},
+					})
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					expectedState := "CHATTING"
+					expectedStateHandled := false
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:           &expectedState,
+						StateHandled:    &expectedStateHandled,
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
+					return nil
+				},
+			},
+			botAccessorMock: &storage.BotAccessorConfigurableMock{
+				UpdateBotStatedFactsUsingTransactionInternal: func(botId string, statedFacts []string, transaction storage.DatabaseTransaction) error {
+					assert.Equal(t, "bot_id4", botId)
+					assert.Equal(t, []string{"I love pizza"}, statedFacts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSequence{Texts: []string{"Pizza, obviously", "I love pizza"}},
+			txShouldCommit:   true,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "does nothing if no ai bot is due to speak",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    nil,
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return groupChatGame("CHATTING", time.Now(), nil)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Hello everyone"},
+			txShouldCommit:   false,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "finishes the game once time is up",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    nil,
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return groupChatGame("CHATTING", time.Now().Add(-10*time.Minute), nil)
+				},
+				UpdateGameStateInternal: func(gameId string, updateOpts storage.GameUpdateOptions) error {
+					expectedState := "FINISHED"
+					expectedResult := "Time is up and nobody was tagged. It's a draw."
+					expectedStateHandled := true
+					assert.Equal(t, storage.GameUpdateOptions{
+						State:           &expectedState,
+						Result:          &expectedResult,
+						StateHandled:    &expectedStateHandled,
+						ExpectedVersion: &gameVersion,
+					}, updateOpts)
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Hello everyone"},
+			txShouldCommit:   false,
+			errorExpected:    false,
+			errorString:      "",
+		},
+		{
+			name: "errors if message could not be created",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock:    &storage.DatabaseTransactionMock{},
+			messageCreatorMock: &storage.MessageCreatorMockFailure{},
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return groupChatGame("CHATTING", time.Now().Add(-time.Minute), nil)
+				},
+				UpdateGameStateUsingTransactionInternal: func(gameId string, updateOpts storage.GameUpdateOptions, transaction storage.DatabaseTransaction) error {
+					return nil
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Hello everyone"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "unable to create message",
+		},
+		{
+			name: "errors if game is not in chatting state",
+			input: map[string]interface{}{
+				"gameId": "game_id1",
+			},
+			transactionMock: nil,
+			gameAccessorMock: &storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return groupChatGame("FINISHED", time.Now(), nil)
+				},
+			},
+			openAiClientMock: &openai.MockClientSuccess{Text: "Hello everyone"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "game should be in Chatting state: game_id1",
+		},
+		{
+			name: "errors if gameId is blank",
+			input: map[string]interface{}{
+				"gameId": "",
+			},
+			transactionMock:  nil,
+			gameAccessorMock: nil,
+			openAiClientMock: &openai.MockClientSuccess{Text: "Hello everyone"},
+			txShouldCommit:   false,
+			errorExpected:    true,
+			errorString:      "gameId is required",
+		},
+	}
+
+	for _, tt := range tests {
+		openAiClient = tt.openAiClientMock
+		promptRegistry, _ = prompts.LoadRegistry("")
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithDatabaseTransactionProviderMock(&storage.DatabaseTransactionProviderMock{
+				Transaction: tt.transactionMock,
+			}),
+			storage.WithGameAccessorMock(tt.gameAccessorMock),
+			storage.WithMessageCreatorMock(tt.messageCreatorMock),
+			storage.WithBotAccessorMock(tt.botAccessorMock),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			rand.Seed(0)
+			jc := jobContext{}
+			err := jc.chatOnBehalfOfAiBots(&work.Job{
+				Args: tt.input,
+			})
+			if tt.errorExpected {
+				assert.EqualError(t, err, tt.errorString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.transactionMock != nil {
+				if tt.txShouldCommit {
+					assert.True(t, tt.transactionMock.Committed, "transaction should have committed")
+				} else {
+					assert.True(t, tt.transactionMock.Rolledback, "transaction should have rolledback")
+					assert.False(t, tt.transactionMock.Committed, "transaction should not have committed")
+				}
+			}
+		})
+	}
+}
+
+// openAiClientMockWaitingForBothJobs holds the first calls until both jobs have made one, so that both jobs come up
+// with their messages from the game as it was before either of them wrote.
+type openAiClientMockWaitingForBothJobs struct {
+	openai.MockClientSuccess
+	mu    sync.Mutex
+	calls int
+	wg    *sync.WaitGroup
+}
+
+func (m *openAiClientMockWaitingForBothJobs) CallCompletionApi(ctx context.Context, prompt string) (*openai.Completion, error) {
+	m.mu.Lock()
+	m.calls++
+	waitForOtherJob := m.calls <= 2
+	m.mu.Unlock()
+	if waitForOtherJob {
+		m.wg.Done()
+		m.wg.Wait()
+	}
+	return m.MockClientSuccess.CallCompletionApi(ctx, prompt)
+}
+
+func Test_chatOnBehalfOfAiBots_TwoJobsForOneGame(t *testing.T) {
+	ctx := context.Background()
+	memoryStorage := storage.NewMemoryStorage(storage.MemoryStorageOptions{})
+	gameId, err := memoryStorage.CreateGame(ctx, storage.GameCreateOptions{Mode: "GROUP_CHAT"})
+	assert.NoError(t, err)
+	game, err := memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	for _, bot := range game.Bots()[:2] {
+		player, err := memoryStorage.CreatePlayer(ctx)
+		assert.NoError(t, err)
+		tx, err := memoryStorage.BeginTransaction(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, memoryStorage.UpdateBotWithPlayerIdUsingTransaction(ctx, bot.Id(), player.Id(), 0, tx))
+		assert.NoError(t, tx.Commit())
+	}
+	// The chat started long enough ago that an AI bot is due to break the silence.
+	chatting := "CHATTING"
+	chatStartedAt := time.Now().Add(-time.Minute)
+	chatTime := int64(300)
+	err = memoryStorage.UpdateGameState(ctx, gameId, storage.GameUpdateOptions{State: &chatting, StateHandledAt: &chatStartedAt, StateTotalTime: &chatTime})
+	assert.NoError(t, err)
+
+	var bothJobsCalled sync.WaitGroup
+	bothJobsCalled.Add(2)
+	openAiClient = &openAiClientMockWaitingForBothJobs{MockClientSuccess: openai.MockClientSuccess{Text: "Hello everyone"}, wg: &bothJobsCalled}
+	promptRegistry, _ = prompts.LoadRegistry("")
+	logger = &utilities.NullLogger{}
+	workerStorage = memoryStorage
+
+	var jobs sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			jc := jobContext{}
+			err := jc.chatOnBehalfOfAiBots(&work.Job{
+				Args: map[string]interface{}{"gameId": gameId},
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	jobs.Wait()
+
+	game, err = memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	assert.Len(t, game.GetDetailedMessages(), 1, "only one of the jobs should have posted")
+}
+
+func Test_accuseOnBehalfOfAiBot_TwoJobsForOneGame(t *testing.T) {
+	ctx := context.Background()
+	memoryStorage := storage.NewMemoryStorage(storage.MemoryStorageOptions{})
+	gameId, err := memoryStorage.CreateGame(ctx, storage.GameCreateOptions{AiAccusations: true})
+	assert.NoError(t, err)
+	game, err := memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	humanBot := game.Bots()[0]
+	player, err := memoryStorage.CreatePlayer(ctx)
+	assert.NoError(t, err)
+	tx, err := memoryStorage.BeginTransaction(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, memoryStorage.UpdateBotWithPlayerIdUsingTransaction(ctx, humanBot.Id(), player.Id(), 0, tx))
+	assert.NoError(t, tx.Commit())
+	// The human has answered, so the AI bots have something new to consider.
+	waitingForAiQuestion := "WAITING_FOR_AI_QUESTION"
+	err = memoryStorage.UpdateGameState(ctx, gameId, storage.GameUpdateOptions{State: &waitingForAiQuestion})
+	assert.NoError(t, err)
+	err = memoryStorage.CreateMessage(ctx, humanBot.Id(), humanBot.Id(), "I live by the sea", "answer", storage.MessageMetadata{})
+	assert.NoError(t, err)
+
+	var bothJobsCalled sync.WaitGroup
+	bothJobsCalled.Add(2)
+	openAiClient = &openAiClientMockWaitingForBothJobs{
+		MockClientSuccess: openai.MockClientSuccess{Text: fmt.Sprintf("Suspect: %s\nConfidence: 80", humanBot.Name())},
+		wg:                &bothJobsCalled,
+	}
+	promptRegistry, _ = prompts.LoadRegistry("")
+	logger = &utilities.NullLogger{}
+	workerStorage = memoryStorage
+
+	var jobs sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			jc := jobContext{}
+			err := jc.accuseOnBehalfOfAiBot(&work.Job{
+				Args: map[string]interface{}{"gameId": gameId},
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	jobs.Wait()
+
+	game, err = memoryStorage.GetGame(ctx, gameId)
+	assert.NoError(t, err)
+	accusationCount := 0
+	for _, message := range game.GetDetailedMessages() {
+		if message.MessageType == "accusation" {
+			accusationCount++
+		}
+	}
+	assert.Equal(t, 1, accusationCount, "only one of the jobs should have accused")
+}
+
+func Test_releaseClaimOnceFailedForGood(t *testing.T) {
+	claimedGame := func(state string) (*model.Game, error) {
+		bot, _ := model.NewBot(model.BotOptions{Id: "bot_id1", Name: "bot1", TypeOfBot: "AI"})
+		return model.NewGame(
+			model.GameOptions{
+				Id:           "game_id1",
+				State:        state,
+				TurnOrder:    []string{"bot_id1"},
+				StateHandled: true,
+				CreatedAt:    time.Now(),
+				UpdatedAt:    time.Now(),
+				Bots:         []*model.Bot{bot},
+				Version:      3,
+			},
+		)
+	}
+	stateHandled := false
+	version := int64(3)
+
+	tests := []struct {
+		name            string
+		job             *work.Job
+		jobErr          error
+		gameState       string
+		expectedUpdates []storage.GameUpdateOptions
+	}{
+		{
+			name:            "releases the claim once the job has failed for the last time",
+			job:             &work.Job{Name: ASK_QUESTION_ON_BEHALF_OF_BOT, Fails: CLAIMED_GAME_JOB_MAX_FAILS - 1, Args: map[string]interface{}{"gameId": "game_id1"}},
+			jobErr:          errors.New("openai is down"),
+			gameState:       "WAITING_FOR_AI_QUESTION",
+			expectedUpdates: []storage.GameUpdateOptions{{StateHandled: &stateHandled, ExpectedVersion: &version}},
+		},
+		{
+			name:      "keeps the claim while the job is going to be retried",
+			job:       &work.Job{Name: ASK_QUESTION_ON_BEHALF_OF_BOT, Fails: CLAIMED_GAME_JOB_MAX_FAILS - 2, Args: map[string]interface{}{"gameId": "game_id1"}},
+			jobErr:    errors.New("openai is down"),
+			gameState: "WAITING_FOR_AI_QUESTION",
+		},
+		{
+			name:      "keeps the claim when the job succeeds",
+			job:       &work.Job{Name: ASK_QUESTION_ON_BEHALF_OF_BOT, Fails: CLAIMED_GAME_JOB_MAX_FAILS - 1, Args: map[string]interface{}{"gameId": "game_id1"}},
+			gameState: "WAITING_FOR_AI_QUESTION",
+		},
+		{
+			name:      "leaves the game alone once it has moved on from the state it was claimed in",
+			job:       &work.Job{Name: ASK_QUESTION_ON_BEHALF_OF_BOT, Fails: CLAIMED_GAME_JOB_MAX_FAILS - 1, Args: map[string]interface{}{"gameId": "game_id1"}},
+			jobErr:    errors.New("openai is down"),
+			gameState: "WAITING_FOR_HUMAN_ANSWER",
+		},
+		{
+			name:      "leaves the games of other jobs alone",
+			job:       &work.Job{Name: CLOSE_VOTING, Fails: CLAIMED_GAME_JOB_MAX_FAILS - 1, Args: map[string]interface{}{"gameId": "game_id1"}},
+			jobErr:    errors.New("could not close voting"),
+			gameState: "VOTING",
+		},
+	}
+
+	for _, tt := range tests {
+		var updates []storage.GameUpdateOptions
+		logger = &utilities.NullLogger{}
+		workerStorage = storage.NewStorageAccessorMock(
+			storage.WithGameAccessorMock(&storage.GameAccessorConfigurableMock{
+				GetGameInternal: func(gameId string) (*model.Game, error) {
+					return claimedGame(tt.gameState)
+				},
+				UpdateGameStateInternal: func(gameId string, updateOpts storage.GameUpdateOptions) error {
+					assert.Equal(t, "game_id1", gameId)
+					updates = append(updates, updateOpts)
+					return nil
+				},
+			}),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			jc := jobContext{}
+			err := jc.releaseClaimOnceFailedForGood(tt.job, func() error {
+				return tt.jobErr
+			})
+			assert.Equal(t, tt.jobErr, err, "the job should fail the way it would have")
+			assert.Equal(t, tt.expectedUpdates, updates)
+		})
+	}
+}
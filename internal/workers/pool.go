@@ -1,9 +1,13 @@
 package workers
 
 import (
+	"context"
+
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
 	"github.com/vipulvpatil/airetreat-go/internal/clients/openai"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
 )
@@ -12,34 +16,104 @@ const START_GAME_ONCE_PLAYERS_HAVE_JOINED = "start_game_once_players_have_joined
 const ASK_QUESTION_ON_BEHALF_OF_BOT = "ask_question_on_behalf_of_bot"
 const ANSWER_QUESTION_ON_BEHALF_OF_BOT = "answer_question_on_behalf_of_bot"
 const DELETE_EXPIRED_GAMES = "delete_expired_games"
+const ACCUSE_ON_BEHALF_OF_AI_BOT = "accuse_on_behalf_of_ai_bot"
+const START_NEXT_ROUND = "start_next_round"
+const CLOSE_VOTING = "close_voting"
+const CHAT_ON_BEHALF_OF_AI_BOTS = "chat_on_behalf_of_ai_bots"
+const ANALYZE_FINISHED_GAME = "analyze_finished_game"
+
+// JobsForClaimedGameStates are the jobs for the states where exactly one job should handle the game, which is why
+// the game is claimed before the job is started.
+var JobsForClaimedGameStates = map[string]string{
+	"PLAYERS_JOINED":          START_GAME_ONCE_PLAYERS_HAVE_JOINED,
+	"BOT_ELIMINATED":          START_NEXT_ROUND,
+	"WAITING_FOR_AI_QUESTION": ASK_QUESTION_ON_BEHALF_OF_BOT,
+	"WAITING_FOR_AI_ANSWER":   ANSWER_QUESTION_ON_BEHALF_OF_BOT,
+}
+
+// CLAIMED_GAME_JOB_MAX_FAILS is how many times a job for a claimed game runs before it fails for good.
+const CLAIMED_GAME_JOB_MAX_FAILS = 4
 
+// workerCtx is done once the workers are shutting down, which abandons any OpenAI request or query that is still running.
+var workerCtx context.Context = context.Background()
 var workerStorage storage.StorageAccessor
 var openAiClient openai.Client
+var promptRegistry *prompts.Registry
 var minDelayAfterAIResponse int
 var maxDelayAfterAIResponse int
+var aiReactionPercent int
 var logger utilities.Logger
 
 type PoolDependencies struct {
+	// Ctx is cancelled to stop the jobs that are running. Defaults to a context that is never done.
+	Ctx          context.Context
 	Namespace    string
 	RedisPool    *redis.Pool
 	Storage      storage.StorageAccessor
-	OpenAiApiKey string
+	OpenAiClient openai.Client
+	Prompts      *prompts.Registry
 	Logger       utilities.Logger
 }
 
+// jobHandlers are the handlers of the jobs, by job name.
+var jobHandlers = map[string]func(*jobContext, *work.Job) error{
+	START_GAME_ONCE_PLAYERS_HAVE_JOINED: (*jobContext).startGameOncePlayersHaveJoined,
+	ASK_QUESTION_ON_BEHALF_OF_BOT:       (*jobContext).askQuestionOnBehalfOfBot,
+	ANSWER_QUESTION_ON_BEHALF_OF_BOT:    (*jobContext).answerQuestionOnBehalfOfBot,
+	DELETE_EXPIRED_GAMES:                (*jobContext).deleteExpiredGames,
+	ACCUSE_ON_BEHALF_OF_AI_BOT:          (*jobContext).accuseOnBehalfOfAiBot,
+	START_NEXT_ROUND:                    (*jobContext).startNextRound,
+	CLOSE_VOTING:                        (*jobContext).closeVoting,
+	CHAT_ON_BEHALF_OF_AI_BOTS:           (*jobContext).chatOnBehalfOfAiBots,
+	ANALYZE_FINISHED_GAME:               (*jobContext).analyzeFinishedGame,
+}
+
 func NewPool(deps PoolDependencies) *work.WorkerPool {
 	pool := work.NewWorkerPool(jobContext{}, 10, deps.Namespace, deps.RedisPool)
 
-	pool.Job(START_GAME_ONCE_PLAYERS_HAVE_JOINED, (*jobContext).startGameOncePlayersHaveJoined)
-	pool.Job(ASK_QUESTION_ON_BEHALF_OF_BOT, (*jobContext).askQuestionOnBehalfOfBot)
-	pool.Job(ANSWER_QUESTION_ON_BEHALF_OF_BOT, (*jobContext).answerQuestionOnBehalfOfBot)
-	pool.Job(DELETE_EXPIRED_GAMES, (*jobContext).deleteExpiredGames)
+	claimedGameJobs := map[string]bool{}
+	for _, jobName := range JobsForClaimedGameStates {
+		claimedGameJobs[jobName] = true
+	}
+	pool.Middleware((*jobContext).releaseClaimOnceFailedForGood)
+	for jobName, handler := range jobHandlers {
+		jobOptions := work.JobOptions{}
+		if claimedGameJobs[jobName] {
+			jobOptions.MaxFails = CLAIMED_GAME_JOB_MAX_FAILS
+		}
+		pool.JobWithOptions(jobName, jobOptions, handler)
+	}
 
-	// TODO: Not sure if this is the best way to do this. But using Package variables for all dependencies required inside any of the jobs.
-	workerStorage = deps.Storage
-	logger = deps.Logger
-	openAiClient = openai.NewClient(openai.OpenAiClientOptions{ApiKey: deps.OpenAiApiKey}, logger)
+	setDependencies(deps)
 	minDelayAfterAIResponse = 8
 	maxDelayAfterAIResponse = 15
+	aiReactionPercent = 25
 	return pool
 }
+
+// RunJob runs a job right away, in the calling goroutine, with deps. The AI bots respond without waiting and never
+// react. It is a hook for tests that play through what the jobs do, without redis or a worker pool.
+func RunJob(deps PoolDependencies, jobName string, args map[string]interface{}) error {
+	handler, ok := jobHandlers[jobName]
+	if !ok {
+		return errors.Errorf("no such job: %s", jobName)
+	}
+
+	setDependencies(deps)
+	minDelayAfterAIResponse = 0
+	maxDelayAfterAIResponse = 1
+	aiReactionPercent = 0
+	return handler(&jobContext{}, &work.Job{Name: jobName, Args: args})
+}
+
+// TODO: Not sure if this is the best way to do this. But using Package variables for all dependencies required inside any of the jobs.
+func setDependencies(deps PoolDependencies) {
+	workerCtx = deps.Ctx
+	if workerCtx == nil {
+		workerCtx = context.Background()
+	}
+	workerStorage = deps.Storage
+	logger = deps.Logger
+	promptRegistry = deps.Prompts
+	openAiClient = deps.OpenAiClient
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/vipulvpatil/airetreat-go/internal/config"
 	"github.com/vipulvpatil/airetreat-go/internal/health"
 	"github.com/vipulvpatil/airetreat-go/internal/server"
+	"github.com/vipulvpatil/airetreat-go/internal/services/prompts"
 	"github.com/vipulvpatil/airetreat-go/internal/storage"
 	"github.com/vipulvpatil/airetreat-go/internal/tls"
 	"github.com/vipulvpatil/airetreat-go/internal/utilities"
@@ -27,6 +28,7 @@ import (
 )
 
 const WORKER_NAMESPACE = "airetreat_go"
+const GAME_CACHE_SIZE = 1000
 
 func main() {
 	rand.Seed(time.Now().UTC().UnixNano())
@@ -62,15 +64,34 @@ func main() {
 		log.Fatalf("Unable to initialize database: %v", err)
 	}
 
+	readReplicaDb, err := storage.InitReadReplicaDb(cfg, logger)
+	if err != nil {
+		log.Fatalf("Unable to initialize read replica: %v", err)
+	}
+
 	dbStorage, err := storage.NewDbStorage(
 		storage.StorageOptions{
-			Db: db,
+			Db:            db,
+			ReadReplicaDb: readReplicaDb,
+			GameCacheSize: GAME_CACHE_SIZE,
+			QueryTimeout:  cfg.DbQueryTimeout,
+			RetryPolicy:   storage.RetryPolicyFromConfig(cfg),
 		},
 	)
 	if err != nil {
 		log.Fatalf("Unable to initialize storage: %v", err)
 	}
 
+	promptRegistry, err := prompts.LoadRegistry(cfg.PromptsDir)
+	if err != nil {
+		log.Fatalf("Unable to load prompts: %v", err)
+	}
+
+	promptExperiment, err := prompts.NewExperiment(cfg.PromptExperiment, promptRegistry)
+	if err != nil {
+		log.Fatalf("Unable to initialize prompt experiment: %v", err)
+	}
+
 	redisPool := &redis.Pool{
 		MaxActive: 5,
 		MaxIdle:   5,
@@ -81,11 +102,18 @@ func main() {
 	}
 	jobStarter := workers.NewJobStarter(WORKER_NAMESPACE, redisPool)
 
+	openAiClient, err := openai.NewClient(openai.OpenAiClientOptions{ApiKey: cfg.OpenAiApiKey}, logger)
+	if err != nil {
+		log.Fatalf("Unable to initialize OpenAI client: %v", err)
+	}
+
 	serverDeps := server.ServerDependencies{
-		Storage:      dbStorage,
-		OpenAiClient: openai.NewClient(openai.OpenAiClientOptions{ApiKey: cfg.OpenAiApiKey}, logger),
-		Config:       cfg,
-		Logger:       logger,
+		Storage:          dbStorage,
+		OpenAiClient:     openAiClient,
+		Prompts:          promptRegistry,
+		PromptExperiment: promptExperiment,
+		Config:           cfg,
+		Logger:           logger,
 	}
 
 	s, err := server.NewServer(serverDeps)
@@ -94,11 +122,16 @@ func main() {
 	}
 	grpcServer := setupGrpcServer(s, cfg, logger)
 
+	// Cancelled on SIGTERM, which stops the game handler loop and abandons whatever the workers are waiting on.
+	serviceCtx, cancelServiceCtx := context.WithCancel(context.Background())
+
 	workerPooldeps := workers.PoolDependencies{
+		Ctx:          serviceCtx,
 		RedisPool:    redisPool,
 		Namespace:    WORKER_NAMESPACE,
 		Storage:      dbStorage,
-		OpenAiApiKey: cfg.OpenAiApiKey,
+		OpenAiClient: openAiClient,
+		Prompts:      promptRegistry,
 		Logger:       logger,
 	}
 	workerPool := workers.NewPool(workerPooldeps)
@@ -108,9 +141,9 @@ func main() {
 	startGrpcServerAsync("ai retreat go", &wg, grpcServer, "9100", logger)
 	httpHealthServer := startHTTPHealthServer(&wg, logger)
 
-	gameHandlerLoopCtx, cancelGameHandlerLoop := context.WithCancel(context.Background())
 	loopTickerDuration := 1 * time.Second
-	go s.GameHandlerLoop(gameHandlerLoopCtx, loopTickerDuration, &wg, jobStarter)
+	go s.GameHandlerLoop(serviceCtx, loopTickerDuration, &wg, jobStarter)
+	go logStorageStats(serviceCtx, dbStorage, 10*time.Minute, logger)
 
 	osTermSig := make(chan os.Signal, 1)
 	signal.Notify(osTermSig, syscall.SIGINT, syscall.SIGTERM)
@@ -119,19 +152,53 @@ func main() {
 
 	<-osTermSig
 
-	cancelGameHandlerLoop()
+	cancelServiceCtx()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	if err := httpHealthServer.Shutdown(ctx); err != nil {
 		panic(err)
 	}
-	grpcServer.GracefulStop()
+	stopGrpcServer(ctx, grpcServer)
 	workerPool.Stop()
 	wg.Wait()
 	logger.LogMessageln("Stopping Service")
 }
 
+// stopGrpcServer lets in-flight calls finish until ctx is done. Any that are still running after that are stopped, which
+// cancels their contexts.
+func stopGrpcServer(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
+
+func logStorageStats(ctx context.Context, dbStorage *storage.Storage, tickerDuration time.Duration, logger utilities.Logger) {
+	ticker := time.NewTicker(tickerDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			stats := dbStorage.GameCacheStats()
+			logger.LogMessagef("Game cache: %d hits, %d misses, %.1f%% hit rate\n", stats.Hits, stats.Misses, stats.HitRate()*100)
+			dbStats := dbStorage.DbStats()
+			logger.LogMessagef(
+				"DB pool: %d open, %d in use, %d idle, %d waits totalling %s\n",
+				dbStats.OpenConnections, dbStats.InUse, dbStats.Idle, dbStats.WaitCount, dbStats.WaitDuration,
+			)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func setupGrpcServer(s *server.AiRetreatGoService, cfg *config.Config, logger utilities.Logger) *grpc.Server {
 	serverOpts := make([]grpc.ServerOption, 0)
 	tlsServerOpts := tlsGrpcServerOptions(cfg, logger)
@@ -21,13 +21,82 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MessageType int32
+
+const (
+	MessageType_MESSAGE_TYPE_UNSPECIFIED MessageType = 0
+	MessageType_QUESTION                 MessageType = 1
+	MessageType_ANSWER                   MessageType = 2
+	MessageType_FOLLOW_UP                MessageType = 3
+	MessageType_PASS                     MessageType = 4
+	MessageType_REACTION                 MessageType = 5
+	MessageType_ACCUSATION               MessageType = 6
+	MessageType_CHAT                     MessageType = 7
+)
+
+// Enum value maps for MessageType.
+var (
+	MessageType_name = map[int32]string{
+		0: "MESSAGE_TYPE_UNSPECIFIED",
+		1: "QUESTION",
+		2: "ANSWER",
+		3: "FOLLOW_UP",
+		4: "PASS",
+		5: "REACTION",
+		6: "ACCUSATION",
+		7: "CHAT",
+	}
+	MessageType_value = map[string]int32{
+		"MESSAGE_TYPE_UNSPECIFIED": 0,
+		"QUESTION":                 1,
+		"ANSWER":                   2,
+		"FOLLOW_UP":                3,
+		"PASS":                     4,
+		"REACTION":                 5,
+		"ACCUSATION":               6,
+		"CHAT":                     7,
+	}
+)
+
+func (x MessageType) Enum() *MessageType {
+	p := new(MessageType)
+	*p = x
+	return p
+}
+
+func (x MessageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_server_proto_enumTypes[0].Descriptor()
+}
+
+func (MessageType) Type() protoreflect.EnumType {
+	return &file_protos_server_proto_enumTypes[0]
+}
+
+func (x MessageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageType.Descriptor instead.
+func (MessageType) EnumDescriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{0}
+}
+
 type CreateGameRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
-	Public   bool   `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"`
+	PlayerId      string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Public        bool   `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"`
+	AiAccusations bool   `protobuf:"varint,3,opt,name=aiAccusations,proto3" json:"aiAccusations,omitempty"`
+	Elimination   bool   `protobuf:"varint,4,opt,name=elimination,proto3" json:"elimination,omitempty"`
+	VotingRounds  int64  `protobuf:"varint,5,opt,name=votingRounds,proto3" json:"votingRounds,omitempty"`
+	AiVotes       bool   `protobuf:"varint,6,opt,name=aiVotes,proto3" json:"aiVotes,omitempty"`
+	Mode          string `protobuf:"bytes,7,opt,name=mode,proto3" json:"mode,omitempty"`
 }
 
 func (x *CreateGameRequest) Reset() {
@@ -76,6 +145,41 @@ func (x *CreateGameRequest) GetPublic() bool {
 	return false
 }
 
+func (x *CreateGameRequest) GetAiAccusations() bool {
+	if x != nil {
+		return x.AiAccusations
+	}
+	return false
+}
+
+func (x *CreateGameRequest) GetElimination() bool {
+	if x != nil {
+		return x.Elimination
+	}
+	return false
+}
+
+func (x *CreateGameRequest) GetVotingRounds() int64 {
+	if x != nil {
+		return x.VotingRounds
+	}
+	return 0
+}
+
+func (x *CreateGameRequest) GetAiVotes() bool {
+	if x != nil {
+		return x.AiVotes
+	}
+	return false
+}
+
+func (x *CreateGameRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
 type CreateGameResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -315,11 +419,11 @@ type SendMessageRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GameId   string `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
-	PlayerId string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
-	BotId    string `protobuf:"bytes,3,opt,name=botId,proto3" json:"botId,omitempty"`
-	Text     string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	Type     string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	GameId   string      `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	PlayerId string      `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	BotId    string      `protobuf:"bytes,3,opt,name=botId,proto3" json:"botId,omitempty"`
+	Text     string      `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Type     MessageType `protobuf:"varint,6,opt,name=type,proto3,enum=protos.MessageType" json:"type,omitempty"`
 }
 
 func (x *SendMessageRequest) Reset() {
@@ -382,11 +486,11 @@ func (x *SendMessageRequest) GetText() string {
 	return ""
 }
 
-func (x *SendMessageRequest) GetType() string {
+func (x *SendMessageRequest) GetType() MessageType {
 	if x != nil {
 		return x.Type
 	}
-	return ""
+	return MessageType_MESSAGE_TYPE_UNSPECIFIED
 }
 
 type SendMessageResponse struct {
@@ -528,6 +632,107 @@ func (*TagResponse) Descriptor() ([]byte, []int) {
 	return file_protos_server_proto_rawDescGZIP(), []int{9}
 }
 
+type CastVoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	BotId    string `protobuf:"bytes,3,opt,name=botId,proto3" json:"botId,omitempty"`
+}
+
+func (x *CastVoteRequest) Reset() {
+	*x = CastVoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CastVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CastVoteRequest) ProtoMessage() {}
+
+func (x *CastVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CastVoteRequest.ProtoReflect.Descriptor instead.
+func (*CastVoteRequest) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CastVoteRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *CastVoteRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *CastVoteRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+type CastVoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CastVoteResponse) Reset() {
+	*x = CastVoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CastVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CastVoteResponse) ProtoMessage() {}
+
+func (x *CastVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CastVoteResponse.ProtoReflect.Descriptor instead.
+func (*CastVoteResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{11}
+}
+
 type HelpRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -540,7 +745,7 @@ type HelpRequest struct {
 func (x *HelpRequest) Reset() {
 	*x = HelpRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[10]
+		mi := &file_protos_server_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -553,7 +758,7 @@ func (x *HelpRequest) String() string {
 func (*HelpRequest) ProtoMessage() {}
 
 func (x *HelpRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[10]
+	mi := &file_protos_server_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +771,7 @@ func (x *HelpRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HelpRequest.ProtoReflect.Descriptor instead.
 func (*HelpRequest) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{10}
+	return file_protos_server_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *HelpRequest) GetGameId() string {
@@ -583,18 +788,76 @@ func (x *HelpRequest) GetPlayerId() string {
 	return ""
 }
 
+type HelpSuggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Style string `protobuf:"bytes,1,opt,name=style,proto3" json:"style,omitempty"`
+	Text  string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *HelpSuggestion) Reset() {
+	*x = HelpSuggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelpSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelpSuggestion) ProtoMessage() {}
+
+func (x *HelpSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelpSuggestion.ProtoReflect.Descriptor instead.
+func (*HelpSuggestion) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HelpSuggestion) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *HelpSuggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 type HelpResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Text        string            `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Suggestions []*HelpSuggestion `protobuf:"bytes,2,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	TargetBotId string            `protobuf:"bytes,3,opt,name=targetBotId,proto3" json:"targetBotId,omitempty"`
+	Rationale   string            `protobuf:"bytes,4,opt,name=rationale,proto3" json:"rationale,omitempty"`
 }
 
 func (x *HelpResponse) Reset() {
 	*x = HelpResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[11]
+		mi := &file_protos_server_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -607,7 +870,7 @@ func (x *HelpResponse) String() string {
 func (*HelpResponse) ProtoMessage() {}
 
 func (x *HelpResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[11]
+	mi := &file_protos_server_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +883,7 @@ func (x *HelpResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HelpResponse.ProtoReflect.Descriptor instead.
 func (*HelpResponse) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{11}
+	return file_protos_server_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *HelpResponse) GetText() string {
@@ -630,6 +893,27 @@ func (x *HelpResponse) GetText() string {
 	return ""
 }
 
+func (x *HelpResponse) GetSuggestions() []*HelpSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *HelpResponse) GetTargetBotId() string {
+	if x != nil {
+		return x.TargetBotId
+	}
+	return ""
+}
+
+func (x *HelpResponse) GetRationale() string {
+	if x != nil {
+		return x.Rationale
+	}
+	return ""
+}
+
 type GetGameForPlayerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -642,7 +926,7 @@ type GetGameForPlayerRequest struct {
 func (x *GetGameForPlayerRequest) Reset() {
 	*x = GetGameForPlayerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[12]
+		mi := &file_protos_server_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -655,7 +939,7 @@ func (x *GetGameForPlayerRequest) String() string {
 func (*GetGameForPlayerRequest) ProtoMessage() {}
 
 func (x *GetGameForPlayerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[12]
+	mi := &file_protos_server_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -668,7 +952,7 @@ func (x *GetGameForPlayerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGameForPlayerRequest.ProtoReflect.Descriptor instead.
 func (*GetGameForPlayerRequest) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{12}
+	return file_protos_server_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetGameForPlayerRequest) GetGameId() string {
@@ -690,23 +974,27 @@ type GetGameForPlayerResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	State          string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
-	DisplayMessage string                 `protobuf:"bytes,2,opt,name=displayMessage,proto3" json:"displayMessage,omitempty"`
-	StateStartedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=stateStartedAt,proto3" json:"stateStartedAt,omitempty"`
-	StateTotalTime int64                  `protobuf:"varint,4,opt,name=stateTotalTime,proto3" json:"stateTotalTime,omitempty"`
-	LastQuestion   string                 `protobuf:"bytes,5,opt,name=lastQuestion,proto3" json:"lastQuestion,omitempty"`
-	MyBotId        string                 `protobuf:"bytes,7,opt,name=myBotId,proto3" json:"myBotId,omitempty"`
-	Bots           []*Bot                 `protobuf:"bytes,8,rep,name=bots,proto3" json:"bots,omitempty"`
-	Messages       []*GameMessage         `protobuf:"bytes,9,rep,name=messages,proto3" json:"messages,omitempty"`
-	WinningBotId   string                 `protobuf:"bytes,10,opt,name=winningBotId,proto3" json:"winningBotId,omitempty"`
-	MyHelpCount    int64                  `protobuf:"varint,11,opt,name=myHelpCount,proto3" json:"myHelpCount,omitempty"`
-	TurnBotName    string                 `protobuf:"bytes,12,opt,name=turnBotName,proto3" json:"turnBotName,omitempty"`
+	State               string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	DisplayMessage      string                 `protobuf:"bytes,2,opt,name=displayMessage,proto3" json:"displayMessage,omitempty"`
+	StateStartedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=stateStartedAt,proto3" json:"stateStartedAt,omitempty"`
+	StateTotalTime      int64                  `protobuf:"varint,4,opt,name=stateTotalTime,proto3" json:"stateTotalTime,omitempty"`
+	LastQuestion        string                 `protobuf:"bytes,5,opt,name=lastQuestion,proto3" json:"lastQuestion,omitempty"`
+	MyBotId             string                 `protobuf:"bytes,7,opt,name=myBotId,proto3" json:"myBotId,omitempty"`
+	Bots                []*Bot                 `protobuf:"bytes,8,rep,name=bots,proto3" json:"bots,omitempty"`
+	Messages            []*GameMessage         `protobuf:"bytes,9,rep,name=messages,proto3" json:"messages,omitempty"`
+	WinningBotId        string                 `protobuf:"bytes,10,opt,name=winningBotId,proto3" json:"winningBotId,omitempty"`
+	MyHelpCount         int64                  `protobuf:"varint,11,opt,name=myHelpCount,proto3" json:"myHelpCount,omitempty"`
+	TurnBotName         string                 `protobuf:"bytes,12,opt,name=turnBotName,proto3" json:"turnBotName,omitempty"`
+	MyTagsRemaining     int64                  `protobuf:"varint,13,opt,name=myTagsRemaining,proto3" json:"myTagsRemaining,omitempty"`
+	MyNextTagAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=myNextTagAt,proto3" json:"myNextTagAt,omitempty"`
+	MyVoteBotId         string                 `protobuf:"bytes,15,opt,name=myVoteBotId,proto3" json:"myVoteBotId,omitempty"`
+	MyMessagesRemaining int64                  `protobuf:"varint,16,opt,name=myMessagesRemaining,proto3" json:"myMessagesRemaining,omitempty"`
 }
 
 func (x *GetGameForPlayerResponse) Reset() {
 	*x = GetGameForPlayerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[13]
+		mi := &file_protos_server_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -719,7 +1007,7 @@ func (x *GetGameForPlayerResponse) String() string {
 func (*GetGameForPlayerResponse) ProtoMessage() {}
 
 func (x *GetGameForPlayerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[13]
+	mi := &file_protos_server_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +1020,7 @@ func (x *GetGameForPlayerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGameForPlayerResponse.ProtoReflect.Descriptor instead.
 func (*GetGameForPlayerResponse) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{13}
+	return file_protos_server_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetGameForPlayerResponse) GetState() string {
@@ -812,19 +1100,48 @@ func (x *GetGameForPlayerResponse) GetTurnBotName() string {
 	return ""
 }
 
+func (x *GetGameForPlayerResponse) GetMyTagsRemaining() int64 {
+	if x != nil {
+		return x.MyTagsRemaining
+	}
+	return 0
+}
+
+func (x *GetGameForPlayerResponse) GetMyNextTagAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MyNextTagAt
+	}
+	return nil
+}
+
+func (x *GetGameForPlayerResponse) GetMyVoteBotId() string {
+	if x != nil {
+		return x.MyVoteBotId
+	}
+	return ""
+}
+
+func (x *GetGameForPlayerResponse) GetMyMessagesRemaining() int64 {
+	if x != nil {
+		return x.MyMessagesRemaining
+	}
+	return 0
+}
+
 type Bot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Eliminated bool   `protobuf:"varint,3,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
 }
 
 func (x *Bot) Reset() {
 	*x = Bot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[14]
+		mi := &file_protos_server_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -837,7 +1154,7 @@ func (x *Bot) String() string {
 func (*Bot) ProtoMessage() {}
 
 func (x *Bot) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[14]
+	mi := &file_protos_server_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,7 +1167,7 @@ func (x *Bot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bot.ProtoReflect.Descriptor instead.
 func (*Bot) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{14}
+	return file_protos_server_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Bot) GetId() string {
@@ -867,21 +1184,35 @@ func (x *Bot) GetName() string {
 	return ""
 }
 
+func (x *Bot) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
 type GameMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceBotId string `protobuf:"bytes,1,opt,name=sourceBotId,proto3" json:"sourceBotId,omitempty"`
-	TargetBotId string `protobuf:"bytes,2,opt,name=targetBotId,proto3" json:"targetBotId,omitempty"`
-	Text        string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	Type        string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	SourceBotId      string      `protobuf:"bytes,1,opt,name=sourceBotId,proto3" json:"sourceBotId,omitempty"`
+	TargetBotId      string      `protobuf:"bytes,2,opt,name=targetBotId,proto3" json:"targetBotId,omitempty"`
+	Text             string      `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Type             MessageType `protobuf:"varint,11,opt,name=type,proto3,enum=protos.MessageType" json:"type,omitempty"`
+	ResponseTimeMs   int64       `protobuf:"varint,5,opt,name=responseTimeMs,proto3" json:"responseTimeMs,omitempty"`
+	HelpUsage        string      `protobuf:"bytes,6,opt,name=helpUsage,proto3" json:"helpUsage,omitempty"`
+	AiModel          string      `protobuf:"bytes,7,opt,name=aiModel,proto3" json:"aiModel,omitempty"`
+	PromptVersion    string      `protobuf:"bytes,8,opt,name=promptVersion,proto3" json:"promptVersion,omitempty"`
+	PromptTokens     int64       `protobuf:"varint,9,opt,name=promptTokens,proto3" json:"promptTokens,omitempty"`
+	CompletionTokens int64       `protobuf:"varint,10,opt,name=completionTokens,proto3" json:"completionTokens,omitempty"`
+	HelpStyle        string      `protobuf:"bytes,12,opt,name=helpStyle,proto3" json:"helpStyle,omitempty"`
 }
 
 func (x *GameMessage) Reset() {
 	*x = GameMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[15]
+		mi := &file_protos_server_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -894,7 +1225,7 @@ func (x *GameMessage) String() string {
 func (*GameMessage) ProtoMessage() {}
 
 func (x *GameMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[15]
+	mi := &file_protos_server_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -907,7 +1238,7 @@ func (x *GameMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameMessage.ProtoReflect.Descriptor instead.
 func (*GameMessage) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{15}
+	return file_protos_server_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GameMessage) GetSourceBotId() string {
@@ -931,16 +1262,65 @@ func (x *GameMessage) GetText() string {
 	return ""
 }
 
-func (x *GameMessage) GetType() string {
+func (x *GameMessage) GetType() MessageType {
 	if x != nil {
 		return x.Type
 	}
-	return ""
+	return MessageType_MESSAGE_TYPE_UNSPECIFIED
 }
 
-type GetGamesForPlayerRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *GameMessage) GetResponseTimeMs() int64 {
+	if x != nil {
+		return x.ResponseTimeMs
+	}
+	return 0
+}
+
+func (x *GameMessage) GetHelpUsage() string {
+	if x != nil {
+		return x.HelpUsage
+	}
+	return ""
+}
+
+func (x *GameMessage) GetAiModel() string {
+	if x != nil {
+		return x.AiModel
+	}
+	return ""
+}
+
+func (x *GameMessage) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *GameMessage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *GameMessage) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *GameMessage) GetHelpStyle() string {
+	if x != nil {
+		return x.HelpStyle
+	}
+	return ""
+}
+
+type GetGamesForPlayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
@@ -949,7 +1329,7 @@ type GetGamesForPlayerRequest struct {
 func (x *GetGamesForPlayerRequest) Reset() {
 	*x = GetGamesForPlayerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[16]
+		mi := &file_protos_server_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -962,7 +1342,7 @@ func (x *GetGamesForPlayerRequest) String() string {
 func (*GetGamesForPlayerRequest) ProtoMessage() {}
 
 func (x *GetGamesForPlayerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[16]
+	mi := &file_protos_server_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -975,7 +1355,7 @@ func (x *GetGamesForPlayerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGamesForPlayerRequest.ProtoReflect.Descriptor instead.
 func (*GetGamesForPlayerRequest) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{16}
+	return file_protos_server_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetGamesForPlayerRequest) GetPlayerId() string {
@@ -993,23 +1373,955 @@ type GetGamesForPlayerResponse struct {
 	GameIds []string `protobuf:"bytes,1,rep,name=gameIds,proto3" json:"gameIds,omitempty"`
 }
 
-func (x *GetGamesForPlayerResponse) Reset() {
-	*x = GetGamesForPlayerResponse{}
+func (x *GetGamesForPlayerResponse) Reset() {
+	*x = GetGamesForPlayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGamesForPlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGamesForPlayerResponse) ProtoMessage() {}
+
+func (x *GetGamesForPlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGamesForPlayerResponse.ProtoReflect.Descriptor instead.
+func (*GetGamesForPlayerResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetGamesForPlayerResponse) GetGameIds() []string {
+	if x != nil {
+		return x.GameIds
+	}
+	return nil
+}
+
+type GetGameAnalysisRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+}
+
+func (x *GetGameAnalysisRequest) Reset() {
+	*x = GetGameAnalysisRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameAnalysisRequest) ProtoMessage() {}
+
+func (x *GetGameAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*GetGameAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetGameAnalysisRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GetGameAnalysisRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type BotAnalysis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BotId          string   `protobuf:"bytes,1,opt,name=botId,proto3" json:"botId,omitempty"`
+	BotName        string   `protobuf:"bytes,2,opt,name=botName,proto3" json:"botName,omitempty"`
+	IsHuman        bool     `protobuf:"varint,3,opt,name=isHuman,proto3" json:"isHuman,omitempty"`
+	HumannessScore int64    `protobuf:"varint,4,opt,name=humannessScore,proto3" json:"humannessScore,omitempty"`
+	Tells          []string `protobuf:"bytes,5,rep,name=tells,proto3" json:"tells,omitempty"`
+}
+
+func (x *BotAnalysis) Reset() {
+	*x = BotAnalysis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BotAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotAnalysis) ProtoMessage() {}
+
+func (x *BotAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotAnalysis.ProtoReflect.Descriptor instead.
+func (*BotAnalysis) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BotAnalysis) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *BotAnalysis) GetBotName() string {
+	if x != nil {
+		return x.BotName
+	}
+	return ""
+}
+
+func (x *BotAnalysis) GetIsHuman() bool {
+	if x != nil {
+		return x.IsHuman
+	}
+	return false
+}
+
+func (x *BotAnalysis) GetHumannessScore() int64 {
+	if x != nil {
+		return x.HumannessScore
+	}
+	return 0
+}
+
+func (x *BotAnalysis) GetTells() []string {
+	if x != nil {
+		return x.Tells
+	}
+	return nil
+}
+
+type GetGameAnalysisResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BotAnalyses []*BotAnalysis `protobuf:"bytes,1,rep,name=botAnalyses,proto3" json:"botAnalyses,omitempty"`
+}
+
+func (x *GetGameAnalysisResponse) Reset() {
+	*x = GetGameAnalysisResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameAnalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameAnalysisResponse) ProtoMessage() {}
+
+func (x *GetGameAnalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameAnalysisResponse.ProtoReflect.Descriptor instead.
+func (*GetGameAnalysisResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetGameAnalysisResponse) GetBotAnalyses() []*BotAnalysis {
+	if x != nil {
+		return x.BotAnalyses
+	}
+	return nil
+}
+
+type SyncPlayerDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+}
+
+func (x *SyncPlayerDataRequest) Reset() {
+	*x = SyncPlayerDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncPlayerDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPlayerDataRequest) ProtoMessage() {}
+
+func (x *SyncPlayerDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPlayerDataRequest.ProtoReflect.Descriptor instead.
+func (*SyncPlayerDataRequest) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SyncPlayerDataRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type SyncPlayerDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId  string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Connected bool   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+}
+
+func (x *SyncPlayerDataResponse) Reset() {
+	*x = SyncPlayerDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncPlayerDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPlayerDataResponse) ProtoMessage() {}
+
+func (x *SyncPlayerDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPlayerDataResponse.ProtoReflect.Descriptor instead.
+func (*SyncPlayerDataResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SyncPlayerDataResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SyncPlayerDataResponse) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+type ExportPlayerDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+}
+
+func (x *ExportPlayerDataRequest) Reset() {
+	*x = ExportPlayerDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPlayerDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPlayerDataRequest) ProtoMessage() {}
+
+func (x *ExportPlayerDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPlayerDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportPlayerDataRequest) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportPlayerDataRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type ExportedUser struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Image string `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+}
+
+func (x *ExportedUser) Reset() {
+	*x = ExportedUser{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedUser) ProtoMessage() {}
+
+func (x *ExportedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedUser.ProtoReflect.Descriptor instead.
+func (*ExportedUser) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportedUser) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type ExportedBot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameId              string       `protobuf:"bytes,2,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Name                string       `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	HelpCount           int64        `protobuf:"varint,4,opt,name=helpCount,proto3" json:"helpCount,omitempty"`
+	LastHelpSuggestions []string     `protobuf:"bytes,5,rep,name=lastHelpSuggestions,proto3" json:"lastHelpSuggestions,omitempty"`
+	TagCount            int64        `protobuf:"varint,6,opt,name=tagCount,proto3" json:"tagCount,omitempty"`
+	Eliminated          bool         `protobuf:"varint,7,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+	Analysis            *BotAnalysis `protobuf:"bytes,8,opt,name=analysis,proto3" json:"analysis,omitempty"`
+}
+
+func (x *ExportedBot) Reset() {
+	*x = ExportedBot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedBot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedBot) ProtoMessage() {}
+
+func (x *ExportedBot) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedBot.ProtoReflect.Descriptor instead.
+func (*ExportedBot) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportedBot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportedBot) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ExportedBot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportedBot) GetHelpCount() int64 {
+	if x != nil {
+		return x.HelpCount
+	}
+	return 0
+}
+
+func (x *ExportedBot) GetLastHelpSuggestions() []string {
+	if x != nil {
+		return x.LastHelpSuggestions
+	}
+	return nil
+}
+
+func (x *ExportedBot) GetTagCount() int64 {
+	if x != nil {
+		return x.TagCount
+	}
+	return 0
+}
+
+func (x *ExportedBot) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+func (x *ExportedBot) GetAnalysis() *BotAnalysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+type ExportedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameId      string                 `protobuf:"bytes,2,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	SourceBotId string                 `protobuf:"bytes,3,opt,name=sourceBotId,proto3" json:"sourceBotId,omitempty"`
+	TargetBotId string                 `protobuf:"bytes,4,opt,name=targetBotId,proto3" json:"targetBotId,omitempty"`
+	Type        MessageType            `protobuf:"varint,5,opt,name=type,proto3,enum=protos.MessageType" json:"type,omitempty"`
+	Text        string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *ExportedMessage) Reset() {
+	*x = ExportedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedMessage) ProtoMessage() {}
+
+func (x *ExportedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedMessage.ProtoReflect.Descriptor instead.
+func (*ExportedMessage) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportedMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportedMessage) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ExportedMessage) GetSourceBotId() string {
+	if x != nil {
+		return x.SourceBotId
+	}
+	return ""
+}
+
+func (x *ExportedMessage) GetTargetBotId() string {
+	if x != nil {
+		return x.TargetBotId
+	}
+	return ""
+}
+
+func (x *ExportedMessage) GetType() MessageType {
+	if x != nil {
+		return x.Type
+	}
+	return MessageType_MESSAGE_TYPE_UNSPECIFIED
+}
+
+func (x *ExportedMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ExportedMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ExportedVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId       string `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	VoterBotId   string `protobuf:"bytes,2,opt,name=voterBotId,proto3" json:"voterBotId,omitempty"`
+	SuspectBotId string `protobuf:"bytes,3,opt,name=suspectBotId,proto3" json:"suspectBotId,omitempty"`
+}
+
+func (x *ExportedVote) Reset() {
+	*x = ExportedVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedVote) ProtoMessage() {}
+
+func (x *ExportedVote) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedVote.ProtoReflect.Descriptor instead.
+func (*ExportedVote) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportedVote) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ExportedVote) GetVoterBotId() string {
+	if x != nil {
+		return x.VoterBotId
+	}
+	return ""
+}
+
+func (x *ExportedVote) GetSuspectBotId() string {
+	if x != nil {
+		return x.SuspectBotId
+	}
+	return ""
+}
+
+type ExportedPracticeResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Won    bool   `protobuf:"varint,2,opt,name=won,proto3" json:"won,omitempty"`
+}
+
+func (x *ExportedPracticeResult) Reset() {
+	*x = ExportedPracticeResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedPracticeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedPracticeResult) ProtoMessage() {}
+
+func (x *ExportedPracticeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedPracticeResult.ProtoReflect.Descriptor instead.
+func (*ExportedPracticeResult) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportedPracticeResult) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ExportedPracticeResult) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+type ExportedPlayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt       *timestamppb.Timestamp    `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Bots            []*ExportedBot            `protobuf:"bytes,3,rep,name=bots,proto3" json:"bots,omitempty"`
+	Messages        []*ExportedMessage        `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	Votes           []*ExportedVote           `protobuf:"bytes,5,rep,name=votes,proto3" json:"votes,omitempty"`
+	PracticeResults []*ExportedPracticeResult `protobuf:"bytes,6,rep,name=practiceResults,proto3" json:"practiceResults,omitempty"`
+}
+
+func (x *ExportedPlayer) Reset() {
+	*x = ExportedPlayer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedPlayer) ProtoMessage() {}
+
+func (x *ExportedPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedPlayer.ProtoReflect.Descriptor instead.
+func (*ExportedPlayer) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportedPlayer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportedPlayer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ExportedPlayer) GetBots() []*ExportedBot {
+	if x != nil {
+		return x.Bots
+	}
+	return nil
+}
+
+func (x *ExportedPlayer) GetMessages() []*ExportedMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ExportedPlayer) GetVotes() []*ExportedVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *ExportedPlayer) GetPracticeResults() []*ExportedPracticeResult {
+	if x != nil {
+		return x.PracticeResults
+	}
+	return nil
+}
+
+type PlayerErasure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PlayerId     string                 `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	BotCount     int64                  `protobuf:"varint,3,opt,name=botCount,proto3" json:"botCount,omitempty"`
+	MessageCount int64                  `protobuf:"varint,4,opt,name=messageCount,proto3" json:"messageCount,omitempty"`
+	ErasedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=erasedAt,proto3" json:"erasedAt,omitempty"`
+}
+
+func (x *PlayerErasure) Reset() {
+	*x = PlayerErasure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_server_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerErasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerErasure) ProtoMessage() {}
+
+func (x *PlayerErasure) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerErasure.ProtoReflect.Descriptor instead.
+func (*PlayerErasure) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PlayerErasure) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlayerErasure) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerErasure) GetBotCount() int64 {
+	if x != nil {
+		return x.BotCount
+	}
+	return 0
+}
+
+func (x *PlayerErasure) GetMessageCount() int64 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *PlayerErasure) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+type ExportPlayerDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User     *ExportedUser     `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Players  []*ExportedPlayer `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	Erasures []*PlayerErasure  `protobuf:"bytes,3,rep,name=erasures,proto3" json:"erasures,omitempty"`
+}
+
+func (x *ExportPlayerDataResponse) Reset() {
+	*x = ExportPlayerDataResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[17]
+		mi := &file_protos_server_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetGamesForPlayerResponse) String() string {
+func (x *ExportPlayerDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGamesForPlayerResponse) ProtoMessage() {}
+func (*ExportPlayerDataResponse) ProtoMessage() {}
 
-func (x *GetGamesForPlayerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[17]
+func (x *ExportPlayerDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1020,19 +2332,33 @@ func (x *GetGamesForPlayerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGamesForPlayerResponse.ProtoReflect.Descriptor instead.
-func (*GetGamesForPlayerResponse) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ExportPlayerDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportPlayerDataResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *GetGamesForPlayerResponse) GetGameIds() []string {
+func (x *ExportPlayerDataResponse) GetUser() *ExportedUser {
 	if x != nil {
-		return x.GameIds
+		return x.User
 	}
 	return nil
 }
 
-type SyncPlayerDataRequest struct {
+func (x *ExportPlayerDataResponse) GetPlayers() []*ExportedPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *ExportPlayerDataResponse) GetErasures() []*PlayerErasure {
+	if x != nil {
+		return x.Erasures
+	}
+	return nil
+}
+
+type ErasePlayerDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1040,23 +2366,23 @@ type SyncPlayerDataRequest struct {
 	PlayerId string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
 }
 
-func (x *SyncPlayerDataRequest) Reset() {
-	*x = SyncPlayerDataRequest{}
+func (x *ErasePlayerDataRequest) Reset() {
+	*x = ErasePlayerDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[18]
+		mi := &file_protos_server_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SyncPlayerDataRequest) String() string {
+func (x *ErasePlayerDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncPlayerDataRequest) ProtoMessage() {}
+func (*ErasePlayerDataRequest) ProtoMessage() {}
 
-func (x *SyncPlayerDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[18]
+func (x *ErasePlayerDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,44 +2393,43 @@ func (x *SyncPlayerDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncPlayerDataRequest.ProtoReflect.Descriptor instead.
-func (*SyncPlayerDataRequest) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ErasePlayerDataRequest.ProtoReflect.Descriptor instead.
+func (*ErasePlayerDataRequest) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *SyncPlayerDataRequest) GetPlayerId() string {
+func (x *ErasePlayerDataRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-type SyncPlayerDataResponse struct {
+type ErasePlayerDataResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PlayerId  string `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
-	Connected bool   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	Erasures []*PlayerErasure `protobuf:"bytes,1,rep,name=erasures,proto3" json:"erasures,omitempty"`
 }
 
-func (x *SyncPlayerDataResponse) Reset() {
-	*x = SyncPlayerDataResponse{}
+func (x *ErasePlayerDataResponse) Reset() {
+	*x = ErasePlayerDataResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_server_proto_msgTypes[19]
+		mi := &file_protos_server_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SyncPlayerDataResponse) String() string {
+func (x *ErasePlayerDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncPlayerDataResponse) ProtoMessage() {}
+func (*ErasePlayerDataResponse) ProtoMessage() {}
 
-func (x *SyncPlayerDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_server_proto_msgTypes[19]
+func (x *ErasePlayerDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_server_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,23 +2440,16 @@ func (x *SyncPlayerDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncPlayerDataResponse.ProtoReflect.Descriptor instead.
-func (*SyncPlayerDataResponse) Descriptor() ([]byte, []int) {
-	return file_protos_server_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *SyncPlayerDataResponse) GetPlayerId() string {
-	if x != nil {
-		return x.PlayerId
-	}
-	return ""
+// Deprecated: Use ErasePlayerDataResponse.ProtoReflect.Descriptor instead.
+func (*ErasePlayerDataResponse) Descriptor() ([]byte, []int) {
+	return file_protos_server_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *SyncPlayerDataResponse) GetConnected() bool {
+func (x *ErasePlayerDataResponse) GetErasures() []*PlayerErasure {
 	if x != nil {
-		return x.Connected
+		return x.Erasures
 	}
-	return false
+	return nil
 }
 
 var File_protos_server_proto protoreflect.FileDescriptor
@@ -1140,154 +2458,364 @@ var file_protos_server_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x1a, 0x1f, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x47,
-	0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1,
+	0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x69, 0x41, 0x63,
+	0x63, 0x75, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x61, 0x69, 0x41, 0x63, 0x63, 0x75, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x22, 0x0a, 0x0c, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x69, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x69, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x22, 0x2c, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x22, 0x45, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x22, 0x2c, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67,
-	0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x12, 0x0a, 0x10,
-	0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x31, 0x0a, 0x13, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47,
-	0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67,
-	0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d,
-	0x65, 0x49, 0x64, 0x22, 0x86, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61,
+	0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x12, 0x0a, 0x10, 0x4a, 0x6f, 0x69, 0x6e, 0x47,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x13, 0x41,
+	0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2e,
+	0x0a, 0x14, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0xa1,
+	0x01, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x74,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x4a, 0x04, 0x08, 0x05,
+	0x10, 0x06, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x56, 0x0a, 0x0a, 0x54, 0x61, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6f, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x49,
+	0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5b, 0x0a, 0x0f, 0x43, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x22, 0x12, 0x0a,
+	0x10, 0x43, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x6c, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0e, 0x48, 0x65, 0x6c, 0x70, 0x53, 0x75, 0x67, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x22, 0x9c, 0x01, 0x0a, 0x0c, 0x48, 0x65, 0x6c, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x48, 0x65, 0x6c, 0x70, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x65, 0x22,
+	0x4d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61,
 	0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65,
 	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62,
-	0x6f, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x15, 0x0a, 0x13,
-	0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x56, 0x0a, 0x0a, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x54,
-	0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x41, 0x0a, 0x0b, 0x48, 0x65,
-	0x6c, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d,
-	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x22, 0x0a,
-	0x0c, 0x48, 0x65, 0x6c, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
-	0x74, 0x22, 0x4d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61,
-	0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
-	0x22, 0xbc, 0x03, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
-	0x26, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x6f,
-	0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x51,
-	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c,
-	0x61, 0x73, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x79, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x79,
-	0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x18, 0x08, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x42, 0x6f, 0x74,
-	0x52, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x6e, 0x69,
-	0x6e, 0x67, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x77,
-	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
-	0x79, 0x48, 0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0b, 0x6d, 0x79, 0x48, 0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a,
-	0x0b, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22,
-	0x29, 0x0a, 0x03, 0x42, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0xf8,
+	0x04, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x51, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x79, 0x42,
+	0x6f, 0x74, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x79, 0x42, 0x6f,
+	0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x42, 0x6f, 0x74, 0x52, 0x04,
+	0x62, 0x6f, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x77, 0x69, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x79, 0x48,
+	0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6d, 0x79, 0x48, 0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x75, 0x72, 0x6e, 0x42, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a,
+	0x0f, 0x6d, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x3c, 0x0a, 0x0b, 0x6d, 0x79, 0x4e, 0x65, 0x78,
+	0x74, 0x54, 0x61, 0x67, 0x41, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6d, 0x79, 0x4e, 0x65, 0x78, 0x74,
+	0x54, 0x61, 0x67, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x79, 0x56, 0x6f, 0x74, 0x65, 0x42,
+	0x6f, 0x74, 0x49, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x79, 0x56, 0x6f,
+	0x74, 0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x79, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x49, 0x0a, 0x03, 0x42, 0x6f, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x64, 0x22, 0x88, 0x03, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x6f,
+	0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x68, 0x65, 0x6c, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x68, 0x65, 0x6c, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x69, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x69,
+	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12,
+	0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x68,
+	0x65, 0x6c, 0x70, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x68, 0x65, 0x6c, 0x70, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22,
+	0x36, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x73, 0x22, 0x4c,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x95, 0x01, 0x0a,
+	0x0b, 0x42, 0x6f, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x62, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x6f, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x69, 0x73, 0x48, 0x75, 0x6d, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
+	0x73, 0x48, 0x75, 0x6d, 0x61, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x68, 0x75, 0x6d, 0x61, 0x6e, 0x6e,
+	0x65, 0x73, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x68, 0x75, 0x6d, 0x61, 0x6e, 0x6e, 0x65, 0x73, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x65, 0x6c, 0x6c, 0x73, 0x22, 0x50, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x0b, 0x62, 0x6f, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x42, 0x6f,
+	0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x0b, 0x62, 0x6f, 0x74, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x22, 0x33, 0x0a, 0x15, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x52, 0x0a, 0x16, 0x53,
+	0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22,
+	0x35, 0x0a, 0x17, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x79, 0x0a, 0x0b, 0x47, 0x61,
-	0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x36, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65,
-	0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x35, 0x0a,
-	0x19, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61,
-	0x6d, 0x65, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x6d,
-	0x65, 0x49, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x15, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x52, 0x0a, 0x16, 0x53, 0x79, 0x6e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x86, 0x02, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x42, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x6c, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x30, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x6c, 0x70, 0x53, 0x75, 0x67, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6c,
+	0x61, 0x73, 0x74, 0x48, 0x65, 0x6c, 0x70, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2f,
+	0x0a, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x42, 0x6f, 0x74, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x22,
+	0xf4, 0x01, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12,
+	0x27, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x38, 0x0a, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6a, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x42, 0x6f, 0x74, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x42, 0x6f, 0x74,
+	0x49, 0x64, 0x22, 0x42, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61,
+	0x6d, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x77, 0x6f, 0x6e, 0x22, 0xae, 0x02, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x42, 0x6f, 0x74, 0x52, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x08,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x48, 0x0a,
+	0x0f, 0x70, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0f, 0x70, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x62, 0x6f, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41, 0x74, 0x22, 0xa9, 0x01,
+	0x0a, 0x18, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x52,
+	0x08, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x16, 0x45, 0x72, 0x61,
+	0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x4c, 0x0a, 0x17, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x65, 0x72,
+	0x61, 0x73, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x45, 0x72, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x52, 0x08, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x73, 0x2a, 0x86, 0x01,
+	0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a,
+	0x18, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x51,
+	0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4e, 0x53,
+	0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x5f,
+	0x55, 0x50, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x41, 0x53, 0x53, 0x10, 0x04, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a,
+	0x41, 0x43, 0x43, 0x55, 0x53, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04,
+	0x43, 0x48, 0x41, 0x54, 0x10, 0x07, 0x32, 0xe1, 0x07, 0x0a, 0x0b, 0x41, 0x69, 0x52, 0x65, 0x74,
+	0x72, 0x65, 0x61, 0x74, 0x47, 0x6f, 0x12, 0x45, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a,
+	0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e,
+	0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b,
+	0x0a, 0x0c, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e,
+	0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x43, 0x61, 0x73, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x61, 0x73,
+	0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x04, 0x48, 0x65, 0x6c, 0x70,
+	0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x48, 0x65, 0x6c, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x48,
+	0x65, 0x6c, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x47,
+	0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x46,
+	0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79,
+	0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e,
 	0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x32, 0x9b, 0x05,
-	0x0a, 0x0b, 0x41, 0x69, 0x52, 0x65, 0x74, 0x72, 0x65, 0x61, 0x74, 0x47, 0x6f, 0x12, 0x45, 0x0a,
-	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65,
-	0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61,
-	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69,
-	0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41,
-	0x75, 0x74, 0x6f, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x6f,
-	0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x03,
-	0x54, 0x61, 0x67, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x61, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33,
-	0x0a, 0x04, 0x48, 0x65, 0x6c, 0x70, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
-	0x48, 0x65, 0x6c, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x48, 0x65, 0x6c, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f,
-	0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x11,
-	0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61,
-	0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74,
-	0x47, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x53, 0x79, 0x6e, 0x63,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69, 0x70, 0x75, 0x6c, 0x76,
-	0x70, 0x61, 0x74, 0x69, 0x6c, 0x2f, 0x61, 0x69, 0x72, 0x65, 0x74, 0x72, 0x65, 0x61, 0x74, 0x2d,
-	0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65,
+	0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69, 0x70, 0x75, 0x6c, 0x76, 0x70,
+	0x61, 0x74, 0x69, 0x6c, 0x2f, 0x61, 0x69, 0x72, 0x65, 0x74, 0x72, 0x65, 0x61, 0x74, 0x2d, 0x67,
+	0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1302,57 +2830,102 @@ func file_protos_server_proto_rawDescGZIP() []byte {
 	return file_protos_server_proto_rawDescData
 }
 
-var file_protos_server_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_protos_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protos_server_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_protos_server_proto_goTypes = []interface{}{
-	(*CreateGameRequest)(nil),         // 0: protos.CreateGameRequest
-	(*CreateGameResponse)(nil),        // 1: protos.CreateGameResponse
-	(*JoinGameRequest)(nil),           // 2: protos.JoinGameRequest
-	(*JoinGameResponse)(nil),          // 3: protos.JoinGameResponse
-	(*AutoJoinGameRequest)(nil),       // 4: protos.AutoJoinGameRequest
-	(*AutoJoinGameResponse)(nil),      // 5: protos.AutoJoinGameResponse
-	(*SendMessageRequest)(nil),        // 6: protos.SendMessageRequest
-	(*SendMessageResponse)(nil),       // 7: protos.SendMessageResponse
-	(*TagRequest)(nil),                // 8: protos.TagRequest
-	(*TagResponse)(nil),               // 9: protos.TagResponse
-	(*HelpRequest)(nil),               // 10: protos.HelpRequest
-	(*HelpResponse)(nil),              // 11: protos.HelpResponse
-	(*GetGameForPlayerRequest)(nil),   // 12: protos.GetGameForPlayerRequest
-	(*GetGameForPlayerResponse)(nil),  // 13: protos.GetGameForPlayerResponse
-	(*Bot)(nil),                       // 14: protos.Bot
-	(*GameMessage)(nil),               // 15: protos.GameMessage
-	(*GetGamesForPlayerRequest)(nil),  // 16: protos.GetGamesForPlayerRequest
-	(*GetGamesForPlayerResponse)(nil), // 17: protos.GetGamesForPlayerResponse
-	(*SyncPlayerDataRequest)(nil),     // 18: protos.SyncPlayerDataRequest
-	(*SyncPlayerDataResponse)(nil),    // 19: protos.SyncPlayerDataResponse
-	(*timestamppb.Timestamp)(nil),     // 20: google.protobuf.Timestamp
+	(MessageType)(0),                  // 0: protos.MessageType
+	(*CreateGameRequest)(nil),         // 1: protos.CreateGameRequest
+	(*CreateGameResponse)(nil),        // 2: protos.CreateGameResponse
+	(*JoinGameRequest)(nil),           // 3: protos.JoinGameRequest
+	(*JoinGameResponse)(nil),          // 4: protos.JoinGameResponse
+	(*AutoJoinGameRequest)(nil),       // 5: protos.AutoJoinGameRequest
+	(*AutoJoinGameResponse)(nil),      // 6: protos.AutoJoinGameResponse
+	(*SendMessageRequest)(nil),        // 7: protos.SendMessageRequest
+	(*SendMessageResponse)(nil),       // 8: protos.SendMessageResponse
+	(*TagRequest)(nil),                // 9: protos.TagRequest
+	(*TagResponse)(nil),               // 10: protos.TagResponse
+	(*CastVoteRequest)(nil),           // 11: protos.CastVoteRequest
+	(*CastVoteResponse)(nil),          // 12: protos.CastVoteResponse
+	(*HelpRequest)(nil),               // 13: protos.HelpRequest
+	(*HelpSuggestion)(nil),            // 14: protos.HelpSuggestion
+	(*HelpResponse)(nil),              // 15: protos.HelpResponse
+	(*GetGameForPlayerRequest)(nil),   // 16: protos.GetGameForPlayerRequest
+	(*GetGameForPlayerResponse)(nil),  // 17: protos.GetGameForPlayerResponse
+	(*Bot)(nil),                       // 18: protos.Bot
+	(*GameMessage)(nil),               // 19: protos.GameMessage
+	(*GetGamesForPlayerRequest)(nil),  // 20: protos.GetGamesForPlayerRequest
+	(*GetGamesForPlayerResponse)(nil), // 21: protos.GetGamesForPlayerResponse
+	(*GetGameAnalysisRequest)(nil),    // 22: protos.GetGameAnalysisRequest
+	(*BotAnalysis)(nil),               // 23: protos.BotAnalysis
+	(*GetGameAnalysisResponse)(nil),   // 24: protos.GetGameAnalysisResponse
+	(*SyncPlayerDataRequest)(nil),     // 25: protos.SyncPlayerDataRequest
+	(*SyncPlayerDataResponse)(nil),    // 26: protos.SyncPlayerDataResponse
+	(*ExportPlayerDataRequest)(nil),   // 27: protos.ExportPlayerDataRequest
+	(*ExportedUser)(nil),              // 28: protos.ExportedUser
+	(*ExportedBot)(nil),               // 29: protos.ExportedBot
+	(*ExportedMessage)(nil),           // 30: protos.ExportedMessage
+	(*ExportedVote)(nil),              // 31: protos.ExportedVote
+	(*ExportedPracticeResult)(nil),    // 32: protos.ExportedPracticeResult
+	(*ExportedPlayer)(nil),            // 33: protos.ExportedPlayer
+	(*PlayerErasure)(nil),             // 34: protos.PlayerErasure
+	(*ExportPlayerDataResponse)(nil),  // 35: protos.ExportPlayerDataResponse
+	(*ErasePlayerDataRequest)(nil),    // 36: protos.ErasePlayerDataRequest
+	(*ErasePlayerDataResponse)(nil),   // 37: protos.ErasePlayerDataResponse
+	(*timestamppb.Timestamp)(nil),     // 38: google.protobuf.Timestamp
 }
 var file_protos_server_proto_depIdxs = []int32{
-	20, // 0: protos.GetGameForPlayerResponse.stateStartedAt:type_name -> google.protobuf.Timestamp
-	14, // 1: protos.GetGameForPlayerResponse.bots:type_name -> protos.Bot
-	15, // 2: protos.GetGameForPlayerResponse.messages:type_name -> protos.GameMessage
-	0,  // 3: protos.AiRetreatGo.CreateGame:input_type -> protos.CreateGameRequest
-	2,  // 4: protos.AiRetreatGo.JoinGame:input_type -> protos.JoinGameRequest
-	4,  // 5: protos.AiRetreatGo.AutoJoinGame:input_type -> protos.AutoJoinGameRequest
-	6,  // 6: protos.AiRetreatGo.SendMessage:input_type -> protos.SendMessageRequest
-	8,  // 7: protos.AiRetreatGo.Tag:input_type -> protos.TagRequest
-	10, // 8: protos.AiRetreatGo.Help:input_type -> protos.HelpRequest
-	12, // 9: protos.AiRetreatGo.GetGameForPlayer:input_type -> protos.GetGameForPlayerRequest
-	16, // 10: protos.AiRetreatGo.GetGamesForPlayer:input_type -> protos.GetGamesForPlayerRequest
-	18, // 11: protos.AiRetreatGo.SyncPlayerData:input_type -> protos.SyncPlayerDataRequest
-	1,  // 12: protos.AiRetreatGo.CreateGame:output_type -> protos.CreateGameResponse
-	3,  // 13: protos.AiRetreatGo.JoinGame:output_type -> protos.JoinGameResponse
-	5,  // 14: protos.AiRetreatGo.AutoJoinGame:output_type -> protos.AutoJoinGameResponse
-	7,  // 15: protos.AiRetreatGo.SendMessage:output_type -> protos.SendMessageResponse
-	9,  // 16: protos.AiRetreatGo.Tag:output_type -> protos.TagResponse
-	11, // 17: protos.AiRetreatGo.Help:output_type -> protos.HelpResponse
-	13, // 18: protos.AiRetreatGo.GetGameForPlayer:output_type -> protos.GetGameForPlayerResponse
-	17, // 19: protos.AiRetreatGo.GetGamesForPlayer:output_type -> protos.GetGamesForPlayerResponse
-	19, // 20: protos.AiRetreatGo.SyncPlayerData:output_type -> protos.SyncPlayerDataResponse
-	12, // [12:21] is the sub-list for method output_type
-	3,  // [3:12] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: protos.SendMessageRequest.type:type_name -> protos.MessageType
+	14, // 1: protos.HelpResponse.suggestions:type_name -> protos.HelpSuggestion
+	38, // 2: protos.GetGameForPlayerResponse.stateStartedAt:type_name -> google.protobuf.Timestamp
+	18, // 3: protos.GetGameForPlayerResponse.bots:type_name -> protos.Bot
+	19, // 4: protos.GetGameForPlayerResponse.messages:type_name -> protos.GameMessage
+	38, // 5: protos.GetGameForPlayerResponse.myNextTagAt:type_name -> google.protobuf.Timestamp
+	0,  // 6: protos.GameMessage.type:type_name -> protos.MessageType
+	23, // 7: protos.GetGameAnalysisResponse.botAnalyses:type_name -> protos.BotAnalysis
+	23, // 8: protos.ExportedBot.analysis:type_name -> protos.BotAnalysis
+	0,  // 9: protos.ExportedMessage.type:type_name -> protos.MessageType
+	38, // 10: protos.ExportedMessage.createdAt:type_name -> google.protobuf.Timestamp
+	38, // 11: protos.ExportedPlayer.createdAt:type_name -> google.protobuf.Timestamp
+	29, // 12: protos.ExportedPlayer.bots:type_name -> protos.ExportedBot
+	30, // 13: protos.ExportedPlayer.messages:type_name -> protos.ExportedMessage
+	31, // 14: protos.ExportedPlayer.votes:type_name -> protos.ExportedVote
+	32, // 15: protos.ExportedPlayer.practiceResults:type_name -> protos.ExportedPracticeResult
+	38, // 16: protos.PlayerErasure.erasedAt:type_name -> google.protobuf.Timestamp
+	28, // 17: protos.ExportPlayerDataResponse.user:type_name -> protos.ExportedUser
+	33, // 18: protos.ExportPlayerDataResponse.players:type_name -> protos.ExportedPlayer
+	34, // 19: protos.ExportPlayerDataResponse.erasures:type_name -> protos.PlayerErasure
+	34, // 20: protos.ErasePlayerDataResponse.erasures:type_name -> protos.PlayerErasure
+	1,  // 21: protos.AiRetreatGo.CreateGame:input_type -> protos.CreateGameRequest
+	3,  // 22: protos.AiRetreatGo.JoinGame:input_type -> protos.JoinGameRequest
+	5,  // 23: protos.AiRetreatGo.AutoJoinGame:input_type -> protos.AutoJoinGameRequest
+	7,  // 24: protos.AiRetreatGo.SendMessage:input_type -> protos.SendMessageRequest
+	9,  // 25: protos.AiRetreatGo.Tag:input_type -> protos.TagRequest
+	11, // 26: protos.AiRetreatGo.CastVote:input_type -> protos.CastVoteRequest
+	13, // 27: protos.AiRetreatGo.Help:input_type -> protos.HelpRequest
+	16, // 28: protos.AiRetreatGo.GetGameForPlayer:input_type -> protos.GetGameForPlayerRequest
+	20, // 29: protos.AiRetreatGo.GetGamesForPlayer:input_type -> protos.GetGamesForPlayerRequest
+	25, // 30: protos.AiRetreatGo.SyncPlayerData:input_type -> protos.SyncPlayerDataRequest
+	22, // 31: protos.AiRetreatGo.GetGameAnalysis:input_type -> protos.GetGameAnalysisRequest
+	27, // 32: protos.AiRetreatGo.ExportPlayerData:input_type -> protos.ExportPlayerDataRequest
+	36, // 33: protos.AiRetreatGo.ErasePlayerData:input_type -> protos.ErasePlayerDataRequest
+	2,  // 34: protos.AiRetreatGo.CreateGame:output_type -> protos.CreateGameResponse
+	4,  // 35: protos.AiRetreatGo.JoinGame:output_type -> protos.JoinGameResponse
+	6,  // 36: protos.AiRetreatGo.AutoJoinGame:output_type -> protos.AutoJoinGameResponse
+	8,  // 37: protos.AiRetreatGo.SendMessage:output_type -> protos.SendMessageResponse
+	10, // 38: protos.AiRetreatGo.Tag:output_type -> protos.TagResponse
+	12, // 39: protos.AiRetreatGo.CastVote:output_type -> protos.CastVoteResponse
+	15, // 40: protos.AiRetreatGo.Help:output_type -> protos.HelpResponse
+	17, // 41: protos.AiRetreatGo.GetGameForPlayer:output_type -> protos.GetGameForPlayerResponse
+	21, // 42: protos.AiRetreatGo.GetGamesForPlayer:output_type -> protos.GetGamesForPlayerResponse
+	26, // 43: protos.AiRetreatGo.SyncPlayerData:output_type -> protos.SyncPlayerDataResponse
+	24, // 44: protos.AiRetreatGo.GetGameAnalysis:output_type -> protos.GetGameAnalysisResponse
+	35, // 45: protos.AiRetreatGo.ExportPlayerData:output_type -> protos.ExportPlayerDataResponse
+	37, // 46: protos.AiRetreatGo.ErasePlayerData:output_type -> protos.ErasePlayerDataResponse
+	34, // [34:47] is the sub-list for method output_type
+	21, // [21:34] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_protos_server_proto_init() }
@@ -1482,7 +3055,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HelpRequest); i {
+			switch v := v.(*CastVoteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1494,7 +3067,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HelpResponse); i {
+			switch v := v.(*CastVoteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1506,7 +3079,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGameForPlayerRequest); i {
+			switch v := v.(*HelpRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1518,7 +3091,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGameForPlayerResponse); i {
+			switch v := v.(*HelpSuggestion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1530,7 +3103,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bot); i {
+			switch v := v.(*HelpResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1542,7 +3115,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameMessage); i {
+			switch v := v.(*GetGameForPlayerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1554,7 +3127,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGamesForPlayerRequest); i {
+			switch v := v.(*GetGameForPlayerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1566,7 +3139,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGamesForPlayerResponse); i {
+			switch v := v.(*Bot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1578,7 +3151,7 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncPlayerDataRequest); i {
+			switch v := v.(*GameMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1590,6 +3163,78 @@ func file_protos_server_proto_init() {
 			}
 		}
 		file_protos_server_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGamesForPlayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGamesForPlayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGameAnalysisRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BotAnalysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGameAnalysisResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncPlayerDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SyncPlayerDataResponse); i {
 			case 0:
 				return &v.state
@@ -1601,19 +3246,152 @@ func file_protos_server_proto_init() {
 				return nil
 			}
 		}
+		file_protos_server_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPlayerDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedUser); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedBot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedPracticeResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedPlayer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerErasure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPlayerDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErasePlayerDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_server_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErasePlayerDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_server_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      1,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_protos_server_proto_goTypes,
 		DependencyIndexes: file_protos_server_proto_depIdxs,
+		EnumInfos:         file_protos_server_proto_enumTypes,
 		MessageInfos:      file_protos_server_proto_msgTypes,
 	}.Build()
 	File_protos_server_proto = out.File
@@ -27,10 +27,14 @@ type AiRetreatGoClient interface {
 	AutoJoinGame(ctx context.Context, in *AutoJoinGameRequest, opts ...grpc.CallOption) (*AutoJoinGameResponse, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 	Tag(ctx context.Context, in *TagRequest, opts ...grpc.CallOption) (*TagResponse, error)
+	CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*CastVoteResponse, error)
 	Help(ctx context.Context, in *HelpRequest, opts ...grpc.CallOption) (*HelpResponse, error)
 	GetGameForPlayer(ctx context.Context, in *GetGameForPlayerRequest, opts ...grpc.CallOption) (*GetGameForPlayerResponse, error)
 	GetGamesForPlayer(ctx context.Context, in *GetGamesForPlayerRequest, opts ...grpc.CallOption) (*GetGamesForPlayerResponse, error)
 	SyncPlayerData(ctx context.Context, in *SyncPlayerDataRequest, opts ...grpc.CallOption) (*SyncPlayerDataResponse, error)
+	GetGameAnalysis(ctx context.Context, in *GetGameAnalysisRequest, opts ...grpc.CallOption) (*GetGameAnalysisResponse, error)
+	ExportPlayerData(ctx context.Context, in *ExportPlayerDataRequest, opts ...grpc.CallOption) (*ExportPlayerDataResponse, error)
+	ErasePlayerData(ctx context.Context, in *ErasePlayerDataRequest, opts ...grpc.CallOption) (*ErasePlayerDataResponse, error)
 }
 
 type aiRetreatGoClient struct {
@@ -86,6 +90,15 @@ func (c *aiRetreatGoClient) Tag(ctx context.Context, in *TagRequest, opts ...grp
 	return out, nil
 }
 
+func (c *aiRetreatGoClient) CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*CastVoteResponse, error) {
+	out := new(CastVoteResponse)
+	err := c.cc.Invoke(ctx, "/protos.AiRetreatGo/CastVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aiRetreatGoClient) Help(ctx context.Context, in *HelpRequest, opts ...grpc.CallOption) (*HelpResponse, error) {
 	out := new(HelpResponse)
 	err := c.cc.Invoke(ctx, "/protos.AiRetreatGo/Help", in, out, opts...)
@@ -122,6 +135,33 @@ func (c *aiRetreatGoClient) SyncPlayerData(ctx context.Context, in *SyncPlayerDa
 	return out, nil
 }
 
+func (c *aiRetreatGoClient) GetGameAnalysis(ctx context.Context, in *GetGameAnalysisRequest, opts ...grpc.CallOption) (*GetGameAnalysisResponse, error) {
+	out := new(GetGameAnalysisResponse)
+	err := c.cc.Invoke(ctx, "/protos.AiRetreatGo/GetGameAnalysis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiRetreatGoClient) ExportPlayerData(ctx context.Context, in *ExportPlayerDataRequest, opts ...grpc.CallOption) (*ExportPlayerDataResponse, error) {
+	out := new(ExportPlayerDataResponse)
+	err := c.cc.Invoke(ctx, "/protos.AiRetreatGo/ExportPlayerData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiRetreatGoClient) ErasePlayerData(ctx context.Context, in *ErasePlayerDataRequest, opts ...grpc.CallOption) (*ErasePlayerDataResponse, error) {
+	out := new(ErasePlayerDataResponse)
+	err := c.cc.Invoke(ctx, "/protos.AiRetreatGo/ErasePlayerData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AiRetreatGoServer is the server API for AiRetreatGo service.
 // All implementations must embed UnimplementedAiRetreatGoServer
 // for forward compatibility
@@ -131,10 +171,14 @@ type AiRetreatGoServer interface {
 	AutoJoinGame(context.Context, *AutoJoinGameRequest) (*AutoJoinGameResponse, error)
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 	Tag(context.Context, *TagRequest) (*TagResponse, error)
+	CastVote(context.Context, *CastVoteRequest) (*CastVoteResponse, error)
 	Help(context.Context, *HelpRequest) (*HelpResponse, error)
 	GetGameForPlayer(context.Context, *GetGameForPlayerRequest) (*GetGameForPlayerResponse, error)
 	GetGamesForPlayer(context.Context, *GetGamesForPlayerRequest) (*GetGamesForPlayerResponse, error)
 	SyncPlayerData(context.Context, *SyncPlayerDataRequest) (*SyncPlayerDataResponse, error)
+	GetGameAnalysis(context.Context, *GetGameAnalysisRequest) (*GetGameAnalysisResponse, error)
+	ExportPlayerData(context.Context, *ExportPlayerDataRequest) (*ExportPlayerDataResponse, error)
+	ErasePlayerData(context.Context, *ErasePlayerDataRequest) (*ErasePlayerDataResponse, error)
 	mustEmbedUnimplementedAiRetreatGoServer()
 }
 
@@ -157,6 +201,9 @@ func (UnimplementedAiRetreatGoServer) SendMessage(context.Context, *SendMessageR
 func (UnimplementedAiRetreatGoServer) Tag(context.Context, *TagRequest) (*TagResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Tag not implemented")
 }
+func (UnimplementedAiRetreatGoServer) CastVote(context.Context, *CastVoteRequest) (*CastVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CastVote not implemented")
+}
 func (UnimplementedAiRetreatGoServer) Help(context.Context, *HelpRequest) (*HelpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Help not implemented")
 }
@@ -169,6 +216,15 @@ func (UnimplementedAiRetreatGoServer) GetGamesForPlayer(context.Context, *GetGam
 func (UnimplementedAiRetreatGoServer) SyncPlayerData(context.Context, *SyncPlayerDataRequest) (*SyncPlayerDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncPlayerData not implemented")
 }
+func (UnimplementedAiRetreatGoServer) GetGameAnalysis(context.Context, *GetGameAnalysisRequest) (*GetGameAnalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameAnalysis not implemented")
+}
+func (UnimplementedAiRetreatGoServer) ExportPlayerData(context.Context, *ExportPlayerDataRequest) (*ExportPlayerDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPlayerData not implemented")
+}
+func (UnimplementedAiRetreatGoServer) ErasePlayerData(context.Context, *ErasePlayerDataRequest) (*ErasePlayerDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErasePlayerData not implemented")
+}
 func (UnimplementedAiRetreatGoServer) mustEmbedUnimplementedAiRetreatGoServer() {}
 
 // UnsafeAiRetreatGoServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +328,24 @@ func _AiRetreatGo_Tag_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AiRetreatGo_CastVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CastVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiRetreatGoServer).CastVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AiRetreatGo/CastVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiRetreatGoServer).CastVote(ctx, req.(*CastVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AiRetreatGo_Help_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HelpRequest)
 	if err := dec(in); err != nil {
@@ -344,6 +418,60 @@ func _AiRetreatGo_SyncPlayerData_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AiRetreatGo_GetGameAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiRetreatGoServer).GetGameAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AiRetreatGo/GetGameAnalysis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiRetreatGoServer).GetGameAnalysis(ctx, req.(*GetGameAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiRetreatGo_ExportPlayerData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPlayerDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiRetreatGoServer).ExportPlayerData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AiRetreatGo/ExportPlayerData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiRetreatGoServer).ExportPlayerData(ctx, req.(*ExportPlayerDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiRetreatGo_ErasePlayerData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ErasePlayerDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiRetreatGoServer).ErasePlayerData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AiRetreatGo/ErasePlayerData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiRetreatGoServer).ErasePlayerData(ctx, req.(*ErasePlayerDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AiRetreatGo_ServiceDesc is the grpc.ServiceDesc for AiRetreatGo service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -371,6 +499,10 @@ var AiRetreatGo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Tag",
 			Handler:    _AiRetreatGo_Tag_Handler,
 		},
+		{
+			MethodName: "CastVote",
+			Handler:    _AiRetreatGo_CastVote_Handler,
+		},
 		{
 			MethodName: "Help",
 			Handler:    _AiRetreatGo_Help_Handler,
@@ -387,6 +519,18 @@ var AiRetreatGo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncPlayerData",
 			Handler:    _AiRetreatGo_SyncPlayerData_Handler,
 		},
+		{
+			MethodName: "GetGameAnalysis",
+			Handler:    _AiRetreatGo_GetGameAnalysis_Handler,
+		},
+		{
+			MethodName: "ExportPlayerData",
+			Handler:    _AiRetreatGo_ExportPlayerData_Handler,
+		},
+		{
+			MethodName: "ErasePlayerData",
+			Handler:    _AiRetreatGo_ErasePlayerData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/server.proto",